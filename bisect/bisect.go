@@ -0,0 +1,215 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package bisect finds the kernel commit that introduced or fixed a bug with git bisect.
+// The caller provides a test function that builds the kernel checked out in the repo,
+// boots it and runs the reproducer, bisect drives git bisect with its verdicts.
+package bisect
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+type Verdict int
+
+const (
+	Good Verdict = iota // the bug does not reproduce
+	Bad                 // the bug reproduces
+	Skip                // the commit can't be tested (e.g. build or boot failure)
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Good:
+		return "good"
+	case Bad:
+		return "bad"
+	case Skip:
+		return "skip"
+	default:
+		return fmt.Sprintf("verdict(%d)", int(v))
+	}
+}
+
+// TestFunc tests the commit that is checked out in the repo.
+// An error aborts bisection (unlike Skip, which is used for commits that can't be tested).
+type TestFunc func(commit string) (Verdict, error)
+
+type Commit struct {
+	Hash    string
+	Title   string
+	Author  string
+	Verdict Verdict
+}
+
+type Result struct {
+	// The first bad commit (the fixing commit for BisectFix),
+	// nil if git could not find it because of skipped commits.
+	Commit *Commit
+	// Commits that could be the first bad commit if Commit is nil.
+	Suspects []*Commit
+	// All tested commits in testing order, including good and bad.
+	Tested []*Commit
+}
+
+// Bisect finds the first bad commit between good and bad in the git repo in dir.
+// The bad commit is tested first to check that the bug reproduces at all, the good one is trusted.
+// logf is used to report progress.
+func Bisect(dir, good, bad string, test TestFunc, logf func(msg string, args ...interface{})) (*Result, error) {
+	return bisect(dir, good, bad, false, test, logf)
+}
+
+// BisectFix finds the first commit between broken (where the bug reproduces) and fixed
+// (where it does not) that fixed the bug. test has the same meaning as for Bisect
+// (Bad means that the bug reproduces), the fixed commit is tested first, the broken one is trusted.
+func BisectFix(dir, broken, fixed string, test TestFunc, logf func(msg string, args ...interface{})) (*Result, error) {
+	return bisect(dir, broken, fixed, true, test, logf)
+}
+
+// bisect runs git bisect between oldCommit and newCommit, with fix the verdicts are inverted for git,
+// so that the first "bad" commit is the first commit where the bug does not reproduce.
+func bisect(dir, oldCommit, newCommit string, fix bool, test TestFunc, logf func(msg string, args ...interface{})) (*Result, error) {
+	oldHash, err := revParse(dir, oldCommit)
+	if err != nil {
+		return nil, err
+	}
+	newHash, err := revParse(dir, newCommit)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := git(dir, "merge-base", "--is-ancestor", oldHash, newHash); err != nil {
+		return nil, fmt.Errorf("commit %v is not an ancestor of commit %v", oldCommit, newCommit)
+	}
+	newVerdict := Bad
+	if fix {
+		newVerdict = Good
+	}
+	res := new(Result)
+	git(dir, "bisect", "reset")
+	defer git(dir, "bisect", "reset")
+	if _, err := git(dir, "checkout", "--quiet", "--force", newHash); err != nil {
+		return nil, err
+	}
+	verdict, err := res.test(dir, newHash, test, logf)
+	if err != nil {
+		return nil, err
+	}
+	if verdict != newVerdict {
+		if fix {
+			return nil, fmt.Errorf("the bug is not fixed on commit %v (%v)", newCommit, verdict)
+		}
+		return nil, fmt.Errorf("the bug does not reproduce on bad commit %v (%v)", newCommit, verdict)
+	}
+	output, bisectErr := git(dir, "bisect", "start", newHash, oldHash)
+	for {
+		// git bisect fails with the list of suspects when only skipped commits are left.
+		if done, err := res.parse(dir, output, newVerdict); done || err != nil {
+			return res, err
+		}
+		if bisectErr != nil {
+			return nil, bisectErr
+		}
+		commit, err := revParse(dir, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		verdict, err := res.test(dir, commit, test, logf)
+		if err != nil {
+			return nil, err
+		}
+		if fix && verdict != Skip {
+			verdict = Good + Bad - verdict
+		}
+		output, bisectErr = git(dir, "bisect", verdict.String())
+	}
+}
+
+func (res *Result) test(dir, hash string, test TestFunc, logf func(msg string, args ...interface{})) (Verdict, error) {
+	commit, err := describe(dir, hash)
+	if err != nil {
+		return 0, err
+	}
+	logf("testing commit %v %v", hash, commit.Title)
+	verdict, err := test(hash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to test commit %v: %v", hash, err)
+	}
+	logf("commit %v is %v", hash, verdict)
+	commit.Verdict = verdict
+	res.Tested = append(res.Tested, commit)
+	return verdict, nil
+}
+
+// parse parses output of git bisect, it returns true when bisection is finished.
+func (res *Result) parse(dir string, output []byte, newVerdict Verdict) (bool, error) {
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasSuffix(line, " is the first bad commit") {
+			commit, err := describe(dir, strings.Fields(line)[0])
+			if err != nil {
+				return true, err
+			}
+			commit.Verdict = newVerdict
+			res.Commit = commit
+			return true, nil
+		}
+		if strings.HasPrefix(line, "There are only 'skip'ped commits left to test.") {
+			// git prints the possible first bad commits one per line after this line.
+			for _, line := range strings.Split(string(output), "\n") {
+				if !isHash(line) {
+					continue
+				}
+				commit, err := describe(dir, line)
+				if err != nil {
+					return true, err
+				}
+				res.Suspects = append(res.Suspects, commit)
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isHash(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func describe(dir, hash string) (*Commit, error) {
+	output, err := git(dir, "log", "-1", "--format=%H%n%s%n%an <%ae>", hash)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 3 {
+		return nil, fmt.Errorf("unexpected git log output: %q", output)
+	}
+	return &Commit{Hash: lines[0], Title: lines[1], Author: lines[2]}, nil
+}
+
+func revParse(dir, rev string) (string, error) {
+	output, err := git(dir, "rev-parse", "--verify", rev+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return output, fmt.Errorf("'git %v' failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return output, nil
+}
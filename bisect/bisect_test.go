@@ -0,0 +1,142 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package bisect
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// createRepo creates a git repo with commits "commit 0".."commit n-1",
+// file "version" contains the commit number.
+func createRepo(t *testing.T, n int) (string, []string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir, err := ioutil.TempDir("", "syz-bisect-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) string {
+		output, err := git(dir, append([]string{"-c", "user.name=test", "-c", "user.email=test@test"}, args...)...)
+		if err != nil {
+			os.RemoveAll(dir)
+			t.Fatal(err)
+		}
+		return string(output)
+	}
+	run("init", "--quiet")
+	var hashes []string
+	for i := 0; i < n; i++ {
+		if err := ioutil.WriteFile(filepath.Join(dir, "version"), []byte(fmt.Sprint(i)), 0600); err != nil {
+			t.Fatal(err)
+		}
+		run("add", "version")
+		run("commit", "--quiet", "-m", fmt.Sprintf("commit %v", i))
+		hashes = append(hashes, strings.TrimSpace(run("rev-parse", "HEAD")))
+	}
+	return dir, hashes
+}
+
+func testFunc(t *testing.T, dir string, bug int, skip map[int]bool) TestFunc {
+	return func(commit string) (Verdict, error) {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "version"))
+		if err != nil {
+			return 0, err
+		}
+		v, err := strconv.Atoi(string(data))
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case skip[v]:
+			return Skip, nil
+		case v >= bug:
+			return Bad, nil
+		default:
+			return Good, nil
+		}
+	}
+}
+
+func TestBisect(t *testing.T) {
+	dir, hashes := createRepo(t, 20)
+	defer os.RemoveAll(dir)
+	for bug := 1; bug < len(hashes); bug++ {
+		res, err := Bisect(dir, hashes[0], hashes[len(hashes)-1], testFunc(t, dir, bug, nil), t.Logf)
+		if err != nil {
+			t.Fatalf("bug %v: %v", bug, err)
+		}
+		if res.Commit == nil || res.Commit.Hash != hashes[bug] {
+			t.Fatalf("bug %v: found %+v, want %v", bug, res.Commit, hashes[bug])
+		}
+		if want := fmt.Sprintf("commit %v", bug); res.Commit.Title != want {
+			t.Fatalf("bug %v: title %q, want %q", bug, res.Commit.Title, want)
+		}
+		if len(res.Tested) > 7 {
+			t.Fatalf("bug %v: tested %v commits", bug, len(res.Tested))
+		}
+	}
+}
+
+func TestBisectSkip(t *testing.T) {
+	dir, hashes := createRepo(t, 20)
+	defer os.RemoveAll(dir)
+	res, err := Bisect(dir, hashes[0], hashes[19], testFunc(t, dir, 10, map[int]bool{9: true, 10: true}), t.Logf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Commit != nil {
+		t.Fatalf("found commit %+v, while it must be ambiguous", res.Commit)
+	}
+	suspects := make(map[string]bool)
+	for _, c := range res.Suspects {
+		suspects[c.Hash] = true
+	}
+	if len(suspects) != 3 || !suspects[hashes[9]] || !suspects[hashes[10]] || !suspects[hashes[11]] {
+		t.Fatalf("bad suspects: %+v", res.Suspects)
+	}
+}
+
+func TestBisectNotReproducible(t *testing.T) {
+	dir, hashes := createRepo(t, 5)
+	defer os.RemoveAll(dir)
+	if _, err := Bisect(dir, hashes[0], hashes[4], testFunc(t, dir, 100, nil), t.Logf); err == nil {
+		t.Fatal("bisection succeeded while the bug does not reproduce")
+	}
+	if _, err := Bisect(dir, hashes[3], hashes[1], testFunc(t, dir, 2, nil), t.Logf); err == nil {
+		t.Fatal("bisection succeeded with swapped good/bad commits")
+	}
+}
+
+func TestBisectFix(t *testing.T) {
+	dir, hashes := createRepo(t, 20)
+	defer os.RemoveAll(dir)
+	for fix := 1; fix < len(hashes); fix++ {
+		// The bug reproduces before the fix.
+		test := func(commit string) (Verdict, error) {
+			v, err := testFunc(t, dir, fix, nil)(commit)
+			if v == Bad {
+				return Good, err
+			}
+			return Bad, err
+		}
+		res, err := BisectFix(dir, hashes[0], hashes[len(hashes)-1], test, t.Logf)
+		if err != nil {
+			t.Fatalf("fix %v: %v", fix, err)
+		}
+		if res.Commit == nil || res.Commit.Hash != hashes[fix] || res.Commit.Verdict != Good {
+			t.Fatalf("fix %v: found %+v, want %v", fix, res.Commit, hashes[fix])
+		}
+	}
+	if _, err := BisectFix(dir, hashes[0], hashes[19], testFunc(t, dir, 0, nil), t.Logf); err == nil {
+		t.Fatal("fix bisection succeeded while the bug is not fixed")
+	}
+}
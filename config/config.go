@@ -7,12 +7,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/syzkaller/fileutil"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/seccomp"
 	"github.com/google/syzkaller/sys"
 	"github.com/google/syzkaller/vm"
 )
@@ -21,38 +27,203 @@ type Config struct {
 	Http    string // TCP address to serve HTTP stats page (e.g. "localhost:50000")
 	Rpc     string // TCP address to serve RPC for fuzzer processes (optional, only useful for type "none")
 	Workdir string
-	Vmlinux string
-	Kernel  string // e.g. arch/x86/boot/bzImage
+	Vmlinux string // without it symbols are taken from /proc/kallsyms of VMs (see syz-manager/kallsyms.go)
 	Cmdline string // kernel command line
-	Image   string // linux image for VMs
-	Initrd  string // linux initial ramdisk. (optional)
-	Cpu     int    // number of VM CPUs
-	Mem     int    // amount of VM memory in MBs
-	Sshkey  string // root ssh key for the image
-	Port    int    // VM ssh port to use
-	Bin     string // qemu/lkvm binary name
 	Debug   bool   // dump all VM output to console
 	Output  string // one of stdout/dmesg/file (useful only for local VM)
 
 	Syzkaller string // path to syzkaller checkout (syz-manager will look for binaries in bin subdir)
-	Type      string // VM type (qemu, kvm, local)
+	Type      string // VM type (qemu, kvm, adb, isolated, gvisor, local, none)
+	OS        string // OS of machines under test: linux (default) or gvisor
+	Target    string // arch of fuzzer/executor binaries if it differs from host (e.g. "386" for 32-bit compat syscalls)
 	Count     int    // number of VMs
 	Procs     int    // number of parallel processes inside of every VM
+	// Additional pools of VMs of other types or with other params that fuzz with the same corpus
+	// (e.g. a few physical boards next to many qemu VMs), see VMPool.
+	Pools []VMPool
+
+	// VM lifecycle: VMs are restarted after Instance_Lifetime minutes of fuzzing (default: 60)
+	// or after Restart_After_Crashes saved crashes (0 means that crashes don't restart the VM by themselves).
+	// Failed VMs are restarted after Restart_Backoff seconds (default: 10), the delay is doubled
+	// for every consecutive failure of the instance up to 32 times.
+	Instance_Lifetime     int
+	Restart_After_Crashes int
+	Restart_Backoff       int
+
+	// Dedicated VM roles, instances are taken out of Count. While there are triage instances,
+	// only they get candidates (and fuzz when there are no candidates). Repro instances don't fuzz,
+	// they run syz-repro on the first crash with every new description.
+	Triage_Count int
+	Repro_Count  int
+	// Re-run saved reproducers of all crashes on repro instances when the manager starts
+	// with a new kernel build, to find out which bugs are fixed.
+	Retest_Repros bool
+	// Command that is run (with sh -c) once per crash description when automatic reproduction
+	// gives it reproducibility score of at least Notify_Score (default: 0.5).
+	Notify_Cmd   string
+	Notify_Score float64
+
+	// Extra environment variables and syz-fuzzer flags for a subset of instances,
+	// e.g. to canary experimental fuzzer features on a part of the fleet (see FuzzerOverride).
+	Fuzzer_Overrides []FuzzerOverride
 
 	Sandbox string // type of sandbox to use during fuzzing:
 	// "none": don't do anything special (has false positives, e.g. due to killing init)
 	// "setuid": impersonate into user nobody (65534), default
 	// "namespace": create a new namespace for fuzzer using CLONE_NEWNS/CLONE_NEWNET/CLONE_NEWPID/etc,
 	//	requires building kernel with CONFIG_NAMESPACES, CONFIG_UTS_NS, CONFIG_USER_NS, CONFIG_PID_NS and CONFIG_NET_NS.
+	// "android": impersonate into untrusted_app (uid/gids and SELinux context), only useful with type "adb"
+
+	// Experimental boot parameter fuzzing: every group lists alternative kernel command line
+	// fragments (e.g. ["slub_debug=FZ", "slub_debug=P", ""]), each instance boots with a random
+	// fragment from every group appended to Cmdline ("" means that nothing is appended).
+	Boot_Params [][]string
+
+	// Per-test-process resource limits enforced with memory/pids cgroups inside of the VM
+	// (requires CONFIG_MEMCG and CONFIG_CGROUP_PIDS), 0 means no limit.
+	Cgroup_Mem  int // memory limit in MB
+	Cgroup_Pids int // max number of tasks
+	// Memory pressure: a hog process inside of the VM keeps only that many MB of memory available
+	// to test processes (within cgroup_mem if it is set, otherwise in the whole VM), 0 means no pressure.
+	Mem_Pressure int
 
 	Cover bool // use kcov coverage (default: true)
 	Leak  bool // do memory leak checking
+	// Count kernel objects (files, sockets, SysV IPC) left behind by programs,
+	// growth of the counts is reported in "leaked <kind>" stats.
+	Count_Objects bool
+
+	// Save every unique KCSAN data race once and don't treat it as a crash
+	// (the kernel must not panic on KCSAN reports for fuzzing to actually continue).
+	Nonfatal_Data_Races bool
+
+	// Hangs (hung tasks, RCU stalls and soft lockups, see vm.IsHang): "restart" saves the hang and restarts
+	// the VM (default), "continue" saves every unique hang once and continues fuzzing in the VM.
+	// Console output after hang reports (including sysrq dumps of locks, CPUs and tasks) saved in crash logs
+	// in KB (default: 1024).
+	Hang_Action        string
+	Hang_Context_After int
+
+	// Console output saved with crash reports: KBs before the report start (default: 256)
+	// and after the report end (default: 128), and whether to also keep the complete console log
+	// of the VM for the first crash with every title.
+	Crash_Context_Before int
+	Crash_Context_After  int
+	Crash_Full_Log       bool
+	// Number of the last executed programs of the VM saved with every crash (default: 100).
+	Crash_Programs int
+	// Crash kernel (e.g. bzImage on the host) that is loaded with kexec -p in VMs before fuzzing,
+	// a filtered dump of the crashed kernel memory is saved for the first crash with every title
+	// (Linux only, see syz-manager/kdump.go).
+	Kdump_Kernel string
+
+	// Manager log files in workdir/logs: manager.log with all messages and <vm>.log with messages about the VM
+	// and its console output. Log_Verbosity is the max verbosity of messages written to the files
+	// (default: 1, console output of the manager is still controlled by -v), files are rotated when they
+	// reach Log_Rotate_Size MB (default: 16) and Log_Rotate_Count rotated files are kept (default: 3).
+	Log_Verbosity    int
+	Log_Rotate_Size  int
+	Log_Rotate_Count int
+
+	// Raw console output of every VM instance is appended to workdir/instances/<vm>/console.log
+	// across VM restarts if Console_Archive_Size is set. The file is rotated when it reaches
+	// Console_Archive_Size MB and Console_Archive_Count rotated files are kept (default: 3).
+	Console_Archive_Size  int
+	Console_Archive_Count int
+
+	// Shrink long kernel timers inside of VMs (TCP keepalive/retransmission, dirty page writeback)
+	// with sysctls, so that code behind them is reachable within the program timeout (Linux only).
+	Fast_Timers bool
+
+	// Console noise control: console log level set in VMs before fuzzing (4-8, 0 leaves the kernel default),
+	// kernel.printk_ratelimit (seconds) and kernel.printk_ratelimit_burst sysctls (0 leaves the default),
+	// and console output rate in KB/s that is considered console flooding (0 disables detection).
+	Console_Loglevel       int
+	Printk_Ratelimit       int
+	Printk_Ratelimit_Burst int
+	Console_Flood          int
+
+	// Liveness timeouts in seconds: a VM without console output for No_Output_Timeout or without executed
+	// programs for Not_Executing_Timeout is considered dead (defaults depend on the VM type, see livenessTimeouts).
+	No_Output_Timeout     int
+	Not_Executing_Timeout int
+
+	// Adapt weights of calls in generated and mutated programs to their recent coverage yield
+	// (executions that gave new coverage per execution), so that calls that stopped yielding are chosen less.
+	Adaptive_Calls bool
+
+	// Bias generation of new calls toward sequences of 3 calls that are frequent in corpus,
+	// in particular toward sequences that end with the call that gave new coverage.
+	Call_Ngrams bool
+
+	// Corpus rotation: every Corpus_Rotation_Period minutes (default: 60) Corpus_Rotation percent
+	// of corpus is set aside, so that restarted VMs fuzz from different starting points,
+	// and the previously set aside inputs are returned for re-triage (0 disables rotation).
+	Corpus_Rotation        int
+	Corpus_Rotation_Period int
 
-	ConsoleDev string // console device for adb vm
+	// Periodic backup of corpus and crashes: rsync destination (local path, host:path over ssh,
+	// rsync://host/module/path) or Google Cloud Storage URL (gs://bucket/path).
+	// The backup mirrors the workdir dirs and replaces them on startup.
+	Backup        string
+	Backup_Period int // minutes between backups (default: 60)
+
+	// Secret that enables management API on the http address (/pause, /resume, /shutdown, /reload),
+	// requests must carry "Authorization: Bearer <api_key>" header.
+	Api_Key string
+
+	// HTTP addresses of other managers (e.g. "http://host:56741") to compare covered functions with on /cover_diff.
+	Cover_Peers []string
+
+	// Port inside of VMs on which fuzzers serve pprof profiles and expvar vars (0 disables).
+	Fuzzer_Debug_Port int
+
+	// Limits on complexity of fuzzing programs (see prog.Budget), 0 means the default.
+	Program_Length     int // target number of calls in generated programs (default: 30)
+	Max_Program_Length int // hard limit on number of calls in a program (default: 2*program_length)
+	Max_Ptr_Depth      int // max nesting of pointers in call arguments (default: 4)
+	Max_Buf_Len        int // max length of random data buffers in bytes (default: 4096)
+
+	// Fuzzing parameters that are tuned per target, they are delivered to fuzzers on connect (see Experiments).
+	Experiments *Experiments
 
 	Enable_Syscalls  []string
 	Disable_Syscalls []string
 	Suppressions     []string
+	// Crash classes (see vm.CrashClasses) that are detected, but are not saved and don't stop the VM,
+	// e.g. ["warning", "hung-task"] on debug kernels. The kernel must not panic on them.
+	Ignores []string
+
+	// Coverage focus: only coverage in kernel functions that match Focus_Functions (regexps)
+	// or contain code from Focus_Files (files or dirs relative to the kernel source dir,
+	// e.g. "drivers/net/tun.c" or "drivers/usb/") is used to grow corpus, the rest is ignored.
+	Focus_Files     []string
+	Focus_Functions []string
+
+	// Dirs with seed programs that are loaded as candidates on every start in addition to workdir/corpus.
+	// The dirs are never modified, so seeds survive corpus minimization.
+	Seeds []string
+
+	// Seccomp profile (in docker/OCI format) installed in test processes, so that only the syscall surface
+	// reachable from a sandbox that uses the profile is fuzzed (Linux only, see package seccomp).
+	Seccomp_Profile string
+
+	// Commit or tag of the kernel sources, it is only reported in exported crash signatures and build info of crashes.
+	Kernel_Commit string
+	// Kernel config archived with crashes (default: .config next to vmlinux, if it exists).
+	Kernel_Config string
+	// Kernel git checkout that patches submitted to /test_patch are applied to, its MAINTAINERS file
+	// is used to find maintainers of guilty files of crashes (default: dir of vmlinux).
+	Kernel_Src string
+	// Dirs that are searched for object files of kernel modules (<name>.ko) to symbolize module coverage
+	// (e.g. build dir of out-of-tree drivers, optional).
+	Module_Objs []string
+	// Command that creates VM image for a patched kernel (run with sh -c in the kernel dir, optional).
+	Patch_Image_Cmd string
+
+	// Backend-specific params from the config section named after Type (e.g. "qemu": {...}).
+	// They are parsed and validated by the corresponding vm package.
+	VM json.RawMessage `json:"-"`
 }
 
 func Parse(filename string) (*Config, map[int]bool, []*regexp.Regexp, error) {
@@ -72,19 +243,30 @@ func parse(data []byte) (*Config, map[int]bool, []*regexp.Regexp, error) {
 		return nil, nil, nil, err
 	}
 	if unknown != "" {
+		if suggestion := suggestField(unknown); suggestion != "" {
+			return nil, nil, nil, fmt.Errorf("unknown field '%v' in config, did you mean '%v'?", unknown, suggestion)
+		}
 		return nil, nil, nil, fmt.Errorf("unknown field '%v' in config", unknown)
 	}
 	cfg := new(Config)
 	cfg.Cover = true
-	cfg.Sandbox = "setuid"
+	// Omitted experiments fields keep default values.
+	cfg.Experiments = DefaultExperiments()
 	if err := json.Unmarshal(data, cfg); err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to parse config file: %v", err)
 	}
-	if _, err := os.Stat(filepath.Join(cfg.Syzkaller, "bin/syz-fuzzer")); err != nil {
-		return nil, nil, nil, fmt.Errorf("bad config syzkaller param: can't find bin/syz-fuzzer")
+	if err := checkOS(cfg); err != nil {
+		return nil, nil, nil, err
 	}
-	if _, err := os.Stat(filepath.Join(cfg.Syzkaller, "bin/syz-executor")); err != nil {
-		return nil, nil, nil, fmt.Errorf("bad config syzkaller param: can't find bin/syz-executor")
+	switch cfg.Target {
+	case "", "amd64", "386", "arm64", "ppc64le":
+	default:
+		return nil, nil, nil, fmt.Errorf("config param target must contain one of amd64/386/arm64/ppc64le")
+	}
+	for _, bin := range []string{"syz-fuzzer", "syz-executor"} {
+		if _, err := os.Stat(cfg.TargetBin(bin)); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config syzkaller param: can't find %v", cfg.TargetBin(bin))
+		}
 	}
 	if cfg.Http == "" {
 		return nil, nil, nil, fmt.Errorf("config param http is empty")
@@ -92,12 +274,32 @@ func parse(data []byte) (*Config, map[int]bool, []*regexp.Regexp, error) {
 	if cfg.Workdir == "" {
 		return nil, nil, nil, fmt.Errorf("config param workdir is empty")
 	}
-	if cfg.Vmlinux == "" {
-		return nil, nil, nil, fmt.Errorf("config param vmlinux is empty")
+	if cfg.Vmlinux != "" {
+		if _, err := os.Stat(cfg.Vmlinux); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config vmlinux param: %v", err)
+		}
+	}
+	if cfg.Kernel_Config != "" {
+		if _, err := os.Stat(cfg.Kernel_Config); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config kernel_config param: %v", err)
+		}
+	}
+	if cfg.Kernel_Src != "" {
+		if _, err := os.Stat(cfg.Kernel_Src); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config kernel_src param: %v", err)
+		}
+	}
+	for _, dir := range cfg.Module_Objs {
+		if st, err := os.Stat(dir); err != nil || !st.IsDir() {
+			return nil, nil, nil, fmt.Errorf("bad config module_objs param: %v is not a directory", dir)
+		}
 	}
 	if cfg.Type == "" {
 		return nil, nil, nil, fmt.Errorf("config param type is empty")
 	}
+	if err := parseVMParams(cfg, data); err != nil {
+		return nil, nil, nil, err
+	}
 	if cfg.Type == "none" {
 		if cfg.Count != 0 {
 			return nil, nil, nil, fmt.Errorf("invalid config param count: %v, type \"none\" does not support param count", cfg.Count)
@@ -113,6 +315,34 @@ func parse(data []byte) (*Config, map[int]bool, []*regexp.Regexp, error) {
 			cfg.Rpc = "localhost:0"
 		}
 	}
+	if cfg.Instance_Lifetime < 0 || cfg.Restart_After_Crashes < 0 || cfg.Restart_Backoff < 0 {
+		return nil, nil, nil, fmt.Errorf("config params instance_lifetime/restart_after_crashes/restart_backoff must not be negative")
+	}
+	if cfg.Instance_Lifetime == 0 {
+		cfg.Instance_Lifetime = 60
+	}
+	if cfg.Restart_Backoff == 0 {
+		cfg.Restart_Backoff = 10
+	}
+	if cfg.Triage_Count < 0 || cfg.Repro_Count < 0 {
+		return nil, nil, nil, fmt.Errorf("config params triage_count/repro_count must not be negative")
+	}
+	if cfg.Triage_Count+cfg.Repro_Count != 0 && cfg.Triage_Count+cfg.Repro_Count >= cfg.Count {
+		return nil, nil, nil, fmt.Errorf("config params triage_count (%v) + repro_count (%v) must be less than count (%v)",
+			cfg.Triage_Count, cfg.Repro_Count, cfg.Count)
+	}
+	if cfg.Retest_Repros && cfg.Repro_Count == 0 {
+		return nil, nil, nil, fmt.Errorf("config param retest_repros requires repro_count")
+	}
+	if cfg.Notify_Cmd != "" && cfg.Repro_Count == 0 {
+		return nil, nil, nil, fmt.Errorf("config param notify_cmd requires repro_count")
+	}
+	if cfg.Notify_Score < 0 || cfg.Notify_Score > 1 {
+		return nil, nil, nil, fmt.Errorf("config param notify_score must be in [0, 1]")
+	}
+	if cfg.Notify_Score == 0 {
+		cfg.Notify_Score = 0.5
+	}
 	if cfg.Procs <= 0 {
 		cfg.Procs = 1
 	}
@@ -128,10 +358,152 @@ func parse(data []byte) (*Config, map[int]bool, []*regexp.Regexp, error) {
 	default:
 		return nil, nil, nil, fmt.Errorf("config param output must contain one of none/stdout/dmesg/file")
 	}
+	for i, group := range cfg.Boot_Params {
+		if len(group) == 0 {
+			return nil, nil, nil, fmt.Errorf("config param boot_params: group #%v is empty", i)
+		}
+	}
+	if cfg.Cgroup_Mem < 0 || cfg.Cgroup_Pids < 0 || cfg.Mem_Pressure < 0 {
+		return nil, nil, nil, fmt.Errorf("config params cgroup_mem/cgroup_pids/mem_pressure must not be negative")
+	}
+	if cfg.Cgroup_Mem != 0 && cfg.Mem_Pressure >= cfg.Cgroup_Mem {
+		return nil, nil, nil, fmt.Errorf("config param mem_pressure must be less than cgroup_mem")
+	}
+	if cfg.Backup_Period < 0 {
+		return nil, nil, nil, fmt.Errorf("config param backup_period must not be negative")
+	}
+	if cfg.Backup != "" && cfg.Backup_Period == 0 {
+		cfg.Backup_Period = 60
+	}
+	if cfg.Corpus_Rotation < 0 || cfg.Corpus_Rotation > 50 || cfg.Corpus_Rotation_Period < 0 {
+		return nil, nil, nil, fmt.Errorf("config param corpus_rotation must be in [0, 50] and corpus_rotation_period must not be negative")
+	}
+	if cfg.Corpus_Rotation != 0 && !cfg.Cover {
+		return nil, nil, nil, fmt.Errorf("config param corpus_rotation requires cover")
+	}
+	if cfg.Console_Loglevel != 0 && (cfg.Console_Loglevel < 4 || cfg.Console_Loglevel > 8) {
+		// Levels below 4 hide KERN_ERR messages, and most crash reports are printed with KERN_ERR.
+		return nil, nil, nil, fmt.Errorf("config param console_loglevel must be in [4, 8]")
+	}
+	if cfg.Printk_Ratelimit < 0 || cfg.Printk_Ratelimit_Burst < 0 || cfg.Console_Flood < 0 {
+		return nil, nil, nil, fmt.Errorf("config params printk_ratelimit/printk_ratelimit_burst/console_flood must not be negative")
+	}
+	if cfg.Crash_Context_Before < 0 || cfg.Crash_Context_After < 0 || cfg.Crash_Programs < 0 {
+		return nil, nil, nil, fmt.Errorf("config params crash_context_before/crash_context_after/crash_programs must not be negative")
+	}
+	if cfg.Crash_Programs == 0 {
+		cfg.Crash_Programs = 100
+	}
+	if cfg.Kdump_Kernel != "" {
+		if cfg.OS != "linux" || cfg.Type != "qemu" && cfg.Type != "isolated" {
+			return nil, nil, nil, fmt.Errorf("config param kdump_kernel is supported only for os linux and types qemu/isolated")
+		}
+		if _, err := os.Stat(cfg.Kdump_Kernel); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config param kdump_kernel: %v", err)
+		}
+	}
+	switch cfg.Hang_Action {
+	case "":
+		cfg.Hang_Action = "restart"
+	case "restart", "continue":
+	default:
+		return nil, nil, nil, fmt.Errorf("config param hang_action must be restart or continue")
+	}
+	if cfg.Hang_Context_After < 0 {
+		return nil, nil, nil, fmt.Errorf("config param hang_context_after must not be negative")
+	}
+	if cfg.Hang_Context_After == 0 {
+		cfg.Hang_Context_After = 1024
+	}
+	if cfg.Crash_Context_Before == 0 {
+		cfg.Crash_Context_Before = 256
+	}
+	if cfg.Crash_Context_After == 0 {
+		cfg.Crash_Context_After = 128
+	}
+	if cfg.No_Output_Timeout < 0 || cfg.Not_Executing_Timeout < 0 {
+		return nil, nil, nil, fmt.Errorf("config params no_output_timeout/not_executing_timeout must not be negative")
+	}
+	noOutput, notExecuting := livenessTimeouts(cfg.Type, cfg.Target)
+	if cfg.No_Output_Timeout == 0 {
+		cfg.No_Output_Timeout = noOutput
+	}
+	if cfg.Not_Executing_Timeout == 0 {
+		cfg.Not_Executing_Timeout = notExecuting
+	}
+	if cfg.Log_Verbosity < 0 || cfg.Log_Rotate_Size < 0 || cfg.Log_Rotate_Count < 0 {
+		return nil, nil, nil, fmt.Errorf("config params log_verbosity/log_rotate_size/log_rotate_count must not be negative")
+	}
+	if cfg.Log_Verbosity == 0 {
+		cfg.Log_Verbosity = 1
+	}
+	if cfg.Log_Rotate_Size == 0 {
+		cfg.Log_Rotate_Size = 16
+	}
+	if cfg.Log_Rotate_Count == 0 {
+		cfg.Log_Rotate_Count = 3
+	}
+	if cfg.Console_Archive_Size < 0 || cfg.Console_Archive_Count < 0 {
+		return nil, nil, nil, fmt.Errorf("config params console_archive_size/console_archive_count must not be negative")
+	}
+	if cfg.Console_Archive_Count == 0 {
+		cfg.Console_Archive_Count = 3
+	}
+	if cfg.Fuzzer_Debug_Port < 0 || cfg.Fuzzer_Debug_Port > 65535 {
+		return nil, nil, nil, fmt.Errorf("config param fuzzer_debug_port must be in [0, 65535]")
+	}
+	if cfg.Adaptive_Calls && !cfg.Cover {
+		return nil, nil, nil, fmt.Errorf("config param adaptive_calls requires cover")
+	}
+	if len(cfg.Focus_Files)+len(cfg.Focus_Functions) != 0 && !cfg.Cover {
+		return nil, nil, nil, fmt.Errorf("config params focus_files/focus_functions require cover")
+	}
+	if len(cfg.Focus_Files)+len(cfg.Focus_Functions) != 0 && cfg.Vmlinux == "" {
+		return nil, nil, nil, fmt.Errorf("config params focus_files/focus_functions require vmlinux")
+	}
+	for _, fn := range cfg.Focus_Functions {
+		if _, err := regexp.Compile(fn); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config param focus_functions: %v", err)
+		}
+	}
+	if cfg.Corpus_Rotation != 0 && cfg.Corpus_Rotation_Period == 0 {
+		cfg.Corpus_Rotation_Period = 60
+	}
+	if err := checkBudget(cfg); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkExperiments(cfg); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkFuzzerOverrides(cfg); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkPools(cfg); err != nil {
+		return nil, nil, nil, err
+	}
+	for _, dir := range cfg.Seeds {
+		if st, err := os.Stat(dir); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config param seeds: %v", err)
+		} else if !st.IsDir() {
+			return nil, nil, nil, fmt.Errorf("bad config param seeds: %v is not a directory", dir)
+		}
+	}
 	switch cfg.Sandbox {
-	case "none", "setuid", "namespace":
+	case "none", "setuid", "namespace", "android":
 	default:
-		return nil, nil, nil, fmt.Errorf("config param sandbox must contain one of none/setuid/namespace")
+		return nil, nil, nil, fmt.Errorf("config param sandbox must contain one of none/setuid/namespace/android")
+	}
+	if cfg.Seccomp_Profile != "" {
+		if cfg.OS != "linux" && cfg.OS != "gvisor" {
+			return nil, nil, nil, fmt.Errorf("config param seccomp_profile is not supported for os %v", cfg.OS)
+		}
+		data, err := ioutil.ReadFile(cfg.Seccomp_Profile)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config param seccomp_profile: %v", err)
+		}
+		if _, err := seccomp.Parse(data); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad config param seccomp_profile: %v", err)
+		}
 	}
 
 	syscalls, err := parseSyscalls(cfg)
@@ -143,34 +515,278 @@ func parse(data []byte) (*Config, map[int]bool, []*regexp.Regexp, error) {
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	for _, class := range cfg.Ignores {
+		known := false
+		for _, c := range vm.CrashClasses() {
+			known = known || c == class
+		}
+		if !known {
+			return nil, nil, nil, fmt.Errorf("unknown crash class %q in config param ignores, known classes: %v",
+				class, strings.Join(vm.CrashClasses(), ", "))
+		}
+	}
 
 	return cfg, syscalls, suppressions, nil
 }
 
-func parseSyscalls(cfg *Config) (map[int]bool, error) {
-	match := func(call *sys.Call, str string) bool {
-		if str == call.CallName || str == call.Name {
-			return true
+// VMPool is a pool of VMs in addition to the Type/Count VMs of the manager. Pool VMs only fuzz
+// (triage and repro instances are taken from the main VMs) and run the same OS and target binaries.
+type VMPool struct {
+	Type   string          // VM type
+	Count  int             // number of VMs
+	Params json.RawMessage // params of the type, like the config section named after the type (e.g. "qemu": {...})
+	// Liveness timeouts of pool VMs (see No_Output_Timeout), the defaults depend on Type.
+	No_Output_Timeout     int
+	Not_Executing_Timeout int
+}
+
+func checkPools(cfg *Config) error {
+	for i := range cfg.Pools {
+		pool := &cfg.Pools[i]
+		if pool.Type == "none" || !isVMType(pool.Type) {
+			return fmt.Errorf("config param pools[%v].type must contain one of %v", i, strings.Join(vm.Types(), "/"))
 		}
-		if len(str) > 1 && str[len(str)-1] == '*' && strings.HasPrefix(call.Name, str[:len(str)-1]) {
-			return true
+		if cfg.Type == "none" {
+			return fmt.Errorf("config param pools can't be used with type none")
+		}
+		if cfg.OS != "linux" && pool.Type != cfg.Type {
+			return fmt.Errorf("config param pools[%v].type: os %v supports only type %v", i, cfg.OS, cfg.Type)
+		}
+		if pool.Count <= 0 || pool.Count > 1000 {
+			return fmt.Errorf("invalid config param pools[%v].count: %v, want (1, 1000]", i, pool.Count)
+		}
+		if pool.No_Output_Timeout < 0 || pool.Not_Executing_Timeout < 0 {
+			return fmt.Errorf("config params pools[%v].no_output_timeout/not_executing_timeout must not be negative", i)
+		}
+		noOutput, notExecuting := livenessTimeouts(pool.Type, cfg.Target)
+		if pool.No_Output_Timeout == 0 {
+			pool.No_Output_Timeout = noOutput
+		}
+		if pool.Not_Executing_Timeout == 0 {
+			pool.Not_Executing_Timeout = notExecuting
+		}
+		if err := vm.Validate(pool.Type, pool.Params); err != nil {
+			return fmt.Errorf("config param pools[%v]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// FuzzerOverride applies to instances with the listed indexes (the number in VM name, e.g. 3 in qemu-3).
+type FuzzerOverride struct {
+	Instances string   // comma-separated indexes and ranges, e.g. "0-3,7" (all instances if empty)
+	Env       []string // NAME=VALUE environment variables of syz-fuzzer
+	Args      string   // flags appended to syz-fuzzer command line, e.g. "-foo=1 -bar"
+	// Name of the A/B group of the instances, stats are broken down per group (the last matching group wins).
+	Group string
+
+	ranges [][2]int
+}
+
+var envNameRe = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+func checkFuzzerOverrides(cfg *Config) error {
+	for i := range cfg.Fuzzer_Overrides {
+		o := &cfg.Fuzzer_Overrides[i]
+		o.ranges = nil
+		for _, r := range strings.Split(o.Instances, ",") {
+			r = strings.TrimSpace(r)
+			if r == "" {
+				continue
+			}
+			bounds := strings.SplitN(r, "-", 2)
+			first, err := strconv.Atoi(bounds[0])
+			last := first
+			if err == nil && len(bounds) == 2 {
+				last, err = strconv.Atoi(bounds[1])
+			}
+			if err != nil || first < 0 || last < first {
+				return fmt.Errorf("config param fuzzer_overrides: bad instances '%v'", r)
+			}
+			o.ranges = append(o.ranges, [2]int{first, last})
+		}
+		for _, env := range o.Env {
+			kv := strings.SplitN(env, "=", 2)
+			if len(kv) != 2 || !envNameRe.MatchString(kv[0]) || strings.ContainsAny(kv[1], " \t\n'\"") {
+				return fmt.Errorf("config param fuzzer_overrides: bad env '%v' (want NAME=VALUE without spaces and quotes)", env)
+			}
+		}
+		if strings.ContainsAny(o.Args, "'\"\n") {
+			return fmt.Errorf("config param fuzzer_overrides: args must not contain quotes")
+		}
+	}
+	return nil
+}
+
+// FuzzerOverrides returns environment variables and extra syz-fuzzer flags of instance with the index,
+// all matching overrides are applied in order.
+func (cfg *Config) FuzzerOverrides(index int) (env []string, args string) {
+	for _, o := range cfg.Fuzzer_Overrides {
+		if !o.matches(index) {
+			continue
+		}
+		env = append(env, o.Env...)
+		if a := strings.TrimSpace(o.Args); a != "" {
+			args = strings.TrimSpace(args + " " + a)
+		}
+	}
+	return env, args
+}
+
+// FuzzerGroup returns the A/B group of instance with the index ("" if it is not in a group).
+func (cfg *Config) FuzzerGroup(index int) string {
+	group := ""
+	for _, o := range cfg.Fuzzer_Overrides {
+		if o.Group != "" && o.matches(index) {
+			group = o.Group
+		}
+	}
+	return group
+}
+
+func (o *FuzzerOverride) matches(index int) bool {
+	match := len(o.ranges) == 0
+	for _, r := range o.ranges {
+		match = match || index >= r[0] && index <= r[1]
+	}
+	return match
+}
+
+func checkBudget(cfg *Config) error {
+	if cfg.Program_Length < 0 || cfg.Max_Program_Length < 0 || cfg.Max_Ptr_Depth < 0 || cfg.Max_Buf_Len < 0 {
+		return fmt.Errorf("config params program_length/max_program_length/max_ptr_depth/max_buf_len must not be negative")
+	}
+	if cfg.Program_Length == 0 {
+		cfg.Program_Length = 30
+	}
+	if cfg.Max_Program_Length == 0 {
+		cfg.Max_Program_Length = 2 * cfg.Program_Length
+	}
+	if cfg.Max_Program_Length < cfg.Program_Length {
+		return fmt.Errorf("config param max_program_length (%v) is less than program_length (%v)",
+			cfg.Max_Program_Length, cfg.Program_Length)
+	}
+	if cfg.Max_Ptr_Depth == 0 {
+		cfg.Max_Ptr_Depth = prog.DefaultBudget.PtrDepth
+	}
+	if cfg.Max_Buf_Len == 0 {
+		cfg.Max_Buf_Len = prog.DefaultBudget.BufLen
+	}
+	return nil
+}
+
+// Budget returns limits for fuzzing programs.
+func (cfg *Config) Budget() prog.Budget {
+	return prog.Budget{
+		MaxCalls: cfg.Max_Program_Length,
+		PtrDepth: cfg.Max_Ptr_Depth,
+		BufLen:   cfg.Max_Buf_Len,
+	}
+}
+
+// Experiments control mutation and generation of programs by fuzzers.
+type Experiments struct {
+	// Relative probabilities of mutation operators (see prog.MutationWeights).
+	Insert_Call int
+	Mutate_Arg  int
+	Squash      int
+	Remove_Call int
+
+	Splice_Prob     float64 // fraction of corpus mutations that splice in calls of another corpus program
+	Generate_Period int     // every n-th fuzzing program is generated rather than mutated from corpus
+	Mutate_Length   int     // mutations insert calls while programs are shorter (default: program_length)
+}
+
+func DefaultExperiments() *Experiments {
+	w := prog.DefaultMutationWeights
+	return &Experiments{
+		Insert_Call:     w.InsertCall,
+		Mutate_Arg:      w.MutateArg,
+		Squash:          w.Squash,
+		Remove_Call:     w.RemoveCall,
+		Splice_Prob:     0.1,
+		Generate_Period: 10,
+	}
+}
+
+var knownExperimentsFields = []string{
+	"Insert_Call",
+	"Mutate_Arg",
+	"Squash",
+	"Remove_Call",
+	"Splice_Prob",
+	"Generate_Period",
+	"Mutate_Length",
+}
+
+func checkExperiments(cfg *Config) error {
+	if cfg.Experiments == nil {
+		cfg.Experiments = DefaultExperiments()
+	}
+	e := cfg.Experiments
+	w := cfg.MutationWeights()
+	if err := w.Validate(); err != nil {
+		return fmt.Errorf("bad config param experiments: %v", err)
+	}
+	if e.Splice_Prob < 0 || e.Splice_Prob > 1 {
+		return fmt.Errorf("bad config param experiments.splice_prob: %v, want [0, 1]", e.Splice_Prob)
+	}
+	if e.Generate_Period <= 0 {
+		return fmt.Errorf("bad config param experiments.generate_period: %v, want > 0", e.Generate_Period)
+	}
+	if e.Mutate_Length < 0 || e.Mutate_Length > cfg.Max_Program_Length {
+		return fmt.Errorf("bad config param experiments.mutate_length: %v, want [0, %v]",
+			e.Mutate_Length, cfg.Max_Program_Length)
+	}
+	if e.Mutate_Length == 0 {
+		e.Mutate_Length = cfg.Program_Length
+	}
+	return nil
+}
+
+// MutationWeights returns weights of mutation operators of fuzzers.
+func (cfg *Config) MutationWeights() prog.MutationWeights {
+	e := cfg.Experiments
+	return prog.MutationWeights{
+		InsertCall: e.Insert_Call,
+		MutateArg:  e.Mutate_Arg,
+		Squash:     e.Squash,
+		RemoveCall: e.Remove_Call,
+	}
+}
+
+// MatchSyscalls returns calls that match pattern: a call name (e.g. "ioctl" means all ioctl variants)
+// or a glob pattern of call names (e.g. "ioctl$DRM_*").
+func MatchSyscalls(pattern string) ([]*sys.Call, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("bad syscall pattern %q: %v", pattern, err)
+	}
+	var calls []*sys.Call
+	for _, call := range sys.Calls {
+		if ok, _ := path.Match(pattern, call.Name); ok || pattern == call.CallName {
+			calls = append(calls, call)
 		}
-		return false
 	}
+	return calls, nil
+}
 
+// parseSyscalls returns IDs of enabled syscalls. Enable_Syscalls and Disable_Syscalls contain call names
+// (e.g. "ioctl" means all ioctl variants, "ioctl$DRM_IOCTL_VERSION" means one variant) or glob patterns
+// of call names (e.g. "ioctl$DRM_*", "socket$netlink*" or "*$bt_*"), disabled calls override enabled.
+func parseSyscalls(cfg *Config) (map[int]bool, error) {
 	syscalls := make(map[int]bool)
 	if len(cfg.Enable_Syscalls) != 0 {
 		for _, c := range cfg.Enable_Syscalls {
-			n := 0
-			for _, call := range sys.Calls {
-				if match(call, c) {
-					syscalls[call.ID] = true
-					n++
-				}
+			calls, err := MatchSyscalls(c)
+			if err != nil {
+				return nil, err
 			}
-			if n == 0 {
+			if len(calls) == 0 {
 				return nil, fmt.Errorf("unknown enabled syscall: %v", c)
 			}
+			for _, call := range calls {
+				syscalls[call.ID] = true
+			}
 		}
 	} else {
 		for _, call := range sys.Calls {
@@ -178,16 +794,16 @@ func parseSyscalls(cfg *Config) (map[int]bool, error) {
 		}
 	}
 	for _, c := range cfg.Disable_Syscalls {
-		n := 0
-		for _, call := range sys.Calls {
-			if match(call, c) {
-				delete(syscalls, call.ID)
-				n++
-			}
+		calls, err := MatchSyscalls(c)
+		if err != nil {
+			return nil, err
 		}
-		if n == 0 {
+		if len(calls) == 0 {
 			return nil, fmt.Errorf("unknown disabled syscall: %v", c)
 		}
+		for _, call := range calls {
+			delete(syscalls, call.ID)
+		}
 	}
 	// They will be generated anyway.
 	syscalls[sys.CallMap["mmap"].ID] = true
@@ -219,76 +835,291 @@ func parseSuppressions(cfg *Config) ([]*regexp.Regexp, error) {
 	return suppressions, nil
 }
 
+// CreateVMConfig creates config of a new VM of Type.
 func CreateVMConfig(cfg *Config) (*vm.Config, error) {
+	return createVMConfig(cfg, cfg.Type, cfg.VM)
+}
+
+// CreatePoolVMConfig creates config of a new VM of the pool (see Pools).
+func CreatePoolVMConfig(cfg *Config, pool *VMPool) (*vm.Config, error) {
+	return createVMConfig(cfg, pool.Type, pool.Params)
+}
+
+func createVMConfig(cfg *Config, typ string, params json.RawMessage) (*vm.Config, error) {
 	workdir, index, err := fileutil.ProcessTempDir(cfg.Workdir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create instance temp dir: %v", err)
 	}
+	cmdline := cfg.Cmdline
+	if len(cfg.Boot_Params) != 0 {
+		rnd := rand.New(rand.NewSource(time.Now().UnixNano() + int64(index)*1e12))
+		cmdline = strings.TrimSpace(cmdline + " " + chooseBootParams(rnd, cfg.Boot_Params))
+	}
 	vmCfg := &vm.Config{
-		Name:       fmt.Sprintf("%v-%v", cfg.Type, index),
-		Index:      index,
-		Workdir:    workdir,
-		Bin:        cfg.Bin,
-		Kernel:     cfg.Kernel,
-		Cmdline:    cfg.Cmdline,
-		Image:      cfg.Image,
-		Initrd:     cfg.Initrd,
-		Sshkey:     cfg.Sshkey,
-		Executor:   filepath.Join(cfg.Syzkaller, "bin", "syz-executor"),
-		ConsoleDev: cfg.ConsoleDev,
-		Cpu:        cfg.Cpu,
-		Mem:        cfg.Mem,
-		Debug:      cfg.Debug,
+		Name:     fmt.Sprintf("%v-%v", typ, index),
+		Index:    index,
+		Workdir:  workdir,
+		Executor: cfg.TargetBin("syz-executor"),
+		OS:       cfg.OS,
+		Cmdline:  cmdline,
+		Debug:    cfg.Debug,
+		Params:   params,
 	}
 	return vmCfg, nil
 }
 
-func checkUnknownFields(data []byte) (string, error) {
-	// While https://github.com/golang/go/issues/15314 is not resolved
-	// we don't have a better way than to enumerate all known fields.
-	var fields = []string{
-		"Http",
-		"Rpc",
-		"Workdir",
-		"Vmlinux",
-		"Kernel",
-		"Cmdline",
-		"Image",
-		"Cpu",
-		"Mem",
-		"Sshkey",
-		"Port",
-		"Bin",
-		"Debug",
-		"Output",
-		"Syzkaller",
-		"Type",
-		"Count",
-		"Procs",
-		"Cover",
-		"Sandbox",
-		"Leak",
-		"ConsoleDev",
-		"Enable_Syscalls",
-		"Disable_Syscalls",
-		"Suppressions",
-		"Initrd",
+// livenessTimeouts returns default liveness timeouts of the VM type in seconds.
+// Devices connected with adb (often over USB2) and targets that qemu emulates without kvm
+// (arm64 and ppc64le on x86 hosts) are several times slower than kvm VMs.
+func livenessTimeouts(typ, target string) (noOutput, notExecuting int) {
+	switch {
+	case typ == "adb":
+		return 5 * 60, 10 * 60
+	case typ == "qemu" && (target == "arm64" || target == "ppc64le"):
+		return 5 * 60, 10 * 60
+	default:
+		return 60, 3 * 60
+	}
+}
+
+// TargetBin returns path to the binary that runs inside of VMs.
+// Binaries for the non-default target are in bin/target (e.g. bin/386, see "make TARGET=386").
+// gVisor runs Linux binaries.
+func (cfg *Config) TargetBin(name string) string {
+	return filepath.Join(cfg.Syzkaller, "bin", cfg.Target, name)
+}
+
+// checkOS checks that the config does not use features that are not supported by the OS
+// and sets OS-specific defaults.
+func checkOS(cfg *Config) error {
+	if cfg.Fast_Timers && cfg.OS != "" && cfg.OS != "linux" {
+		return fmt.Errorf("config param fast_timers is supported only for os linux")
+	}
+	if (cfg.Console_Loglevel != 0 || cfg.Printk_Ratelimit != 0 || cfg.Printk_Ratelimit_Burst != 0) &&
+		cfg.OS != "" && cfg.OS != "linux" {
+		return fmt.Errorf("config params console_loglevel/printk_ratelimit/printk_ratelimit_burst are supported only for os linux")
 	}
+	switch cfg.OS {
+	case "", "linux":
+		cfg.OS = "linux"
+		if cfg.Sandbox == "" {
+			cfg.Sandbox = "setuid"
+		}
+	case "gvisor":
+		// gVisor implements Linux syscalls in user space, so Linux binaries, descriptions and sandboxes
+		// work as is, but there is no kmemleak/KCSAN/cgroups and it can't run in VMs.
+		if cfg.Sandbox == "" {
+			cfg.Sandbox = "setuid"
+		}
+		if cfg.Leak || cfg.Nonfatal_Data_Races || cfg.Cgroup_Mem != 0 || cfg.Cgroup_Pids != 0 || cfg.Mem_Pressure != 0 {
+			return fmt.Errorf("config params leak/nonfatal_data_races/cgroup_mem/cgroup_pids/mem_pressure are not supported for os gvisor")
+		}
+		if cfg.Type != "" && cfg.Type != "gvisor" {
+			return fmt.Errorf("config param type: os gvisor supports only type gvisor")
+		}
+		switch cfg.Target {
+		case "", "amd64":
+		default:
+			return fmt.Errorf("config param target: os gvisor supports only amd64")
+		}
+	default:
+		return fmt.Errorf("config param os must contain one of linux/gvisor")
+	}
+	return nil
+}
+
+// chooseBootParams selects a random fragment from every group of boot params.
+func chooseBootParams(rnd *rand.Rand, groups [][]string) string {
+	var params []string
+	for _, group := range groups {
+		if p := group[rnd.Intn(len(group))]; p != "" {
+			params = append(params, p)
+		}
+	}
+	return strings.Join(params, " ")
+}
+
+// While https://github.com/golang/go/issues/15314 is not resolved
+// we don't have a better way than to enumerate all known fields.
+var knownFields = []string{
+	"Http",
+	"Rpc",
+	"Workdir",
+	"Vmlinux",
+	"Cmdline",
+	"Boot_Params",
+	"Cgroup_Mem",
+	"Cgroup_Pids",
+	"Mem_Pressure",
+	"Count_Objects",
+	"Debug",
+	"Output",
+	"Syzkaller",
+	"Type",
+	"OS",
+	"Target",
+	"Count",
+	"Procs",
+	"Pools",
+	"Instance_Lifetime",
+	"Restart_After_Crashes",
+	"Restart_Backoff",
+	"Triage_Count",
+	"Repro_Count",
+	"Retest_Repros",
+	"Notify_Cmd",
+	"Notify_Score",
+	"Fuzzer_Overrides",
+	"Cover",
+	"Sandbox",
+	"Leak",
+	"Nonfatal_Data_Races",
+	"Hang_Action",
+	"Hang_Context_After",
+	"Crash_Context_Before",
+	"Crash_Context_After",
+	"Crash_Full_Log",
+	"Crash_Programs",
+	"Kdump_Kernel",
+	"Log_Verbosity",
+	"Log_Rotate_Size",
+	"Log_Rotate_Count",
+	"Console_Archive_Size",
+	"Console_Archive_Count",
+	"Fast_Timers",
+	"Console_Loglevel",
+	"Printk_Ratelimit",
+	"Printk_Ratelimit_Burst",
+	"Console_Flood",
+	"No_Output_Timeout",
+	"Not_Executing_Timeout",
+	"Adaptive_Calls",
+	"Call_Ngrams",
+	"Corpus_Rotation",
+	"Corpus_Rotation_Period",
+	"Backup",
+	"Backup_Period",
+	"Api_Key",
+	"Cover_Peers",
+	"Fuzzer_Debug_Port",
+	"Program_Length",
+	"Max_Program_Length",
+	"Max_Ptr_Depth",
+	"Max_Buf_Len",
+	"Experiments",
+	"Enable_Syscalls",
+	"Disable_Syscalls",
+	"Suppressions",
+	"Ignores",
+	"Focus_Files",
+	"Focus_Functions",
+	"Seeds",
+	"Seccomp_Profile",
+	"Kernel_Commit",
+	"Kernel_Config",
+	"Kernel_Src",
+	"Module_Objs",
+	"Patch_Image_Cmd",
+}
+
+func checkUnknownFields(data []byte) (string, error) {
 	f := make(map[string]interface{})
 	if err := json.Unmarshal(data, &f); err != nil {
 		return "", fmt.Errorf("failed to parse config file: %v", err)
 	}
-	for k := range f {
-		ok := false
-		for _, k1 := range fields {
-			if strings.ToLower(k) == strings.ToLower(k1) {
-				ok = true
-				break
-			}
+	for k, v := range f {
+		if isVMType(k) {
+			continue
 		}
-		if !ok {
+		if !isKnownField(k, knownFields) {
 			return k, nil
 		}
+		if sub, ok := v.(map[string]interface{}); ok && strings.ToLower(k) == "experiments" {
+			for k1 := range sub {
+				if !isKnownField(k1, knownExperimentsFields) {
+					return k + "." + k1, nil
+				}
+			}
+		}
 	}
 	return "", nil
 }
+
+func isKnownField(name string, fields []string) bool {
+	for _, field := range fields {
+		if strings.ToLower(name) == strings.ToLower(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestField returns a known config field that is most similar to the unknown field name
+// (to catch typos like "sandbox " or "enable_syscall"), or "" if nothing is similar enough.
+func suggestField(name string) string {
+	name = strings.ToLower(name)
+	best, bestDist := "", 3
+	for _, field := range knownFields {
+		field = strings.ToLower(field)
+		// Short field names are similar to everything, so they are suggested only for small typos.
+		if dist := editDistance(name, field); dist < bestDist && dist < len(field)-1 || strings.TrimSpace(name) == field {
+			best, bestDist = field, dist
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func isVMType(name string) bool {
+	for _, typ := range vm.Types() {
+		if strings.ToLower(name) == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// parseVMParams extracts the config section for the VM type and lets the vm package validate it,
+// so that errors are detected upfront rather than when VMs are created.
+// Sections for other VM types are rejected since they would be silently ignored.
+func parseVMParams(cfg *Config, data []byte) error {
+	if cfg.Type != "none" && !isVMType(cfg.Type) {
+		return fmt.Errorf("config param type must contain one of %v/none", strings.Join(vm.Types(), "/"))
+	}
+	sections := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return fmt.Errorf("failed to parse config file: %v", err)
+	}
+	for name, section := range sections {
+		if !isVMType(name) {
+			continue
+		}
+		if strings.ToLower(name) != cfg.Type {
+			return fmt.Errorf("config section %v is not used by type %v", name, cfg.Type)
+		}
+		cfg.VM = section
+	}
+	if cfg.Type == "none" {
+		return nil
+	}
+	return vm.Validate(cfg.Type, cfg.VM)
+}
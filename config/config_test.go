@@ -4,7 +4,21 @@
 package config
 
 import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"strings"
 	"testing"
+
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys"
+	_ "github.com/google/syzkaller/vm/adb"
+	_ "github.com/google/syzkaller/vm/gvisor"
+	_ "github.com/google/syzkaller/vm/isolated"
+	_ "github.com/google/syzkaller/vm/kvm"
+	_ "github.com/google/syzkaller/vm/local"
+	_ "github.com/google/syzkaller/vm/qemu"
 )
 
 func TestUnknown(t *testing.T) {
@@ -14,3 +28,332 @@ func TestUnknown(t *testing.T) {
 		t.Fatalf("unknown field is not detected (%v)", err)
 	}
 }
+
+func TestBootParams(t *testing.T) {
+	groups := [][]string{
+		{"slub_debug=FZ", "slub_debug=P"},
+		{"nosmp", ""},
+		{""},
+	}
+	rnd := rand.New(rand.NewSource(0))
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		params := chooseBootParams(rnd, groups)
+		seen[params] = true
+		fields := strings.Fields(params)
+		if len(fields) == 0 || len(fields) > 2 || !strings.HasPrefix(fields[0], "slub_debug=") {
+			t.Fatalf("bad boot params: '%v'", params)
+		}
+		if len(fields) == 2 && fields[1] != "nosmp" {
+			t.Fatalf("bad boot params: '%v'", params)
+		}
+	}
+	if len(seen) != 4 {
+		t.Fatalf("expected 4 different combinations, got %v", len(seen))
+	}
+}
+
+func TestUnknownSuggestion(t *testing.T) {
+	tests := map[string]string{
+		`{"sandbox ": "none"}`:           "unknown field 'sandbox ' in config, did you mean 'sandbox'?",
+		`{"enable_syscall": []}`:         "unknown field 'enable_syscall' in config, did you mean 'enable_syscalls'?",
+		`{"Vmlinx": "/vmlinux"}`:         "unknown field 'Vmlinx' in config, did you mean 'vmlinux'?",
+		`{"something_else": "bar"}`:      "unknown field 'something_else' in config",
+		`{"experiments": {"squsah": 1}}`: "unknown field 'experiments.squsah' in config",
+	}
+	for data, want := range tests {
+		_, _, _, err := parse([]byte(data))
+		if err == nil || err.Error() != want {
+			t.Fatalf("config %v: want error '%v', got '%v'", data, want, err)
+		}
+	}
+}
+
+func TestParseVMParams(t *testing.T) {
+	key, err := ioutil.TempFile("", "syz-config-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key.Close()
+	defer os.Remove(key.Name())
+	if err := os.Chmod(key.Name(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		typ  string
+		data string
+		err  string
+	}{
+		{"local", `{}`, ""},
+		{"qeum", `{}`, "config param type must contain one of adb/gvisor/isolated/kvm/local/qemu/none"},
+		{"qemu", fmt.Sprintf(`{"qemu": {"sshkey": %q, "cpu": 1, "mem": 1024}}`, key.Name()), "config param qemu.image is required"},
+		{"adb", `{}`, "config param adb.console is required"},
+		{"adb", `{"adb": {"devices": [{"serial": "a", "console": "tcp://host:1234"}, {"console": "tcp://host:1235"}]}}`,
+			"adb.devices[1].serial is required"},
+		{"adb", `{"adb": {"devices": [{"serial": "host:5555", "console": "tcp://host"}]}}`, "bad config param adb.devices[0].console"},
+		{"adb", `{"adb": {"devices": [{"serial": "host:5555", "console": "tcp://host:1234", "server": "farm:5037"}]}}`, ""},
+		{"qemu", fmt.Sprintf(`{"qemu": {"image": %q, "sshkey": %q, "cpu": 1, "mem": 1024}}`, key.Name(), key.Name()), "is accessible by others"},
+		{"qemu", fmt.Sprintf(`{"qemu": {"image": %q, "sshkey": %q, "icount": "11"}}`, key.Name(), key.Name()), "bad config param qemu.icount"},
+		{"kvm", `{"kvm": {"kernel": "/non/existent/bzImage"}}`, "bad config param kvm.kernel"},
+		{"kvm", `{"kvm": {"kernel": "/non/existent/bzImage", "image": "foo"}}`, "unknown config param kvm.image"},
+		{"local", `{"qemu": {}}`, "config section qemu is not used by type local"},
+		{"local", `{"local": {"cpu": 1}}`, "type local does not have config params"},
+		{"isolated", `{"isolated": {"sshkey": "/non/existent/key"}}`, "config param isolated.targets is empty"},
+	}
+	for i, test := range tests {
+		err := parseVMParams(&Config{Type: test.typ}, []byte(test.data))
+		if test.err == "" && err != nil || test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Fatalf("test #%v: want error '%v', got '%v'", i, test.err, err)
+		}
+	}
+	if err := os.Chmod(key.Name(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{Type: "qemu"}
+	data := fmt.Sprintf(`{"qemu": {"image": %q, "sshkey": %q, "cpu": 1, "mem": 1024}}`, key.Name(), key.Name())
+	if err := parseVMParams(cfg, []byte(data)); err != nil {
+		t.Fatalf("valid config is rejected: %v", err)
+	}
+	if len(cfg.VM) == 0 {
+		t.Fatalf("qemu config section is not extracted")
+	}
+}
+
+func TestCheckOS(t *testing.T) {
+	tests := []struct {
+		cfg Config
+		err string
+	}{
+		{Config{}, ""},
+		{Config{OS: "plan9"}, "config param os must contain one of linux/gvisor"},
+		{Config{OS: "freebsd"}, "config param os must contain one of linux/gvisor"},
+		{Config{OS: "windows"}, "config param os must contain one of linux/gvisor"},
+		{Config{OS: "gvisor", Fast_Timers: true}, "fast_timers is supported only for os linux"},
+		{Config{Fast_Timers: true}, ""},
+		{Config{OS: "gvisor", Type: "gvisor", Console_Loglevel: 7}, "console_loglevel/printk_ratelimit/printk_ratelimit_burst are supported only for os linux"},
+		{Config{OS: "gvisor", Type: "gvisor"}, ""},
+		{Config{OS: "gvisor", Type: "qemu"}, "os gvisor supports only type gvisor"},
+		{Config{OS: "gvisor", Type: "gvisor", Leak: true}, "are not supported for os gvisor"},
+		{Config{OS: "gvisor", Type: "gvisor", Mem_Pressure: 64}, "are not supported for os gvisor"},
+	}
+	for i, test := range tests {
+		err := checkOS(&test.cfg)
+		if test.err == "" && err != nil || test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Fatalf("test #%v: want error '%v', got '%v'", i, test.err, err)
+		}
+	}
+	cfg := &Config{OS: "gvisor", Syzkaller: "/syzkaller"}
+	if err := checkOS(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Sandbox != "setuid" || cfg.TargetBin("syz-fuzzer") != "/syzkaller/bin/syz-fuzzer" {
+		t.Fatalf("bad gvisor defaults: sandbox %v, fuzzer %v", cfg.Sandbox, cfg.TargetBin("syz-fuzzer"))
+	}
+}
+
+func TestCheckBudget(t *testing.T) {
+	tests := []struct {
+		cfg Config
+		err string
+	}{
+		{Config{}, ""},
+		{Config{Program_Length: 10, Max_Program_Length: 10, Max_Ptr_Depth: 1, Max_Buf_Len: 1}, ""},
+		{Config{Max_Buf_Len: -1}, "must not be negative"},
+		{Config{Program_Length: 40, Max_Program_Length: 20}, "max_program_length (20) is less than program_length (40)"},
+	}
+	for i, test := range tests {
+		err := checkBudget(&test.cfg)
+		if test.err == "" && err != nil || test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Fatalf("test #%v: want error '%v', got '%v'", i, test.err, err)
+		}
+	}
+	cfg := &Config{Program_Length: 20}
+	if err := checkBudget(cfg); err != nil {
+		t.Fatal(err)
+	}
+	budget := cfg.Budget()
+	if err := budget.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if budget.MaxCalls != 40 || budget.PtrDepth != prog.DefaultBudget.PtrDepth || budget.BufLen != prog.DefaultBudget.BufLen {
+		t.Fatalf("bad default budget: %+v", budget)
+	}
+}
+
+func TestCheckExperiments(t *testing.T) {
+	cfg := &Config{Program_Length: 30, Max_Program_Length: 60}
+	if err := checkExperiments(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if w := cfg.MutationWeights(); w != prog.DefaultMutationWeights {
+		t.Fatalf("bad default mutation weights: %+v", w)
+	}
+	if cfg.Experiments.Mutate_Length != 30 || cfg.Experiments.Generate_Period != 10 {
+		t.Fatalf("bad default experiments: %+v", *cfg.Experiments)
+	}
+	tests := []struct {
+		e   func(*Experiments)
+		err string
+	}{
+		{func(e *Experiments) { e.Squash, e.Mutate_Arg = 0, 0 }, ""},
+		{func(e *Experiments) { e.Mutate_Length = 60 }, ""},
+		{func(e *Experiments) { e.Squash = -1 }, "want >= 0"},
+		{func(e *Experiments) { e.Remove_Call = 0 }, "must be > 0"},
+		{func(e *Experiments) { e.Splice_Prob = 1.5 }, "splice_prob"},
+		{func(e *Experiments) { e.Generate_Period = 0 }, "generate_period"},
+		{func(e *Experiments) { e.Mutate_Length = 61 }, "mutate_length"},
+	}
+	for i, test := range tests {
+		cfg := &Config{Program_Length: 30, Max_Program_Length: 60, Experiments: DefaultExperiments()}
+		test.e(cfg.Experiments)
+		err := checkExperiments(cfg)
+		if test.err == "" && err != nil || test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Fatalf("test #%v: want error '%v', got '%v'", i, test.err, err)
+		}
+	}
+}
+
+func TestParseSyscalls(t *testing.T) {
+	tests := []struct {
+		enable  []string
+		disable []string
+		want    []string
+		notWant []string
+		err     string
+	}{
+		{
+			enable:  []string{"ioctl$DRM_*"},
+			want:    []string{"ioctl$DRM_IOCTL_VERSION", "mmap"},
+			notWant: []string{"ioctl", "ioctl$TCSETS", "read"},
+		},
+		{
+			enable:  []string{"socket$netlink*", "ioctl"},
+			disable: []string{"ioctl$DRM_*"},
+			want:    []string{"socket$netlink", "ioctl", "ioctl$TCSETS"},
+			notWant: []string{"ioctl$DRM_IOCTL_VERSION", "socket$unix"},
+		},
+		{
+			disable: []string{"*$bt_*"},
+			want:    []string{"read", "socket$unix"},
+			notWant: []string{"socket$bt_hci", "socket$bt_sco"},
+		},
+		{
+			enable: []string{"ioctl$NO_SUCH_*"},
+			err:    "unknown enabled syscall",
+		},
+		{
+			enable: []string{"ioctl$[DRM"},
+			err:    "bad syscall pattern",
+		},
+	}
+	for i, test := range tests {
+		calls, err := parseSyscalls(&Config{Enable_Syscalls: test.enable, Disable_Syscalls: test.disable})
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("test #%v: want error '%v', got '%v'", i, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test #%v: %v", i, err)
+		}
+		for _, name := range test.want {
+			if !calls[sys.CallMap[name].ID] {
+				t.Fatalf("test #%v: %v is not enabled", i, name)
+			}
+		}
+		for _, name := range test.notWant {
+			if calls[sys.CallMap[name].ID] {
+				t.Fatalf("test #%v: %v is enabled", i, name)
+			}
+		}
+	}
+}
+
+func TestFuzzerOverrides(t *testing.T) {
+	cfg := &Config{Fuzzer_Overrides: []FuzzerOverride{
+		{Env: []string{"GOGC=50"}},
+		{Instances: "0-1, 5", Args: "-foo=1", Env: []string{"A=b"}},
+		{Instances: "1", Args: " -bar "},
+	}}
+	if err := checkFuzzerOverrides(cfg); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		index int
+		env   string
+		args  string
+	}{
+		{0, "GOGC=50 A=b", "-foo=1"},
+		{1, "GOGC=50 A=b", "-foo=1 -bar"},
+		{2, "GOGC=50", ""},
+		{5, "GOGC=50 A=b", "-foo=1"},
+	}
+	for _, test := range tests {
+		env, args := cfg.FuzzerOverrides(test.index)
+		if strings.Join(env, " ") != test.env || args != test.args {
+			t.Fatalf("instance %v: got env '%v' args '%v', want '%v' '%v'", test.index, env, args, test.env, test.args)
+		}
+	}
+	cfg = &Config{Fuzzer_Overrides: []FuzzerOverride{
+		{Instances: "0-3", Group: "a"},
+		{Instances: "4-7", Group: "b", Args: "-collide_prob=0"},
+		{Instances: "3", Env: []string{"GOGC=50"}},
+		{Instances: "2", Group: "c"},
+	}}
+	if err := checkFuzzerOverrides(cfg); err != nil {
+		t.Fatal(err)
+	}
+	for index, want := range []string{"a", "a", "c", "a", "b", "b", "b", "b", ""} {
+		if group := cfg.FuzzerGroup(index); group != want {
+			t.Fatalf("instance %v: got group '%v', want '%v'", index, group, want)
+		}
+	}
+	for _, bad := range []FuzzerOverride{
+		{Instances: "3-1"},
+		{Instances: "x"},
+		{Instances: "-1"},
+		{Env: []string{"A"}},
+		{Env: []string{"1A=b"}},
+		{Env: []string{"A=b c"}},
+		{Args: "-foo='a b'"},
+	} {
+		if err := checkFuzzerOverrides(&Config{Fuzzer_Overrides: []FuzzerOverride{bad}}); err == nil {
+			t.Fatalf("override %+v is not rejected", bad)
+		}
+	}
+}
+
+func TestCheckPools(t *testing.T) {
+	tests := []struct {
+		pool VMPool
+		err  string
+	}{
+		{VMPool{Type: "local", Count: 2}, ""},
+		{VMPool{Type: "none", Count: 1}, "config param pools[0].type must contain one of"},
+		{VMPool{Type: "qemu ", Count: 1}, "config param pools[0].type must contain one of"},
+		{VMPool{Type: "local", Count: 0}, "invalid config param pools[0].count"},
+		{VMPool{Type: "local", Count: 1, No_Output_Timeout: -1}, "must not be negative"},
+		{VMPool{Type: "local", Count: 1, Params: []byte(`{"cpu": 1}`)}, "config param pools[0]: type local does not have config params"},
+		{VMPool{Type: "isolated", Count: 1, Params: []byte(`{}`)}, "config param pools[0]: config param isolated.targets is empty"},
+	}
+	for i, test := range tests {
+		cfg := &Config{Type: "qemu", OS: "linux", Pools: []VMPool{test.pool}}
+		err := checkPools(cfg)
+		if test.err == "" && err != nil || test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Fatalf("test #%v: want error '%v', got '%v'", i, test.err, err)
+		}
+	}
+	cfg := &Config{Type: "qemu", OS: "linux", Pools: []VMPool{{Type: "adb", Count: 1, Not_Executing_Timeout: 30}}}
+	cfg.Pools[0].Params = []byte(`{"console": "tcp://board:1234"}`)
+	if err := checkPools(cfg); err != nil {
+		t.Fatalf("valid pool is rejected: %v", err)
+	}
+	if pool := cfg.Pools[0]; pool.No_Output_Timeout != 300 || pool.Not_Executing_Timeout != 30 {
+		t.Fatalf("bad pool liveness timeouts: %v, %v", pool.No_Output_Timeout, pool.Not_Executing_Timeout)
+	}
+	cfg = &Config{Type: "gvisor", OS: "gvisor", Pools: []VMPool{{Type: "local", Count: 1}}}
+	if err := checkPools(cfg); err == nil {
+		t.Fatalf("pool of other type is accepted for gvisor")
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Compress encodes canonical (sorted) cover as varint deltas between consecutive PCs.
+// PCs of a single input are close to each other, so most deltas fit into 1-2 bytes
+// instead of 4 bytes per PC (and instead of ~10 bytes per PC in JSON-RPC).
+func Compress(cov Cover) []byte {
+	if len(cov) == 0 {
+		return nil
+	}
+	buf := make([]byte, 0, 2*len(cov))
+	var tmp [binary.MaxVarintLen32]byte
+	prev := uint32(0)
+	for _, pc := range cov {
+		n := binary.PutUvarint(tmp[:], uint64(pc-prev))
+		buf = append(buf, tmp[:n]...)
+		prev = pc
+	}
+	return buf
+}
+
+// Decompress decodes cover encoded with Compress.
+func Decompress(data []byte) (Cover, error) {
+	var cov Cover
+	prev := uint32(0)
+	for len(data) != 0 {
+		delta, n := binary.Uvarint(data)
+		if n <= 0 || delta > uint64(^uint32(0)-prev) {
+			return nil, fmt.Errorf("corrupted compressed cover")
+		}
+		prev += uint32(delta)
+		cov = append(cov, prev)
+		data = data[n:]
+	}
+	return cov, nil
+}
+
+// MustDecompress is Decompress for data that was produced by Compress in the same process.
+func MustDecompress(data []byte) Cover {
+	cov, err := Decompress(data)
+	if err != nil {
+		panic(err)
+	}
+	return cov
+}
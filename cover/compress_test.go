@@ -0,0 +1,41 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	rnd, iters := initTest(t)
+	for i := 0; i < iters; i++ {
+		var cov []uint32
+		for n := rnd.Intn(100); n > 0; n-- {
+			if rnd.Intn(10) == 0 {
+				cov = append(cov, rnd.Uint32())
+			} else {
+				cov = append(cov, 0x81000000+uint32(rnd.Intn(1<<16)))
+			}
+		}
+		canon := Canonicalize(cov)
+		data := Compress(canon)
+		got, err := Decompress(data)
+		if err != nil {
+			t.Fatalf("failed to decompress %v: %v", canon, err)
+		}
+		if len(canon) == 0 && len(got) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, canon) {
+			t.Fatalf("cover changed after compression:\n%v\n%v", canon, got)
+		}
+	}
+	if _, err := Decompress([]byte{0xff}); err == nil {
+		t.Fatalf("truncated varint is not detected")
+	}
+	if _, err := Decompress([]byte{0xff, 0xff, 0xff, 0xff, 0x0f, 0x01}); err == nil {
+		t.Fatalf("PC overflow is not detected")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"sort"
+)
+
+// Range is a [Start, End) range of PCs (truncated to 32 bits like PCs in Cover).
+type Range struct {
+	Start uint32
+	End   uint32
+}
+
+// Filter is a sorted list of non-overlapping PC ranges, nil filter accepts all PCs.
+type Filter []Range
+
+// MakeFilter sorts and merges ranges.
+func MakeFilter(ranges []Range) Filter {
+	sorted := append(rangeArray(nil), ranges...)
+	sort.Sort(sorted)
+	var f Filter
+	for _, r := range sorted {
+		if r.Start >= r.End {
+			continue
+		}
+		if last := len(f) - 1; last >= 0 && r.Start <= f[last].End {
+			if r.End > f[last].End {
+				f[last].End = r.End
+			}
+			continue
+		}
+		f = append(f, r)
+	}
+	return f
+}
+
+// Contains returns whether pc is in one of the ranges.
+func (f Filter) Contains(pc uint32) bool {
+	i := sort.Search(len(f), func(i int) bool { return f[i].End > pc })
+	return i < len(f) && f[i].Start <= pc
+}
+
+// Apply returns PCs of cov that are in the filter, order of PCs is preserved.
+func (f Filter) Apply(cov Cover) Cover {
+	if f == nil {
+		return cov
+	}
+	var res Cover
+	for _, pc := range cov {
+		if f.Contains(pc) {
+			res = append(res, pc)
+		}
+	}
+	return res
+}
+
+type rangeArray []Range
+
+func (a rangeArray) Len() int           { return len(a) }
+func (a rangeArray) Less(i, j int) bool { return a[i].Start < a[j].Start }
+func (a rangeArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
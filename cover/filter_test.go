@@ -0,0 +1,24 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f := MakeFilter([]Range{{30, 40}, {10, 20}, {15, 25}, {50, 50}, {40, 45}})
+	if want := (Filter{{10, 25}, {30, 45}}); !reflect.DeepEqual(f, want) {
+		t.Fatalf("bad filter: got %v, want %v", f, want)
+	}
+	got := f.Apply(Cover{5, 10, 24, 25, 29, 44, 30, 45, 100})
+	if want := (Cover{10, 24, 44, 30}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("bad filtered cover: got %v, want %v", got, want)
+	}
+	var all Filter
+	if got := all.Apply(Cover{1, 2}); !reflect.DeepEqual(got, Cover{1, 2}) {
+		t.Fatalf("nil filter changed cover: %v", got)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bufio"
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Symbol is a kernel text symbol from /proc/kallsyms.
+// Sizes are not listed there, so a symbol is assumed to end where the next one starts.
+type Symbol struct {
+	Name   string
+	Module string // empty for the core kernel
+	Start  uint64
+	End    uint64
+}
+
+// KallsymsText returns lines of /proc/kallsyms contents with text (code) symbols.
+func KallsymsText(data []byte) []byte {
+	buf := new(bytes.Buffer)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		// Format: addr type name [module]
+		fields := strings.Fields(s.Text())
+		if len(fields) >= 3 && (fields[1] == "t" || fields[1] == "T") {
+			buf.Write(s.Bytes())
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
+
+// ParseKallsyms parses text symbols from /proc/kallsyms contents and returns them sorted by address.
+// Symbols with unknown (zeroed out due to kptr_restrict) addresses are skipped.
+func ParseKallsyms(data []byte) []Symbol {
+	var syms []Symbol
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 3 || fields[1] != "t" && fields[1] != "T" {
+			continue
+		}
+		addr, err := strconv.ParseUint(fields[0], 16, 64)
+		if err != nil || addr == 0 {
+			continue
+		}
+		sym := Symbol{Name: fields[2], Start: addr}
+		if len(fields) >= 4 {
+			sym.Module = strings.Trim(fields[3], "[]")
+		}
+		syms = append(syms, sym)
+	}
+	sort.SliceStable(syms, func(i, j int) bool { return syms[i].Start < syms[j].Start })
+	for i := range syms {
+		if i+1 < len(syms) {
+			syms[i].End = syms[i+1].Start
+		} else {
+			syms[i].End = syms[i].Start + 1
+		}
+	}
+	return syms
+}
+
+// SymbolAt returns index of the symbol that contains pc in syms sorted by address, or -1.
+func SymbolAt(syms []Symbol, pc uint64) int {
+	i := sort.Search(len(syms), func(i int) bool { return syms[i].End > pc })
+	if i == len(syms) || pc < syms[i].Start {
+		return -1
+	}
+	return i
+}
@@ -0,0 +1,53 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseKallsyms(t *testing.T) {
+	data := []byte(`ffffffff81000000 T _stext
+ffffffff81000100 t do_one_initcall
+ffffffff82000000 D some_data
+0000000000000000 T hidden
+ffffffff81000080 T start_kernel
+ffffffffa0248000 t vmx_vcpu_run	[kvm_intel]
+ffffffffa0248400 T vmx_init	[kvm_intel]
+`)
+	text := string(KallsymsText(data))
+	if strings.Contains(text, "some_data") || !strings.Contains(text, "vmx_init\t[kvm_intel]\n") {
+		t.Fatalf("bad text symbols:\n%v", text)
+	}
+	syms := ParseKallsyms([]byte(text))
+	want := []Symbol{
+		{"_stext", "", 0xffffffff81000000, 0xffffffff81000080},
+		{"start_kernel", "", 0xffffffff81000080, 0xffffffff81000100},
+		{"do_one_initcall", "", 0xffffffff81000100, 0xffffffffa0248000},
+		{"vmx_vcpu_run", "kvm_intel", 0xffffffffa0248000, 0xffffffffa0248400},
+		{"vmx_init", "kvm_intel", 0xffffffffa0248400, 0xffffffffa0248401},
+	}
+	if !reflect.DeepEqual(syms, want) {
+		t.Fatalf("got symbols %+v, want %+v", syms, want)
+	}
+	tests := []struct {
+		pc  uint64
+		sym int
+	}{
+		{0xffffffff80000000, -1},
+		{0xffffffff81000000, 0},
+		{0xffffffff8100007f, 0},
+		{0xffffffff81000080, 1},
+		{0xffffffffa02483ff, 3},
+		{0xffffffffa0248400, 4},
+		{0xffffffffa0248401, -1},
+	}
+	for _, test := range tests {
+		if sym := SymbolAt(syms, test.pc); sym != test.sym {
+			t.Errorf("pc 0x%x: got symbol %v, want %v", test.pc, sym, test.sym)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Module describes a loaded kernel module (as listed in /proc/modules).
+type Module struct {
+	Name string
+	Addr uint64 // load address of the module core
+	Size uint64 // size of the module core in bytes
+}
+
+// CoreKernel is the pseudo module name used for PCs that don't belong to any loaded module.
+const CoreKernel = "vmlinux"
+
+// ParseModules parses /proc/modules contents.
+// Modules with unknown (zeroed out due to kptr_restrict) addresses are skipped.
+func ParseModules(data []byte) ([]Module, error) {
+	var modules []Module
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		// Format: name size refcount deps state addr [taint]
+		fields := strings.Fields(s.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		size, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse module size '%v': %v", fields[1], err)
+		}
+		addr, err := strconv.ParseUint(fields[5], 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse module address '%v': %v", fields[5], err)
+		}
+		if addr == 0 {
+			continue
+		}
+		modules = append(modules, Module{fields[0], addr, size})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+// ModuleOffset returns name of the module that contains pc and offset of pc within the module.
+// Module coverage PCs are truncated to 32 bits the same way as core kernel PCs.
+func ModuleOffset(modules []Module, pc uint32) (string, uint32) {
+	for _, m := range modules {
+		start := uint32(m.Addr)
+		if pc >= start && uint64(pc-start) < m.Size {
+			return m.Name, pc - start
+		}
+	}
+	return CoreKernel, pc
+}
+
+// SplitByModule splits cov into per-module coverage.
+// PCs that don't belong to any module are attributed to CoreKernel.
+func SplitByModule(cov Cover, modules []Module) map[string]Cover {
+	res := make(map[string]Cover)
+	for _, pc := range cov {
+		name, _ := ModuleOffset(modules, pc)
+		res[name] = append(res[name], pc)
+	}
+	return res
+}
+
+// RelocateModules converts module PCs in cov from module layout from (load addresses in one VM)
+// to layout to (e.g. another VM or the canonical layout, see MergeModules). Core kernel PCs are kept as is,
+// PCs of modules that are not present in to are dropped.
+func RelocateModules(cov Cover, from, to []Module) Cover {
+	if len(from) == 0 {
+		return cov
+	}
+	bases := make(map[string]uint32)
+	for _, m := range to {
+		bases[m.Name] = uint32(m.Addr)
+	}
+	res := make([]uint32, 0, len(cov))
+	for _, pc := range cov {
+		name, off := ModuleOffset(from, pc)
+		if name == CoreKernel {
+			res = append(res, pc)
+		} else if base, ok := bases[name]; ok {
+			res = append(res, base+off)
+		}
+	}
+	return Canonicalize(res)
+}
+
+// MergeModules adds modules that are not present in the layout to the layout.
+// A new module keeps its load address if it does not overlap with modules already in the layout,
+// otherwise it's placed after the last module (module coverage PCs are relative to the module anyway).
+func MergeModules(layout, modules []Module) []Module {
+	has := make(map[string]bool)
+	for _, m := range layout {
+		has[m.Name] = true
+	}
+	for _, m := range modules {
+		if has[m.Name] {
+			continue
+		}
+		has[m.Name] = true
+		end := uint64(0)
+		for _, m1 := range layout {
+			if m.Addr < m1.Addr+m1.Size && m1.Addr < m.Addr+m.Size {
+				m.Addr = 0
+			}
+			if end < m1.Addr+m1.Size {
+				end = m1.Addr + m1.Size
+			}
+		}
+		if m.Addr == 0 {
+			const pageSize = 4 << 10
+			m.Addr = (end + pageSize - 1) &^ (pageSize - 1)
+		}
+		layout = append(layout, m)
+	}
+	return layout
+}
+
+// ModuleObjs finds object files of kernel modules (<name>.ko) in dirs and returns their paths by module name.
+// Dashes in file names are replaced with underscores the same way the kernel names modules,
+// if a module is found in several dirs the first dir wins.
+func ModuleObjs(dirs []string) (map[string]string, error) {
+	objs := make(map[string]string)
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(info.Name(), ".ko") {
+				return nil
+			}
+			name := strings.Replace(strings.TrimSuffix(info.Name(), ".ko"), "-", "_", -1)
+			if objs[name] == "" {
+				objs[name] = path
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find module objects in %v: %v", dir, err)
+		}
+	}
+	return objs, nil
+}
+
+// ModuleNames returns sorted names of modules with CoreKernel first.
+func ModuleNames(modules []Module) []string {
+	var names []string
+	for _, m := range modules {
+		names = append(names, m.Name)
+	}
+	sort.Strings(names)
+	return append([]string{CoreKernel}, names...)
+}
@@ -0,0 +1,138 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseModules(t *testing.T) {
+	data := []byte(`kvm_intel 172032 0 - Live 0xffffffffa0248000
+kvm 536576 1 kvm_intel, Live 0xffffffffa01c3000 (O)
+hidden 4096 0 - Live 0x0000000000000000
+`)
+	modules, err := ParseModules(data)
+	if err != nil {
+		t.Fatalf("failed to parse modules: %v", err)
+	}
+	want := []Module{
+		{"kvm_intel", 0xffffffffa0248000, 172032},
+		{"kvm", 0xffffffffa01c3000, 536576},
+	}
+	if !reflect.DeepEqual(modules, want) {
+		t.Fatalf("got modules %+v, want %+v", modules, want)
+	}
+	if _, err := ParseModules([]byte("foo bar 0 - Live 0x1000\n")); err == nil {
+		t.Fatalf("bad module size is not detected")
+	}
+}
+
+func TestSplitByModule(t *testing.T) {
+	modules := []Module{
+		{"foo", 0xffffffffa0000000, 0x1000},
+		{"bar", 0xffffffffa0010000, 0x100},
+	}
+	cov := Cover{0x81000000, 0x81000010, 0xa0000000, 0xa0000fff, 0xa0001000, 0xa0010010}
+	got := SplitByModule(cov, modules)
+	want := map[string]Cover{
+		CoreKernel: {0x81000000, 0x81000010, 0xa0001000},
+		"foo":      {0xa0000000, 0xa0000fff},
+		"bar":      {0xa0010010},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if name, off := ModuleOffset(modules, 0xa0010010); name != "bar" || off != 0x10 {
+		t.Fatalf("bad module offset: %v+0x%x", name, off)
+	}
+	names := ModuleNames(modules)
+	if !reflect.DeepEqual(names, []string{CoreKernel, "bar", "foo"}) {
+		t.Fatalf("bad module names: %+v", names)
+	}
+}
+
+func TestModuleObjs(t *testing.T) {
+	dir1, err := ioutil.TempDir("", "syz-modules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir1)
+	dir2, err := ioutil.TempDir("", "syz-modules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir2)
+	files := []string{
+		filepath.Join(dir1, "drivers", "net", "foo-bar.ko"),
+		filepath.Join(dir1, "drivers", "net", "foo.o"),
+		filepath.Join(dir1, "kvm.ko"),
+		filepath.Join(dir2, "kvm.ko"),
+		filepath.Join(dir2, "extra", "baz.ko"),
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(f, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	objs, err := ModuleObjs([]string{dir1, dir2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"foo_bar": files[0],
+		"kvm":     files[2],
+		"baz":     files[4],
+	}
+	if !reflect.DeepEqual(objs, want) {
+		t.Fatalf("got module objects %+v, want %+v", objs, want)
+	}
+	if _, err := ModuleObjs([]string{filepath.Join(dir1, "non-existent")}); err == nil {
+		t.Fatalf("non-existent dir is not detected")
+	}
+}
+
+func TestRelocateModules(t *testing.T) {
+	from := []Module{
+		{"foo", 0xffffffffa0010000, 0x1000},
+		{"bar", 0xffffffffa0000000, 0x100},
+		{"baz", 0xffffffffa0020000, 0x100},
+	}
+	to := []Module{
+		{"foo", 0xffffffffa0000000, 0x1000},
+		{"bar", 0xffffffffa0010000, 0x100},
+	}
+	cov := Cover{0x81000000, 0xa0000010, 0xa0010020, 0xa0020000}
+	got := RelocateModules(cov, from, to)
+	want := Cover{0x81000000, 0xa0000020, 0xa0010010}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %x, want %x", got, want)
+	}
+	if got := RelocateModules(cov, nil, to); !reflect.DeepEqual(got, cov) {
+		t.Fatalf("coverage without modules is changed: %x", got)
+	}
+}
+
+func TestMergeModules(t *testing.T) {
+	layout := []Module{{"foo", 0xffffffffa0000000, 0x1000}}
+	layout = MergeModules(layout, []Module{
+		{"bar", 0xffffffffa0000800, 0x100},
+		{"foo", 0xffffffffa0010000, 0x1000},
+		{"baz", 0xffffffffa0020000, 0x100},
+	})
+	want := []Module{
+		{"foo", 0xffffffffa0000000, 0x1000},
+		{"bar", 0xffffffffa0001000, 0x100},
+		{"baz", 0xffffffffa0020000, 0x100},
+	}
+	if !reflect.DeepEqual(layout, want) {
+		t.Fatalf("got %+v, want %+v", layout, want)
+	}
+}
@@ -6,6 +6,7 @@ package csource
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -45,13 +46,30 @@ func Write(p *prog.Prog, opts Options) []byte {
 		fmt.Fprintf(w, "#define SYS_%v %v\n", name, c.Meta.NR)
 		fmt.Fprintf(w, "#endif\n")
 	}
+	if handled["mmap"] {
+		// On 386 SYS_mmap is old_mmap that takes a pointer to the args, mmap2 takes the offset in pages.
+		fmt.Fprintf(w, "#if defined(__i386__)\n")
+		fmt.Fprintf(w, "#undef SYS_mmap\n")
+		fmt.Fprintf(w, "#define SYS_mmap SYS_mmap2\n")
+		fmt.Fprintf(w, "#define MMAP_OFFSET(off) ((off) / 4096)\n")
+		fmt.Fprintf(w, "#else\n")
+		fmt.Fprintf(w, "#define MMAP_OFFSET(off) (off)\n")
+		fmt.Fprintf(w, "#endif\n")
+	}
 	fmt.Fprintf(w, "\n")
 
 	calls, nvar := generateCalls(exec)
 	fmt.Fprintf(w, "long r[%v];\n\n", nvar)
 
+	setup := ""
+	if p.UsesFilePool() {
+		writeSetupFiles(w)
+		setup = "\tsetup_files();\n"
+	}
+
 	if !opts.Threaded && !opts.Collide {
 		fmt.Fprintf(w, "int main()\n{\n")
+		fmt.Fprintf(w, "%v", setup)
 		fmt.Fprintf(w, "\tmemset(r, -1, sizeof(r));\n")
 		for _, c := range calls {
 			fmt.Fprintf(w, "%s", c)
@@ -72,6 +90,7 @@ func Write(p *prog.Prog, opts Options) []byte {
 		fmt.Fprintf(w, "\tlong i;\n")
 		fmt.Fprintf(w, "\tpthread_t th[%v];\n", len(calls))
 		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "%v", setup)
 		fmt.Fprintf(w, "\tmemset(r, -1, sizeof(r));\n")
 		fmt.Fprintf(w, "\tfor (i = 0; i < %v; i++) {\n", len(calls))
 		fmt.Fprintf(w, "\t\tpthread_create(&th[i], 0, thr, (void*)i);\n")
@@ -90,6 +109,34 @@ func Write(p *prog.Prog, opts Options) []byte {
 	return w.Bytes()
 }
 
+// writeSetupFiles writes setup_files function that creates prog.FilePool
+// the same way executor does.
+func writeSetupFiles(w io.Writer) {
+	fmt.Fprintf(w, "#include <fcntl.h>\n")
+	fmt.Fprintf(w, "#include <sys/stat.h>\n")
+	fmt.Fprintf(w, "#include <sys/sysmacros.h>\n\n")
+	fmt.Fprintf(w, "void setup_files()\n{\n")
+	fmt.Fprintf(w, "\tmkdir(\"%v\", 0777);\n", prog.FilePoolDir)
+	for _, f := range prog.FilePool {
+		switch f.Kind {
+		case prog.FileRegular:
+			fmt.Fprintf(w, "\tclose(open(\"%v\", O_RDWR | O_CREAT, 0666));\n", f.Name)
+		case prog.FileDir:
+			fmt.Fprintf(w, "\tmkdir(\"%v\", 0777);\n", f.Name)
+		case prog.FileSymlink:
+			fmt.Fprintf(w, "\tsymlink(\"%v\", \"%v\");\n", f.Target, f.Name)
+		case prog.FileChar, prog.FileBlock:
+			mode := "S_IFCHR"
+			if f.Kind == prog.FileBlock {
+				mode = "S_IFBLK"
+			}
+			fmt.Fprintf(w, "\tif (mknod(\"%v\", %v | 0666, makedev(%v, %v)))\n", f.Name, mode, f.Major, f.Minor)
+			fmt.Fprintf(w, "\t\tsymlink(\"/dev/%v\", \"%v\");\n", f.Target, f.Name)
+		}
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
+
 func generateCalls(exec []byte) ([]string, int) {
 	read := func() uintptr {
 		if len(exec) < 8 {
@@ -172,6 +219,10 @@ loop:
 				_ = size
 				switch typ {
 				case prog.ExecArgConst:
+					if meta.CallName == "mmap" && i == 5 {
+						fmt.Fprintf(w, ", MMAP_OFFSET(0x%xul)", read())
+						break
+					}
 					fmt.Fprintf(w, ", 0x%xul", read())
 				case prog.ExecArgResult:
 					fmt.Fprintf(w, ", %v", resultRef())
@@ -8,8 +8,14 @@
 #include <grp.h>
 #include <limits.h>
 #include <linux/capability.h>
+#include <linux/filter.h>
 #include <linux/futex.h>
+#include <linux/genetlink.h>
+#include <linux/kvm.h>
+#include <linux/loop.h>
 #include <linux/reboot.h>
+#include <linux/seccomp.h>
+#include <linux/usb/ch9.h>
 #include <pthread.h>
 #include <signal.h>
 #include <stdarg.h>
@@ -24,8 +30,10 @@
 #include <sys/prctl.h>
 #include <sys/reboot.h>
 #include <sys/resource.h>
+#include <sys/socket.h>
 #include <sys/stat.h>
 #include <sys/syscall.h>
+#include <sys/sysmacros.h>
 #include <sys/time.h>
 #include <sys/types.h>
 #include <sys/wait.h>
@@ -49,6 +57,14 @@ const int kMaxArgs = 9;
 const int kMaxThreads = 16;
 const int kMaxCommands = 4 << 10;
 const int kCoverSize = 16 << 10;
+const int kMaxImageSize = 16 << 20;
+const int kMaxKillDelay = 20; // ms
+const int kMaxUsbDevices = 4;
+const int kMaxUsbEndpoints = 16;
+const int kMaxUdcs = 8;
+const int kUsbBufSize = 4 << 10;
+const int kMaxSeccompRules = 1024;
+const int kMaxFiles = 256;
 
 const uint64_t instr_eof = -1;
 const uint64_t instr_copyin = -2;
@@ -71,6 +87,7 @@ enum sandbox_type {
 	sandbox_none,
 	sandbox_setuid,
 	sandbox_namespace,
+	sandbox_android,
 };
 
 bool flag_debug;
@@ -80,9 +97,15 @@ bool flag_collide;
 bool flag_deduplicate;
 bool flag_sandbox_privs;
 sandbox_type flag_sandbox;
+uint64_t flag_cgroup_mem;
+uint64_t flag_cgroup_pids;
+uint64_t flag_mem_pressure;
+bool flag_seccomp;
+bool flag_objects;
 
 __attribute__((aligned(64 << 10))) char input_data[kMaxInput];
 __attribute__((aligned(64 << 10))) char output_data[kMaxOutput];
+uint64_t* input_program; // program in input_data after the header
 uint32_t* output_pos;
 int completed;
 int running;
@@ -128,8 +151,22 @@ int sandbox_proc(void* arg);
 int do_sandbox_none();
 int do_sandbox_setuid();
 int do_sandbox_namespace();
+int do_sandbox_android();
 void sandbox_common();
+void cgroup_setup();
+void cgroup_remove();
+void cgroup_test_create(int iter);
+void cgroup_test_join();
+void cgroup_test_remove();
+void count_objects();
+void mem_hog_start();
+void mem_hog_stop();
+void seccomp_setup(uint64_t** input_posp);
+void seccomp_install();
 void loop();
+void read_files(uint64_t** input_posp);
+void setup_files();
+void link_files();
 void execute_one();
 uint64_t read_input(uint64_t** input_posp, bool peek = false);
 uint64_t read_arg(uint64_t** input_posp);
@@ -150,6 +187,15 @@ void cover_enable(thread_t* th);
 void cover_reset(thread_t* th);
 uint64_t cover_read(thread_t* th);
 uint64_t cover_dedup(thread_t* th, uint64_t n);
+long syz_mount_image(uint64_t fs, uint64_t dir, uint64_t size, uint64_t nsegs, uint64_t segments, uint64_t flags, uint64_t opts);
+long syz_usb_connect(uint64_t speed, uint64_t dev_len, uint64_t dev, uint64_t conf_len, uint64_t conf);
+long syz_usb_control_io(uint64_t fd, uint64_t resp_len, uint64_t resp);
+long syz_usb_ep_write(uint64_t fd, uint64_t ep, uint64_t len, uint64_t data);
+long syz_kvm_setup_cpu(uint64_t vmfd, uint64_t cpufd, uint64_t text, uint64_t ntext, uint64_t flags, uint64_t opts, uint64_t nopt);
+long syz_genetlink_get_family_id(uint64_t name);
+long syz_socket_bind(uint64_t domain, uint64_t type, uint64_t proto, uint64_t addr, uint64_t addrlen);
+long syz_memfd_data(uint64_t name, uint64_t flags, uint64_t data, uint64_t len);
+long syz_mount_tmpfs(uint64_t dir, uint64_t flags, uint64_t opts);
 
 int main(int argc, char** argv)
 {
@@ -181,10 +227,25 @@ int main(int argc, char** argv)
 		flag_sandbox = sandbox_setuid;
 	else if (flags & (1 << 6))
 		flag_sandbox = sandbox_namespace;
+	else if (flags & (1 << 7))
+		flag_sandbox = sandbox_android;
+	flag_seccomp = flags & (1 << 10);
+	flag_objects = flags & (1 << 11);
 	if (!flag_threaded)
 		flag_collide = false;
+	srand(getpid());
+	uint64_t* input_pos = (uint64_t*)&input_data[0] + 1;
+	flag_cgroup_mem = read_input(&input_pos);
+	flag_cgroup_pids = read_input(&input_pos);
+	flag_mem_pressure = read_input(&input_pos);
+	if (flag_seccomp)
+		seccomp_setup(&input_pos);
+	read_files(&input_pos);
+	input_program = input_pos;
 
 	cover_open();
+	cgroup_setup();
+	mem_hog_start();
 
 	// Don't need that SIGCANCEL/SIGSETXID glibc stuff.
 	// SIGCANCEL sent to main thread causes it to exit
@@ -206,6 +267,9 @@ int main(int argc, char** argv)
 	case sandbox_namespace:
 		pid = do_sandbox_namespace();
 		break;
+	case sandbox_android:
+		pid = do_sandbox_android();
+		break;
 	default:
 		fail("unknown sandbox type");
 	}
@@ -216,6 +280,8 @@ int main(int argc, char** argv)
 	while (waitpid(pid, &status, __WALL) != pid) {
 	}
 	status = WEXITSTATUS(status);
+	mem_hog_stop();
+	cgroup_remove();
 	if (status == kFailStatus)
 		fail("loop failed");
 	if (status == kErrorStatus)
@@ -234,6 +300,7 @@ void loop()
 	if (write(kOutPipeFd, &tmp, 1) != 1)
 		fail("control pipe write failed");
 
+	setup_files();
 	for (int iter = 0;; iter++) {
 		// Create a new private work dir for this test (removed at the end of the loop).
 		char cwdbuf[256];
@@ -244,6 +311,7 @@ void loop()
 		if (read(kInPipeFd, &tmp, 1) != 1)
 			fail("control pipe read failed");
 
+		cgroup_test_create(iter);
 		int pid = fork();
 		if (pid < 0)
 			fail("clone failed");
@@ -252,6 +320,8 @@ void loop()
 			setpgrp();
 			if (chdir(cwdbuf))
 				fail("failed to chdir");
+			cgroup_test_join();
+			link_files();
 			close(kInPipeFd);
 			close(kOutPipeFd);
 			execute_one();
@@ -260,6 +330,13 @@ void loop()
 		}
 		debug("spawned worker pid %d\n", pid);
 
+		// Kill mode can be requested per program (bit 9 in the program flags):
+		// the worker is killed with SIGKILL or SIGSEGV at a random point
+		// to exercise kernel exit/cleanup paths for threads blocked in syscalls.
+		bool kill_worker = ((uint64_t*)input_data)[0] & (1 << 9);
+		uint64_t kill_delay = rand() % kMaxKillDelay;
+		int kill_sig = rand() % 2 ? SIGKILL : SIGSEGV;
+
 		// We used to use sigtimedwait(SIGCHLD) to wait for the subprocess.
 		// But SIGCHLD is also delivered when a process stops/continues,
 		// so it would require a loop with status analysis and timeout recalculation.
@@ -275,6 +352,12 @@ void loop()
 				break;
 			}
 			usleep(1000);
+			if (kill_worker && current_time_ms() - start >= kill_delay) {
+				debug("killing with signal %d\n", kill_sig);
+				kill(-pid, kill_sig);
+				kill(pid, kill_sig);
+				kill_worker = false;
+			}
 			if (current_time_ms() - start > 5 * 1000) {
 				debug("waitpid(%d)=%d (%d)\n", pid, res, errno0);
 				debug("killing\n");
@@ -292,6 +375,9 @@ void loop()
 			fail("child failed");
 		if (status == kErrorStatus)
 			error("child errored");
+		cgroup_test_remove();
+		if (flag_objects)
+			count_objects();
 		remove_dir(cwdbuf);
 		if (write(kOutPipeFd, &tmp, 1) != 1)
 			fail("control pipe write failed");
@@ -337,6 +423,39 @@ int do_sandbox_namespace()
 		     CLONE_NEWUSER | CLONE_NEWPID | CLONE_NEWUTS | CLONE_NEWNET, NULL);
 }
 
+// Android untrusted_app identity (see system/core/include/private/android_filesystem_config.h):
+// uid/gid is AID_APP + 999, supplementary groups are AID_INET, AID_EVERYBODY,
+// AID_CACHE_GID_START + 999 and AID_SHARED_GID_START + 999.
+const int kAndroidAppUid = 10000 + 999;
+const gid_t kAndroidAppGroups[] = {3003, 9997, 20000 + 999, 50000 + 999};
+const char* kAndroidAppContext = "u:r:untrusted_app:s0:c512,c768";
+
+int do_sandbox_android()
+{
+	int pid = fork();
+	if (pid)
+		return pid;
+
+	sandbox_common();
+
+	if (setgroups(sizeof(kAndroidAppGroups) / sizeof(kAndroidAppGroups[0]), kAndroidAppGroups))
+		fail("failed to setgroups");
+	if (syscall(SYS_setresgid, kAndroidAppUid, kAndroidAppUid, kAndroidAppUid))
+		fail("failed to setresgid");
+	if (syscall(SYS_setresuid, kAndroidAppUid, kAndroidAppUid, kAndroidAppUid))
+		fail("failed to setresuid");
+	// Transition into the app SELinux domain. It is fine to not do it
+	// only if SELinux is not present on the device at all.
+	if (!write_file("/proc/self/attr/current", "%s", kAndroidAppContext)) {
+		if (access("/sys/fs/selinux", F_OK) == 0)
+			fail("failed to set SELinux context %s", kAndroidAppContext);
+		debug("SELinux is not present, not changing context\n");
+	}
+
+	loop();
+	exit(1);
+}
+
 void sandbox_common()
 {
 	prctl(PR_SET_PDEATHSIG, SIGKILL, 0, 0, 0);
@@ -359,6 +478,361 @@ void sandbox_common()
 	unshare(CLONE_IO);
 }
 
+// With seccomp flag the header contains a seccomp filter: default action (SECCOMP_RET_*),
+// number of rules and pairs of syscall number and action.
+// Threads that execute syscalls install the filter before the first call, so that syscalls
+// (including ones done by pseudo-syscalls) are restricted like in the target sandbox,
+// while the main thread of the test process is not restricted and can manage the threads.
+// The filtered threads need futex and exit themselves (and write for debug output).
+struct sock_filter seccomp_insns[2 * kMaxSeccompRules + 16];
+struct sock_fprog seccomp_prog;
+
+void seccomp_rule(int* pos, uint32_t nr, uint32_t action)
+{
+	seccomp_insns[(*pos)++] = (struct sock_filter)BPF_JUMP(BPF_JMP | BPF_JEQ | BPF_K, nr, 0, 1);
+	seccomp_insns[(*pos)++] = (struct sock_filter)BPF_STMT(BPF_RET | BPF_K, action);
+}
+
+void seccomp_setup(uint64_t** input_posp)
+{
+	uint32_t def = (uint32_t)read_input(input_posp);
+	uint64_t nrules = read_input(input_posp);
+	if (nrules > kMaxSeccompRules)
+		fail("too many seccomp rules: %lu", nrules);
+	int pos = 0;
+	seccomp_insns[pos++] = (struct sock_filter)BPF_STMT(BPF_LD | BPF_W | BPF_ABS, offsetof(struct seccomp_data, nr));
+	seccomp_rule(&pos, SYS_futex, SECCOMP_RET_ALLOW);
+	seccomp_rule(&pos, SYS_exit, SECCOMP_RET_ALLOW);
+	seccomp_rule(&pos, SYS_exit_group, SECCOMP_RET_ALLOW);
+	if (flag_debug)
+		seccomp_rule(&pos, SYS_write, SECCOMP_RET_ALLOW);
+	for (uint64_t i = 0; i < nrules; i++) {
+		uint32_t nr = (uint32_t)read_input(input_posp);
+		uint32_t action = (uint32_t)read_input(input_posp);
+		seccomp_rule(&pos, nr, action);
+	}
+	seccomp_insns[pos++] = (struct sock_filter)BPF_STMT(BPF_RET | BPF_K, def);
+	seccomp_prog.len = pos;
+	seccomp_prog.filter = seccomp_insns;
+	debug("seccomp filter: %lu rules, default action 0x%x\n", nrules, def);
+}
+
+void seccomp_install()
+{
+	if (prctl(PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0))
+		fail("prctl(PR_SET_NO_NEW_PRIVS) failed");
+	if (prctl(PR_SET_SECCOMP, SECCOMP_MODE_FILTER, &seccomp_prog))
+		fail("failed to install seccomp filter");
+}
+
+// Test processes are confined with memory/pids cgroups, so that a runaway program
+// is killed by the cgroup OOM killer instead of bringing down the whole machine.
+// The executor process joins a private cgroup named syzPID before spawning the sandbox
+// (so the sandbox and the memory hog are limited as well) and makes it writable by the sandbox uid.
+// Then the loop creates a dedicated cgroup syzPID/testN with the same limits for every test process,
+// the test process moves itself into it right after fork and the cgroup is removed when the test finishes.
+// Cgroups are mounted at /syzcgroup/{memory,pids}.
+const char* kCgroupRoot = "/syzcgroup";
+char cgroup_mem_dir[128];
+char cgroup_pids_dir[128];
+char cgroup_test_mem_dir[256];
+char cgroup_test_pids_dir[256];
+
+// cgroup_rmdir removes a cgroup along with test cgroups left in it.
+void cgroup_rmdir(const char* dir)
+{
+	if (DIR* dp = opendir(dir)) {
+		while (dirent* ep = readdir(dp)) {
+			if (ep->d_type != DT_DIR || ep->d_name[0] == '.')
+				continue;
+			char sub[512];
+			snprintf(sub, sizeof(sub), "%s/%s", dir, ep->d_name);
+			rmdir(sub);
+		}
+		closedir(dp);
+	}
+	rmdir(dir);
+}
+
+void cgroup_create(const char* controller, char* dir, int size)
+{
+	char mnt[64];
+	snprintf(mnt, sizeof(mnt), "%s/%s", kCgroupRoot, controller);
+	mkdir(kCgroupRoot, 0777);
+	mkdir(mnt, 0777);
+	char procs[128];
+	snprintf(procs, sizeof(procs), "%s/cgroup.procs", mnt);
+	if (access(procs, F_OK) && mount("none", mnt, "cgroup", 0, controller))
+		fail("failed to mount %s cgroup", controller);
+	// Remove cgroups left by killed executors (they are killed on hangs).
+	if (DIR* dp = opendir(mnt)) {
+		while (dirent* ep = readdir(dp)) {
+			int pid = 0;
+			if (sscanf(ep->d_name, "syz%d", &pid) != 1 || kill(pid, 0) == 0 || errno != ESRCH)
+				continue;
+			char stale[128];
+			snprintf(stale, sizeof(stale), "%s/%s", mnt, ep->d_name);
+			cgroup_rmdir(stale);
+		}
+		closedir(dp);
+	}
+	snprintf(dir, size, "%s/syz%d", mnt, getpid());
+	if (mkdir(dir, 0777) && errno != EEXIST)
+		fail("failed to create %s", dir);
+	// The loop creates test cgroups under the sandbox uid (see do_sandbox_setuid and do_sandbox_android),
+	// the namespace sandbox maps root to the real uid.
+	int uid = 0;
+	if (flag_sandbox == sandbox_setuid)
+		uid = 65534;
+	else if (flag_sandbox == sandbox_android)
+		uid = kAndroidAppUid;
+	if (uid && chown(dir, uid, uid))
+		fail("failed to chown %s", dir);
+}
+
+void cgroup_setup()
+{
+	if (flag_cgroup_mem) {
+		cgroup_create("memory", cgroup_mem_dir, sizeof(cgroup_mem_dir));
+		char file[256];
+		// Usage of test cgroups is accounted in the executor cgroup (memory pressure relies on that).
+		// It is the default on new kernels and the write fails if it is already inherited from parent.
+		snprintf(file, sizeof(file), "%s/memory.use_hierarchy", cgroup_mem_dir);
+		write_file(file, "1");
+		snprintf(file, sizeof(file), "%s/memory.limit_in_bytes", cgroup_mem_dir);
+		if (!write_file(file, "%llu", (unsigned long long)flag_cgroup_mem))
+			fail("failed to write %s", file);
+		snprintf(file, sizeof(file), "%s/cgroup.procs", cgroup_mem_dir);
+		if (!write_file(file, "%d", getpid()))
+			fail("failed to write %s", file);
+	}
+	if (flag_cgroup_pids) {
+		cgroup_create("pids", cgroup_pids_dir, sizeof(cgroup_pids_dir));
+		char file[256];
+		snprintf(file, sizeof(file), "%s/pids.max", cgroup_pids_dir);
+		if (!write_file(file, "%llu", (unsigned long long)flag_cgroup_pids))
+			fail("failed to write %s", file);
+		snprintf(file, sizeof(file), "%s/cgroup.procs", cgroup_pids_dir);
+		if (!write_file(file, "%d", getpid()))
+			fail("failed to write %s", file);
+	}
+}
+
+// cgroup_test_create creates cgroups for the next test process (called by the loop in the sandbox).
+// If the sandbox does not allow that (e.g. SELinux policy), the test process stays in the executor cgroup.
+void cgroup_test_create(int iter)
+{
+	cgroup_test_mem_dir[0] = 0;
+	cgroup_test_pids_dir[0] = 0;
+	if (flag_cgroup_mem) {
+		snprintf(cgroup_test_mem_dir, sizeof(cgroup_test_mem_dir), "%s/test%d", cgroup_mem_dir, iter);
+		char file[512];
+		snprintf(file, sizeof(file), "%s/memory.limit_in_bytes", cgroup_test_mem_dir);
+		if (mkdir(cgroup_test_mem_dir, 0777)) {
+			debug("failed to create %s: %d\n", cgroup_test_mem_dir, errno);
+			cgroup_test_mem_dir[0] = 0;
+		} else if (!write_file(file, "%llu", (unsigned long long)flag_cgroup_mem)) {
+			fail("failed to write %s", file);
+		}
+	}
+	if (flag_cgroup_pids) {
+		snprintf(cgroup_test_pids_dir, sizeof(cgroup_test_pids_dir), "%s/test%d", cgroup_pids_dir, iter);
+		char file[512];
+		snprintf(file, sizeof(file), "%s/pids.max", cgroup_test_pids_dir);
+		if (mkdir(cgroup_test_pids_dir, 0777)) {
+			debug("failed to create %s: %d\n", cgroup_test_pids_dir, errno);
+			cgroup_test_pids_dir[0] = 0;
+		} else if (!write_file(file, "%llu", (unsigned long long)flag_cgroup_pids)) {
+			fail("failed to write %s", file);
+		}
+	}
+}
+
+// cgroup_test_join moves the test process into its cgroups (called in the test process after fork).
+void cgroup_test_join()
+{
+	char* dirs[] = {cgroup_test_mem_dir, cgroup_test_pids_dir};
+	for (int i = 0; i < 2; i++) {
+		if (!dirs[i][0])
+			continue;
+		// 0 means the writing process (getpid is not the right pid in the namespace sandbox).
+		char file[512];
+		snprintf(file, sizeof(file), "%s/cgroup.procs", dirs[i]);
+		if (!write_file(file, "0"))
+			fail("failed to write %s", file);
+	}
+}
+
+// cgroup_test_remove removes cgroups of the finished test process (called by the loop).
+// Killed processes can take some time to exit, cgroups that are still busy after that
+// are removed along with the executor cgroup.
+void cgroup_test_remove()
+{
+	char* dirs[] = {cgroup_test_mem_dir, cgroup_test_pids_dir};
+	for (int i = 0; i < 2; i++) {
+		if (!dirs[i][0])
+			continue;
+		for (int attempt = 0; rmdir(dirs[i]) && errno == EBUSY && attempt < 100; attempt++)
+			usleep(1000);
+		dirs[i][0] = 0;
+	}
+}
+
+void cgroup_remove()
+{
+	// Move ourselves back to the root cgroup, otherwise rmdir fails with EBUSY.
+	// This is best-effort, leftovers are removed by the next executor.
+	const char* controllers[] = {"memory", "pids"};
+	char* dirs[] = {cgroup_mem_dir, cgroup_pids_dir};
+	for (int i = 0; i < 2; i++) {
+		if (!dirs[i][0])
+			continue;
+		char file[128];
+		snprintf(file, sizeof(file), "%s/%s/cgroup.procs", kCgroupRoot, controllers[i]);
+		write_file(file, "%d", getpid());
+		cgroup_rmdir(dirs[i]);
+	}
+}
+
+// With objects flag the loop counts kernel objects that programs can leave behind after every
+// test process exits and stores the counts at the end of the output region (see ipc.ObjectKinds).
+// The loop runs in the sandbox, so sockets are counted in the sandbox network namespace
+// and SysV IPC objects in the sandbox IPC namespace.
+const int kObjectKinds = 5;
+const int kObjectsOffset = kMaxOutput - kObjectKinds * sizeof(uint64_t);
+const uint64_t kObjectUnknown = (uint64_t)-1;
+
+// read_proc_field returns the numeric whitespace-separated field of the beginning of a /proc file.
+uint64_t read_proc_field(const char* file, int field)
+{
+	char buf[256];
+	int fd = open(file, O_RDONLY);
+	if (fd == -1)
+		return kObjectUnknown;
+	int n = read(fd, buf, sizeof(buf) - 1);
+	close(fd);
+	if (n <= 0)
+		return kObjectUnknown;
+	buf[n] = 0;
+	char* pos = buf + strspn(buf, " \t\n");
+	for (int i = 0; i < field; i++) {
+		pos += strcspn(pos, " \t\n");
+		pos += strspn(pos, " \t\n");
+	}
+	char* end = NULL;
+	unsigned long long v = strtoull(pos, &end, 10);
+	if (end == pos)
+		return kObjectUnknown;
+	return v;
+}
+
+// count_proc_lines returns number of lines in a /proc table without the header line.
+uint64_t count_proc_lines(const char* file)
+{
+	int fd = open(file, O_RDONLY);
+	if (fd == -1)
+		return kObjectUnknown;
+	uint64_t lines = 0;
+	char buf[4096];
+	int n;
+	while ((n = read(fd, buf, sizeof(buf))) > 0) {
+		for (int i = 0; i < n; i++)
+			lines += buf[i] == '\n';
+	}
+	close(fd);
+	if (n < 0 || lines == 0)
+		return kObjectUnknown;
+	return lines - 1;
+}
+
+void count_objects()
+{
+	uint64_t* counts = (uint64_t*)&output_data[kObjectsOffset];
+	counts[0] = read_proc_field("/proc/sys/fs/file-nr", 0); // allocated, free, max
+	counts[1] = read_proc_field("/proc/net/sockstat", 2); // sockets: used N
+	counts[2] = count_proc_lines("/proc/sysvipc/shm");
+	counts[3] = count_proc_lines("/proc/sysvipc/msg");
+	counts[4] = count_proc_lines("/proc/sysvipc/sem");
+}
+
+// Memory pressure: many use-after-free and OOM-path bugs reproduce only when allocations start failing.
+// With non-zero memory pressure in the header (in bytes) the executor spawns a hog process that
+// allocates memory until only that much is available to test processes: within the memory cgroup
+// if the cgroup memory limit is set (the hog joins the same cgroup), otherwise in the whole machine
+// (MemAvailable). The hog is protected from the OOM killer and gives memory back when available
+// memory drops below half of the pressure, so that test processes still make progress.
+const uint64_t kMemHogChunk = 1 << 20;
+const int kMemHogMaxChunks = 64 << 10;
+int mem_hog_pid;
+
+uint64_t mem_available()
+{
+	if (flag_cgroup_mem) {
+		char file[256];
+		snprintf(file, sizeof(file), "%s/memory.usage_in_bytes", cgroup_mem_dir);
+		FILE* f = fopen(file, "r");
+		if (!f)
+			fail("failed to open %s", file);
+		unsigned long long usage = 0;
+		int n = fscanf(f, "%llu", &usage);
+		fclose(f);
+		if (n != 1)
+			fail("failed to parse %s", file);
+		return usage < flag_cgroup_mem ? flag_cgroup_mem - usage : 0;
+	}
+	FILE* f = fopen("/proc/meminfo", "r");
+	if (!f)
+		fail("failed to open /proc/meminfo");
+	char line[256];
+	unsigned long long avail = 0;
+	while (fgets(line, sizeof(line), f)) {
+		if (sscanf(line, "MemAvailable: %llu kB", &avail) == 1)
+			break;
+	}
+	fclose(f);
+	return avail << 10;
+}
+
+void mem_hog_start()
+{
+	if (!flag_mem_pressure)
+		return;
+	mem_hog_pid = fork();
+	if (mem_hog_pid < 0)
+		fail("fork failed");
+	if (mem_hog_pid) {
+		debug("spawned memory hog pid %d\n", mem_hog_pid);
+		return;
+	}
+	prctl(PR_SET_PDEATHSIG, SIGKILL, 0, 0, 0);
+	write_file("/proc/self/oom_score_adj", "-1000");
+	static void* chunks[kMemHogMaxChunks];
+	int nchunks = 0;
+	for (;;) {
+		uint64_t avail = mem_available();
+		if (avail > flag_mem_pressure + kMemHogChunk && nchunks < kMemHogMaxChunks) {
+			void* p = mmap(NULL, kMemHogChunk, PROT_READ | PROT_WRITE, MAP_PRIVATE | MAP_ANONYMOUS | MAP_POPULATE, -1, 0);
+			if (p != MAP_FAILED) {
+				chunks[nchunks++] = p;
+				continue;
+			}
+		} else if (avail < flag_mem_pressure / 2 && nchunks > 0) {
+			munmap(chunks[--nchunks], kMemHogChunk);
+			continue;
+		}
+		usleep(100 * 1000);
+	}
+}
+
+void mem_hog_stop()
+{
+	if (!mem_hog_pid)
+		return;
+	kill(mem_hog_pid, SIGKILL);
+	while (waitpid(mem_hog_pid, NULL, __WALL) != mem_hog_pid) {
+	}
+}
+
 int sandbox_proc(void* arg)
 {
 	sandbox_common();
@@ -417,16 +891,109 @@ int sandbox_proc(void* arg)
 	exit(1);
 }
 
+// Pool of files that filename arguments refer to (prog.FilePool), it comes in the input header
+// (see ipc.appendFilePool), so that the list is not duplicated here.
+enum file_kind {
+	file_regular, // prog.FileKind values
+	file_dir,
+	file_symlink,
+	file_char,
+	file_block,
+};
+
+struct file_node {
+	uint64_t kind;
+	uint64_t major;
+	uint64_t minor;
+	const char* name;
+	const char* target; // symlink target or /dev name of device node
+};
+
+const char* files_dir;
+char files_link[256];
+file_node files[kMaxFiles];
+uint64_t nfiles;
+
+const char* read_string(uint64_t** input_posp)
+{
+	uint64_t size = read_input(input_posp);
+	const char* str = (const char*)*input_posp;
+	if (size == 0 || size > (uint64_t)(input_data + kMaxInput - str) || str[size - 1])
+		fail("bad string in input header");
+	*input_posp += (size + 7) / 8;
+	return str;
+}
+
+void read_files(uint64_t** input_posp)
+{
+	files_dir = read_string(input_posp);
+	nfiles = read_input(input_posp);
+	if (nfiles > kMaxFiles)
+		fail("too many files: %lu", nfiles);
+	for (uint64_t i = 0; i < nfiles; i++) {
+		file_node* n = &files[i];
+		n->kind = read_input(input_posp);
+		n->major = read_input(input_posp);
+		n->minor = read_input(input_posp);
+		n->name = read_string(input_posp);
+		n->target = read_string(input_posp);
+	}
+	if (snprintf(files_link, sizeof(files_link), "../%s", files_dir) >= (int)sizeof(files_link))
+		fail("files dir is too long: %s", files_dir);
+}
+
+// setup_files creates the file pool once in the working dir of the loop. Test processes share it
+// via a symlink in their working dirs (see link_files), so a program can see pool files
+// changed or removed by previous programs. Errors are ignored: the pool is best-effort,
+// e.g. device nodes can't be created without CAP_MKNOD and are replaced with symlinks into /dev.
+void setup_files()
+{
+	mkdir(files_dir, 0777);
+	for (uint64_t i = 0; i < nfiles; i++) {
+		const file_node* n = &files[i];
+		switch (n->kind) {
+		case file_regular:
+			close(open(n->name, O_RDWR | O_CREAT, 0666));
+			break;
+		case file_dir:
+			mkdir(n->name, 0777);
+			break;
+		case file_symlink:
+			symlink(n->target, n->name);
+			break;
+		case file_char:
+		case file_block:
+			if (mknod(n->name, (n->kind == file_char ? S_IFCHR : S_IFBLK) | 0666, makedev(n->major, n->minor))) {
+				char dev[256];
+				snprintf(dev, sizeof(dev), "/dev/%s", n->target);
+				symlink(dev, n->name);
+			}
+			break;
+		}
+	}
+}
+
+// link_files makes the file pool visible in the working dir of a test process.
+void link_files()
+{
+	symlink(files_link, files_dir);
+}
+
 void execute_one()
 {
 retry:
-	uint64_t* input_pos = (uint64_t*)&input_data[0];
-	read_input(&input_pos); // flags
+	// Collide mode can be toggled per program (fuzzer uses it only for a fraction of programs).
+	uint64_t flags = ((uint64_t*)input_data)[0];
+	flag_collide = flag_threaded && (flags & (1 << 3));
+	uint64_t* input_pos = input_program;
 	output_pos = (uint32_t*)&output_data[0];
 	write_output(0); // Number of executed syscalls (updated later).
 
-	if (!collide && !flag_threaded)
+	if (!collide && !flag_threaded) {
 		cover_enable(&threads[0]);
+		if (flag_seccomp)
+			seccomp_install();
+	}
 
 	int call_index = 0;
 	for (int n = 0;; n++) {
@@ -631,6 +1198,8 @@ void* worker_thread(void* arg)
 	thread_t* th = (thread_t*)arg;
 
 	cover_enable(th);
+	if (flag_seccomp)
+		seccomp_install();
 	for (;;) {
 		while (!__atomic_load_n(&th->ready, __ATOMIC_ACQUIRE))
 			syscall(SYS_futex, &th->ready, FUTEX_WAIT, 0, 0);
@@ -656,9 +1225,18 @@ void execute_call(thread_t* th)
 	default: {
 		if (th->num_args > 6)
 			fail("bad number of arguments");
-		th->res = syscall(call->sys_nr, th->args[0], th->args[1], th->args[2], th->args[3], th->args[4], th->args[5]);
+		// Cast args to long, otherwise 64-bit args are passed incorrectly through varargs on 32-bit archs.
+		th->res = syscall(call->sys_nr, (long)th->args[0], (long)th->args[1], (long)th->args[2], (long)th->args[3], (long)th->args[4], (long)th->args[5]);
+		break;
+	}
+#if defined(__i386__)
+	case __NR_mmap2: {
+		// mmap is mmap2 on 386 (__NR_mmap is old_mmap that takes a pointer to the args),
+		// mmap2 takes the offset in pages.
+		th->res = syscall(__NR_mmap2, (long)th->args[0], (long)th->args[1], (long)th->args[2], (long)th->args[3], (long)th->args[4], (long)(th->args[5] / 4096));
 		break;
 	}
+#endif
 	case __NR_syz_open_dev: {
 		const char* dev = (char*)th->args[0];
 		if ((uintptr_t)dev == 0xc || (uintptr_t)dev == 0xb) {
@@ -756,6 +1334,51 @@ void execute_call(thread_t* th)
 		th->res = fd;
 		break;
 	}
+	case __NR_syz_mount_image: {
+		// syz_mount_image(fs filesystem, dir filename, size intptr, nsegs len[segments], segments ptr[in, array[fs_image_segment]], flags flags[mount_flags], opts buffer[in])
+		th->res = syz_mount_image(th->args[0], th->args[1], th->args[2], th->args[3], th->args[4], th->args[5], th->args[6]);
+		break;
+	}
+	case __NR_syz_usb_connect: {
+		// syz_usb_connect(speed flags[usb_device_speed], dev_len len[dev], dev ptr[in, usb_device_descriptor], conf_len len[conf], conf ptr[in, usb_config_descriptor]) fd[usb]
+		th->res = syz_usb_connect(th->args[0], th->args[1], th->args[2], th->args[3], th->args[4]);
+		break;
+	}
+	case __NR_syz_usb_control_io: {
+		// syz_usb_control_io(fd fd[usb], resp_len len[resp], resp buffer[in])
+		th->res = syz_usb_control_io(th->args[0], th->args[1], th->args[2]);
+		break;
+	}
+	case __NR_syz_usb_ep_write: {
+		// syz_usb_ep_write(fd fd[usb], ep int8, len len[data], data buffer[in])
+		th->res = syz_usb_ep_write(th->args[0], th->args[1], th->args[2], th->args[3]);
+		break;
+	}
+	case __NR_syz_genetlink_get_family_id: {
+		// syz_genetlink_get_family_id(name strconst) genl_family
+		th->res = syz_genetlink_get_family_id(th->args[0]);
+		break;
+	}
+	case __NR_syz_socket_bind: {
+		// syz_socket_bind(domain flags[socket_domain], type flags[socket_type], proto int8, addr ptr[in, sockaddr], addrlen len[addr]) fd[sock]
+		th->res = syz_socket_bind(th->args[0], th->args[1], th->args[2], th->args[3], th->args[4]);
+		break;
+	}
+	case __NR_syz_memfd_data: {
+		// syz_memfd_data(name string, flags flags[memfd_flags], data buffer[in], len len[data]) fd
+		th->res = syz_memfd_data(th->args[0], th->args[1], th->args[2], th->args[3]);
+		break;
+	}
+	case __NR_syz_mount_tmpfs: {
+		// syz_mount_tmpfs(dir filename, flags flags[mount_flags], opts buffer[in]) fd[dir]
+		th->res = syz_mount_tmpfs(th->args[0], th->args[1], th->args[2]);
+		break;
+	}
+	case __NR_syz_kvm_setup_cpu: {
+		// syz_kvm_setup_cpu(fd fd[kvmvm], cpufd fd[kvmcpu], text ptr[in, array[kvm_text_x86, 1]], ntext len[text], flags flags[kvm_setup_flags], opts ptr[in, array[kvm_setup_opt_x86]], nopt len[opts])
+		th->res = syz_kvm_setup_cpu(th->args[0], th->args[1], th->args[2], th->args[3], th->args[4], th->args[5], th->args[6]);
+		break;
+	}
 	}
 	th->reserrno = errno;
 	th->cover_size = cover_read(th);
@@ -768,6 +1391,576 @@ void execute_call(thread_t* th)
 	syscall(SYS_futex, &th->done, FUTEX_WAKE);
 }
 
+// fs_image_segment in sys/fs_image.txt, intptr fields have pointer size.
+struct fs_image_segment {
+	void* data;
+	uintptr_t size;
+	uintptr_t offset;
+};
+
+long syz_mount_image(uint64_t fs, uint64_t dir, uint64_t size, uint64_t nsegs, uint64_t segments, uint64_t flags, uint64_t opts)
+{
+	// The image is a sparse file in the test working dir (removed along with the dir),
+	// attached to a free loop device. The loop device is detached right after mount,
+	// kernel keeps it alive until the filesystem is unmounted.
+	if (size > kMaxImageSize)
+		size = kMaxImageSize;
+	int res = -1;
+	int imgfd = open("./syz-image", O_RDWR | O_CREAT | O_TRUNC, 0600);
+	if (imgfd == -1)
+		return -1;
+	if (ftruncate(imgfd, size)) {
+		close(imgfd);
+		return -1;
+	}
+	fs_image_segment* segs = (fs_image_segment*)segments;
+	for (uint64_t i = 0; i < nsegs; i++) {
+		// Bogus data pointers make pwrite fail with EFAULT, which is fine.
+		uint64_t off = segs[i].offset;
+		uint64_t sz = segs[i].size;
+		if (off >= size)
+			continue;
+		if (sz > size - off)
+			sz = size - off;
+		if (pwrite(imgfd, segs[i].data, sz, off) < 0)
+			debug("syz_mount_image: pwrite failed (errno %d)\n", errno);
+	}
+	int loopfd = -1;
+	char loopname[64];
+	int ctlfd = open("/dev/loop-control", O_RDWR);
+	if (ctlfd != -1) {
+		int loopnum = ioctl(ctlfd, LOOP_CTL_GET_FREE);
+		close(ctlfd);
+		if (loopnum >= 0) {
+			sprintf(loopname, "/dev/loop%d", loopnum);
+			loopfd = open(loopname, O_RDWR);
+		}
+	}
+	if (loopfd != -1) {
+		if (ioctl(loopfd, LOOP_SET_FD, imgfd) == 0) {
+			res = mount(loopname, (char*)dir, (char*)fs, flags, (char*)opts);
+			int err = errno;
+			ioctl(loopfd, LOOP_CLR_FD, 0);
+			errno = err;
+		}
+		close(loopfd);
+	}
+	close(imgfd);
+	return res;
+}
+
+// Raw gadget interface (include/uapi/linux/usb/raw_gadget.h),
+// the header is not yet available in most distros.
+struct usb_raw_init {
+	uint8_t driver_name[128];
+	uint8_t device_name[128];
+	uint8_t speed;
+};
+
+enum usb_raw_event_type {
+	USB_RAW_EVENT_INVALID,
+	USB_RAW_EVENT_CONNECT,
+	USB_RAW_EVENT_CONTROL,
+};
+
+struct usb_raw_event {
+	uint32_t type;
+	uint32_t length;
+	uint8_t data[0];
+};
+
+struct usb_raw_ep_io {
+	uint16_t ep;
+	uint16_t flags;
+	uint32_t length;
+	uint8_t data[0];
+};
+
+#define USB_RAW_IOCTL_INIT _IOW('U', 0, struct usb_raw_init)
+#define USB_RAW_IOCTL_RUN _IO('U', 1)
+#define USB_RAW_IOCTL_EVENT_FETCH _IOR('U', 2, struct usb_raw_event)
+#define USB_RAW_IOCTL_EP0_WRITE _IOW('U', 3, struct usb_raw_ep_io)
+#define USB_RAW_IOCTL_EP0_READ _IOWR('U', 4, struct usb_raw_ep_io)
+#define USB_RAW_IOCTL_EP_ENABLE _IOW('U', 5, struct usb_endpoint_descriptor)
+#define USB_RAW_IOCTL_EP_WRITE _IOW('U', 7, struct usb_raw_ep_io)
+#define USB_RAW_IOCTL_CONFIGURE _IO('U', 9)
+#define USB_RAW_IOCTL_VBUS_DRAW _IOW('U', 10, uint32_t)
+#define USB_RAW_IOCTL_EP0_STALL _IO('U', 12)
+
+struct usb_raw_control_event {
+	usb_raw_event inner;
+	usb_ctrlrequest ctrl;
+};
+
+struct usb_raw_ep_io_data {
+	usb_raw_ep_io inner;
+	char data[kUsbBufSize];
+};
+
+// Emulated devices, used to map endpoint addresses to raw gadget endpoint handles.
+struct usb_device_t {
+	int fd;
+	int nep;
+	uint8_t ep_addr[kMaxUsbEndpoints];
+	int ep_handle[kMaxUsbEndpoints];
+};
+
+usb_device_t usb_devices[kMaxUsbDevices];
+int usb_devices_num;
+
+int usb_fetch_control(int fd, usb_ctrlrequest* ctrl)
+{
+	for (;;) {
+		usb_raw_control_event event = {};
+		event.inner.length = sizeof(event.ctrl);
+		if (ioctl(fd, USB_RAW_IOCTL_EVENT_FETCH, &event))
+			return -1;
+		if (event.inner.type == USB_RAW_EVENT_CONTROL) {
+			*ctrl = event.ctrl;
+			debug("usb: control request type=0x%x req=0x%x value=0x%x len=%d\n",
+			      ctrl->bRequestType, ctrl->bRequest, ctrl->wValue, ctrl->wLength);
+			return 0;
+		}
+	}
+}
+
+// usb_ep0_reply sends data in response to IN control requests (truncated to wLength),
+// or reads and discards the request data for OUT requests.
+int usb_ep0_reply(int fd, usb_ctrlrequest* ctrl, const void* data, uint64_t len)
+{
+	usb_raw_ep_io_data io = {};
+	if (ctrl->bRequestType & USB_DIR_IN) {
+		if (len > ctrl->wLength)
+			len = ctrl->wLength;
+		if (len > sizeof(io.data))
+			len = sizeof(io.data);
+		if (len)
+			memcpy(io.data, data, len);
+		io.inner.length = len;
+		return ioctl(fd, USB_RAW_IOCTL_EP0_WRITE, &io);
+	}
+	io.inner.length = ctrl->wLength;
+	return ioctl(fd, USB_RAW_IOCTL_EP0_READ, &io);
+}
+
+void usb_enable_endpoints(usb_device_t* dev, uint8_t* conf, uint64_t conf_len)
+{
+	for (uint64_t off = USB_DT_CONFIG_SIZE; off + 2 <= conf_len && dev->nep < kMaxUsbEndpoints;) {
+		uint8_t len = conf[off];
+		if (len < 2 || off + len > conf_len)
+			break;
+		if (conf[off + 1] == USB_DT_ENDPOINT && len >= USB_DT_ENDPOINT_SIZE) {
+			usb_endpoint_descriptor ep = {};
+			memcpy(&ep, conf + off, len < sizeof(ep) ? len : sizeof(ep));
+			int handle = ioctl(dev->fd, USB_RAW_IOCTL_EP_ENABLE, &ep);
+			if (handle >= 0) {
+				dev->ep_addr[dev->nep] = ep.bEndpointAddress;
+				dev->ep_handle[dev->nep] = handle;
+				dev->nep++;
+			}
+		}
+		off += len;
+	}
+}
+
+long syz_usb_connect(uint64_t speed, uint64_t dev_len, uint64_t dev, uint64_t conf_len, uint64_t conf)
+{
+	int idx = __atomic_fetch_add(&usb_devices_num, 1, __ATOMIC_RELAXED);
+	if (idx >= kMaxUsbDevices) {
+		errno = EBUSY;
+		return -1;
+	}
+	usb_device_t* udev = &usb_devices[idx];
+	udev->fd = -1;
+	// Several executors fuzz concurrently, each needs own UDC
+	// (dummy_hcd is loaded with num=N), so take the first free one.
+	int fd = -1;
+	for (int i = 0; i < kMaxUdcs; i++) {
+		fd = open("/dev/raw-gadget", O_RDWR);
+		if (fd == -1)
+			return -1;
+		usb_raw_init init = {};
+		strcpy((char*)init.driver_name, "dummy_udc");
+		sprintf((char*)init.device_name, "dummy_udc.%d", i);
+		init.speed = speed;
+		if (ioctl(fd, USB_RAW_IOCTL_INIT, &init) == 0 && ioctl(fd, USB_RAW_IOCTL_RUN, 0) == 0)
+			break;
+		close(fd);
+		fd = -1;
+	}
+	if (fd == -1)
+		return -1;
+	udev->fd = fd;
+	uint8_t* confp = (uint8_t*)conf;
+	if (conf_len >= USB_DT_CONFIG_SIZE) {
+		uint16_t total = conf_len;
+		memcpy(confp + 2, &total, sizeof(total));
+	}
+	// Answer enumeration requests until the host selects configuration.
+	// Everything we don't know about is acked with an empty response.
+	for (int i = 0; i < 64; i++) {
+		usb_ctrlrequest ctrl = {};
+		if (usb_fetch_control(fd, &ctrl))
+			break;
+		if ((ctrl.bRequestType & USB_TYPE_MASK) != USB_TYPE_STANDARD) {
+			usb_ep0_reply(fd, &ctrl, 0, 0);
+			continue;
+		}
+		if (ctrl.bRequest == USB_REQ_GET_DESCRIPTOR) {
+			static const uint8_t lang_desc[] = {4, USB_DT_STRING, 0x09, 0x04};
+			static const uint8_t str_desc[] = {8, USB_DT_STRING, 's', 0, 'y', 0, 'z', 0};
+			switch (ctrl.wValue >> 8) {
+			case USB_DT_DEVICE:
+				usb_ep0_reply(fd, &ctrl, (void*)dev, dev_len);
+				break;
+			case USB_DT_CONFIG:
+				usb_ep0_reply(fd, &ctrl, (void*)conf, conf_len);
+				break;
+			case USB_DT_STRING:
+				if ((ctrl.wValue & 0xff) == 0)
+					usb_ep0_reply(fd, &ctrl, lang_desc, sizeof(lang_desc));
+				else
+					usb_ep0_reply(fd, &ctrl, str_desc, sizeof(str_desc));
+				break;
+			default:
+				ioctl(fd, USB_RAW_IOCTL_EP0_STALL, 0);
+				break;
+			}
+			continue;
+		}
+		if (ctrl.bRequest == USB_REQ_SET_CONFIGURATION) {
+			usb_enable_endpoints(udev, confp, conf_len);
+			uint32_t power = conf_len >= USB_DT_CONFIG_SIZE ? confp[8] * 2 : 0;
+			ioctl(fd, USB_RAW_IOCTL_VBUS_DRAW, power);
+			ioctl(fd, USB_RAW_IOCTL_CONFIGURE, 0);
+			usb_ep0_reply(fd, &ctrl, 0, 0);
+			break;
+		}
+		usb_ep0_reply(fd, &ctrl, 0, 0);
+	}
+	return fd;
+}
+
+long syz_usb_control_io(uint64_t fd, uint64_t resp_len, uint64_t resp)
+{
+	usb_ctrlrequest ctrl = {};
+	if (usb_fetch_control(fd, &ctrl))
+		return -1;
+	return usb_ep0_reply(fd, &ctrl, (void*)resp, resp_len);
+}
+
+long syz_usb_ep_write(uint64_t fd, uint64_t ep, uint64_t len, uint64_t data)
+{
+	int num = __atomic_load_n(&usb_devices_num, __ATOMIC_RELAXED);
+	for (int i = 0; i < num && i < kMaxUsbDevices; i++) {
+		usb_device_t* dev = &usb_devices[i];
+		if (dev->fd != (int)fd)
+			continue;
+		for (int j = 0; j < dev->nep; j++) {
+			if (dev->ep_addr[j] != (uint8_t)ep)
+				continue;
+			usb_raw_ep_io_data io = {};
+			io.inner.ep = dev->ep_handle[j];
+			if (len > sizeof(io.data))
+				len = sizeof(io.data);
+			io.inner.length = len;
+			memcpy(io.data, (void*)data, len);
+			return ioctl(fd, USB_RAW_IOCTL_EP_WRITE, &io);
+		}
+	}
+	errno = EINVAL;
+	return -1;
+}
+
+long syz_genetlink_get_family_id(uint64_t name)
+{
+	// Send CTRL_CMD_GETFAMILY with CTRL_ATTR_FAMILY_NAME to nlctrl
+	// and fish CTRL_ATTR_FAMILY_ID out of the reply.
+	struct {
+		nlmsghdr hdr;
+		genlmsghdr genl;
+		char attrs[256];
+	} msg;
+	int namelen = strnlen((char*)name, GENL_NAMSIZ - 1) + 1;
+	memset(&msg, 0, sizeof(msg));
+	msg.hdr.nlmsg_len = NLMSG_LENGTH(GENL_HDRLEN + NLA_HDRLEN + namelen);
+	msg.hdr.nlmsg_type = GENL_ID_CTRL;
+	msg.hdr.nlmsg_flags = NLM_F_REQUEST | NLM_F_ACK;
+	msg.genl.cmd = CTRL_CMD_GETFAMILY;
+	nlattr* attr = (nlattr*)msg.attrs;
+	attr->nla_len = NLA_HDRLEN + namelen;
+	attr->nla_type = CTRL_ATTR_FAMILY_NAME;
+	memcpy(msg.attrs + NLA_HDRLEN, (char*)name, namelen - 1);
+	int fd = socket(AF_NETLINK, SOCK_RAW, NETLINK_GENERIC);
+	if (fd == -1)
+		return -1;
+	if (send(fd, &msg, msg.hdr.nlmsg_len, 0) == -1) {
+		close(fd);
+		return -1;
+	}
+	char buf[4 << 10];
+	int n = recv(fd, buf, sizeof(buf), 0);
+	close(fd);
+	nlmsghdr* hdr = (nlmsghdr*)buf;
+	if (n < (int)NLMSG_LENGTH(GENL_HDRLEN) || hdr->nlmsg_type == NLMSG_ERROR) {
+		errno = EINVAL;
+		if (n >= (int)NLMSG_LENGTH(sizeof(nlmsgerr)) && hdr->nlmsg_type == NLMSG_ERROR)
+			errno = -((nlmsgerr*)NLMSG_DATA(hdr))->error;
+		return -1;
+	}
+	for (int off = NLMSG_LENGTH(GENL_HDRLEN); off + NLA_HDRLEN <= n;) {
+		attr = (nlattr*)(buf + off);
+		if (attr->nla_len < NLA_HDRLEN)
+			break;
+		if (attr->nla_type == CTRL_ATTR_FAMILY_ID && attr->nla_len >= NLA_HDRLEN + sizeof(uint16_t))
+			return *(uint16_t*)(buf + off + NLA_HDRLEN);
+		off += NLA_ALIGN(attr->nla_len);
+	}
+	errno = EINVAL;
+	return -1;
+}
+
+// Composite pseudo-syscalls for multi-step idioms that random programs rarely get right.
+// On failure the intermediate fd is closed, errno is the one of the failed step.
+
+long syz_socket_bind(uint64_t domain, uint64_t type, uint64_t proto, uint64_t addr, uint64_t addrlen)
+{
+	int fd = socket(domain, type, proto);
+	if (fd == -1)
+		return -1;
+	if (bind(fd, (sockaddr*)addr, addrlen)) {
+		int err = errno;
+		close(fd);
+		errno = err;
+		return -1;
+	}
+	return fd;
+}
+
+long syz_memfd_data(uint64_t name, uint64_t flags, uint64_t data, uint64_t len)
+{
+	int fd = syscall(__NR_memfd_create, (char*)name, flags);
+	if (fd == -1)
+		return -1;
+	for (uint64_t off = 0; off < len;) {
+		ssize_t n = write(fd, (char*)data + off, len - off);
+		if (n <= 0) {
+			int err = n == 0 ? ENOSPC : errno;
+			close(fd);
+			errno = err;
+			return -1;
+		}
+		off += n;
+	}
+	if (lseek(fd, 0, SEEK_SET)) {
+		int err = errno;
+		close(fd);
+		errno = err;
+		return -1;
+	}
+	return fd;
+}
+
+long syz_mount_tmpfs(uint64_t dir, uint64_t flags, uint64_t opts)
+{
+	if (mkdir((char*)dir, 0777) && errno != EEXIST)
+		return -1;
+	if (mount("", (char*)dir, "tmpfs", flags, (char*)opts))
+		return -1;
+	return open((char*)dir, O_RDONLY | O_DIRECTORY);
+}
+
+#if defined(__x86_64__) || defined(__i386__)
+// Guest physical memory layout for syz_kvm_setup_cpu.
+const uint64_t kKvmGuestPages = 24;
+const uint64_t kKvmPageSize = 4 << 10;
+const uint64_t kKvmAddrGdt = 0x0000;
+const uint64_t kKvmAddrPml4 = 0x1000;
+const uint64_t kKvmAddrPdp = 0x2000;
+const uint64_t kKvmAddrPd = 0x3000;
+const uint64_t kKvmAddrPd32 = 0x4000;
+const uint64_t kKvmAddrText = 0x5000;
+const uint64_t kKvmMaxText = 0x4000;
+const uint64_t kKvmAddrStack = 0xa000;
+const uint64_t kKvmAddrTss = 0xfffbd000;
+
+// Selectors of the GDT entries set up in guest memory.
+const uint16_t kKvmSelCode = 1 << 3;
+const uint16_t kKvmSelData = 2 << 3;
+const uint16_t kKvmSelCodeCpl3 = (3 << 3) | 3;
+const uint16_t kKvmSelDataCpl3 = (4 << 3) | 3;
+
+const uint64_t kX86CR0PE = 1ull << 0;
+const uint64_t kX86CR0PG = 1ull << 31;
+const uint64_t kX86CR4PSE = 1ull << 4;
+const uint64_t kX86CR4PAE = 1ull << 5;
+const uint64_t kX86EferLME = 1ull << 8;
+const uint64_t kX86EferLMA = 1ull << 10;
+const uint64_t kX86PdeFlags = 0x87; // present, writable, user, large page
+
+const uint64_t kKvmSetupPaging = 1 << 0;
+const uint64_t kKvmSetupCpl3 = 1 << 1;
+
+// kvm_text_x86 and kvm_setup_opt_x86 in sys/kvm.txt.
+struct kvm_text {
+	uintptr_t mode;
+	const void* text;
+	uintptr_t size;
+};
+
+struct kvm_opt {
+	uint64_t typ;
+	uint64_t val;
+};
+
+void kvm_fill_segment(kvm_segment* seg, uint16_t sel, bool code, bool is64)
+{
+	memset(seg, 0, sizeof(*seg));
+	seg->selector = sel;
+	seg->base = 0;
+	seg->limit = 0xffffffff;
+	seg->type = code ? 11 : 3; // execute/read accessed : read/write accessed
+	seg->present = 1;
+	seg->dpl = sel & 3;
+	seg->s = 1;
+	seg->g = 1;
+	seg->db = !is64 || !code;
+	seg->l = is64 && code;
+}
+
+uint64_t kvm_gdt_entry(const kvm_segment* seg)
+{
+	uint64_t limit = seg->g ? seg->limit >> 12 : seg->limit;
+	uint64_t access = seg->type | (seg->s << 4) | (seg->dpl << 5) | (seg->present << 7);
+	uint64_t attrs = (seg->l << 1) | (seg->db << 2) | (seg->g << 3);
+	return (limit & 0xffff) | ((seg->base & 0xffffff) << 16) | (access << 40) |
+	       (((limit >> 16) & 0xf) << 48) | (attrs << 52) | (((seg->base >> 24) & 0xff) << 56);
+}
+
+// Guest memory is allocated here rather than passed by the fuzzer, so that we know its size
+// and can't crash on unmapped memory. It must stay mapped while VMs run, so there is a single
+// static region for the whole test process: all VMs set up by a program share it
+// (the setup writes the same tables, only the text can differ).
+__attribute__((aligned(4 << 10))) char kvm_guest_mem[kKvmGuestPages * kKvmPageSize];
+
+long syz_kvm_setup_cpu(uint64_t vmfd, uint64_t cpufd, uint64_t text, uint64_t ntext, uint64_t flags, uint64_t opts, uint64_t nopt)
+{
+	const uint64_t guest_size = sizeof(kvm_guest_mem);
+	char* host_mem = kvm_guest_mem;
+	kvm_userspace_memory_region memreg = {};
+	memreg.slot = 0;
+	memreg.guest_phys_addr = 0;
+	memreg.memory_size = guest_size;
+	memreg.userspace_addr = (uintptr_t)host_mem;
+	if (ioctl(vmfd, KVM_SET_USER_MEMORY_REGION, &memreg))
+		return -1;
+	// Required on Intel before entering real mode, may fail if already set.
+	ioctl(vmfd, KVM_SET_TSS_ADDR, kKvmAddrTss);
+
+	kvm_sregs sregs;
+	if (ioctl(cpufd, KVM_GET_SREGS, &sregs))
+		return -1;
+	kvm_regs regs = {};
+	regs.rflags = 2;
+	regs.rsp = kKvmAddrStack;
+	regs.rip = kKvmAddrText;
+
+	uint64_t mode = 16;
+	if (ntext != 0) {
+		kvm_text* t = (kvm_text*)text;
+		mode = t->mode;
+		uint64_t size = t->size;
+		if (size > kKvmMaxText)
+			size = kKvmMaxText;
+		memcpy(host_mem + kKvmAddrText, t->text, size);
+	}
+
+	bool cpl3 = flags & kKvmSetupCpl3;
+	kvm_segment code, data;
+	kvm_fill_segment(&code, cpl3 ? kKvmSelCodeCpl3 : kKvmSelCode, true, mode == 64);
+	kvm_fill_segment(&data, cpl3 ? kKvmSelDataCpl3 : kKvmSelData, false, mode == 64);
+	uint64_t* gdt = (uint64_t*)(host_mem + kKvmAddrGdt);
+	gdt[kKvmSelCode >> 3] = kvm_gdt_entry(&code);
+	gdt[kKvmSelData >> 3] = kvm_gdt_entry(&data);
+	gdt[kKvmSelCodeCpl3 >> 3] = kvm_gdt_entry(&code);
+	gdt[kKvmSelDataCpl3 >> 3] = kvm_gdt_entry(&data);
+	sregs.gdt.base = kKvmAddrGdt;
+	sregs.gdt.limit = 5 * 8 - 1;
+
+	switch (mode) {
+	case 32:
+		sregs.cr0 |= kX86CR0PE;
+		if (flags & kKvmSetupPaging) {
+			// Identity map the first 4GB with 4MB pages.
+			uint32_t* pd = (uint32_t*)(host_mem + kKvmAddrPd32);
+			for (uint32_t i = 0; i < 1024; i++)
+				pd[i] = (i << 22) | kX86PdeFlags;
+			sregs.cr3 = kKvmAddrPd32;
+			sregs.cr4 |= kX86CR4PSE;
+			sregs.cr0 |= kX86CR0PG;
+		}
+		break;
+	case 64: {
+		// Identity map the first 1GB with 2MB pages.
+		uint64_t* pml4 = (uint64_t*)(host_mem + kKvmAddrPml4);
+		uint64_t* pdp = (uint64_t*)(host_mem + kKvmAddrPdp);
+		uint64_t* pd = (uint64_t*)(host_mem + kKvmAddrPd);
+		pml4[0] = kKvmAddrPdp | 7;
+		pdp[0] = kKvmAddrPd | 7;
+		for (uint64_t i = 0; i < 512; i++)
+			pd[i] = (i << 21) | kX86PdeFlags;
+		sregs.cr3 = kKvmAddrPml4;
+		sregs.cr4 |= kX86CR4PAE;
+		sregs.cr0 |= kX86CR0PE | kX86CR0PG;
+		sregs.efer |= kX86EferLME | kX86EferLMA;
+		break;
+	}
+	default:
+		// Real mode: flat 64K segments at 0, code and stack below 64K.
+		break;
+	}
+	if (mode == 32 || mode == 64) {
+		sregs.cs = code;
+		sregs.ds = sregs.es = sregs.fs = sregs.gs = sregs.ss = data;
+	} else {
+		sregs.cs.selector = sregs.ds.selector = sregs.es.selector = 0;
+		sregs.fs.selector = sregs.gs.selector = sregs.ss.selector = 0;
+		sregs.cs.base = sregs.ds.base = sregs.es.base = 0;
+		sregs.fs.base = sregs.gs.base = sregs.ss.base = 0;
+	}
+
+	kvm_opt* optp = (kvm_opt*)opts;
+	for (uint64_t i = 0; i < nopt; i++) {
+		switch (optp[i].typ) {
+		case 0:
+			sregs.cr0 |= optp[i].val;
+			break;
+		case 1:
+			sregs.cr4 |= optp[i].val;
+			break;
+		case 2:
+			sregs.efer |= optp[i].val;
+			break;
+		case 3:
+			regs.rflags |= optp[i].val;
+			break;
+		}
+	}
+
+	if (ioctl(cpufd, KVM_SET_SREGS, &sregs))
+		return -1;
+	if (ioctl(cpufd, KVM_SET_REGS, &regs))
+		return -1;
+	return 0;
+}
+#else
+long syz_kvm_setup_cpu(uint64_t vmfd, uint64_t cpufd, uint64_t text, uint64_t ntext, uint64_t flags, uint64_t opts, uint64_t nopt)
+{
+	errno = ENOSYS;
+	return -1;
+}
+#endif
+
 void cover_open()
 {
 	if (!flag_cover)
@@ -920,7 +2113,7 @@ void write_output(uint32_t v)
 {
 	if (collide)
 		return;
-	if ((char*)output_pos >= output_data + kMaxOutput)
+	if ((char*)output_pos >= output_data + kObjectsOffset)
 		fail("output overflow");
 	*output_pos++ = v;
 }
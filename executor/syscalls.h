@@ -2,8 +2,17 @@
 
 #define __NR_syz_fuse_mount	1000003
 #define __NR_syz_fuseblk_mount	1000004
+#define __NR_syz_genetlink_get_family_id	1000010
+#define __NR_syz_kvm_setup_cpu	1000009
+#define __NR_syz_memfd_data	1000012
+#define __NR_syz_mount_image	1000005
+#define __NR_syz_mount_tmpfs	1000013
 #define __NR_syz_open_dev	1000001
 #define __NR_syz_open_pts	1000002
+#define __NR_syz_socket_bind	1000011
+#define __NR_syz_usb_connect	1000006
+#define __NR_syz_usb_control_io	1000007
+#define __NR_syz_usb_ep_write	1000008
 
 
 struct call_t {
@@ -84,6 +93,7 @@ call_t syscalls[] = {
 	{"mlockall", 151},
 	{"munlockall", 152},
 	{"memfd_create", 319},
+	{"syz_memfd_data", 1000012},
 	{"unshare", 272},
 	{"kcmp", 312},
 	{"futex", 202},
@@ -233,6 +243,7 @@ call_t syscalls[] = {
 	{"mount", 165},
 	{"mount$fs", 165},
 	{"umount2", 166},
+	{"syz_mount_tmpfs", 1000013},
 	{"pivot_root", 155},
 	{"sysfs$1", 139},
 	{"sysfs$2", 139},
@@ -383,6 +394,8 @@ call_t syscalls[] = {
 	{"accept4", 288},
 	{"bind", 49},
 	{"listen", 50},
+	{"syz_socket_bind$inet", 1000011},
+	{"syz_socket_bind$inet6", 1000011},
 	{"connect", 42},
 	{"shutdown", 48},
 	{"sendto", 44},
@@ -447,6 +460,7 @@ call_t syscalls[] = {
 	{"socket$unix", 41},
 	{"socketpair$unix", 53},
 	{"bind$unix", 49},
+	{"syz_socket_bind$unix", 1000011},
 	{"connect$unix", 42},
 	{"accept$unix", 43},
 	{"accept4$unix", 288},
@@ -930,6 +944,7 @@ call_t syscalls[] = {
 	{"ioctl$KVM_GET_REG_LIST", 16},
 	{"ioctl$KVM_SET_GUEST_DEBUG", 16},
 	{"ioctl$KVM_SMI", 16},
+	{"syz_kvm_setup_cpu$x86", 1000009},
 	{"open$xenevtchn", 2},
 	{"syz_open_dev$sndseq", 1000001},
 	{"write$sndseq", 1},
@@ -1060,6 +1075,19 @@ call_t syscalls[] = {
 	{"setsockopt$NETLINK_LISTEN_ALL_NSID", 54},
 	{"setsockopt$NETLINK_CAP_ACK", 54},
 	{"getsockopt$netlink", 55},
+	{"socket$netlink_route", 41},
+	{"sendmsg$netlink_route", 46},
+	{"socket$netlink_generic", 41},
+	{"syz_genetlink_get_family_id$nlctrl", 1000010},
+	{"syz_genetlink_get_family_id$taskstats", 1000010},
+	{"syz_genetlink_get_family_id$tcp_metrics", 1000010},
+	{"syz_genetlink_get_family_id$ipvs", 1000010},
+	{"syz_genetlink_get_family_id$net_dm", 1000010},
+	{"syz_genetlink_get_family_id$nl80211", 1000010},
+	{"syz_genetlink_get_family_id$l2tp", 1000010},
+	{"syz_genetlink_get_family_id$fou", 1000010},
+	{"syz_genetlink_get_family_id$team", 1000010},
+	{"sendmsg$netlink_generic", 46},
 	{"syz_open_dev$tun", 1000001},
 	{"write$tun", 1},
 	{"ioctl$TUNGETFEATURES", 16},
@@ -1121,6 +1149,17 @@ call_t syscalls[] = {
 	{"ioctl$NETROM_SIOCGSTAMP", 16},
 	{"ioctl$NETROM_SIOCGSTAMPNS", 16},
 	{"ioctl$NETROM_SIOCADDRT", 16},
+	{"syz_mount_image", 1000005},
+	{"syz_mount_image$ext4", 1000005},
+	{"syz_mount_image$vfat", 1000005},
+	{"syz_mount_image$btrfs", 1000005},
+	{"syz_mount_image$xfs", 1000005},
+	{"syz_mount_image$iso9660", 1000005},
+	{"syz_mount_image$hfsplus", 1000005},
+	{"syz_mount_image$f2fs", 1000005},
+	{"syz_usb_connect", 1000006},
+	{"syz_usb_control_io", 1000007},
+	{"syz_usb_ep_write", 1000008},
 
 };
 #endif
@@ -1176,7 +1215,7 @@ call_t syscalls[] = {
 	{"ioctl$UFFDIO_WAKE", 54},
 	{"ioctl$UFFDIO_COPY", 54},
 	{"ioctl$UFFDIO_ZEROPAGE", 54},
-	{"mmap", 90},
+	{"mmap", 192},
 	{"munmap", 91},
 	{"mremap", 163},
 	{"remap_file_pages", 257},
@@ -1197,6 +1236,7 @@ call_t syscalls[] = {
 	{"mlockall", 152},
 	{"munlockall", 153},
 	{"memfd_create", 356},
+	{"syz_memfd_data", 1000012},
 	{"unshare", 310},
 	{"kcmp", 349},
 	{"futex", 240},
@@ -1346,6 +1386,7 @@ call_t syscalls[] = {
 	{"mount", 21},
 	{"mount$fs", 21},
 	{"umount2", 52},
+	{"syz_mount_tmpfs", 1000013},
 	{"pivot_root", 217},
 	{"sysfs$1", 135},
 	{"sysfs$2", 135},
@@ -1496,6 +1537,8 @@ call_t syscalls[] = {
 	{"accept4", 364},
 	{"bind", 361},
 	{"listen", 363},
+	{"syz_socket_bind$inet", 1000011},
+	{"syz_socket_bind$inet6", 1000011},
 	{"connect", 362},
 	{"shutdown", 373},
 	{"sendto", 369},
@@ -1560,6 +1603,7 @@ call_t syscalls[] = {
 	{"socket$unix", 359},
 	{"socketpair$unix", 360},
 	{"bind$unix", 361},
+	{"syz_socket_bind$unix", 1000011},
 	{"connect$unix", 362},
 	{"accept$unix", -1},
 	{"accept4$unix", 364},
@@ -2043,6 +2087,7 @@ call_t syscalls[] = {
 	{"ioctl$KVM_GET_REG_LIST", 54},
 	{"ioctl$KVM_SET_GUEST_DEBUG", 54},
 	{"ioctl$KVM_SMI", 54},
+	{"syz_kvm_setup_cpu$x86", 1000009},
 	{"open$xenevtchn", 5},
 	{"syz_open_dev$sndseq", 1000001},
 	{"write$sndseq", 4},
@@ -2173,6 +2218,19 @@ call_t syscalls[] = {
 	{"setsockopt$NETLINK_LISTEN_ALL_NSID", 366},
 	{"setsockopt$NETLINK_CAP_ACK", 366},
 	{"getsockopt$netlink", 365},
+	{"socket$netlink_route", 359},
+	{"sendmsg$netlink_route", 370},
+	{"socket$netlink_generic", 359},
+	{"syz_genetlink_get_family_id$nlctrl", 1000010},
+	{"syz_genetlink_get_family_id$taskstats", 1000010},
+	{"syz_genetlink_get_family_id$tcp_metrics", 1000010},
+	{"syz_genetlink_get_family_id$ipvs", 1000010},
+	{"syz_genetlink_get_family_id$net_dm", 1000010},
+	{"syz_genetlink_get_family_id$nl80211", 1000010},
+	{"syz_genetlink_get_family_id$l2tp", 1000010},
+	{"syz_genetlink_get_family_id$fou", 1000010},
+	{"syz_genetlink_get_family_id$team", 1000010},
+	{"sendmsg$netlink_generic", 370},
 	{"syz_open_dev$tun", 1000001},
 	{"write$tun", 4},
 	{"ioctl$TUNGETFEATURES", 54},
@@ -2234,6 +2292,17 @@ call_t syscalls[] = {
 	{"ioctl$NETROM_SIOCGSTAMP", 54},
 	{"ioctl$NETROM_SIOCGSTAMPNS", 54},
 	{"ioctl$NETROM_SIOCADDRT", 54},
+	{"syz_mount_image", 1000005},
+	{"syz_mount_image$ext4", 1000005},
+	{"syz_mount_image$vfat", 1000005},
+	{"syz_mount_image$btrfs", 1000005},
+	{"syz_mount_image$xfs", 1000005},
+	{"syz_mount_image$iso9660", 1000005},
+	{"syz_mount_image$hfsplus", 1000005},
+	{"syz_mount_image$f2fs", 1000005},
+	{"syz_usb_connect", 1000006},
+	{"syz_usb_control_io", 1000007},
+	{"syz_usb_ep_write", 1000008},
 
 };
 #endif
@@ -2310,6 +2379,7 @@ call_t syscalls[] = {
 	{"mlockall", 230},
 	{"munlockall", 231},
 	{"memfd_create", 279},
+	{"syz_memfd_data", 1000012},
 	{"unshare", 97},
 	{"kcmp", 272},
 	{"futex", 98},
@@ -2459,6 +2529,7 @@ call_t syscalls[] = {
 	{"mount", 40},
 	{"mount$fs", 40},
 	{"umount2", 39},
+	{"syz_mount_tmpfs", 1000013},
 	{"pivot_root", 41},
 	{"sysfs$1", -1},
 	{"sysfs$2", -1},
@@ -2609,6 +2680,8 @@ call_t syscalls[] = {
 	{"accept4", 242},
 	{"bind", 200},
 	{"listen", 201},
+	{"syz_socket_bind$inet", 1000011},
+	{"syz_socket_bind$inet6", 1000011},
 	{"connect", 203},
 	{"shutdown", 210},
 	{"sendto", 206},
@@ -2673,6 +2746,7 @@ call_t syscalls[] = {
 	{"socket$unix", 198},
 	{"socketpair$unix", 199},
 	{"bind$unix", 200},
+	{"syz_socket_bind$unix", 1000011},
 	{"connect$unix", 203},
 	{"accept$unix", 202},
 	{"accept4$unix", 242},
@@ -3156,6 +3230,7 @@ call_t syscalls[] = {
 	{"ioctl$KVM_GET_REG_LIST", 29},
 	{"ioctl$KVM_SET_GUEST_DEBUG", 29},
 	{"ioctl$KVM_SMI", 29},
+	{"syz_kvm_setup_cpu$x86", 1000009},
 	{"open$xenevtchn", -1},
 	{"syz_open_dev$sndseq", 1000001},
 	{"write$sndseq", 64},
@@ -3286,6 +3361,19 @@ call_t syscalls[] = {
 	{"setsockopt$NETLINK_LISTEN_ALL_NSID", 208},
 	{"setsockopt$NETLINK_CAP_ACK", 208},
 	{"getsockopt$netlink", 209},
+	{"socket$netlink_route", 198},
+	{"sendmsg$netlink_route", 211},
+	{"socket$netlink_generic", 198},
+	{"syz_genetlink_get_family_id$nlctrl", 1000010},
+	{"syz_genetlink_get_family_id$taskstats", 1000010},
+	{"syz_genetlink_get_family_id$tcp_metrics", 1000010},
+	{"syz_genetlink_get_family_id$ipvs", 1000010},
+	{"syz_genetlink_get_family_id$net_dm", 1000010},
+	{"syz_genetlink_get_family_id$nl80211", 1000010},
+	{"syz_genetlink_get_family_id$l2tp", 1000010},
+	{"syz_genetlink_get_family_id$fou", 1000010},
+	{"syz_genetlink_get_family_id$team", 1000010},
+	{"sendmsg$netlink_generic", 211},
 	{"syz_open_dev$tun", 1000001},
 	{"write$tun", 64},
 	{"ioctl$TUNGETFEATURES", 29},
@@ -3347,6 +3435,17 @@ call_t syscalls[] = {
 	{"ioctl$NETROM_SIOCGSTAMP", 29},
 	{"ioctl$NETROM_SIOCGSTAMPNS", 29},
 	{"ioctl$NETROM_SIOCADDRT", 29},
+	{"syz_mount_image", 1000005},
+	{"syz_mount_image$ext4", 1000005},
+	{"syz_mount_image$vfat", 1000005},
+	{"syz_mount_image$btrfs", 1000005},
+	{"syz_mount_image$xfs", 1000005},
+	{"syz_mount_image$iso9660", 1000005},
+	{"syz_mount_image$hfsplus", 1000005},
+	{"syz_mount_image$f2fs", 1000005},
+	{"syz_usb_connect", 1000006},
+	{"syz_usb_control_io", 1000007},
+	{"syz_usb_ep_write", 1000008},
 
 };
 #endif
@@ -3423,6 +3522,7 @@ call_t syscalls[] = {
 	{"mlockall", 152},
 	{"munlockall", 153},
 	{"memfd_create", 360},
+	{"syz_memfd_data", 1000012},
 	{"unshare", 282},
 	{"kcmp", 354},
 	{"futex", 221},
@@ -3572,6 +3672,7 @@ call_t syscalls[] = {
 	{"mount", 21},
 	{"mount$fs", 21},
 	{"umount2", 52},
+	{"syz_mount_tmpfs", 1000013},
 	{"pivot_root", 203},
 	{"sysfs$1", 135},
 	{"sysfs$2", 135},
@@ -3722,6 +3823,8 @@ call_t syscalls[] = {
 	{"accept4", 344},
 	{"bind", 327},
 	{"listen", 329},
+	{"syz_socket_bind$inet", 1000011},
+	{"syz_socket_bind$inet6", 1000011},
 	{"connect", 328},
 	{"shutdown", 338},
 	{"sendto", 335},
@@ -3786,6 +3889,7 @@ call_t syscalls[] = {
 	{"socket$unix", 326},
 	{"socketpair$unix", 333},
 	{"bind$unix", 327},
+	{"syz_socket_bind$unix", 1000011},
 	{"connect$unix", 328},
 	{"accept$unix", 330},
 	{"accept4$unix", 344},
@@ -4269,6 +4373,7 @@ call_t syscalls[] = {
 	{"ioctl$KVM_GET_REG_LIST", 54},
 	{"ioctl$KVM_SET_GUEST_DEBUG", 54},
 	{"ioctl$KVM_SMI", 54},
+	{"syz_kvm_setup_cpu$x86", 1000009},
 	{"open$xenevtchn", 5},
 	{"syz_open_dev$sndseq", 1000001},
 	{"write$sndseq", 4},
@@ -4399,6 +4504,19 @@ call_t syscalls[] = {
 	{"setsockopt$NETLINK_LISTEN_ALL_NSID", 339},
 	{"setsockopt$NETLINK_CAP_ACK", 339},
 	{"getsockopt$netlink", 340},
+	{"socket$netlink_route", 326},
+	{"sendmsg$netlink_route", 341},
+	{"socket$netlink_generic", 326},
+	{"syz_genetlink_get_family_id$nlctrl", 1000010},
+	{"syz_genetlink_get_family_id$taskstats", 1000010},
+	{"syz_genetlink_get_family_id$tcp_metrics", 1000010},
+	{"syz_genetlink_get_family_id$ipvs", 1000010},
+	{"syz_genetlink_get_family_id$net_dm", 1000010},
+	{"syz_genetlink_get_family_id$nl80211", 1000010},
+	{"syz_genetlink_get_family_id$l2tp", 1000010},
+	{"syz_genetlink_get_family_id$fou", 1000010},
+	{"syz_genetlink_get_family_id$team", 1000010},
+	{"sendmsg$netlink_generic", 341},
 	{"syz_open_dev$tun", 1000001},
 	{"write$tun", 4},
 	{"ioctl$TUNGETFEATURES", 54},
@@ -4460,6 +4578,17 @@ call_t syscalls[] = {
 	{"ioctl$NETROM_SIOCGSTAMP", 54},
 	{"ioctl$NETROM_SIOCGSTAMPNS", 54},
 	{"ioctl$NETROM_SIOCADDRT", 54},
+	{"syz_mount_image", 1000005},
+	{"syz_mount_image$ext4", 1000005},
+	{"syz_mount_image$vfat", 1000005},
+	{"syz_mount_image$btrfs", 1000005},
+	{"syz_mount_image$xfs", 1000005},
+	{"syz_mount_image$iso9660", 1000005},
+	{"syz_mount_image$hfsplus", 1000005},
+	{"syz_mount_image$f2fs", 1000005},
+	{"syz_usb_connect", 1000006},
+	{"syz_usb_control_io", 1000007},
+	{"syz_usb_ep_write", 1000008},
 
 };
 #endif
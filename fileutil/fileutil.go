@@ -4,6 +4,8 @@
 package fileutil
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -12,7 +14,6 @@ import (
 	"strconv"
 	"sync"
 	"syscall"
-	"unsafe"
 )
 
 var copyMu sync.Mutex
@@ -42,6 +43,20 @@ func CopyFile(oldFile, newFile string, serial bool) error {
 	return nil
 }
 
+// Hash returns hex SHA1 of the file contents.
+func Hash(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha1.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // WriteTempFile writes data to a temp file and returns its name.
 func WriteTempFile(data []byte) (string, error) {
 	f, err := ioutil.TempFile("", "syzkaller")
@@ -113,7 +128,6 @@ func UmountAll(dir string) {
 		if f.IsDir() {
 			UmountAll(name)
 		}
-		fn := []byte(name + "\x00")
-		syscall.Syscall(syscall.SYS_UMOUNT2, uintptr(unsafe.Pointer(&fn[0])), syscall.MNT_FORCE, 0)
+		syscall.Unmount(name, mntForce)
 	}
 }
@@ -0,0 +1,10 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fileutil
+
+import (
+	"syscall"
+)
+
+const mntForce = syscall.MNT_FORCE
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"syscall"
@@ -36,6 +37,30 @@ func DetectSupportedSyscalls() (map[*sys.Call]bool, error) {
 	return supported, nil
 }
 
+// Features describes kernel features that matter for fuzzing.
+type Features struct {
+	Kcov     bool // /sys/kernel/debug/kcov can be opened (CONFIG_KCOV and mounted debugfs)
+	Debugfs  bool // debugfs is mounted at /sys/kernel/debug (CONFIG_DEBUG_FS)
+	Kasan    bool // kernel is built with CONFIG_KASAN
+	Kallsyms bool // /proc/kallsyms is readable, so syscall support detection is precise
+}
+
+// DetectFeatures checks what kernel features are available on host.
+func DetectFeatures() Features {
+	var f Features
+	if fd, err := syscall.Open("/sys/kernel/debug/kcov", syscall.O_RDWR, 0); err == nil {
+		syscall.Close(fd)
+		f.Kcov = true
+	}
+	if mounts, err := ioutil.ReadFile("/proc/mounts"); err == nil {
+		f.Debugfs = bytes.Contains(mounts, []byte(" /sys/kernel/debug debugfs "))
+	}
+	kallsyms, _ := ioutil.ReadFile("/proc/kallsyms")
+	f.Kallsyms = len(kallsyms) != 0
+	f.Kasan = bytes.Contains(kallsyms, []byte(" kasan_report"))
+	return f
+}
+
 func isSupported(kallsyms []byte, c *sys.Call) bool {
 	if c.NR == -1 {
 		return false // don't even have a syscall number
@@ -91,6 +116,36 @@ func isSupportedSyzkall(c *sys.Call) bool {
 	case "syz_fuseblk_mount":
 		_, err := os.Stat("/dev/fuse")
 		return err == nil && syscall.Getuid() == 0
+	case "syz_usb_connect", "syz_usb_control_io", "syz_usb_ep_write":
+		_, err := os.Stat("/dev/raw-gadget")
+		return err == nil && syscall.Getuid() == 0
+	case "syz_genetlink_get_family_id", "syz_socket_bind", "syz_memfd_data":
+		return true
+	case "syz_mount_tmpfs":
+		return syscall.Getuid() == 0
+	case "syz_kvm_setup_cpu":
+		if runtime.GOARCH != "amd64" && runtime.GOARCH != "386" {
+			return false
+		}
+		_, err := os.Stat("/dev/kvm")
+		return err == nil
+	case "syz_mount_image":
+		if _, err := os.Stat("/dev/loop-control"); err != nil || syscall.Getuid() != 0 {
+			return false
+		}
+		ptr, ok := c.Args[0].(sys.PtrType)
+		if !ok {
+			return true
+		}
+		fs, ok := ptr.Type.(sys.StrConstType)
+		if !ok {
+			return true
+		}
+		filesystems, _ := ioutil.ReadFile("/proc/filesystems")
+		if len(filesystems) == 0 {
+			return true
+		}
+		return bytes.Contains(filesystems, []byte("\t"+fs.Val[:len(fs.Val)-1]+"\n"))
 	default:
 		panic("unknown syzkall: " + c.Name)
 	}
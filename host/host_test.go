@@ -70,3 +70,14 @@ func TestSupportedSyscalls(t *testing.T) {
 		}
 	}
 }
+
+func TestDetectFeatures(t *testing.T) {
+	f := DetectFeatures()
+	t.Logf("features: %+v", f)
+	if f.Kasan && !f.Kallsyms {
+		t.Fatalf("kasan detected without kallsyms")
+	}
+	if f.Kcov && !f.Debugfs {
+		t.Fatalf("kcov detected without debugfs")
+	}
+}
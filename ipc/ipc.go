@@ -13,12 +13,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/google/syzkaller/fileutil"
 	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/seccomp"
 )
 
 type Env struct {
@@ -26,6 +28,7 @@ type Env struct {
 	Out []byte
 
 	cmd     *command
+	header  []byte
 	inFile  *os.File
 	outFile *os.File
 	bin     []string
@@ -44,14 +47,27 @@ const (
 	FlagDedupCover                           // deduplicate coverage in executor
 	FlagSandboxSetuid                        // impersonate nobody user
 	FlagSandboxNamespace                     // use namespaces for sandboxing
+	FlagSandboxAndroid                       // impersonate untrusted_app
+	FlagLeak                                 // scan for memory leaks with kmemleak between programs (see KmemleakScan)
+	FlagKill                                 // kill test process at a random point (set per program with SetKill)
+	FlagSeccomp                              // install seccomp filter from -seccomp profile in test threads
+	FlagObjects                              // count kernel objects after every program (see Env.Objects)
 )
 
 var (
 	flagThreaded = flag.Bool("threaded", true, "use threaded mode in executor")
 	flagCollide  = flag.Bool("collide", true, "collide syscalls to provoke data races")
 	flagCover    = flag.Bool("cover", true, "collect coverage")
-	flagSandbox  = flag.String("sandbox", "setuid", "sandbox for fuzzing (none/setuid/namespace)")
+	flagSandbox  = flag.String("sandbox", "setuid", "sandbox for fuzzing (none/setuid/namespace/android)")
 	flagDebug    = flag.Bool("debug", false, "debug output from executor")
+	flagLeak     = flag.Bool("leak", false, "detect memory leaks with kmemleak after every program (very slow)")
+	flagObjects  = flag.Bool("objects", false, "count kernel objects left behind by programs (see ObjectKinds)")
+	// Test processes are placed into memory/pids cgroups with these limits (0 means no limit),
+	// so that a runaway program is killed instead of OOMing the whole machine.
+	flagCgroupMem   = flag.Int("cgroup_mem", 0, "memory limit for test processes in MB")
+	flagCgroupPids  = flag.Int("cgroup_pids", 0, "max number of tasks for test processes")
+	flagMemPressure = flag.Int("mem_pressure", 0, "keep only that many MB of memory available to test processes (with a memory hog process)")
+	flagSeccomp     = flag.String("seccomp", "", "seccomp profile (docker format) that restricts syscalls of test processes")
 	// Executor protects against most hangs, so we use quite large timeout here.
 	// Executor can be slow due to global locks in namespaces and other things,
 	// so let's better wait than report false misleading crashes.
@@ -76,15 +92,48 @@ func DefaultFlags() (uint64, time.Duration, error) {
 		flags |= FlagSandboxSetuid
 	case "namespace":
 		flags |= FlagSandboxNamespace
+	case "android":
+		flags |= FlagSandboxAndroid
 	default:
-		return 0, 0, fmt.Errorf("flag sandbox must contain one of none/setuid/namespace")
+		return 0, 0, fmt.Errorf("flag sandbox must contain one of none/setuid/namespace/android")
 	}
 	if *flagDebug {
 		flags |= FlagDebug
 	}
+	if *flagLeak {
+		flags |= FlagLeak
+	}
+	if *flagObjects {
+		flags |= FlagObjects
+	}
+	if *flagSeccomp != "" {
+		if _, err := loadSeccompProfile(); err != nil {
+			return 0, 0, err
+		}
+		flags |= FlagSeccomp
+	}
 	return flags, *flagTimeout, nil
 }
 
+var (
+	seccompOnce   sync.Once
+	seccompFilter *seccomp.Filter
+	seccompErr    error
+)
+
+// loadSeccompProfile parses the profile given in -seccomp flag once for all envs.
+func loadSeccompProfile() (*seccomp.Filter, error) {
+	seccompOnce.Do(func() {
+		data, err := ioutil.ReadFile(*flagSeccomp)
+		if err != nil {
+			seccompErr = fmt.Errorf("failed to read seccomp profile: %v", err)
+			return
+		}
+		seccompFilter, seccompErr = seccomp.Parse(data)
+	})
+	return seccompFilter, seccompErr
+}
+
 func MakeEnv(bin string, timeout time.Duration, flags uint64) (*Env, error) {
 	// IPC timeout must be larger then executor timeout.
 	// Otherwise IPC will kill parent executor but leave child executor alive.
@@ -109,12 +158,27 @@ func MakeEnv(bin string, timeout time.Duration, flags uint64) (*Env, error) {
 			closeMapping(outf, outmem)
 		}
 	}()
-	for i := 0; i < 8; i++ {
-		inmem[i] = byte(flags >> (8 * uint(i)))
+	// Executor header: flags, cgroup memory limit in bytes, cgroup pids limit, memory pressure in bytes,
+	// followed by the seccomp filter with FlagSeccomp and prog.FilePool.
+	header := []uint64{flags, uint64(*flagCgroupMem) << 20, uint64(*flagCgroupPids), uint64(*flagMemPressure) << 20}
+	if flags&FlagSeccomp != 0 {
+		filter, err := loadSeccompProfile()
+		if err != nil {
+			return nil, err
+		}
+		// Seccomp filter: default action, number of rules and pairs of syscall number and action.
+		header = append(header, uint64(filter.Default), uint64(len(filter.Rules)))
+		for _, r := range filter.Rules {
+			header = append(header, uint64(r.NR), uint64(r.Action))
+		}
+	}
+	header = appendFilePool(header, prog.FilePoolDir, prog.FilePool)
+	for i, v := range header {
+		binary.LittleEndian.PutUint64(inmem[i*8:], v)
 	}
-	inmem = inmem[8:]
 	env := &Env{
-		In:      inmem,
+		In:      inmem[len(header)*8:],
+		header:  inmem[:len(header)*8],
 		Out:     outmem,
 		inFile:  inf,
 		outFile: outf,
@@ -134,6 +198,28 @@ func MakeEnv(bin string, timeout time.Duration, flags uint64) (*Env, error) {
 	return env, nil
 }
 
+// SetCollide enables or disables collide mode for subsequent executions.
+// Has effect only if the env was created with FlagThreaded and FlagCollide.
+func (env *Env) SetCollide(collide bool) {
+	env.setHeaderFlag(FlagCollide, collide && env.flags&FlagCollide != 0)
+}
+
+// SetKill enables or disables killing of the test process with SIGKILL/SIGSEGV
+// at a random point during subsequent executions. Killed programs have partial output.
+func (env *Env) SetKill(kill bool) {
+	env.setHeaderFlag(FlagKill, kill)
+}
+
+func (env *Env) setHeaderFlag(flag uint64, on bool) {
+	flags := binary.LittleEndian.Uint64(env.header)
+	if on {
+		flags |= flag
+	} else {
+		flags &^= flag
+	}
+	binary.LittleEndian.PutUint64(env.header, flags)
+}
+
 func (env *Env) Close() error {
 	if env.cmd != nil {
 		env.cmd.close()
@@ -165,11 +251,14 @@ func (env *Env) Exec(p *prog.Prog) (output []byte, cov [][]uint32, errnos []int,
 		}
 		copy(env.In, progData)
 	}
-	if env.flags&FlagCover != 0 {
-		// Zero out the first word (ncmd), so that we don't have garbage there
-		// if executor crashes before writing non-garbage there.
-		for i := 0; i < 4; i++ {
-			env.Out[i] = 0
+	// Zero out the first word (ncmd), so that we don't have garbage there
+	// if executor crashes before writing non-garbage there.
+	for i := 0; i < 4; i++ {
+		env.Out[i] = 0
+	}
+	if env.flags&FlagObjects != 0 {
+		for i := range ObjectKinds {
+			binary.LittleEndian.PutUint64(env.objects()[i*8:], ObjectUnknown)
 		}
 	}
 
@@ -189,17 +278,19 @@ func (env *Env) Exec(p *prog.Prog) (output []byte, cov [][]uint32, errnos []int,
 		return
 	}
 
-	if env.flags&FlagCover == 0 || p == nil {
+	if p == nil {
 		return
 	}
-	// Read out coverage information.
+	// Read out coverage information and errnos (executor writes errnos even if coverage is disabled).
 	r := bytes.NewReader(env.Out)
 	var ncmd uint32
 	if err := binary.Read(r, binary.LittleEndian, &ncmd); err != nil {
 		err0 = fmt.Errorf("failed to read output coverage: %v", err)
 		return
 	}
-	cov = make([][]uint32, len(p.Calls))
+	if env.flags&FlagCover != 0 {
+		cov = make([][]uint32, len(p.Calls))
+	}
 	errnos = make([]int, len(p.Calls))
 	for i := range errnos {
 		errnos[i] = -1 // not executed
@@ -222,11 +313,11 @@ func (env *Env) Exec(p *prog.Prog) (output []byte, cov [][]uint32, errnos []int,
 			err0 = fmt.Errorf("failed to read output coverage: %v", err)
 			return
 		}
-		if int(callIndex) > len(cov) {
+		if int(callIndex) >= len(errnos) {
 			err0 = fmt.Errorf("failed to read output coverage: expect index %v, got %v", i, callIndex)
 			return
 		}
-		if cov[callIndex] != nil {
+		if errnos[callIndex] != -1 {
 			err0 = fmt.Errorf("failed to read output coverage: double coverage for call %v", callIndex)
 			return
 		}
@@ -243,7 +334,9 @@ func (env *Env) Exec(p *prog.Prog) (output []byte, cov [][]uint32, errnos []int,
 			}
 			cov1[j] = pc
 		}
-		cov[callIndex] = cov1
+		if cov != nil {
+			cov[callIndex] = cov1
+		}
 		errnos[callIndex] = int(errno)
 	}
 	return
@@ -318,7 +411,7 @@ func makeCommand(bin []string, timeout time.Duration, flags uint64, inFile *os.F
 		}
 	}()
 
-	if flags&(FlagSandboxSetuid|FlagSandboxNamespace) != 0 {
+	if flags&(FlagSandboxSetuid|FlagSandboxNamespace|FlagSandboxAndroid) != 0 {
 		if err := os.Chmod(dir, 0777); err != nil {
 			return nil, fmt.Errorf("failed to chmod temp dir: %v", err)
 		}
@@ -489,3 +582,27 @@ func (c *command) exec() (output []byte, failed, hanged, restart bool, err0 erro
 	}
 	return
 }
+
+// appendFilePool encodes file pool for the executor: pool dir, number of nodes and for every node
+// its kind, major and minor numbers, name and target. Strings are encoded as length (including
+// the terminating zero byte) followed by the zero-terminated string padded to 8 bytes.
+func appendFilePool(header []uint64, dir string, pool []prog.FileNode) []uint64 {
+	header = appendString(header, dir)
+	header = append(header, uint64(len(pool)))
+	for _, f := range pool {
+		header = append(header, uint64(f.Kind), uint64(f.Major), uint64(f.Minor))
+		header = appendString(header, f.Name)
+		header = appendString(header, f.Target)
+	}
+	return header
+}
+
+func appendString(header []uint64, s string) []uint64 {
+	data := make([]byte, (len(s)+8)/8*8)
+	copy(data, s)
+	header = append(header, uint64(len(s)+1))
+	for i := 0; i < len(data); i += 8 {
+		header = append(header, binary.LittleEndian.Uint64(data[i:]))
+	}
+	return header
+}
@@ -6,6 +6,7 @@ package ipc
 import (
 	"math/rand"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -98,3 +99,89 @@ func TestExecute(t *testing.T) {
 		}
 	}
 }
+
+func TestSeccompExecute(t *testing.T) {
+	bin := buildExecutor(t)
+	defer os.Remove(bin)
+
+	profile, err := fileutil.WriteTempFile([]byte(`{"defaultAction": "SCMP_ACT_ERRNO",
+		"syscalls": [{"names": ["getpid"], "action": "SCMP_ACT_ALLOW"},
+			{"names": ["sched_yield"], "action": "SCMP_ACT_ERRNO", "errnoRet": 38}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(profile)
+	*flagSeccomp = profile
+	defer func() { *flagSeccomp = "" }()
+	p, err := prog.Deserialize([]byte("getpid()\nsched_yield()\ngeteuid()\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, flag := range []uint64{FlagSeccomp, FlagSeccomp | FlagThreaded} {
+		env, err := MakeEnv(bin, timeout, flag)
+		if err != nil {
+			t.Fatalf("failed to create env: %v", err)
+		}
+		defer env.Close()
+		output, _, errnos, _, _, err := env.Exec(p)
+		if err != nil {
+			t.Fatalf("failed to run executor: %v\n%s", err, output)
+		}
+		if want := []int{0, 38, 1}; !reflect.DeepEqual(errnos, want) {
+			t.Fatalf("flags 0x%x: got errnos %v, want %v", flag, errnos, want)
+		}
+	}
+}
+
+func TestFilePool(t *testing.T) {
+	bin := buildExecutor(t)
+	defer os.Remove(bin)
+
+	env, err := MakeEnv(bin, timeout, 0)
+	if err != nil {
+		t.Fatalf("failed to create env: %v", err)
+	}
+	defer env.Close()
+	// open of ./files/link0, ./files/link2 (dangling symlink) and ./files/dir0/file0.
+	p, err := prog.Deserialize([]byte(
+		"mmap(&(0x7f0000000000)=nil, (0x1000), 0x3, 0x32, 0xffffffffffffffff, 0x0)\n" +
+			"open(&(0x7f0000000000)=\"2e2f66696c65732f6c696e6b3000\", 0x0, 0x0)\n" +
+			"open(&(0x7f0000000000)=\"2e2f66696c65732f6c696e6b3200\", 0x0, 0x0)\n" +
+			"open(&(0x7f0000000000)=\"2e2f66696c65732f646972302f66696c653000\", 0x0, 0x0)\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The pool is created once, so check that every test sees it.
+	for i := 0; i < 3; i++ {
+		output, _, errnos, _, _, err := env.Exec(p)
+		if err != nil {
+			t.Fatalf("failed to run executor: %v\n%s", err, output)
+		}
+		if want := []int{0, 0, 2, 0}; !reflect.DeepEqual(errnos, want) {
+			t.Fatalf("run #%v: got errnos %v, want %v", i, errnos, want)
+		}
+	}
+}
+
+func TestFormatLeaks(t *testing.T) {
+	tests := []struct {
+		leaks string
+		want  string
+	}{
+		{"", ""},
+		{
+			"unreferenced object 0xffff88003a1b2c40 (size 32):\n  comm \"syz-executor\", pid 123\n",
+			"BUG: memory leak\nunreferenced object 0xffff88003a1b2c40 (size 32):\n  comm \"syz-executor\", pid 123\n",
+		},
+		{
+			"unreferenced object 0xffff1 (size 32):\n  backtrace:\nunreferenced object 0xffff2 (size 64):\n  backtrace:\n",
+			"BUG: memory leak\nunreferenced object 0xffff1 (size 32):\n  backtrace:\n" +
+				"BUG: memory leak\nunreferenced object 0xffff2 (size 64):\n  backtrace:\n",
+		},
+	}
+	for i, test := range tests {
+		if got := string(formatLeaks([]byte(test.leaks))); got != test.want {
+			t.Errorf("test #%v: got:\n%v\nwant:\n%v", i, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package ipc
+
+import (
+	"bytes"
+	"fmt"
+	"syscall"
+	"time"
+)
+
+const kmemleakFile = "/sys/kernel/debug/kmemleak"
+
+// KmemleakInit turns off periodic kmemleak scanning if enable is set (leaks are scanned for
+// with KmemleakScan between programs instead), or turns kmemleak off completely otherwise.
+func KmemleakInit(enable bool) error {
+	fd, err := syscall.Open(kmemleakFile, syscall.O_RDWR, 0)
+	if err != nil {
+		if !enable {
+			return nil
+		}
+		return fmt.Errorf("%v is missing (%v). Enable CONFIG_KMEMLEAK and mount debugfs", kmemleakFile, err)
+	}
+	defer syscall.Close(fd)
+	what := "scan=off"
+	if !enable {
+		what = "off"
+	}
+	if _, err := syscall.Write(fd, []byte(what)); err != nil {
+		// kmemleak returns EBUSY when kmemleak is already turned off.
+		if err != syscall.EBUSY {
+			return fmt.Errorf("failed to write %v to kmemleak: %v", what, err)
+		}
+	}
+	if enable {
+		// Drop leaks accumulated during boot, they are not caused by our programs.
+		for _, cmd := range []string{"scan", "scan", "clear"} {
+			if _, err := syscall.Write(fd, []byte(cmd)); err != nil {
+				return fmt.Errorf("failed to write %v to kmemleak: %v", cmd, err)
+			}
+		}
+	}
+	return nil
+}
+
+// KmemleakScan scans for leaks and returns reports of the leaked objects (see formatLeaks).
+// It must be called when no programs are running (e.g. from the Gate callback),
+// then the leaks are caused by the programs executed since the previous scan.
+func KmemleakScan() ([]byte, error) {
+	fd, err := syscall.Open(kmemleakFile, syscall.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+	// Kmemleak has false positives. To mitigate most of them, it checksums
+	// potentially leaked objects, and reports them only on the next scan
+	// iff the checksum does not change. Because of that we do the following
+	// intricate dance:
+	// Scan, sleep, scan again. At this point we can get some leaks.
+	// If there are leaks, we sleep and scan again, this can remove
+	// false leaks. Then, read kmemleak again. If we get leaks now, then
+	// hopefully these are true positives during the previous testing cycle.
+	scan := func() ([]byte, error) {
+		if _, err := syscall.Write(fd, []byte("scan")); err != nil {
+			return nil, fmt.Errorf("failed to write scan to kmemleak: %v", err)
+		}
+		if _, err := syscall.Seek(fd, 0, 0); err != nil {
+			return nil, fmt.Errorf("failed to seek kmemleak: %v", err)
+		}
+		buf := make([]byte, 128<<10)
+		n, err := syscall.Read(fd, buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kmemleak: %v", err)
+		}
+		return buf[:n], nil
+	}
+	if _, err := scan(); err != nil {
+		return nil, err
+	}
+	time.Sleep(time.Second)
+	leaks, err := scan()
+	if err != nil {
+		return nil, err
+	}
+	if len(leaks) != 0 {
+		time.Sleep(time.Second)
+		if leaks, err = scan(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := syscall.Write(fd, []byte("clear")); err != nil {
+		return nil, fmt.Errorf("failed to write clear to kmemleak: %v", err)
+	}
+	return formatLeaks(leaks), nil
+}
+
+// formatLeaks reports every leaked object separately as "BUG: memory leak",
+// so that manager can extract a meaningful title for each of them.
+func formatLeaks(leaks []byte) []byte {
+	const object = "unreferenced object"
+	buf := new(bytes.Buffer)
+	for len(leaks) != 0 {
+		next := bytes.Index(leaks[1:], []byte(object))
+		if next == -1 {
+			next = len(leaks)
+		} else {
+			next++
+		}
+		fmt.Fprintf(buf, "BUG: memory leak\n%s\n", bytes.TrimRight(leaks[:next], "\n"))
+		leaks = leaks[next:]
+	}
+	return buf.Bytes()
+}
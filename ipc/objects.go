@@ -0,0 +1,35 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package ipc
+
+import (
+	"encoding/binary"
+)
+
+// With FlagObjects the executor counts kernel objects that programs can leave behind (ObjectKinds)
+// after every test process exits and stores the counts at the end of the output region.
+// The counts are taken in the sandbox after the test process and its children are dead: sockets
+// in the sandbox network namespace, SysV IPC objects in the sandbox IPC namespace, open files system-wide
+// (so they include files of concurrently running programs).
+var ObjectKinds = []string{"files", "sockets", "shm", "msg", "sem"}
+
+// ObjectUnknown is the count of objects that the executor failed to count.
+const ObjectUnknown = ^uint64(0)
+
+func (env *Env) objects() []byte {
+	return env.Out[len(env.Out)-len(ObjectKinds)*8:]
+}
+
+// Objects returns object counts in ObjectKinds order after the last execution
+// (ObjectUnknown if the executor failed to count them), or nil if env is created without FlagObjects.
+func (env *Env) Objects() []uint64 {
+	if env.flags&FlagObjects == 0 {
+		return nil
+	}
+	counts := make([]uint64, len(ObjectKinds))
+	for i := range counts {
+		counts[i] = binary.LittleEndian.Uint64(env.objects()[i*8:])
+	}
+	return counts
+}
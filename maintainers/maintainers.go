@@ -0,0 +1,207 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package maintainers finds maintainers and mailing lists responsible for kernel source files
+// using the kernel MAINTAINERS file, like scripts/get_maintainer.pl -f does
+// (F:/X: patterns only, without git history and K:/N: matching).
+package maintainers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Entry is a MAINTAINERS section.
+type Entry struct {
+	Name        string   // section title, e.g. "NETWORKING DRIVERS"
+	Status      string   // S:
+	Maintainers []string // M:, "Name <email>"
+	Reviewers   []string // R:
+	Lists       []string // L:
+	Files       []string // F: patterns
+	Excludes    []string // X: patterns
+}
+
+type Maintainers struct {
+	Entries []*Entry
+
+	compileOnce sync.Once
+	patterns    map[string]*regexp.Regexp
+}
+
+// Load parses MAINTAINERS in kernel source dir srcDir. F: patterns that name directories in srcDir
+// without a trailing slash are treated as directories, as get_maintainer.pl does.
+func Load(srcDir string) (*Maintainers, error) {
+	data, err := ioutil.ReadFile(filepath.Join(srcDir, "MAINTAINERS"))
+	if err != nil {
+		return nil, err
+	}
+	m, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range m.Entries {
+		for _, patterns := range [][]string{e.Files, e.Excludes} {
+			for i, p := range patterns {
+				if strings.HasSuffix(p, "/") || strings.ContainsAny(p, "*?") {
+					continue
+				}
+				if st, err := os.Stat(filepath.Join(srcDir, p)); err == nil && st.IsDir() {
+					patterns[i] = p + "/"
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// Parse parses MAINTAINERS file contents. Text before the first section
+// (the descriptive header of the file) is skipped.
+func Parse(data []byte) (*Maintainers, error) {
+	m := new(Maintainers)
+	var e *Entry
+	title := ""
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		ln := strings.TrimRight(s.Text(), " \t")
+		if ln == "" {
+			e, title = nil, ""
+			continue
+		}
+		if len(ln) < 3 || ln[1] != ':' || ln[0] < 'A' || ln[0] > 'Z' {
+			// A section title is the line before the first tag line.
+			title = ln
+			e = nil
+			continue
+		}
+		if e == nil {
+			if title == "" {
+				continue // tag description in the header
+			}
+			e = &Entry{Name: title}
+			m.Entries = append(m.Entries, e)
+		}
+		value := strings.TrimSpace(ln[2:])
+		switch ln[0] {
+		case 'S':
+			e.Status = value
+		case 'M':
+			e.Maintainers = append(e.Maintainers, value)
+		case 'R':
+			e.Reviewers = append(e.Reviewers, value)
+		case 'L':
+			e.Lists = append(e.Lists, value)
+		case 'F':
+			e.Files = append(e.Files, value)
+		case 'X':
+			e.Excludes = append(e.Excludes, value)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse MAINTAINERS: %v", err)
+	}
+	return m, nil
+}
+
+// Match returns entries responsible for file (relative to the kernel source dir),
+// the most specific ones (with the deepest matching pattern) first.
+func (m *Maintainers) Match(file string) []*Entry {
+	m.compileOnce.Do(m.compile)
+	type match struct {
+		e     *Entry
+		depth int
+	}
+	var matches []match
+	for _, e := range m.Entries {
+		depth := -1
+		for _, p := range e.Files {
+			if m.patterns[p].MatchString(file) && strings.Count(p, "/") > depth {
+				depth = strings.Count(p, "/")
+			}
+		}
+		if depth == -1 {
+			continue
+		}
+		excluded := false
+		for _, p := range e.Excludes {
+			excluded = excluded || m.patterns[p].MatchString(file)
+		}
+		if !excluded {
+			matches = append(matches, match{e, depth})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].depth > matches[j].depth
+	})
+	var res []*Entry
+	for _, m := range matches {
+		res = append(res, m.e)
+	}
+	return res
+}
+
+// Emails returns maintainers, reviewers and mailing lists for file without duplicates,
+// the most specific entries first.
+func (m *Maintainers) Emails(file string) []string {
+	var res []string
+	dup := make(map[string]bool)
+	for _, e := range m.Match(file) {
+		var addrs []string
+		addrs = append(addrs, e.Maintainers...)
+		addrs = append(addrs, e.Reviewers...)
+		for _, list := range e.Lists {
+			// Lists can have a note after the address: "linux-mm@kvack.org (moderated for non-subscribers)".
+			if f := strings.Fields(list); len(f) != 0 {
+				addrs = append(addrs, f[0])
+			}
+		}
+		for _, addr := range addrs {
+			if !dup[addr] {
+				dup[addr] = true
+				res = append(res, addr)
+			}
+		}
+	}
+	return res
+}
+
+// compile compiles F:/X: patterns: "dir/" matches all files under dir, other patterns are globs
+// that match files at the same depth ("*" does not match "/").
+func (m *Maintainers) compile() {
+	m.patterns = make(map[string]*regexp.Regexp)
+	for _, e := range m.Entries {
+		for _, p := range append(append([]string{}, e.Files...), e.Excludes...) {
+			if m.patterns[p] != nil {
+				continue
+			}
+			expr := "^" + globToRegexp(p)
+			if !strings.HasSuffix(p, "/") {
+				expr += "$"
+			}
+			m.patterns[p] = regexp.MustCompile(expr)
+		}
+	}
+}
+
+func globToRegexp(pattern string) string {
+	buf := new(bytes.Buffer)
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			buf.WriteString("[^/]*")
+		case '?':
+			buf.WriteString("[^/]")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,110 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package maintainers
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testMaintainers = `List of maintainers and how to submit kernel changes
+
+Descriptions of section entries:
+
+	M: *Mail* patches to: FullName <address@domain>
+	F: *Files* and directories wildcard patterns.
+
+Maintainers List
+----------------
+
+NETWORKING [GENERAL]
+M:	David S. Miller <davem@davemloft.net>
+L:	netdev@vger.kernel.org
+S:	Maintained
+F:	net/
+F:	include/net/
+X:	net/ipv4/
+X:	net/ipv6/
+
+NETWORKING [IPv4/IPv6]
+M:	David S. Miller <davem@davemloft.net>
+M:	Alexey Kuznetsov <kuznet@ms2.inr.ac.ru>
+L:	netdev@vger.kernel.org
+S:	Maintained
+F:	net/ipv4/
+F:	net/ipv6/
+
+TUN/TAP driver
+M:	Maxim Krasnyansky <maxk@qti.qualcomm.com>
+S:	Maintained
+F:	drivers/net/tun.c
+
+MEMORY MANAGEMENT
+L:	linux-mm@kvack.org (moderated for non-subscribers)
+S:	Maintained
+F:	include/linux/mm.h
+F:	mm/
+
+SLAB ALLOCATOR
+R:	Christoph Lameter <cl@linux.com>
+L:	linux-mm@kvack.org
+S:	Maintained
+F:	mm/sl?b*
+
+THE REST
+M:	Linus Torvalds <torvalds@linux-foundation.org>
+L:	linux-kernel@vger.kernel.org
+S:	Buried alive in reporters
+F:	*
+F:	*/
+`
+
+func TestParse(t *testing.T) {
+	m, err := Parse([]byte(testMaintainers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Entries) != 6 {
+		t.Fatalf("parsed %v entries, want 6", len(m.Entries))
+	}
+	want := &Entry{
+		Name:        "NETWORKING [GENERAL]",
+		Status:      "Maintained",
+		Maintainers: []string{"David S. Miller <davem@davemloft.net>"},
+		Lists:       []string{"netdev@vger.kernel.org"},
+		Files:       []string{"net/", "include/net/"},
+		Excludes:    []string{"net/ipv4/", "net/ipv6/"},
+	}
+	if !reflect.DeepEqual(m.Entries[0], want) {
+		t.Fatalf("got entry:\n%+v\nwant:\n%+v", m.Entries[0], want)
+	}
+}
+
+func TestEmails(t *testing.T) {
+	m, err := Parse([]byte(testMaintainers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest := []string{"Linus Torvalds <torvalds@linux-foundation.org>", "linux-kernel@vger.kernel.org"}
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{"net/core/dev.c", []string{"David S. Miller <davem@davemloft.net>", "netdev@vger.kernel.org"}},
+		{"net/ipv4/tcp.c", []string{"David S. Miller <davem@davemloft.net>", "Alexey Kuznetsov <kuznet@ms2.inr.ac.ru>",
+			"netdev@vger.kernel.org"}},
+		{"drivers/net/tun.c", []string{"Maxim Krasnyansky <maxk@qti.qualcomm.com>"}},
+		{"mm/slub.c", []string{"linux-mm@kvack.org", "Christoph Lameter <cl@linux.com>"}},
+		{"mm/kasan/report.c", []string{"linux-mm@kvack.org"}},
+		{"kernel/fork.c", nil},
+		{"Makefile", nil},
+	}
+	for _, test := range tests {
+		got := m.Emails(test.file)
+		want := append(test.want, rest...)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: got %q, want %q", test.file, got, want)
+		}
+	}
+}
@@ -115,6 +115,20 @@ func (s *state) addressable(addr, size *Arg, ok bool) {
 	}
 }
 
+// mapped returns true if size bytes pointed to by addr are in mapped pages.
+func (s *state) mapped(addr *Arg, size uintptr) bool {
+	if size == 0 {
+		return true
+	}
+	start := physicalAddr(addr) - dataOffset
+	for page := start / pageSize; page <= (start+size-1)/pageSize; page++ {
+		if page >= maxPages || !s.pages[page] {
+			return false
+		}
+	}
+	return true
+}
+
 func foreachSubargImpl(arg *Arg, parent *[]*Arg, f func(arg, base *Arg, parent *[]*Arg)) {
 	var rec func(arg, base *Arg, parent *[]*Arg)
 	rec = func(arg, base *Arg, parent *[]*Arg) {
@@ -221,6 +235,42 @@ func assignTypeAndDir(c *Call) error {
 	return nil
 }
 
+// assignSizesCall updates len[parent] fields of all structs in the call according to
+// current struct sizes, and byte sizes of pointed-to objects. Mutation of a deeply nested field
+// (e.g. a netlink attribute inside of a nested attribute) changes sizes of all enclosing objects.
+func assignSizesCall(c *Call) {
+	update := func(args []*Arg, types []sys.Type, parentSize uintptr) {
+		for i, typ := range types {
+			l, ok := typ.(sys.LenType)
+			if !ok || args[i].Kind != ArgConst {
+				continue
+			}
+			if l.Buf == "parent" {
+				args[i].Val = parentSize
+				continue
+			}
+			if !l.ByteSize {
+				continue
+			}
+			for j, typ1 := range types {
+				if typ1.Name() == l.Buf && args[j].Kind == ArgPointer && args[j].Res != nil {
+					args[i].Val = args[j].Res.Size(args[j].Res.Type)
+				}
+			}
+		}
+	}
+	foreachArg(c, func(arg, _ *Arg, _ *[]*Arg) {
+		if str, ok := arg.Type.(sys.StructType); ok && arg.Kind == ArgGroup {
+			update(arg.Inner, str.Fields, arg.Size(str))
+		}
+	})
+	var size uintptr
+	for i, arg := range c.Args {
+		size += arg.Size(c.Meta.Args[i])
+	}
+	update(c.Args, c.Meta.Args, size)
+}
+
 func sanitizeCall(c *Call) {
 	switch c.Meta.CallName {
 	case "mmap":
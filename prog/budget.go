@@ -0,0 +1,51 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"fmt"
+)
+
+// Budget limits complexity of generated and mutated programs.
+// Without limits mutations keep growing programs (new calls, mmaps for larger arguments,
+// longer buffers), such programs are slow to execute and minimize, but don't give more coverage.
+type Budget struct {
+	MaxCalls int // hard limit on number of calls (including implicitly added mmaps and resource constructors)
+	PtrDepth int // max nesting of pointers, deeper optional pointers are NULL
+	BufLen   int // max length of random data buffers
+}
+
+var DefaultBudget = Budget{
+	MaxCalls: 60,
+	PtrDepth: 4,
+	BufLen:   4 << 10,
+}
+
+func (b *Budget) Validate() error {
+	if b.MaxCalls <= 0 {
+		return fmt.Errorf("bad max calls %v, want > 0", b.MaxCalls)
+	}
+	if b.PtrDepth <= 0 {
+		return fmt.Errorf("bad pointer depth %v, want > 0", b.PtrDepth)
+	}
+	if b.BufLen <= 0 {
+		return fmt.Errorf("bad buffer length %v, want > 0", b.BufLen)
+	}
+	return nil
+}
+
+// SetBudget sets limits for programs generated and mutated with ct (DefaultBudget by default).
+func (ct *ChoiceTable) SetBudget(b Budget) {
+	if err := b.Validate(); err != nil {
+		panic(err)
+	}
+	ct.budget = b
+}
+
+func (ct *ChoiceTable) getBudget() *Budget {
+	if ct == nil {
+		return &DefaultBudget
+	}
+	return &ct.budget
+}
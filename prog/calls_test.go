@@ -0,0 +1,131 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/syzkaller/sys"
+)
+
+// TestCalls runs every described syscall through generation, serialization, mutation
+// and minimization and checks program invariants, so that a broken description
+// is caught here rather than by the fuzzer.
+func TestCalls(t *testing.T) {
+	rs, _ := initTest(t)
+	iters := 10
+	if testing.Short() {
+		iters = 1
+	}
+	for _, meta := range sys.Calls {
+		for i := 0; i < iters; i++ {
+			p := generateCallProg(rs, meta)
+			if err := checkCallProg(p); err != nil {
+				t.Fatalf("%v: bad generated program: %v\n%s", meta.Name, err, p.Serialize())
+			}
+			data := p.Serialize()
+			p1, err := Deserialize(data)
+			if err != nil {
+				t.Fatalf("%v: failed to deserialize program: %v\n%s", meta.Name, err, data)
+			}
+			if data1 := p1.Serialize(); !bytes.Equal(data, data1) {
+				t.Fatalf("%v: program changed after serialize/deserialize\noriginal:\n%s\n\nnew:\n%s\n",
+					meta.Name, data, data1)
+			}
+			p.SerializeForExec()
+			p1.Mutate(rs, 10, nil)
+			if err := checkCallProg(p1); err != nil {
+				t.Fatalf("%v: bad mutated program: %v\noriginal:\n%s\n\nmutated:\n%s\n",
+					meta.Name, err, data, p1.Serialize())
+			}
+			if data1 := p.Serialize(); !bytes.Equal(data, data1) {
+				t.Fatalf("%v: program changed after mutation of a copy\noriginal:\n%s\n\nnew:\n%s\n",
+					meta.Name, data, data1)
+			}
+			Minimize(p, len(p.Calls)-1, func(p2 *Prog, callIndex int) bool {
+				if err := checkCallProg(p2); err != nil {
+					t.Fatalf("%v: bad minimized program: %v\noriginal:\n%s\n\nminimized:\n%s\n",
+						meta.Name, err, data, p2.Serialize())
+				}
+				if p2.Calls[callIndex].Meta != meta {
+					t.Fatalf("%v: minimization lost the call, index %v points to %v",
+						meta.Name, callIndex, p2.Calls[callIndex].Meta.Name)
+				}
+				return false
+			})
+		}
+	}
+}
+
+// generateCallProg generates a program that ends with a call to meta
+// (preceded by calls that create its resources and mmaps for its arguments).
+func generateCallProg(rs rand.Source, meta *sys.Call) *Prog {
+	p := new(Prog)
+	r := newRand(rs, &DefaultBudget)
+	s := newState(nil)
+	for _, c := range r.generateParticularCall(s, meta) {
+		s.analyze(c)
+		p.Calls = append(p.Calls, c)
+	}
+	return p
+}
+
+func checkCallProg(p *Prog) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	for _, c := range p.Calls {
+		if err := checkSizes(c); err != nil {
+			return fmt.Errorf("call %v: %v", c.Meta.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkSizes checks that len[parent] and byte size fields match sizes of the referenced objects.
+func checkSizes(c *Call) error {
+	check := func(args []*Arg, types []sys.Type, parentSize uintptr) error {
+		for i, typ := range types {
+			l, ok := typ.(sys.LenType)
+			if !ok || args[i].Kind != ArgConst {
+				continue
+			}
+			if l.Buf == "parent" {
+				if args[i].Val != parentSize {
+					return fmt.Errorf("len %v: got %v, want parent size %v", l.Name(), args[i].Val, parentSize)
+				}
+				continue
+			}
+			if !l.ByteSize {
+				continue
+			}
+			for j, typ1 := range types {
+				if typ1.Name() != l.Buf || args[j].Kind != ArgPointer || args[j].Res == nil {
+					continue
+				}
+				if size := args[j].Res.Size(args[j].Res.Type); args[i].Val != size {
+					return fmt.Errorf("len %v: got %v, want size of %v %v", l.Name(), args[i].Val, l.Buf, size)
+				}
+			}
+		}
+		return nil
+	}
+	var err error
+	foreachArg(c, func(arg, _ *Arg, _ *[]*Arg) {
+		if str, ok := arg.Type.(sys.StructType); ok && arg.Kind == ArgGroup && err == nil {
+			err = check(arg.Inner, str.Fields, arg.Size(str))
+		}
+	})
+	if err != nil {
+		return err
+	}
+	var size uintptr
+	for i, arg := range c.Args {
+		size += arg.Size(c.Meta.Args[i])
+	}
+	return check(c.Args, c.Meta.Args, size)
+}
@@ -354,7 +354,7 @@ const (
 )
 
 func serializeAddr(a *Arg, base bool) string {
-	page := a.AddrPage * encodingPageSize
+	page := uint64(a.AddrPage * encodingPageSize)
 	if base {
 		page += encodingAddrBase
 	}
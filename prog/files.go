@@ -0,0 +1,73 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"strings"
+
+	"github.com/google/syzkaller/sys"
+)
+
+type FileKind int
+
+const (
+	FileRegular FileKind = iota
+	FileDir
+	FileSymlink
+	FileChar  // character device node, symlink to /dev/Target if mknod fails
+	FileBlock // block device node, symlink to /dev/Target if mknod fails
+)
+
+// FileNode is a file in the file pool that filename arguments can refer to.
+// Kind values are used by the executor as is, so they must not be reordered.
+type FileNode struct {
+	Name   string
+	Kind   FileKind
+	Target string // symlink target or /dev name of device node
+	Major  int
+	Minor  int
+}
+
+// FilePoolDir is the dir in the test working dir where FilePool files are created.
+const FilePoolDir = "./files"
+
+// FilePool is a pool of existing files, symlinks and device nodes that filename arguments draw from,
+// so that VFS and driver paths see existing, reusable names. Parents go before children.
+// The executor receives the pool in the input header and creates it once for all tests of the process,
+// C reproducers create it with setup_files generated by csource.
+var FilePool = []FileNode{
+	{Name: FilePoolDir + "/file0", Kind: FileRegular},
+	{Name: FilePoolDir + "/file1", Kind: FileRegular},
+	{Name: FilePoolDir + "/dir0", Kind: FileDir},
+	{Name: FilePoolDir + "/dir0/file0", Kind: FileRegular},
+	{Name: FilePoolDir + "/link0", Kind: FileSymlink, Target: "file0"},
+	{Name: FilePoolDir + "/link1", Kind: FileSymlink, Target: "dir0"},
+	{Name: FilePoolDir + "/link2", Kind: FileSymlink, Target: "nonexistent"},
+	{Name: FilePoolDir + "/link3", Kind: FileSymlink, Target: "link3"},
+	{Name: FilePoolDir + "/null", Kind: FileChar, Target: "null", Major: 1, Minor: 3},
+	{Name: FilePoolDir + "/zero", Kind: FileChar, Target: "zero", Major: 1, Minor: 5},
+	{Name: FilePoolDir + "/random", Kind: FileChar, Target: "random", Major: 1, Minor: 8},
+	{Name: FilePoolDir + "/tty", Kind: FileChar, Target: "tty", Major: 5, Minor: 0},
+	{Name: FilePoolDir + "/ptmx", Kind: FileChar, Target: "ptmx", Major: 5, Minor: 2},
+	{Name: FilePoolDir + "/fuse", Kind: FileChar, Target: "fuse", Major: 10, Minor: 229},
+	{Name: FilePoolDir + "/kvm", Kind: FileChar, Target: "kvm", Major: 10, Minor: 232},
+	{Name: FilePoolDir + "/loop0", Kind: FileBlock, Target: "loop0", Major: 7, Minor: 0},
+}
+
+// UsesFilePool says if any filename argument of the program refers to FilePool.
+func (p *Prog) UsesFilePool() bool {
+	for _, c := range p.Calls {
+		used := false
+		foreachArg(c, func(arg, _ *Arg, _ *[]*Arg) {
+			if _, ok := arg.Type.(sys.FilenameType); ok && arg.Kind == ArgData &&
+				strings.HasPrefix(string(arg.Data), FilePoolDir+"/") {
+				used = true
+			}
+		})
+		if used {
+			return true
+		}
+	}
+	return false
+}
@@ -8,20 +8,29 @@ import (
 )
 
 // Generate generates a random program of length ~ncalls.
-// calls is a set of allowed syscalls, if nil all syscalls are used.
+// ct is a set of allowed syscalls and program budget, if nil all syscalls
+// and DefaultBudget are used.
 func Generate(rs rand.Source, ncalls int, ct *ChoiceTable) *Prog {
 	p := new(Prog)
-	r := newRand(rs)
+	r := newRand(rs, ct.getBudget())
 	s := newState(ct)
-	for len(p.Calls) < ncalls {
-		calls := r.generateCall(s, p)
+	for n := r.progLen(ncalls); len(p.Calls) < n; {
+		calls := r.generateCall(s, p, len(p.Calls))
 		for _, c := range calls {
 			s.analyze(c)
 			p.Calls = append(p.Calls, c)
 		}
 	}
+	p.trimToBudget(r.budget)
 	if err := p.validate(); err != nil {
 		panic(err)
 	}
 	return p
 }
+
+// trimToBudget removes trailing calls that exceed the budget.
+func (p *Prog) trimToBudget(b *Budget) {
+	if len(p.Calls) > b.MaxCalls {
+		p.TrimAfter(b.MaxCalls - 1)
+	}
+}
@@ -10,13 +10,17 @@ import (
 	"github.com/google/syzkaller/sys"
 )
 
+// Mutate mutates p, inserting new calls while p is shorter than ncalls.
+// Mutations are chosen according to the weights of ct (DefaultMutationWeights if ct is nil),
+// the result is trimmed to the budget of ct (DefaultBudget if ct is nil).
 func (p *Prog) Mutate(rs rand.Source, ncalls int, ct *ChoiceTable) {
-	r := newRand(rs)
+	r := newRand(rs, ct.getBudget())
+	w := ct.getMutationWeights()
 	retry := false
 	for stop := false; !stop || retry; stop = r.bin() {
 		retry = false
 		r.choose(
-			20, func() {
+			w.InsertCall, func() {
 				// Insert a new call.
 				if len(p.Calls) >= ncalls {
 					retry = true
@@ -28,10 +32,10 @@ func (p *Prog) Mutate(rs rand.Source, ncalls int, ct *ChoiceTable) {
 					c = p.Calls[idx]
 				}
 				s := analyze(ct, p, c)
-				calls := r.generateCall(s, p)
+				calls := r.generateCall(s, p, idx)
 				p.insertBefore(c, calls)
 			},
-			10, func() {
+			w.MutateArg, func() {
 				// Change args of a call.
 				if len(p.Calls) == 0 {
 					retry = true
@@ -143,6 +147,11 @@ func (p *Prog) Mutate(rs rand.Source, ncalls int, ct *ChoiceTable) {
 						arg1, calls1 := r.addr(s, size, arg.Res)
 						p.replaceArg(arg, arg1, calls1)
 					case sys.StructType:
+						if arg.Kind == ArgData {
+							// Squashed struct, sizes are updated below.
+							arg.Data = mutateData(r, append([]byte{}, arg.Data...))
+							break
+						}
 						ctor := isSpecialStruct(a)
 						if ctor == nil {
 							panic("bad arg returned by mutationArgs: StructType")
@@ -171,7 +180,6 @@ func (p *Prog) Mutate(rs rand.Source, ncalls int, ct *ChoiceTable) {
 					}
 
 					// Update associated size argument if there is one.
-					// TODO: update parent size.
 					if size != nil {
 						name := arg.Type.Name()
 						if name == "" && base != nil {
@@ -206,9 +214,54 @@ func (p *Prog) Mutate(rs rand.Source, ncalls int, ct *ChoiceTable) {
 						arg.AddrPage = arg1.AddrPage
 						arg.AddrOffset = arg1.AddrOffset
 					}
+					// Update sizes of all enclosing structs.
+					assignSizesCall(c)
 				}
 			},
-			1, func() {
+			w.Squash, func() {
+				// Squash a struct into a blob (so that it is mutated bytewise) or restore its fields.
+				if len(p.Calls) == 0 {
+					retry = true
+					return
+				}
+				c := p.Calls[r.Intn(len(p.Calls))]
+				ptrs := squashablePtrs(c)
+				if len(ptrs) == 0 {
+					retry = true
+					return
+				}
+				ptr := ptrs[r.Intn(len(ptrs))]
+				size := ptr.Res.Size(ptr.Res.Type)
+				if ptr.Res.Kind == ArgData {
+					s := analyze(ct, p, c)
+					arg, _, calls := r.generateArg(s, ptr.Res.Type, ptr.Res.Dir, nil)
+					data := ptr.Res.Data
+					ptr.Res = arg
+					assignTypeAndDir(c)
+					unsquashArg(arg, data)
+					for _, c1 := range calls {
+						assignTypeAndDir(c1)
+						sanitizeCall(c1)
+					}
+					p.insertBefore(c, calls)
+					if size < arg.Size(arg.Type) {
+						ptr1, calls1 := r.addr(s, arg.Size(arg.Type), arg)
+						for _, c1 := range calls1 {
+							assignTypeAndDir(c1)
+							sanitizeCall(c1)
+						}
+						p.insertBefore(c, calls1)
+						ptr.AddrPage = ptr1.AddrPage
+						ptr.AddrOffset = ptr1.AddrOffset
+					}
+				} else {
+					ptr.Res = dataArg(squashArg(ptr.Res))
+				}
+				assignTypeAndDir(c)
+				sanitizeCall(c)
+				assignSizesCall(c)
+			},
+			w.RemoveCall, func() {
 				// Remove a random call.
 				if len(p.Calls) == 0 {
 					retry = true
@@ -219,6 +272,7 @@ func (p *Prog) Mutate(rs rand.Source, ncalls int, ct *ChoiceTable) {
 			},
 		)
 	}
+	p.trimToBudget(r.budget)
 	for _, c := range p.Calls {
 		assignTypeAndDir(c)
 		sanitizeCall(c)
@@ -228,6 +282,112 @@ func (p *Prog) Mutate(rs rand.Source, ncalls int, ct *ChoiceTable) {
 	}
 }
 
+// Splice inserts a random subsequence of calls of p0 into p at a random position (p0 is not changed).
+// Resources that the inserted calls use but don't create are rewired to compatible resources created
+// by preceding calls of p (or replaced with default values), pointers into memory that is not mapped
+// in p are reallocated. Calls of p are removed while p is longer than ncalls.
+func (p *Prog) Splice(rs rand.Source, p0 *Prog, ncalls int, ct *ChoiceTable) {
+	if len(p0.Calls) == 0 || ncalls <= 0 {
+		return
+	}
+	r := newRand(rs, ct.getBudget())
+	p1 := p0.Clone()
+	from := r.Intn(len(p1.Calls))
+	n := 1 + r.biasedRand(len(p1.Calls)-from, 5)
+	if n > ncalls {
+		n = ncalls
+	}
+	calls := p1.Calls[from : from+n]
+	inserted := make(map[*Call]bool)
+	for _, c := range calls {
+		inserted[c] = true
+	}
+	idx := r.Intn(len(p.Calls) + 1)
+	var c0 *Call
+	if idx < len(p.Calls) {
+		c0 = p.Calls[idx]
+	}
+	s := analyze(ct, p, c0)
+	for _, c := range calls {
+		foreachArgArray(&c.Args, c.Ret, func(arg, _ *Arg, _ *[]*Arg) {
+			// Drop references from calls of p0 that are not inserted.
+			for use := range arg.Uses {
+				if !inserted[use.Call] {
+					delete(arg.Uses, use)
+				}
+			}
+			if arg.Kind == ArgResult && !inserted[arg.Res.Call] {
+				r.rewireResult(s, arg)
+			}
+		})
+		foreachArg(c, func(arg, _ *Arg, _ *[]*Arg) {
+			if arg.Kind != ArgPointer || arg.Res == nil || s.mapped(arg, arg.Res.Size(arg.Res.Type)) {
+				return
+			}
+			arg1, calls1 := r.addr(s, arg.Res.Size(arg.Res.Type), arg.Res)
+			for _, c1 := range calls1 {
+				assignTypeAndDir(c1)
+				sanitizeCall(c1)
+				s.analyze(c1)
+			}
+			p.insertBefore(c0, calls1)
+			arg.AddrPage = arg1.AddrPage
+			arg.AddrOffset = arg1.AddrOffset
+		})
+		assignTypeAndDir(c)
+		sanitizeCall(c)
+		s.analyze(c)
+	}
+	p.insertBefore(c0, calls)
+	for len(p.Calls) > ncalls {
+		var idxs []int
+		for i, c := range p.Calls {
+			if !inserted[c] {
+				idxs = append(idxs, i)
+			}
+		}
+		if len(idxs) == 0 {
+			p.TrimAfter(ncalls - 1)
+			break
+		}
+		p.removeCall(idxs[r.Intn(len(idxs))])
+	}
+	p.trimToBudget(r.budget)
+	if err := p.validate(); err != nil {
+		panic(err)
+	}
+}
+
+// rewireResult makes result arg reference a compatible resource from s, or turns it into a default value.
+func (r *randGen) rewireResult(s *state, arg *Arg) {
+	delete(arg.Res.Uses, arg)
+	var allres []*Arg
+	if typ, ok := arg.Type.(sys.ResourceType); ok {
+		if ress := s.resources[typ.Kind]; ress != nil {
+			allres = append(allres, ress[typ.Subkind]...)
+			if typ.Subkind != sys.ResAny {
+				allres = append(allres, ress[sys.ResAny]...)
+			} else {
+				for sk, v := range ress {
+					if sk != sys.ResAny {
+						allres = append(allres, v...)
+					}
+				}
+			}
+		}
+	}
+	if len(allres) == 0 {
+		arg.Kind, arg.Res, arg.Val = ArgConst, nil, arg.Type.Default()
+		arg.OpDiv, arg.OpAdd = 0, 0
+		return
+	}
+	arg.Res = allres[r.Intn(len(allres))]
+	if arg.Res.Uses == nil {
+		arg.Res.Uses = make(map[*Arg]bool)
+	}
+	arg.Res.Uses[arg] = true
+}
+
 // Minimize minimizes program p into an equivalent program using the equivalence
 // predicate pred.  It iteratively generates simpler programs and asks pred
 // whether it is equal to the orginal program or not. If it is equivalent then
@@ -337,6 +497,10 @@ func mutationArgs(c *Call) (args, bases []*Arg, parents []*[]*Arg) {
 	foreachArg(c, func(arg, base *Arg, parent *[]*Arg) {
 		switch typ := arg.Type.(type) {
 		case sys.StructType:
+			if arg.Kind == ArgData {
+				// Squashed structs are mutated as blobs.
+				break
+			}
 			if isSpecialStruct(typ) == nil {
 				// For structs only individual fields are updated.
 				return
@@ -374,6 +538,9 @@ func mutateData(r *randGen, data []byte) []byte {
 	for stop := false; !stop; stop = r.bin() {
 		r.choose(
 			1, func() {
+				if len(data) >= r.budget.BufLen {
+					return
+				}
 				data = append(data, byte(r.rand(256)))
 			},
 			1, func() {
@@ -402,3 +569,98 @@ func mutateData(r *randGen, data []byte) []byte {
 	}
 	return data
 }
+
+// squashablePtrs returns pointers to structs that can be squashed into blobs (or are already squashed).
+// Structs that contain pointers or resources are not squashed, because their values can't be represented
+// as plain bytes.
+func squashablePtrs(c *Call) []*Arg {
+	var ptrs []*Arg
+	foreachArg(c, func(arg, _ *Arg, _ *[]*Arg) {
+		if arg.Kind != ArgPointer || arg.Res == nil || arg.Res.Dir == DirOut {
+			return
+		}
+		if _, ok := arg.Res.Type.(sys.StructType); !ok {
+			return
+		}
+		ok := true
+		foreachSubarg(arg.Res, func(arg1, _ *Arg, _ *[]*Arg) {
+			if _, res := arg1.Type.(sys.ResourceType); res || len(arg1.Uses) != 0 {
+				ok = false
+			}
+			if arg1.Kind == ArgPointer || arg1.Kind == ArgResult {
+				ok = false
+			}
+		})
+		if ok {
+			ptrs = append(ptrs, arg)
+		}
+	})
+	return ptrs
+}
+
+// squashArg returns memory contents of a struct arg the way the executor lays it out.
+// The squashed struct is represented by a data arg with the struct type, this allows the fuzzer
+// to break out of overly strict descriptions (e.g. set flags that are not described).
+func squashArg(arg *Arg) []byte {
+	var data []byte
+	var rec func(*Arg)
+	rec = func(arg *Arg) {
+		switch arg.Kind {
+		case ArgConst:
+			for i := uintptr(0); i < arg.Size(arg.Type); i++ {
+				data = append(data, byte(arg.Val>>(i*8)))
+			}
+		case ArgPageSize:
+			v := arg.AddrPage * pageSize
+			for i := uintptr(0); i < arg.Size(arg.Type); i++ {
+				data = append(data, byte(v>>(i*8)))
+			}
+		case ArgData:
+			data = append(data, arg.Data...)
+		case ArgGroup:
+			for _, arg1 := range arg.Inner {
+				rec(arg1)
+			}
+		case ArgUnion:
+			rec(arg.Option)
+		default:
+			panic(fmt.Sprintf("squashing arg of kind %v", arg.Kind))
+		}
+	}
+	rec(arg)
+	return data
+}
+
+// unsquashArg fills in fields of a freshly generated struct arg from squashed data where possible,
+// so that restoring a squashed struct preserves values of fields that were not changed.
+func unsquashArg(arg *Arg, data []byte) {
+	var off uintptr
+	foreachSubarg(arg, func(arg1, _ *Arg, _ *[]*Arg) {
+		if arg1.Kind == ArgGroup || arg1.Kind == ArgUnion {
+			return
+		}
+		size := arg1.Size(arg1.Type)
+		if off+size <= uintptr(len(data)) {
+			switch arg1.Kind {
+			case ArgConst:
+				switch arg1.Type.(type) {
+				case sys.ConstType, sys.LenType, sys.ResourceType:
+					// Keep values that are required by descriptions (sizes are updated later).
+				default:
+					if arg1.Dir == DirOut || sys.IsPad(arg1.Type) {
+						break
+					}
+					arg1.Val = 0
+					for i := uintptr(0); i < size; i++ {
+						arg1.Val |= uintptr(data[off+i]) << (i * 8)
+					}
+				}
+			case ArgData:
+				if arg1.Dir != DirOut {
+					copy(arg1.Data, data[off:off+size])
+				}
+			}
+		}
+		off += size
+	})
+}
@@ -46,6 +46,77 @@ next:
 	}
 }
 
+func TestSplice(t *testing.T) {
+	rs, iters := initTest(t)
+	for i := 0; i < iters; i++ {
+		p := Generate(rs, 10, nil)
+		p0 := Generate(rs, 10, nil)
+		data0 := p0.Serialize()
+		p.Splice(rs, p0, 15, nil)
+		if data := p0.Serialize(); !bytes.Equal(data0, data) {
+			t.Fatalf("program changed after splicing\noriginal:\n%s\n\nnew:\n%s\n", data0, data)
+		}
+		if len(p.Calls) > 15 {
+			t.Fatalf("spliced program has %v calls, want at most 15", len(p.Calls))
+		}
+		data := p.Serialize()
+		p1, err := Deserialize(data)
+		if err != nil {
+			t.Fatalf("failed to deserialize spliced program: %v\n%s", err, data)
+		}
+		if data1 := p1.Serialize(); !bytes.Equal(data, data1) {
+			t.Fatalf("spliced program changed after deserialization\noriginal:\n%s\n\nnew:\n%s\n", data, data1)
+		}
+		p.Mutate(rs, 15, nil)
+	}
+}
+
+func TestSquash(t *testing.T) {
+	rs, iters := initTest(t)
+	for i := 0; i < iters; i++ {
+		p := Generate(rs, 10, nil)
+		p1 := p.Clone()
+		data0 := p.Serialize()
+		squashed := 0
+		for ci, c := range p.Calls {
+			ptrs, ptrs1 := squashablePtrs(c), squashablePtrs(p1.Calls[ci])
+			for j, ptr := range ptrs {
+				data := squashArg(ptr.Res)
+				if size := ptr.Res.Size(ptr.Res.Type); uintptr(len(data)) != size {
+					t.Fatalf("squashed %v into %v bytes, want %v", ptr.Res.Type.Name(), len(data), size)
+				}
+				ptr.Res = dataArg(data)
+				// Restoring fields from unchanged data must not change memory contents
+				// (values that don't fit into fields are truncated).
+				unsquashArg(ptrs1[j].Res, data)
+				if data1 := squashArg(ptrs1[j].Res); !bytes.Equal(data, data1) {
+					t.Fatalf("struct %v changed after unsquash:\n%x\n%x", ptr.Res.Type.Name(), data, data1)
+				}
+				squashed++
+			}
+			assignTypeAndDir(c)
+		}
+		if err := p.validate(); err != nil {
+			t.Fatalf("squashed program is invalid: %v\n%s", err, data0)
+		}
+		data := p.Serialize()
+		p2, err := Deserialize(data)
+		if err != nil {
+			t.Fatalf("failed to deserialize squashed program: %v\n%s", err, data)
+		}
+		if data2 := p2.Serialize(); !bytes.Equal(data, data2) {
+			t.Fatalf("squashed program changed after deserialization\noriginal:\n%s\n\nnew:\n%s\n", data, data2)
+		}
+		p.SerializeForExec()
+		if squashed == 0 {
+			continue
+		}
+		for try := 0; try < 10; try++ {
+			p.Mutate(rs, 10, nil)
+		}
+	}
+}
+
 func TestMutateTable(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode")
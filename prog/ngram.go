@@ -0,0 +1,135 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/google/syzkaller/sys"
+)
+
+// Call-to-call priorities only capture pairs of calls. The n-gram model captures
+// sequences of 3 calls found in corpus programs (which all gave new coverage),
+// with the call that gave coverage weighted higher, and generation continues
+// a known sequence of 2 calls with a likely third call.
+// mmaps are ignored, they are inserted for arguments anyway.
+
+// NGram is a sequence of 3 calls (call IDs) and its weight in corpus.
+type NGram struct {
+	Calls  [3]int
+	Weight int
+}
+
+// ngramCoverWeight is weight of sequences that end with the call that gave new coverage.
+const ngramCoverWeight = 10
+
+// CalculateNGrams returns at most max sequences of 3 calls with the largest weights in corpus.
+// covered[i] is index of the call in corpus[i] that gave new coverage (-1 if unknown).
+func CalculateNGrams(corpus []*Prog, covered []int, max int) []NGram {
+	weights := make(map[[3]int]int)
+	for i, p := range corpus {
+		var seq []int
+		for j, c := range p.Calls {
+			if c.Meta.Name == "mmap" {
+				continue
+			}
+			seq = append(seq, c.Meta.ID)
+			if len(seq) < 3 {
+				continue
+			}
+			w := 1
+			if i < len(covered) && covered[i] == j {
+				w = ngramCoverWeight
+			}
+			weights[[3]int{seq[len(seq)-3], seq[len(seq)-2], seq[len(seq)-1]}] += w
+		}
+	}
+	ngrams := make(ngramArray, 0, len(weights))
+	for calls, w := range weights {
+		ngrams = append(ngrams, NGram{calls, w})
+	}
+	sort.Sort(ngrams)
+	if len(ngrams) > max {
+		ngrams = ngrams[:max]
+	}
+	return ngrams
+}
+
+type ngramArray []NGram
+
+func (a ngramArray) Len() int { return len(a) }
+func (a ngramArray) Less(i, j int) bool {
+	if a[i].Weight != a[j].Weight {
+		return a[i].Weight > a[j].Weight
+	}
+	for k := range a[i].Calls {
+		if a[i].Calls[k] != a[j].Calls[k] {
+			return a[i].Calls[k] < a[j].Calls[k]
+		}
+	}
+	return false
+}
+func (a ngramArray) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+// ngramChoice is a weighted choice of the call that follows 2 calls.
+type ngramChoice struct {
+	calls []int
+	run   []int
+}
+
+// SetNGrams makes generation continue known sequences of calls with ngrams (only enabled calls are used).
+func (ct *ChoiceTable) SetNGrams(ngrams []NGram) {
+	ct.ngrams = make(map[[2]int]*ngramChoice)
+	for _, ng := range ngrams {
+		if ng.Weight <= 0 {
+			continue
+		}
+		valid := true
+		for _, id := range ng.Calls {
+			if id < 0 || id >= len(sys.Calls) || !ct.enabled[sys.Calls[id]] {
+				valid = false
+			}
+		}
+		if !valid {
+			continue
+		}
+		key := [2]int{ng.Calls[0], ng.Calls[1]}
+		ch := ct.ngrams[key]
+		if ch == nil {
+			ch = new(ngramChoice)
+			ct.ngrams[key] = ch
+		}
+		sum := ng.Weight
+		if n := len(ch.run); n != 0 {
+			sum += ch.run[n-1]
+		}
+		ch.calls = append(ch.calls, ng.Calls[2])
+		ch.run = append(ch.run, sum)
+	}
+}
+
+// chooseNGram chooses the call that follows the last 2 calls (ignoring mmaps) before position pos in p.
+func (ct *ChoiceTable) chooseNGram(r *rand.Rand, p *Prog, pos int) (int, bool) {
+	if ct == nil || len(ct.ngrams) == 0 {
+		return 0, false
+	}
+	var key [2]int
+	n := 0
+	for i := pos - 1; i >= 0 && n < 2; i-- {
+		if c := p.Calls[i].Meta; c.Name != "mmap" {
+			key[1-n] = c.ID
+			n++
+		}
+	}
+	if n < 2 {
+		return 0, false
+	}
+	ch := ct.ngrams[key]
+	if ch == nil {
+		return 0, false
+	}
+	x := r.Intn(ch.run[len(ch.run)-1])
+	return ch.calls[sort.SearchInts(ch.run, x+1)], true
+}
@@ -37,6 +37,23 @@ func CalculatePriorities(corpus []*Prog) [][]float32 {
 	return dynamic
 }
 
+// ApplyCallWeights returns priorities with the priority of choosing every call scaled by its weight
+// (weights are indexed by call ID). Weights let fuzzers adapt to coverage yield of calls
+// without recalculating priorities.
+func ApplyCallWeights(prios [][]float32, weights []float32) [][]float32 {
+	if weights == nil {
+		return prios
+	}
+	res := make([][]float32, len(prios))
+	for i, prio := range prios {
+		res[i] = make([]float32, len(prio))
+		for j, p := range prio {
+			res[i][j] = p * weights[j]
+		}
+	}
+	return res
+}
+
 func calcStaticPriorities() [][]float32 {
 	uses := make(map[string]map[int]float32)
 	for _, c := range sys.Calls {
@@ -243,6 +260,9 @@ type ChoiceTable struct {
 	run          [][]int
 	enabledCalls []*sys.Call
 	enabled      map[*sys.Call]bool
+	budget       Budget
+	weights      MutationWeights
+	ngrams       map[[2]int]*ngramChoice
 }
 
 func BuildChoiceTable(prios [][]float32, enabled map[*sys.Call]bool) *ChoiceTable {
@@ -270,7 +290,7 @@ func BuildChoiceTable(prios [][]float32, enabled map[*sys.Call]bool) *ChoiceTabl
 			run[i][j] = sum
 		}
 	}
-	return &ChoiceTable{run, enabledCalls, enabled}
+	return &ChoiceTable{run, enabledCalls, enabled, DefaultBudget, DefaultMutationWeights, nil}
 }
 
 func (ct *ChoiceTable) Choose(r *rand.Rand, call int) int {
@@ -71,6 +71,10 @@ func (a *Arg) Size(typ sys.Type) uintptr {
 	case sys.BufferType:
 		return uintptr(len(a.Data))
 	case sys.StructType:
+		if a.Kind == ArgData {
+			// Squashed struct (see squashArg).
+			return uintptr(len(a.Data))
+		}
 		var size uintptr
 		for i, f := range typ1.Fields {
 			size += a.Inner[i].Size(f)
@@ -6,8 +6,11 @@ package prog
 import (
 	"bytes"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/google/syzkaller/sys"
 )
 
 func initTest(t *testing.T) (rand.Source, int) {
@@ -54,3 +57,103 @@ func TestSerializeForExec(t *testing.T) {
 		p.SerializeForExec()
 	}
 }
+
+func TestBudget(t *testing.T) {
+	rs, iters := initTest(t)
+	ct := BuildChoiceTable(CalculatePriorities(nil), nil)
+	budget := Budget{MaxCalls: 8, PtrDepth: 1, BufLen: 16}
+	ct.SetBudget(budget)
+	check := func(p *Prog) {
+		if len(p.Calls) > budget.MaxCalls {
+			t.Fatalf("program has %v calls, budget %v:\n%s", len(p.Calls), budget.MaxCalls, p.Serialize())
+		}
+		for _, c := range p.Calls {
+			foreachArg(c, func(arg, _ *Arg, _ *[]*Arg) {
+				if typ, ok := arg.Type.(sys.BufferType); ok && typ.Kind == sys.BufferBlob && len(arg.Data) > budget.BufLen {
+					t.Fatalf("buffer has %v bytes, budget %v:\n%s", len(arg.Data), budget.BufLen, p.Serialize())
+				}
+			})
+		}
+	}
+	for i := 0; i < iters; i++ {
+		p := Generate(rs, 10, ct)
+		check(p)
+		p.Mutate(rs, 10, ct)
+		check(p)
+	}
+}
+
+func TestFilePool(t *testing.T) {
+	seen := map[string]bool{FilePoolDir: true}
+	for _, f := range FilePool {
+		if !strings.HasPrefix(f.Name, FilePoolDir+"/") {
+			t.Fatalf("file %v is not in %v", f.Name, FilePoolDir)
+		}
+		if seen[f.Name] {
+			t.Fatalf("duplicate file %v", f.Name)
+		}
+		if dir := f.Name[:strings.LastIndexByte(f.Name, '/')]; !seen[dir] {
+			t.Fatalf("file %v goes before its parent", f.Name)
+		}
+		seen[f.Name] = true
+	}
+	rs, iters := initTest(t)
+	used := false
+	for i := 0; i < iters && !used; i++ {
+		used = Generate(rs, 10, nil).UsesFilePool()
+	}
+	if !used {
+		t.Fatalf("generated programs don't use file pool")
+	}
+}
+
+func TestNGrams(t *testing.T) {
+	rs, iters := initTest(t)
+	var corpus []*Prog
+	for _, data := range []string{
+		"getpid()\nsched_yield()\ngettid()\n",
+		"sched_yield()\ngetpid()\nsched_yield()\ngettid()\n",
+	} {
+		p, err := Deserialize([]byte(data))
+		if err != nil {
+			t.Fatalf("failed to deserialize program: %v\n%s", err, data)
+		}
+		corpus = append(corpus, p)
+	}
+	getpid := sys.CallMap["getpid"].ID
+	yield := sys.CallMap["sched_yield"].ID
+	gettid := sys.CallMap["gettid"].ID
+	ngrams := CalculateNGrams(corpus, []int{2, -1}, 10)
+	want := []NGram{
+		{[3]int{getpid, yield, gettid}, ngramCoverWeight + 1},
+		{[3]int{yield, getpid, yield}, 1},
+	}
+	if len(ngrams) != len(want) {
+		t.Fatalf("got %v ngrams, want %v: %+v", len(ngrams), len(want), ngrams)
+	}
+	for i := range want {
+		if ngrams[i] != want[i] {
+			t.Fatalf("ngram #%v: got %+v, want %+v", i, ngrams[i], want[i])
+		}
+	}
+	if ngrams := CalculateNGrams(corpus, nil, 1); len(ngrams) != 1 {
+		t.Fatalf("got %v ngrams, want 1", len(ngrams))
+	}
+
+	ct := BuildChoiceTable(CalculatePriorities(corpus), nil)
+	ct.SetNGrams(ngrams)
+	p := corpus[0]
+	r := rand.New(rs)
+	for i := 0; i < iters; i++ {
+		id, ok := ct.chooseNGram(r, p, 2)
+		if !ok || id != gettid {
+			t.Fatalf("chose %v/%v, want %v", id, ok, gettid)
+		}
+		if _, ok := ct.chooseNGram(r, p, 1); ok {
+			t.Fatalf("chose a call without 2 preceding calls")
+		}
+	}
+	for i := 0; i < iters; i++ {
+		Generate(rs, 10, ct)
+	}
+}
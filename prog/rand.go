@@ -15,11 +15,13 @@ import (
 
 type randGen struct {
 	*rand.Rand
+	budget           *Budget
 	inCreateResource bool
+	ptrDepth         int // number of pointers enclosing the currently generated arg
 }
 
-func newRand(rs rand.Source) *randGen {
-	return &randGen{rand.New(rs), false}
+func newRand(rs rand.Source, budget *Budget) *randGen {
+	return &randGen{Rand: rand.New(rs), budget: budget}
 }
 
 func (r *randGen) rand(n int) uintptr {
@@ -35,15 +37,16 @@ func (r *randGen) oneOf(n int) bool {
 }
 
 func (r *randGen) rand64() uintptr {
-	v := uintptr(r.Int63())
+	v := uint64(r.Int63())
 	if r.bin() {
 		v |= 1 << 63
 	}
-	return v
+	return uintptr(v)
 }
 
 // Some potentially interesting integers.
-var specialInts = []uintptr{
+// Values that don't fit into uintptr are truncated on 32-bit targets.
+var specialInts = []uint64{
 	0, 1, 31, 32, 63, 64, 127, 128,
 	129, 255, 256, 257, 511, 512,
 	1023, 1024, 1025, 2047, 2048, 4095, 4096,
@@ -57,7 +60,7 @@ func (r *randGen) randInt() uintptr {
 	v := r.rand64()
 	r.choose(
 		100, func() { v %= 10 },
-		50, func() { v = specialInts[r.Intn(len(specialInts))] },
+		50, func() { v = uintptr(specialInts[r.Intn(len(specialInts))]) },
 		10, func() { v %= 256 },
 		10, func() { v %= 4 << 10 },
 		10, func() { v %= 64 << 10 },
@@ -88,14 +91,31 @@ func (r *randGen) biasedRand(n, k int) int {
 }
 
 func (r *randGen) randBufLen() (n uintptr) {
+	max := r.budget.BufLen
 	r.choose(
 		1, func() { n = 0 },
-		50, func() { n = r.rand(256) },
-		5, func() { n = 4 << 10 },
+		50, func() {
+			if max > 256 {
+				n = r.rand(256)
+			} else {
+				n = r.rand(max + 1)
+			}
+		},
+		5, func() { n = uintptr(max) },
 	)
 	return
 }
 
+// progLen returns length of a new program. Lengths are uniformly distributed around ncalls:
+// short programs are fast to execute and minimize, long programs give more interactions between calls.
+func (r *randGen) progLen(ncalls int) int {
+	n := ncalls/2 + r.Intn(ncalls+1)
+	if n > r.budget.MaxCalls {
+		n = r.budget.MaxCalls
+	}
+	return n
+}
+
 func (r *randGen) randPageCount() (n uintptr) {
 	r.choose(
 		100, func() { n = r.rand(4) + 1 },
@@ -122,6 +142,10 @@ func (r *randGen) flags(vv []uintptr) uintptr {
 
 func (r *randGen) filename(s *state) string {
 	// TODO: support procfs and sysfs
+	if r.oneOf(4) {
+		// Existing file, symlink or device node created by executor.
+		return FilePool[r.Intn(len(FilePool))].Name + "\x00"
+	}
 	dir := "."
 	if r.oneOf(2) && len(s.files) != 0 {
 		files := make([]string, 0, len(s.files))
@@ -554,7 +578,13 @@ func (r *randGen) choose(args ...interface{}) {
 	panic("choose is broken")
 }
 
-func (r *randGen) generateCall(s *state, p *Prog) []*Call {
+// generateCall generates a call to be inserted into p at position pos.
+func (r *randGen) generateCall(s *state, p *Prog, pos int) []*Call {
+	if r.bin() {
+		if id, ok := s.ct.chooseNGram(r.Rand, p, pos); ok {
+			return r.generateParticularCall(s, sys.Calls[id])
+		}
+	}
 	call := -1
 	if len(p.Calls) != 0 {
 		for i := 0; i < 5; i++ {
@@ -628,6 +658,12 @@ func (r *randGen) generateArgs(s *state, types []sys.Type, dir ArgDir) ([]*Arg,
 	return args, calls
 }
 
+// ptrTooDeep says if typ is a pointer that would exceed pointer depth budget.
+func (r *randGen) ptrTooDeep(typ sys.Type) bool {
+	_, ok := typ.(sys.PtrType)
+	return ok && r.ptrDepth >= r.budget.PtrDepth
+}
+
 func (r *randGen) generateArg(s *state, typ sys.Type, dir ArgDir, sizes map[string]*Arg) (arg, size *Arg, calls []*Call) {
 	if dir == DirOut {
 		// No need to generate something interesting for output scalar arguments.
@@ -640,7 +676,7 @@ func (r *randGen) generateArg(s *state, typ sys.Type, dir ArgDir, sizes map[stri
 		}
 	}
 
-	if typ.Optional() && r.oneOf(10) {
+	if typ.Optional() && (r.oneOf(10) || r.ptrTooDeep(typ)) {
 		if _, ok := typ.(sys.BufferType); ok {
 			panic("impossible") // parent PtrType must be Optional instead
 		}
@@ -785,7 +821,9 @@ func (r *randGen) generateArg(s *state, typ sys.Type, dir ArgDir, sizes map[stri
 		opt, size, calls := r.generateArg(s, optType, dir, sizes)
 		return unionArg(opt, optType), size, calls
 	case sys.PtrType:
+		r.ptrDepth++
 		inner, size, calls := r.generateArg(s, a.Type, ArgDir(a.Dir), sizes)
+		r.ptrDepth--
 		if ArgDir(a.Dir) == DirOut && inner == nil {
 			// No data, but we should have got size.
 			arg, calls1 := r.addr(s, size.Val, nil)
@@ -794,6 +832,7 @@ func (r *randGen) generateArg(s *state, typ sys.Type, dir ArgDir, sizes map[stri
 		}
 		if size == nil {
 			size = constArg(inner.Size(a.Type))
+			size.ByteSize = size.Val
 		}
 		if a.Type.Name() == "iocb" && r.bin() && len(s.resources[sys.ResIocbPtr][sys.ResAny]) != 0 {
 			// It is weird, but these are actually identified by kernel by address.
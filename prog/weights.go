@@ -0,0 +1,49 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"fmt"
+)
+
+// MutationWeights are relative probabilities of mutation operators applied by Mutate.
+type MutationWeights struct {
+	InsertCall int // insert a new call
+	MutateArg  int // change args of a call
+	Squash     int // squash a struct into a blob or restore it
+	RemoveCall int // remove a call
+}
+
+var DefaultMutationWeights = MutationWeights{
+	InsertCall: 20,
+	MutateArg:  10,
+	Squash:     1,
+	RemoveCall: 1,
+}
+
+func (w *MutationWeights) Validate() error {
+	if w.InsertCall < 0 || w.MutateArg < 0 || w.Squash < 0 || w.RemoveCall < 0 {
+		return fmt.Errorf("bad mutation weights %+v, want >= 0", *w)
+	}
+	// Otherwise some programs can't be mutated (e.g. programs without args that already have max calls).
+	if w.InsertCall == 0 || w.RemoveCall == 0 {
+		return fmt.Errorf("bad mutation weights %+v, insert and remove call weights must be > 0", *w)
+	}
+	return nil
+}
+
+// SetMutationWeights sets weights of mutations of programs mutated with ct (DefaultMutationWeights by default).
+func (ct *ChoiceTable) SetMutationWeights(w MutationWeights) {
+	if err := w.Validate(); err != nil {
+		panic(err)
+	}
+	ct.weights = w
+}
+
+func (ct *ChoiceTable) getMutationWeights() *MutationWeights {
+	if ct == nil {
+		return &DefaultMutationWeights
+	}
+	return &ct.weights
+}
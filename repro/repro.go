@@ -0,0 +1,84 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package repro keeps track of crash reproduction results.
+// syz-repro saves results for a crash log next to it (crash-xxx.repro),
+// syz-manager uses them to sort crashes by reproducibility.
+package repro
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// Suffix of result files, result for crash log "crash-xxx" is in "crash-xxx.repro".
+const Suffix = ".repro"
+
+type Result struct {
+	Attempts   int // number of syz-repro runs
+	Reproduced int // number of runs that found a reproducer
+	Runs       int // number of confirmation re-runs of found reproducers
+	Crashes    int // number of confirmation re-runs that crashed the kernel
+}
+
+// Add accumulates results of r1 into r.
+func (r *Result) Add(r1 *Result) {
+	r.Attempts += r1.Attempts
+	r.Reproduced += r1.Reproduced
+	r.Runs += r1.Runs
+	r.Crashes += r1.Crashes
+}
+
+// Score returns reproducibility score in [0, 1]: fraction of attempts that found a reproducer
+// multiplied by fraction of confirmation re-runs that crashed the kernel.
+// Returns -1 if there were no attempts.
+func (r *Result) Score() float64 {
+	if r.Attempts == 0 {
+		return -1
+	}
+	score := float64(r.Reproduced) / float64(r.Attempts)
+	if r.Runs != 0 {
+		score *= float64(r.Crashes) / float64(r.Runs)
+	}
+	return score
+}
+
+func (r *Result) String() string {
+	if r.Attempts == 0 {
+		return "not tried"
+	}
+	return fmt.Sprintf("%.2f (reproduced %v/%v, confirmed %v/%v)",
+		r.Score(), r.Reproduced, r.Attempts, r.Crashes, r.Runs)
+}
+
+// Load loads results for crash log file, missing results file is not an error.
+func Load(crashFile string) (*Result, error) {
+	r := new(Result)
+	data, err := ioutil.ReadFile(crashFile + Suffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %v", crashFile+Suffix, err)
+	}
+	return r, nil
+}
+
+// Record adds r to results saved for crash log file.
+func Record(crashFile string, r *Result) error {
+	res, err := Load(crashFile)
+	if err != nil {
+		return err
+	}
+	res.Add(r)
+	data, err := json.MarshalIndent(res, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(crashFile+Suffix, data, 0660)
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package repro
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		r     Result
+		score float64
+	}{
+		{Result{}, -1},
+		{Result{Attempts: 2}, 0},
+		{Result{Attempts: 2, Reproduced: 1}, 0.5},
+		{Result{Attempts: 1, Reproduced: 1, Runs: 4, Crashes: 3}, 0.75},
+		{Result{Attempts: 2, Reproduced: 1, Runs: 4, Crashes: 2}, 0.25},
+	}
+	for i, test := range tests {
+		if score := test.r.Score(); score != test.score {
+			t.Errorf("test #%v: %+v: score %v, want %v", i, test.r, score, test.score)
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syz-repro-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	crash := filepath.Join(dir, "crash-qemu-0-1")
+	if r, err := Load(crash); err != nil || *r != (Result{}) {
+		t.Fatalf("bad result for missing file: %+v, %v", r, err)
+	}
+	if err := Record(crash, &Result{Attempts: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(crash, &Result{Attempts: 1, Reproduced: 1, Runs: 3, Crashes: 3}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(crash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Result{Attempts: 2, Reproduced: 1, Runs: 3, Crashes: 3}); *r != want {
+		t.Fatalf("bad result: %+v, want %+v", *r, want)
+	}
+}
+
+func TestProg(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syz-repro-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	crash := filepath.Join(dir, "crash-qemu-0-1")
+	if _, _, err := LoadProg(crash); !os.IsNotExist(err) {
+		t.Fatalf("loaded missing prog: %v", err)
+	}
+	prog := []byte("mmap(&(0x7f0000000000/0x1000)=nil, 0x1000, 0x3, 0x32, 0xffffffffffffffff, 0x0)\n")
+	opts := ProgOptions{Threaded: true, Multiplier: 10}
+	if err := SaveProg(crash, prog, opts); err != nil {
+		t.Fatal(err)
+	}
+	prog1, opts1, err := LoadProg(crash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(prog1) != string(prog) || opts1 != opts {
+		t.Fatalf("loaded %+v:\n%s\nwant %+v:\n%s", opts1, prog1, opts, prog)
+	}
+}
+
+func TestFixedSince(t *testing.T) {
+	tests := []struct {
+		crashes []int // crashes in every retest, -1 means that the retest did not run
+		fixed   int   // index of the retest since which the bug is fixed, -1 if not fixed
+	}{
+		{nil, -1},
+		{[]int{2}, -1},
+		{[]int{0}, 0},
+		{[]int{3, 0, 0}, 1},
+		{[]int{0, 1, 0, -1, 0}, 2},
+		{[]int{0, 0, 1}, -1},
+		{[]int{-1}, -1},
+	}
+	for i, test := range tests {
+		var retests []Retest
+		for j, crashes := range test.crashes {
+			r := Retest{KernelBuild: fmt.Sprint(j), Runs: 3, Crashes: crashes}
+			if crashes == -1 {
+				r.Runs, r.Crashes = 0, 0
+			}
+			retests = append(retests, r)
+		}
+		fixed := FixedSince(retests)
+		if test.fixed == -1 && fixed != nil || test.fixed != -1 && (fixed == nil || fixed.KernelBuild != fmt.Sprint(test.fixed)) {
+			t.Errorf("test #%v: fixed since %+v, want #%v", i, fixed, test.fixed)
+		}
+	}
+}
+
+func TestBisections(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syz-repro-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	crash := filepath.Join(dir, "crash-qemu-0-1")
+	if res, err := LoadBisections(crash); err != nil || res != nil {
+		t.Fatalf("bad result for missing file: %+v, %v", res, err)
+	}
+	for _, b := range []Bisection{
+		{Commit: "1", Crash: "KASAN: use-after-free Read in foo"},
+		{Fix: true, Suspects: []string{"2 a", "3 b"}},
+		{Fix: true, Commit: "4"},
+	} {
+		if err := RecordBisection(crash, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := LoadBisections(crash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 3 {
+		t.Fatalf("loaded %v bisections, want 3", len(res))
+	}
+	if b := LastBisection(res, false); b == nil || b.Commit != "1" || b.Crash == "" {
+		t.Fatalf("bad last cause bisection: %+v", b)
+	}
+	if b := LastBisection(res, true); b == nil || b.Commit != "4" {
+		t.Fatalf("bad last fix bisection: %+v", b)
+	}
+	if b := LastBisection(res[:1], true); b != nil {
+		t.Fatalf("found fix bisection: %+v", b)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package repro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// syz-repro saves the found reproducer for crash log "crash-xxx" in "crash-xxx.prog",
+// so that it can be re-tested on new kernel builds (syz-repro -retest). Results of re-tests
+// are appended to "crash-xxx.retest", results of syz-bisect -crash to "crash-xxx.bisect".
+// External reproducers (syz-repro -external) are saved the same way, C reproducers in "xxx.c".
+const (
+	ProgSuffix   = ".prog"
+	CSuffix      = ".c"
+	RetestSuffix = ".retest"
+	BisectSuffix = ".bisect"
+)
+
+// ProgOptions are execution options the reproducer crashed the kernel with.
+type ProgOptions struct {
+	Threaded   bool
+	Collide    bool
+	Multiplier int // syz-repro repeats the program 100*Multiplier times (1000*Multiplier if Threaded)
+}
+
+// SaveProg saves reproducer program for crash log file, the options are saved in the first line comment.
+func SaveProg(crashFile string, prog []byte, opts ProgOptions) error {
+	optsData, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	data := append([]byte(fmt.Sprintf("# %s\n", optsData)), prog...)
+	return ioutil.WriteFile(crashFile+ProgSuffix, data, 0660)
+}
+
+// LoadProg loads reproducer program for crash log file saved with SaveProg.
+func LoadProg(crashFile string) ([]byte, ProgOptions, error) {
+	var opts ProgOptions
+	data, err := ioutil.ReadFile(crashFile + ProgSuffix)
+	if err != nil {
+		return nil, opts, err
+	}
+	nl := bytes.IndexByte(data, '\n')
+	if !bytes.HasPrefix(data, []byte("# ")) || nl == -1 {
+		return nil, opts, fmt.Errorf("%v: no options line", crashFile+ProgSuffix)
+	}
+	if err := json.Unmarshal(data[2:nl], &opts); err != nil {
+		return nil, opts, fmt.Errorf("failed to parse options in %v: %v", crashFile+ProgSuffix, err)
+	}
+	return data[nl+1:], opts, nil
+}
+
+// Retest is a result of re-running the saved reproducer on a kernel build.
+type Retest struct {
+	Time         time.Time
+	KernelBuild  string // hash of vmlinux
+	KernelCommit string // kernel_commit config param, if set
+	Runs         int
+	Crashes      int
+	Desc         string `json:",omitempty"` // description of the first crash
+}
+
+// LoadRetests loads re-test results for crash log file in chronological order.
+// Missing results file is not an error.
+func LoadRetests(crashFile string) ([]Retest, error) {
+	data, err := ioutil.ReadFile(crashFile + RetestSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var res []Retest
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %v", crashFile+RetestSuffix, err)
+	}
+	return res, nil
+}
+
+// RecordRetest appends r to re-test results saved for crash log file.
+func RecordRetest(crashFile string, r Retest) error {
+	res, err := LoadRetests(crashFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(append(res, r), "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(crashFile+RetestSuffix, data, 0660)
+}
+
+// FixedSince returns the first re-test since which the reproducer does not crash the kernel anymore,
+// or nil if the bug still reproduces on the latest re-tested build (or it was not re-tested).
+func FixedSince(retests []Retest) *Retest {
+	var fixed *Retest
+	for i := range retests {
+		if retests[i].Runs == 0 {
+			continue
+		}
+		if retests[i].Crashes != 0 {
+			fixed = nil
+		} else if fixed == nil {
+			fixed = &retests[i]
+		}
+	}
+	return fixed
+}
+
+// Bisection is a result of bisecting kernel history with the saved reproducer (syz-bisect -crash).
+type Bisection struct {
+	Time     time.Time
+	Fix      bool     // the fixing commit was searched for (syz-bisect -fix), otherwise the first bad one
+	Commit   string   // hash of the found commit, empty if it could not be found because of skipped commits
+	Title    string   // title of the found commit
+	Author   string   // author of the found commit
+	Suspects []string `json:",omitempty"` // "hash title" of commits that could be the found one if Commit is empty
+	Crash    string   `json:",omitempty"` // description of the crash on the first bad commit (not for Fix)
+}
+
+// LoadBisections loads bisection results for crash log file in chronological order.
+// Missing results file is not an error.
+func LoadBisections(crashFile string) ([]Bisection, error) {
+	data, err := ioutil.ReadFile(crashFile + BisectSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var res []Bisection
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %v", crashFile+BisectSuffix, err)
+	}
+	return res, nil
+}
+
+// RecordBisection appends b to bisection results saved for crash log file.
+func RecordBisection(crashFile string, b Bisection) error {
+	res, err := LoadBisections(crashFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(append(res, b), "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(crashFile+BisectSuffix, data, 0660)
+}
+
+// LastBisection returns the latest bisection result for fixing (fix) or for bad commit,
+// or nil if there is none.
+func LastBisection(bisections []Bisection, fix bool) *Bisection {
+	for i := len(bisections) - 1; i >= 0; i-- {
+		if bisections[i].Fix == fix {
+			return &bisections[i]
+		}
+	}
+	return nil
+}
@@ -5,20 +5,56 @@
 // between various parts of the system.
 package rpctype
 
+import (
+	"bytes"
+	"strings"
+
+	"github.com/google/syzkaller/cover"
+	"github.com/google/syzkaller/prog"
+)
+
 type RpcInput struct {
 	Call      string
 	Prog      []byte
 	CallIndex int
-	Cover     []uint32
+	Cover     []byte // canonical cover compressed with cover.Compress
 }
 
 type ConnectArgs struct {
-	Name string
+	Name     string
+	Revision string         // descriptions revision of the fuzzer (sys.Revision)
+	Modules  []cover.Module // loaded kernel modules
+
+	KernelVersion string // contents of /proc/version
 }
 
 type ConnectRes struct {
-	Prios        [][]float32
-	EnabledCalls string
+	Prios         [][]float32
+	EnabledCalls  string
+	ProgramLength int          // target number of calls in generated programs
+	Budget        prog.Budget  // limits for generated and mutated programs
+	CoverFilter   cover.Filter // only coverage in these PC ranges is used (all coverage if empty)
+	CallStats     bool         // report CallStats in Poll
+	NGrams        []prog.NGram // frequent sequences of calls in corpus (with Call_Ngrams)
+	// Experiments of the manager config (see config.Experiments).
+	Weights        prog.MutationWeights
+	SpliceProb     float64
+	GeneratePeriod int
+	MutateLength   int
+
+	NeedKallsyms bool // send text symbols from /proc/kallsyms in CheckArgs (there is no vmlinux)
+}
+
+// CheckArgs is the result of the machine check done by a fuzzer on startup.
+type CheckArgs struct {
+	Name                 string
+	Kcov                 bool
+	Debugfs              bool
+	Kasan                bool
+	Kallsyms             bool
+	UnsupportedCalls     []string // calls the kernel does not support (not compiled in, ENOSYS, missing devices)
+	TransitivelyDisabled []string // calls that can't get resources from supported calls
+	KallsymsData         []byte   // text symbols from /proc/kallsyms if ConnectRes.NeedKallsyms is set
 }
 
 type NewInputArgs struct {
@@ -26,12 +62,82 @@ type NewInputArgs struct {
 	RpcInput
 }
 
+// RpcCandidate is an untriaged program, ID is hex-encoded hash of the program.
+type RpcCandidate struct {
+	ID   string
+	Prog []byte
+}
+
+// CallStat is statistics of a call in generated and mutated programs.
+type CallStat struct {
+	Execs    uint64
+	NewCover uint64 // executions that gave new coverage
+}
+
 type PollArgs struct {
-	Name  string
-	Stats map[string]uint64
+	Name           string
+	Stats          map[string]uint64
+	DoneCandidates []string            // IDs of candidates triaged since the last poll
+	CallStats      map[string]CallStat // per-call statistics since the last poll
+	Modules        []cover.Module      // loaded kernel modules, nil if they have not changed since the last poll
 }
 
 type PollRes struct {
-	Candidates [][]byte
-	NewInputs  []RpcInput
+	Candidates   []RpcCandidate
+	NewInputs    []RpcInput
+	CallWeights  []float32 // new weights of calls (indexed by call ID), nil if they have not changed
+	EnabledCalls string    // new enabled calls (see ConnectRes), empty if they have not changed
+}
+
+// FuzzerExit is a reason for syz-fuzzer to exit that is not a kernel bug. The fuzzer prints
+// "SYZFAIL: <reason>: <details>" and exits with Status. The manager looks for the line in the
+// output (not all VM types propagate exit status of the fuzzer) and reports the failure
+// instead of saving a "lost connection" crash.
+type FuzzerExit struct {
+	Status int
+	Reason string
+}
+
+var (
+	ExitManagerUnreachable = FuzzerExit{80, "manager unreachable"}
+	ExitExecutorFailed     = FuzzerExit{81, "executor incompatible"}
+	ExitKcovUnavailable    = FuzzerExit{82, "kcov unavailable"}
+	ExitRevisionMismatch   = FuzzerExit{83, "descriptions mismatch"}
+
+	FuzzerExits = []FuzzerExit{ExitManagerUnreachable, ExitExecutorFailed, ExitKcovUnavailable, ExitRevisionMismatch}
+)
+
+const FuzzerExitPrefix = "SYZFAIL: "
+
+// ErrRevisionMismatch is contained in the error returned by Manager.Connect
+// if descriptions of the fuzzer differ from descriptions of the manager.
+const ErrRevisionMismatch = "descriptions revision mismatch"
+
+// ParseFuzzerExit finds the last exit line printed by the fuzzer in output
+// and returns the reason and details from it.
+func ParseFuzzerExit(output []byte) (FuzzerExit, string, bool) {
+	pos := bytes.LastIndex(output, []byte(FuzzerExitPrefix))
+	if pos == -1 {
+		return FuzzerExit{}, "", false
+	}
+	line := output[pos+len(FuzzerExitPrefix):]
+	if nl := bytes.IndexByte(line, '\n'); nl != -1 {
+		line = line[:nl]
+	}
+	for _, e := range FuzzerExits {
+		if bytes.HasPrefix(line, []byte(e.Reason+":")) {
+			return e, strings.TrimSpace(string(line[len(e.Reason)+1:])), true
+		}
+	}
+	return FuzzerExit{}, "", false
+}
+
+// FuzzerExitStatus returns the exit reason with the given exit status of the fuzzer.
+func FuzzerExitStatus(status int) (FuzzerExit, bool) {
+	for _, e := range FuzzerExits {
+		if e.Status == status {
+			return e, true
+		}
+	}
+	return FuzzerExit{}, false
 }
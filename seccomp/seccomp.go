@@ -0,0 +1,156 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package seccomp parses seccomp profiles in the format used by docker/runc/podman.
+// Executor threads that execute syscalls install a seccomp filter built from the profile
+// (see ipc.FlagSeccomp), so that fuzzing is restricted to the syscall surface reachable
+// from a sandbox that uses the profile.
+// Only syscall numbers are matched: argument conditions of rules that allow syscalls are ignored
+// (the rule allows the syscall with any arguments), other rules with argument conditions are skipped,
+// rules that apply only with some capabilities ("includes") are skipped as well.
+// Syscalls that are not present in descriptions can't be matched and get the default action.
+// Executor needs futex and exit (and write with debug output) in the filtered threads,
+// so these syscalls are always allowed.
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/syzkaller/sys"
+)
+
+// Seccomp actions (SECCOMP_RET_*).
+const (
+	ActKillThread  = uint32(0x00000000)
+	ActKillProcess = uint32(0x80000000)
+	ActTrap        = uint32(0x00030000)
+	ActErrno       = uint32(0x00050000) // | errno
+	ActTrace       = uint32(0x7ff00000)
+	ActLog         = uint32(0x7ffc0000)
+	ActAllow       = uint32(0x7fff0000)
+)
+
+type Rule struct {
+	NR     int
+	Action uint32
+}
+
+// Filter is a parsed seccomp profile.
+type Filter struct {
+	Default uint32
+	Rules   []Rule // syscall numbers for the current arch, sorted by NR, at most one rule per NR
+
+	actions map[string]uint32 // by syscall name
+}
+
+// Pseudo-syscalls (syz_*) have numbers starting from this.
+const pseudoNR = 1000000
+
+type profile struct {
+	DefaultAction   string
+	DefaultErrnoRet *int
+	Syscalls        []struct {
+		Names    []string
+		Name     string // old format with one name per rule
+		Action   string
+		ErrnoRet *int
+		Args     []json.RawMessage
+		Includes struct {
+			Caps   []string
+			Arches []string
+		}
+	}
+}
+
+// Parse parses a docker/OCI seccomp profile.
+func Parse(data []byte) (*Filter, error) {
+	p := new(profile)
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse seccomp profile: %v", err)
+	}
+	def, err := parseAction(p.DefaultAction, p.DefaultErrnoRet)
+	if err != nil {
+		return nil, err
+	}
+	filter := &Filter{Default: def, actions: make(map[string]uint32)}
+	for _, rule := range p.Syscalls {
+		act, err := parseAction(rule.Action, rule.ErrnoRet)
+		if err != nil {
+			return nil, err
+		}
+		if len(rule.Includes.Caps) != 0 || len(rule.Includes.Arches) != 0 {
+			continue
+		}
+		if len(rule.Args) != 0 && act != ActAllow && act != ActLog {
+			continue
+		}
+		names := rule.Names
+		if rule.Name != "" {
+			names = append(names, rule.Name)
+		}
+		for _, name := range names {
+			if _, dup := filter.actions[name]; !dup || act == ActAllow {
+				filter.actions[name] = act
+			}
+		}
+	}
+	added := make(map[int]bool)
+	for _, c := range sys.Calls {
+		act, ok := filter.actions[c.CallName]
+		if !ok || act == def || c.NR >= pseudoNR || added[c.NR] {
+			continue
+		}
+		added[c.NR] = true
+		filter.Rules = append(filter.Rules, Rule{c.NR, act})
+	}
+	sort.Sort(ruleArray(filter.Rules))
+	return filter, nil
+}
+
+func parseAction(action string, errno *int) (uint32, error) {
+	switch action {
+	case "SCMP_ACT_ALLOW":
+		return ActAllow, nil
+	case "SCMP_ACT_LOG":
+		return ActLog, nil
+	case "SCMP_ACT_ERRNO":
+		e := 1 // EPERM
+		if errno != nil {
+			e = *errno
+		}
+		if e < 0 || e > 4095 {
+			return 0, fmt.Errorf("bad seccomp errno %v", e)
+		}
+		return ActErrno | uint32(e), nil
+	case "SCMP_ACT_TRACE":
+		return ActTrace, nil
+	case "SCMP_ACT_TRAP":
+		return ActTrap, nil
+	case "SCMP_ACT_KILL", "SCMP_ACT_KILL_THREAD":
+		return ActKillThread, nil
+	case "SCMP_ACT_KILL_PROCESS":
+		return ActKillProcess, nil
+	default:
+		return 0, fmt.Errorf("unknown seccomp action '%v'", action)
+	}
+}
+
+// Allowed returns true if the filter lets the call through (pseudo-syscalls are not filtered themselves).
+func (f *Filter) Allowed(c *sys.Call) bool {
+	if c.NR >= pseudoNR {
+		return true
+	}
+	act, ok := f.actions[c.CallName]
+	if !ok {
+		act = f.Default
+	}
+	return act == ActAllow || act == ActLog
+}
+
+type ruleArray []Rule
+
+func (a ruleArray) Len() int           { return len(a) }
+func (a ruleArray) Less(i, j int) bool { return a[i].NR < a[j].NR }
+func (a ruleArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
@@ -0,0 +1,45 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package seccomp
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/sys"
+)
+
+func TestSeccompProfile(t *testing.T) {
+	profile := `{
+		"defaultAction": "SCMP_ACT_ERRNO",
+		"syscalls": [
+			{"names": ["getpid", "gettid", "no_such_syscall"], "action": "SCMP_ACT_ALLOW"},
+			{"names": ["getuid"], "action": "SCMP_ACT_ALLOW", "args": [{"index": 0, "value": 1, "op": "SCMP_CMP_EQ"}]},
+			{"names": ["getgid"], "action": "SCMP_ACT_ALLOW", "includes": {"caps": ["CAP_SYS_ADMIN"]}},
+			{"names": ["sched_yield"], "action": "SCMP_ACT_ERRNO", "errnoRet": 38}
+		]
+	}`
+	filter, err := Parse([]byte(profile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.Default != ActErrno|1 {
+		t.Fatalf("bad default action 0x%x", filter.Default)
+	}
+	for name, allowed := range map[string]bool{
+		"getpid":          true,
+		"gettid":          true,
+		"getuid":          true,
+		"getgid":          false,
+		"sched_yield":     false,
+		"geteuid":         false,
+		"syz_mount_tmpfs": true,
+	} {
+		if got := filter.Allowed(sys.CallMap[name]); got != allowed {
+			t.Errorf("%v: allowed %v, want %v", name, got, allowed)
+		}
+	}
+	if _, err := Parse([]byte(`{"defaultAction": "SCMP_ACT_FOO"}`)); err == nil {
+		t.Fatalf("unknown action is not detected")
+	}
+}
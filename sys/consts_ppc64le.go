@@ -0,0 +1,5 @@
+// AUTOGENERATED FILE
+package sys
+
+// Const values are not extracted for ppc64le, run make extract for it.
+var _ = constsAreNotExtractedForThisArch
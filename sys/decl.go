@@ -5,9 +5,19 @@ package sys
 
 import (
 	"fmt"
+	"unsafe"
 )
 
-const ptrSize = 8
+// ptrSize is size of pointers and intptr on the target arch.
+// Descriptions are compiled for the target (e.g. GOARCH=386 for 32-bit compat target),
+// so it is the same as the size of pointers in the fuzzer itself.
+const ptrSize = unsafe.Sizeof(uintptr(0))
+
+// u64 converts v to uintptr, it is used by generated code for values
+// that are truncated on 32-bit targets.
+func u64(v uint64) uintptr {
+	return uintptr(v)
+}
 
 type Call struct {
 	ID       int
@@ -68,6 +78,7 @@ const (
 	ResTimerid
 	ResIocbPtr
 	ResDrmCtx
+	ResGenlFamily
 )
 
 const (
@@ -116,6 +127,7 @@ const (
 	FdRandom
 	FdKcm
 	FdNetRom
+	FdUsb
 
 	IPCMsq
 	IPCSem
@@ -134,6 +146,7 @@ func ResourceKinds() []ResourceKind {
 		ResGid,
 		ResTimerid,
 		ResIocbPtr,
+		ResGenlFamily,
 	}
 }
 
@@ -146,10 +159,10 @@ func ResourceSubkinds(kind ResourceKind) []ResourceSubkind {
 			FdAlg, FdAlgConn, FdNfcRaw, FdNfcLlcp, FdBtHci, FdBtSco, FdBtL2cap,
 			FdBtRfcomm, FdBtHidp, FdBtCmtp, FdBtBnep, FdUnix, FdSctp, FdNetlink, FdKvm, FdKvmVm,
 			FdKvmCpu, FdSndSeq, FdSndTimer, FdSndControl, FdInputEvent, FdTun, FdRandom, FdKcm,
-			FdNetRom}
+			FdNetRom, FdUsb}
 	case ResIPC:
 		return []ResourceSubkind{IPCMsq, IPCSem, IPCShm}
-	case ResIOCtx, ResKey, ResInotifyDesc, ResPid, ResUid, ResGid, ResTimerid, ResIocbPtr, ResDrmCtx:
+	case ResIOCtx, ResKey, ResInotifyDesc, ResPid, ResUid, ResGid, ResTimerid, ResIocbPtr, ResDrmCtx, ResGenlFamily:
 		return []ResourceSubkind{ResAny}
 	default:
 		panic("unknown resource kind")
@@ -194,6 +207,8 @@ func (t ResourceType) Default() uintptr {
 		return 0
 	case ResDrmCtx:
 		return 0
+	case ResGenlFamily:
+		return 0
 	default:
 		panic("unknown resource type")
 	}
@@ -223,6 +238,8 @@ func (t ResourceType) SpecialValues() []uintptr {
 		return []uintptr{0}
 	case ResDrmCtx:
 		return []uintptr{0}
+	case ResGenlFamily:
+		return []uintptr{0}
 	default:
 		panic("unknown resource kind")
 	}
@@ -250,6 +267,8 @@ func (t ResourceType) Size() uintptr {
 		return 4
 	case ResDrmCtx:
 		return 4
+	case ResGenlFamily:
+		return 2
 	default:
 		panic("unknown resource kind")
 	}
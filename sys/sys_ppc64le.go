@@ -5,4 +5,4 @@
 package sys
 
 // Maps internal syscall ID onto kernel syscall number.
-var numbers = []int{5, 5, 286, 8, 6, 3, 179, 145, 320, 4, 180, 146, 321, 19, 41, 63, 316, 42, 317, 284, 283, 285, 186, 106, 107, 108, 167, 281, 82, 280, 236, 315, 237, 238, 303, 305, 313, 307, 314, 306, 311, 312, 364, 54, 54, 54, 54, 54, 54, 90, 91, 163, 239, 125, 144, 205, 233, 191, 259, 301, 258, 261, 260, 206, 150, 378, 151, 152, 153, 360, 282, 354, 221, 300, 299, 0, 54, 54, 54, 54, 54, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 227, 228, 229, 230, 231, 183, 184, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, -1, 358, 262, 264, 265, 266, 267, 263, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 14, 288, 15, 94, 297, 181, 16, 95, 289, 309, 298, 30, 251, 290, 304, 47, 50, 23, 46, 24, 49, 57, 132, 65, 20, 207, 70, 71, 164, 169, 165, 170, 138, 139, 80, 81, 136, 275, 318, 276, 277, 323, 324, 9, 294, 295, 83, 10, 292, 85, 296, 38, 293, 357, 39, 287, 40, 92, 93, 143, 118, 148, 36, 348, -1, 235, 141, 202, 345, 346, 21, 21, 52, 203, 135, 135, 135, 99, 100, 86, 128, 353, 129, 268, 130, 103, 122, 116, 62, 51, 77, 76, 75, 325, 110, 101, 274, 274, 273, 273, 350, 209, 210, 211, 212, 213, 214, 215, 216, 217, 218, 219, 220, 13, 246, 245, 347, 247, 248, 240, 242, 243, 241, 244, 173, 174, 172, 175, 176, 178, 177, 322, 185, 250, 208, 29, 27, 162, 105, 104, 1, 234, 272, 114, 43, -1, -1, 123, 123, 123, 123, 351, 352, 232, 96, 97, 157, 156, 161, 155, 154, 223, 222, 356, 355, 158, 359, 365, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 326, 333, 330, 344, 327, 329, 328, 338, 335, 341, 349, 337, 342, 343, 331, 332, 340, 339, 54, 54, 339, 340, 339, 339, 340, 339, 340, 339, 340, 339, 339, 339, 340, 339, 340, 340, 339, 340, 339, 340, 339, 340, 339, 340, 340, 339, 340, 339, 340, 339, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 326, 333, 327, 328, 330, 344, 335, 341, 349, 337, 331, 332, 326, 327, 339, 339, 330, 341, 349, 326, 327, 328, 330, 339, 339, 340, 341, 349, 326, 328, 326, 327, 54, 339, 339, 339, 340, 326, 327, 328, 340, 340, 326, 327, 328, 339, 340, 339, 340, 339, 340, 326, 327, 328, 339, 340, 340, 326, 54, 54, 54, 54, 326, 54, 54, 54, 54, 326, 54, 54, 54, 54, 54, 54, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 5, 1000002, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 319, 54, 54, 54, 54, 54, 54, 54, 54, 54, 269, 270, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 361, 361, 361, 361, 361, 361, 361, 361, 361, 361, 1000003, 1000004, 54, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 1000001, 1000001, 1000001, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 5, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 326, 326, 333, 327, 328, 330, 344, 335, 341, 349, 337, 331, 332, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 54, 1000001, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 5, 1000001, 4, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000001, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000001, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000001, 1000001, 1000001, 4, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 326, 327, 328, 331, 332, 341, 339, 339, 339, 339, 339, 339, 339, 339, 339, 340, 1000001, 4, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000001, 1000001, 54, 54, 54, 54, 54, 326, 339, 340, 341, 342, 54, 54, 54, 326, 327, 328, 330, 329, 341, 342, 331, 332, 339, 339, 339, 339, 339, 340, 340, 340, 340, 340, 54, 54, 54, 54, 54}
+var numbers = []int{5, 5, 286, 8, 6, 3, 179, 145, 320, 4, 180, 146, 321, 19, 41, 63, 316, 42, 317, 284, 283, 285, 186, 106, 107, 108, 167, 281, 82, 280, 236, 315, 237, 238, 303, 305, 313, 307, 314, 306, 311, 312, 364, 54, 54, 54, 54, 54, 54, 90, 91, 163, 239, 125, 144, 205, 233, 191, 259, 301, 258, 261, 260, 206, 150, 378, 151, 152, 153, 360, 1000012, 282, 354, 221, 300, 299, 0, 54, 54, 54, 54, 54, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 227, 228, 229, 230, 231, 183, 184, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, -1, 358, 262, 264, 265, 266, 267, 263, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 14, 288, 15, 94, 297, 181, 16, 95, 289, 309, 298, 30, 251, 290, 304, 47, 50, 23, 46, 24, 49, 57, 132, 65, 20, 207, 70, 71, 164, 169, 165, 170, 138, 139, 80, 81, 136, 275, 318, 276, 277, 323, 324, 9, 294, 295, 83, 10, 292, 85, 296, 38, 293, 357, 39, 287, 40, 92, 93, 143, 118, 148, 36, 348, -1, 235, 141, 202, 345, 346, 21, 21, 52, 1000013, 203, 135, 135, 135, 99, 100, 86, 128, 353, 129, 268, 130, 103, 122, 116, 62, 51, 77, 76, 75, 325, 110, 101, 274, 274, 273, 273, 350, 209, 210, 211, 212, 213, 214, 215, 216, 217, 218, 219, 220, 13, 246, 245, 347, 247, 248, 240, 242, 243, 241, 244, 173, 174, 172, 175, 176, 178, 177, 322, 185, 250, 208, 29, 27, 162, 105, 104, 1, 234, 272, 114, 43, -1, -1, 123, 123, 123, 123, 351, 352, 232, 96, 97, 157, 156, 161, 155, 154, 223, 222, 356, 355, 158, 359, 365, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 1000001, 326, 333, 330, 344, 327, 329, 1000011, 1000011, 328, 338, 335, 341, 349, 337, 342, 343, 331, 332, 340, 339, 54, 54, 339, 340, 339, 339, 340, 339, 340, 339, 340, 339, 339, 339, 340, 339, 340, 340, 339, 340, 339, 340, 339, 340, 339, 340, 340, 339, 340, 339, 340, 339, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 326, 333, 327, 1000011, 328, 330, 344, 335, 341, 349, 337, 331, 332, 326, 327, 339, 339, 330, 341, 349, 326, 327, 328, 330, 339, 339, 340, 341, 349, 326, 328, 326, 327, 54, 339, 339, 339, 340, 326, 327, 328, 340, 340, 326, 327, 328, 339, 340, 339, 340, 339, 340, 326, 327, 328, 339, 340, 340, 326, 54, 54, 54, 54, 326, 54, 54, 54, 54, 326, 54, 54, 54, 54, 54, 54, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 339, 340, 5, 1000002, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 319, 54, 54, 54, 54, 54, 54, 54, 54, 54, 269, 270, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 271, 361, 361, 361, 361, 361, 361, 361, 361, 361, 361, 1000003, 1000004, 54, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 1000001, 1000001, 1000001, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 5, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 326, 326, 333, 327, 328, 330, 344, 335, 341, 349, 337, 331, 332, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 339, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 340, 54, 1000001, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000009, 5, 1000001, 4, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000001, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000001, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000001, 1000001, 1000001, 4, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 326, 327, 328, 331, 332, 341, 339, 339, 339, 339, 339, 339, 339, 339, 339, 340, 326, 341, 326, 1000010, 1000010, 1000010, 1000010, 1000010, 1000010, 1000010, 1000010, 1000010, 341, 1000001, 4, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 1000001, 1000001, 54, 54, 54, 54, 54, 326, 339, 340, 341, 342, 54, 54, 54, 326, 327, 328, 330, 329, 341, 342, 331, 332, 339, 339, 339, 339, 339, 340, 340, 340, 340, 340, 54, 54, 54, 54, 54, 1000005, 1000005, 1000005, 1000005, 1000005, 1000005, 1000005, 1000005, 1000006, 1000007, 1000008}
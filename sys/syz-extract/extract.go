@@ -0,0 +1,160 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-extract extracts values of constants used in syscall descriptions (flags, defines,
+// syscall numbers) from kernel headers and saves them into per-arch sys/<name>_<arch>.const files,
+// which are then consumed by syz-sysgen.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/syzkaller/sysparser"
+)
+
+var (
+	flagLinux    = flag.String("linux", "", "path to linux kernel source checkout")
+	flagLinuxBld = flag.String("linuxbld", "", "path to linux kernel build directory")
+	flagArch     = flag.String("arch", "", "comma-separated list of arches to extract (all by default)")
+	flagV        = flag.Int("v", 0, "verbosity")
+)
+
+type Arch struct {
+	KernelHeaderArch string
+	KernelInclude    string
+	CC               string
+	CFlags           []string
+}
+
+var archs = map[string]*Arch{
+	"amd64":   {"x86", "asm/unistd.h", "gcc", []string{"-m64"}},
+	"386":     {"x86", "asm/unistd.h", "gcc", []string{"-m32", "-D__SYSCALL_COMPAT", "-DCONFIG_COMPAT", "-DCONFIG_X86_32"}},
+	"arm64":   {"arm64", "asm/unistd.h", "aarch64-linux-gnu-gcc", []string{}},
+	"ppc64le": {"powerpc", "asm/unistd.h", "powerpc64le-linux-gnu-gcc", []string{}},
+}
+
+func main() {
+	flag.Parse()
+	if *flagLinux == "" {
+		failf("provide path to linux kernel checkout via -linux flag (or make extract LINUX= flag)")
+	}
+	if *flagLinuxBld == "" {
+		logf(1, "No kernel build directory provided, assuming in-place build")
+		flagLinuxBld = flagLinux
+	}
+	if len(flag.Args()) == 0 {
+		failf("usage: syz-extract -linux=linux_checkout input_file...")
+	}
+	var goarchs []string
+	if *flagArch != "" {
+		goarchs = strings.Split(*flagArch, ",")
+	} else {
+		for goarch := range archs {
+			goarchs = append(goarchs, goarch)
+		}
+		sort.Strings(goarchs)
+	}
+	for _, goarch := range goarchs {
+		arch := archs[goarch]
+		if arch == nil {
+			failf("unknown arch %v", goarch)
+		}
+		for _, f := range flag.Args() {
+			logf(1, "Extract consts for %v from %v", goarch, f)
+			processFile(goarch, arch, f)
+		}
+	}
+}
+
+func processFile(goarch string, arch *Arch, file string) {
+	inf, err := os.Open(file)
+	if err != nil {
+		failf("failed to open input file: %v", err)
+	}
+	defer inf.Close()
+	desc := sysparser.Parse(inf)
+	consts := compileConsts(arch, desc)
+
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "# AUTOGENERATED FILE\n")
+	var names []string
+	for name := range consts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "%v = %v\n", name, consts[name])
+	}
+	outname := strings.TrimSuffix(file, ".txt") + "_" + goarch + ".const"
+	if err := ioutil.WriteFile(outname, out.Bytes(), 0640); err != nil {
+		failf("failed to write output file: %v", err)
+	}
+}
+
+func compileConsts(arch *Arch, desc *sysparser.Description) map[string]uint64 {
+	vals := make(map[string]bool)
+	for _, fvals := range desc.Flags {
+		for _, v := range fvals {
+			if isIdentifier(v) {
+				vals[v] = true
+			}
+		}
+	}
+	defines := make(map[string]string)
+	for k, v := range desc.Defines {
+		vals[k] = true
+		defines[k] = v
+	}
+	// Syscalls that are not present on the arch are not recorded in the const file.
+	for _, sc := range desc.Syscalls {
+		name := "__NR_" + sc.CallName
+		vals[name] = true
+		defines[name] = "-1"
+		if sc.CallName == "mmap" {
+			// mmap is mmap2 on 386 (see syscallAliases in sysgen).
+			vals["__NR_mmap2"] = true
+			defines["__NR_mmap2"] = "-1"
+		}
+	}
+	var valArray []string
+	for v := range vals {
+		valArray = append(valArray, v)
+	}
+	includes := append([]string{arch.KernelInclude}, desc.Includes...)
+	res := make(map[string]uint64)
+	for i, n := range fetchValues(arch, valArray, includes, defines) {
+		if strings.HasPrefix(valArray[i], "__NR_") && n == ^uint64(0) {
+			continue
+		}
+		res[valArray[i]] = n
+	}
+	return res
+}
+
+func isIdentifier(s string) bool {
+	for i, c := range s {
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || i > 0 && (c >= '0' && c <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func failf(msg string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, msg+"\n", args...)
+	os.Exit(1)
+}
+
+func logf(v int, msg string, args ...interface{}) {
+	if *flagV >= v {
+		log.Printf(msg, args...)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2015 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"debug/elf"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// fetchValues converts literal constants (e.g. O_APPEND) or any other C expressions
+// into their respective numeric values. It does so by compiling a C object file
+// with an array initialized with the provided expressions and reading the array
+// from the object file. The object file is not executed, so this works with cross-compilers.
+func fetchValues(arch *Arch, vals []string, includes []string, defines map[string]string) []uint64 {
+	logf(1, "Use C compiler %v to fetch constant values for arch=%v", arch.CC, arch.KernelHeaderArch)
+	includeText := ""
+	for _, inc := range includes {
+		includeText += fmt.Sprintf("#include <%v>\n", inc)
+	}
+	definesText := ""
+	for k, v := range defines {
+		definesText += fmt.Sprintf("#ifndef %v\n#define %v %v\n#endif\n", k, k, v)
+	}
+	src := strings.Replace(fetchSrc, "[[INCLUDES]]", includeText, 1)
+	src = strings.Replace(src, "[[DEFAULTS]]", definesText, 1)
+	src = strings.Replace(src, "[[VALS]]", strings.Join(vals, ","), 1)
+	obj, err := ioutil.TempFile("", "")
+	if err != nil {
+		failf("failed to create temp file: %v", err)
+	}
+	obj.Close()
+	defer os.Remove(obj.Name())
+	logf(2, "  Build C object into temp file %v", obj.Name())
+
+	karch := arch.KernelHeaderArch
+	args := []string{"-x", "c", "-", "-c", "-o", obj.Name()}
+	args = append(args, arch.CFlags...)
+	args = append(args, []string{
+		// This would be useful to ensure that we don't include any host headers,
+		// but kernel includes at least <stdarg.h>
+		// "-nostdinc",
+		"-I.",
+		"-D__KERNEL__",
+		"-DKBUILD_MODNAME=\"-\"",
+		"-I" + *flagLinux + "/arch/" + karch + "/include",
+		"-I" + *flagLinuxBld + "/arch/" + karch + "/include/generated/uapi",
+		"-I" + *flagLinuxBld + "/arch/" + karch + "/include/generated",
+		"-I" + *flagLinuxBld + "/include",
+		"-I" + *flagLinux + "/include",
+		"-I" + *flagLinux + "/arch/" + karch + "/include/uapi",
+		"-I" + *flagLinuxBld + "/arch/" + karch + "/include/generated/uapi",
+		"-I" + *flagLinux + "/include/uapi",
+		"-I" + *flagLinuxBld + "/include/generated/uapi",
+		"-I" + *flagLinux,
+		"-include", *flagLinux + "/include/linux/kconfig.h",
+	}...)
+
+	logf(4, "  Source code:\n%v", src)
+	logf(2, "  Execute %v with: %v", arch.CC, args)
+	cmd := exec.Command(arch.CC, args...)
+	cmd.Stdin = strings.NewReader(src)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		failf("failed to run %v: %v\n%v", arch.CC, err, string(out))
+	}
+
+	res, err := readValues(obj.Name(), len(vals))
+	if err != nil {
+		failf("failed to read values from object file: %v", err)
+	}
+	return res
+}
+
+// readValues reads contents of the vals array from the object file
+// using byte order of the target arch.
+func readValues(file string, n int) ([]uint64, error) {
+	f, err := elf.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	syms, err := f.Symbols()
+	if err != nil {
+		return nil, err
+	}
+	for _, sym := range syms {
+		if sym.Name != "vals" {
+			continue
+		}
+		if sym.Size != uint64(n)*8 {
+			return nil, fmt.Errorf("vals has size %v, want %v", sym.Size, n*8)
+		}
+		if int(sym.Section) >= len(f.Sections) {
+			return nil, fmt.Errorf("vals is in bad section %v", sym.Section)
+		}
+		data, err := f.Sections[sym.Section].Data()
+		if err != nil {
+			return nil, err
+		}
+		off := sym.Value - f.Sections[sym.Section].Addr
+		if off+sym.Size > uint64(len(data)) {
+			return nil, fmt.Errorf("vals is out of section bounds")
+		}
+		res := make([]uint64, n)
+		for i := range res {
+			res[i] = f.ByteOrder.Uint64(data[off+uint64(i)*8:])
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("no vals symbol")
+}
+
+var fetchSrc = `
+[[INCLUDES]]
+[[DEFAULTS]]
+unsigned long long vals[] = {[[VALS]]};
+`
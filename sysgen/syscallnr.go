@@ -6,63 +6,74 @@ package main
 import (
 	"bytes"
 	"sort"
-	"strconv"
 	"text/template"
+
+	"github.com/google/syzkaller/sysparser"
 )
 
 type Arch struct {
-	GOARCH           string
-	CARCH            []string
-	KernelHeaderArch string
-	KernelInclude    string
-	CFlags           []string
-	Numbers          []int
+	GOARCH  string
+	CARCH   []string
+	Numbers []int
 }
 
 var archs = []*Arch{
-	{"amd64", []string{"__x86_64__"}, "x86", "asm/unistd.h", []string{"-m64"}, nil},
-	{"386", []string{"__i386__"}, "x86", "asm/unistd.h", []string{"-D__SYSCALL_COMPAT", "-DCONFIG_COMPAT", "-DCONFIG_X86_32"}, nil},
-	{"arm64", []string{"__aarch64__"}, "arm64", "asm/unistd.h", []string{}, nil},
-	{"ppc64le", []string{"__ppc64__", "__PPC64__", "__powerpc64__"}, "powerpc", "asm/unistd.h", []string{}, nil},
+	{"amd64", []string{"__x86_64__"}, nil},
+	{"386", []string{"__i386__"}, nil},
+	{"arm64", []string{"__aarch64__"}, nil},
+	{"ppc64le", []string{"__ppc64__", "__PPC64__", "__powerpc64__"}, nil},
 }
 
 var syzkalls = map[string]int{
-	"syz_open_dev":      1000001,
-	"syz_open_pts":      1000002,
-	"syz_fuse_mount":    1000003,
-	"syz_fuseblk_mount": 1000004,
+	"syz_open_dev":                1000001,
+	"syz_open_pts":                1000002,
+	"syz_fuse_mount":              1000003,
+	"syz_fuseblk_mount":           1000004,
+	"syz_mount_image":             1000005,
+	"syz_usb_connect":             1000006,
+	"syz_usb_control_io":          1000007,
+	"syz_usb_ep_write":            1000008,
+	"syz_kvm_setup_cpu":           1000009,
+	"syz_genetlink_get_family_id": 1000010,
+	"syz_socket_bind":             1000011,
+	"syz_memfd_data":              1000012,
+	"syz_mount_tmpfs":             1000013,
 }
 
-func generateSyscallsNumbers(syscalls []Syscall) {
+func generateSyscallsNumbers(syscalls []sysparser.Syscall) {
 	for _, arch := range archs {
-		fetchSyscallsNumbers(arch, syscalls)
 		generateSyscallsNumbersArch(arch, syscalls)
 	}
 	generateExecutorSyscalls(syscalls)
 }
 
-func fetchSyscallsNumbers(arch *Arch, syscalls []Syscall) {
-	includes := []string{arch.KernelInclude}
-	var vals []string
-	defines := make(map[string]string)
+// syscallAliases maps calls onto the syscalls that implement them on the arch.
+// On 386 __NR_mmap is old_mmap that takes a pointer to a struct with the arguments,
+// the 6-argument mmap is mmap2 (the offset is passed in pages, see executor and csource).
+var syscallAliases = map[string]map[string]string{
+	"386": {"mmap": "mmap2"},
+}
+
+// fetchSyscallsNumbers looks up syscall numbers in the arch consts,
+// syscalls that are not present on the arch get -1.
+func fetchSyscallsNumbers(arch *Arch, syscalls []sysparser.Syscall, consts map[string]uint64) {
 	for _, sc := range syscalls {
-		name := "__NR_" + sc.CallName
-		vals = append(vals, name)
-		defines[name] = "-1"
-		if nr := syzkalls[sc.CallName]; nr != 0 {
-			defines[name] = strconv.Itoa(nr)
+		name := sc.CallName
+		if alias := syscallAliases[arch.GOARCH][name]; alias != "" {
+			name = alias
 		}
-	}
-	for _, s := range fetchValues(arch.KernelHeaderArch, vals, includes, defines, arch.CFlags) {
-		n, err := strconv.ParseUint(s, 10, 64)
-		if err != nil {
-			failf("failed to parse syscall number '%v': %v", s, err)
+		nr := -1
+		if v, ok := consts["__NR_"+name]; ok {
+			nr = int(v)
+		}
+		if v := syzkalls[sc.CallName]; v != 0 {
+			nr = v
 		}
-		arch.Numbers = append(arch.Numbers, int(n))
+		arch.Numbers = append(arch.Numbers, nr)
 	}
 }
 
-func generateSyscallsNumbersArch(arch *Arch, syscalls []Syscall) {
+func generateSyscallsNumbersArch(arch *Arch, syscalls []sysparser.Syscall) {
 	var archcode string = "sys/sys_" + arch.GOARCH + ".go"
 	logf(1, "Generate code with syscall numbers for arch=%v in %v", arch.GOARCH, archcode)
 	buf := new(bytes.Buffer)
@@ -72,7 +83,7 @@ func generateSyscallsNumbersArch(arch *Arch, syscalls []Syscall) {
 	writeSource(archcode, buf.Bytes())
 }
 
-func generateExecutorSyscalls(syscalls []Syscall) {
+func generateExecutorSyscalls(syscalls []sysparser.Syscall) {
 	var data SyscallsData
 	for _, arch := range archs {
 		var calls []SyscallData
@@ -0,0 +1,31 @@
+// Copyright 2017 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/sysparser"
+)
+
+func TestMmapNumbers(t *testing.T) {
+	// On 386 the 6-argument mmap is mmap2, __NR_mmap is old_mmap.
+	want := map[string]int{
+		"amd64": 9,
+		"386":   192,
+	}
+	syscalls := []sysparser.Syscall{{Name: "mmap", CallName: "mmap"}}
+	for _, arch := range archs {
+		nr, ok := want[arch.GOARCH]
+		if !ok {
+			continue
+		}
+		a := &Arch{GOARCH: arch.GOARCH}
+		consts, _ := readConsts(arch.GOARCH, []string{"../sys/sys.txt"})
+		fetchSyscallsNumbers(a, syscalls, consts)
+		if len(a.Numbers) != 1 || a.Numbers[0] != nr {
+			t.Errorf("%v: got mmap numbers %v, want %v", arch.GOARCH, a.Numbers, nr)
+		}
+	}
+}
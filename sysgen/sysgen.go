@@ -6,34 +6,30 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"go/format"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/google/syzkaller/sysparser"
 )
 
 var (
-	flagLinux    = flag.String("linux", "", "path to linux kernel source checkout")
-	flagLinuxBld = flag.String("linuxbld", "", "path to linux kernel build directory")
-	flagV        = flag.Int("v", 0, "verbosity")
+	flagV = flag.Int("v", 0, "verbosity")
 )
 
 func main() {
 	flag.Parse()
-	if *flagLinux == "" {
-		failf("provide path to linux kernel checkout via -linux flag (or make generate LINUX= flag)")
-	}
-	if *flagLinuxBld == "" {
-		logf(1, "No kernel build directory provided, assuming in-place build")
-		flagLinuxBld = flagLinux
-	}
 	if len(flag.Args()) == 0 {
-		failf("usage: sysgen -linux=linux_checkout input_file")
+		failf("usage: sysgen input_file...")
 	}
 
 	var r io.Reader
@@ -52,16 +48,50 @@ func main() {
 	}
 
 	logf(1, "Parse system call descriptions")
-	includes, defines, syscalls, structs, unnamed, flags := parse(r)
+	desc := sysparser.Parse(r)
+	syscalls, structs, unnamed := desc.Syscalls, desc.Structs, desc.Unnamed
+	logf(1, "Load const files")
+	consts := make(map[string]map[string]uint64)
+	// Const values are used only for archs they are extracted for,
+	// other archs have only syscall numbers and don't get const tables.
+	extracted := make(map[string]map[string]uint64)
+	for _, arch := range archs {
+		archConsts, ok := readConsts(arch.GOARCH, flag.Args())
+		consts[arch.GOARCH] = archConsts
+		if ok {
+			extracted[arch.GOARCH] = archConsts
+		} else {
+			logf(0, "WARNING: const values are not extracted for arch=%v (run make extract), builds for it will fail", arch.GOARCH)
+		}
+	}
+	if len(extracted) == 0 {
+		failf("const values are not extracted for any arch (run make extract)")
+	}
 	logf(1, "Build flag definitions")
-	intFlags, flagVals := compileFlags(includes, defines, flags)
+	intFlags, flagVals := compileFlags(extracted, desc.Defines, desc.Flags)
+	for _, arch := range archs {
+		fetchSyscallsNumbers(arch, syscalls, consts[arch.GOARCH])
+	}
 
 	var initcode string = "sys/sys.go"
 	logf(1, "Generate code to init system call data in %v", initcode)
 	out := new(bytes.Buffer)
 	generate(syscalls, structs, unnamed, intFlags, flagVals, out)
+	generateRevision(out, extracted, flagVals)
 	writeSource(initcode, out.Bytes())
 
+	for _, arch := range archs {
+		var constcode string = "sys/consts_" + arch.GOARCH + ".go"
+		logf(1, "Generate code for constant values for arch=%v in %v", arch.GOARCH, constcode)
+		out = new(bytes.Buffer)
+		if extracted[arch.GOARCH] != nil {
+			generateArchConsts(arch.GOARCH, consts[arch.GOARCH], flagVals, out)
+		} else {
+			generateNotExtractedConsts(arch.GOARCH, out)
+		}
+		writeSource(constcode, out.Bytes())
+	}
+
 	var constcode string = "prog/consts.go"
 	logf(1, "Generate code for constant values in %v", constcode)
 	out = new(bytes.Buffer)
@@ -71,23 +101,24 @@ func main() {
 	generateSyscallsNumbers(syscalls)
 }
 
-type Syscall struct {
-	Name     string
-	CallName string
-	Args     [][]string
-	Ret      []string
-}
-
-type Struct struct {
-	Name    string
-	Flds    [][]string
-	IsUnion bool
-	Packed  bool
-	Varlen  bool
-	Align   int
+// generateRevision appends descriptions revision to the generated code.
+// The revision covers everything that affects program format: calls and types
+// (the code generated so far), syscall numbers on all archs and const values on extracted archs.
+func generateRevision(out *bytes.Buffer, consts map[string]map[string]uint64, ids map[string]string) {
+	hash := sha1.New()
+	hash.Write(out.Bytes())
+	names := sortedNames(ids)
+	for _, arch := range archs {
+		fmt.Fprintf(hash, "%v: %v\n", arch.GOARCH, arch.Numbers)
+		for _, name := range names {
+			fmt.Fprintf(hash, "%v=%v\n", name, consts[arch.GOARCH][name])
+		}
+	}
+	fmt.Fprintf(out, "\n// Revision identifies the descriptions, fuzzer and manager must use the same revision.\n")
+	fmt.Fprintf(out, "const Revision = %q\n", hex.EncodeToString(hash.Sum(nil)))
 }
 
-func generate(syscalls []Syscall, structs map[string]Struct, unnamed map[string][]string, flags map[string][]string, flagVals map[string]string, out io.Writer) {
+func generate(syscalls []sysparser.Syscall, structs map[string]sysparser.Struct, unnamed map[string][]string, flags map[string][]string, flagVals map[string]string, out io.Writer) {
 	fmt.Fprintf(out, "// AUTOGENERATED FILE\n")
 	fmt.Fprintf(out, "package sys\n\n")
 
@@ -113,7 +144,7 @@ func generate(syscalls []Syscall, structs map[string]Struct, unnamed map[string]
 	fmt.Fprintf(out, "}\n")
 }
 
-func generateArg(name, typ string, a []string, structs map[string]Struct, unnamed map[string][]string, flags map[string][]string, flagVals map[string]string, isField bool, out io.Writer) {
+func generateArg(name, typ string, a []string, structs map[string]sysparser.Struct, unnamed map[string][]string, flags map[string][]string, flagVals map[string]string, isField bool, out io.Writer) {
 	name = "\"" + name + "\""
 	opt := false
 	for i, v := range a {
@@ -181,8 +212,13 @@ func generateArg(name, typ string, a []string, structs map[string]Struct, unname
 			failf("wrong number of arguments for %v arg %v want %v, got %v", typ, name, want, len(a))
 		}
 		fmt.Fprintf(out, "ResourceType{%v, Kind: ResDrmCtx}", common())
+	case "genl_family":
+		if want := 0; len(a) != want {
+			failf("wrong number of arguments for %v arg %v want %v, got %v", typ, name, want, len(a))
+		}
+		fmt.Fprintf(out, "ResourceType{%v, Kind: ResGenlFamily}", common())
 	case "fileoff":
-		var size uint64
+		size := "0"
 		if isField {
 			if want := 2; len(a) != want {
 				failf("wrong number of arguments for %v arg %v, want %v, got %v", typ, name, want, len(a))
@@ -236,7 +272,7 @@ func generateArg(name, typ string, a []string, structs map[string]Struct, unname
 		}
 		fmt.Fprintf(out, "VmaType{%v}", common())
 	case "len", "bytesize":
-		var size uint64
+		size := "0"
 		if isField {
 			if want := 2; len(a) != want {
 				failf("wrong number of arguments for %v arg %v, want %v, got %v", typ, name, want, len(a))
@@ -249,7 +285,7 @@ func generateArg(name, typ string, a []string, structs map[string]Struct, unname
 		}
 		fmt.Fprintf(out, "LenType{%v, Buf: \"%v\", TypeSize: %v, ByteSize: %v}", common(), a[0], size, typ == "bytesize")
 	case "flags":
-		var size uint64
+		size := "0"
 		if isField {
 			if want := 2; len(a) != want {
 				failf("wrong number of arguments for %v arg %v, want %v, got %v", typ, name, want, len(a))
@@ -264,9 +300,13 @@ func generateArg(name, typ string, a []string, structs map[string]Struct, unname
 		if len(vals) == 0 {
 			failf("unknown flag %v", a[0])
 		}
-		fmt.Fprintf(out, "FlagsType{%v, TypeSize: %v, Vals: []uintptr{%v}}", common(), size, strings.Join(vals, ","))
+		var uvals []string
+		for _, v := range vals {
+			uvals = append(uvals, fmtUintptr(v, false))
+		}
+		fmt.Fprintf(out, "FlagsType{%v, TypeSize: %v, Vals: []uintptr{%v}}", common(), size, strings.Join(uvals, ","))
 	case "const":
-		var size uint64
+		size := "0"
 		if isField {
 			if want := 2; len(a) != want {
 				failf("wrong number of arguments for %v arg %v, want %v, got %v", typ, name, want, len(a))
@@ -281,7 +321,7 @@ func generateArg(name, typ string, a []string, structs map[string]Struct, unname
 		if val == "" {
 			val = a[0]
 		}
-		fmt.Fprintf(out, "ConstType{%v, TypeSize: %v, Val: uintptr(%v)}", common(), size, val)
+		fmt.Fprintf(out, "ConstType{%v, TypeSize: %v, Val: %v}", common(), size, fmtUintptr(val, true))
 	case "strconst":
 		if want := 1; len(a) != want {
 			failf("wrong number of arguments for %v arg %v, want %v, got %v", typ, name, want, len(a))
@@ -387,7 +427,7 @@ func generateArg(name, typ string, a []string, structs map[string]Struct, unname
 	}
 }
 
-func generateType(typ string, structs map[string]Struct, unnamed map[string][]string, flags map[string][]string, flagVals map[string]string) string {
+func generateType(typ string, structs map[string]sysparser.Struct, unnamed map[string][]string, flags map[string][]string, flagVals map[string]string) string {
 	buf := new(bytes.Buffer)
 	generateArg("", typ, nil, structs, unnamed, flags, flagVals, true, buf)
 	return buf.String()
@@ -485,6 +525,8 @@ func fmtFdKind(s string) string {
 		return "FdKcm"
 	case "netrom":
 		return "FdNetRom"
+	case "usb":
+		return "FdUsb"
 	default:
 		failf("bad fd type %v", s)
 		return ""
@@ -519,17 +561,35 @@ func fmtDir(s string) string {
 	}
 }
 
-func typeToSize(typ string) uint64 {
+// fmtUintptr returns Go expression for uintptr value v (conversion is optional for untyped contexts).
+// Values that don't fit into 32 bits are truncated at runtime on 32-bit targets
+// (constant conversion would not compile there).
+func fmtUintptr(v string, conv bool) string {
+	if strings.HasPrefix(v, "u64(") {
+		return v
+	}
+	if n, err := strconv.ParseUint(v, 0, 64); err == nil && n > 1<<32-1 {
+		return fmt.Sprintf("u64(%v)", v)
+	}
+	if !conv {
+		return v
+	}
+	return fmt.Sprintf("uintptr(%v)", v)
+}
+
+// typeToSize returns Go expression for size of the type in bytes.
+// Size of intptr depends on the target arch, so it refers to sys.ptrSize.
+func typeToSize(typ string) string {
 	switch typ {
-	case "int8", "int16", "int32", "int64", "intptr":
+	case "int8", "int16", "int32", "int64":
+		sz, _ := strconv.ParseInt(typ[3:], 10, 64)
+		return strconv.FormatInt(sz/8, 10)
+	case "intptr":
+		return "ptrSize"
 	default:
 		failf("unknown type %v", typ)
+		return ""
 	}
-	sz := int64(64) // TODO: assume that pointer is 8 bytes for now
-	if typ != "intptr" {
-		sz, _ = strconv.ParseInt(typ[3:], 10, 64)
-	}
-	return uint64(sz / 8)
 }
 
 type F struct {
@@ -548,24 +608,97 @@ type SortedSyscall struct {
 	nr   int
 }
 
-func generateConsts(flags map[string]string, out io.Writer) {
+// generateArchConsts generates values of all identifiers used in descriptions for the arch.
+// The generated code refers to the identifiers, so the values differ between archs.
+func generateArchConsts(arch string, consts map[string]uint64, ids map[string]string, out io.Writer) {
 	var ff []F
-	for k, v := range flags {
-		ff = append(ff, F{k, v})
+	for k := range ids {
+		ff = append(ff, F{k, strconv.FormatUint(consts[k], 10)})
 	}
 	sort.Sort(FlagArray(ff))
 
 	fmt.Fprintf(out, "// AUTOGENERATED FILE\n")
-	fmt.Fprintf(out, "package prog\n\n")
+	fmt.Fprintf(out, "package sys\n\n")
 	fmt.Fprintf(out, "const (\n")
 	for _, f := range ff {
 		fmt.Fprintf(out, "	%v = %v\n", f.name, f.val)
 	}
 	fmt.Fprintf(out, ")\n")
-	fmt.Fprintf(out, "\n")
 }
 
-func compileFlags(includes []string, defines map[string]string, flags map[string][]string) (map[string][]string, map[string]string) {
+// generateNotExtractedConsts generates the const table for an arch with not extracted const values.
+// The table refers to an undefined name, so that builds for the arch fail with a clear error
+// instead of using wrong values.
+func generateNotExtractedConsts(arch string, out io.Writer) {
+	fmt.Fprintf(out, "// AUTOGENERATED FILE\n")
+	fmt.Fprintf(out, "package sys\n\n")
+	fmt.Fprintf(out, "// Const values are not extracted for %v, run make extract for it.\n", arch)
+	fmt.Fprintf(out, "var _ = constsAreNotExtractedForThisArch\n")
+}
+
+// generateConsts exports the arch-specific constants to prog
+// (unexported identifiers can't be referenced from there).
+func generateConsts(ids map[string]string, out io.Writer) {
+	fmt.Fprintf(out, "// AUTOGENERATED FILE\n")
+	fmt.Fprintf(out, "package prog\n\n")
+	fmt.Fprintf(out, "import \"github.com/google/syzkaller/sys\"\n\n")
+	fmt.Fprintf(out, "const (\n")
+	for _, name := range sortedNames(ids) {
+		if name[0] >= 'A' && name[0] <= 'Z' {
+			fmt.Fprintf(out, "	%v = sys.%v\n", name, name)
+		}
+	}
+	fmt.Fprintf(out, ")\n")
+}
+
+func sortedNames(ids map[string]string) []string {
+	var names []string
+	for name := range ids {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readConsts reads sys/<name>_<arch>.const files (produced by syz-extract) for all description files.
+// It returns false if some of the files are marked as not extracted (they contain only syscall numbers).
+func readConsts(arch string, files []string) (map[string]uint64, bool) {
+	consts := make(map[string]uint64)
+	extracted := true
+	for _, f := range files {
+		constFile := strings.TrimSuffix(f, ".txt") + "_" + arch + ".const"
+		logf(2, "  Load consts from %v", constFile)
+		data, err := ioutil.ReadFile(constFile)
+		if err != nil {
+			failf("failed to read const file (run make extract): %v", err)
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "# NOT EXTRACTED") {
+				logf(2, "  %v: %v", constFile, line)
+				extracted = false
+			}
+			if line == "" || line[0] == '#' {
+				continue
+			}
+			eq := strings.IndexByte(line, '=')
+			if eq == -1 {
+				failf("%v:%v: bad line: %v", constFile, i+1, line)
+			}
+			name := strings.TrimSpace(line[:eq])
+			val, err := strconv.ParseUint(strings.TrimSpace(line[eq+1:]), 0, 64)
+			if err != nil {
+				failf("%v:%v: bad value: %v", constFile, i+1, line)
+			}
+			consts[name] = val
+		}
+	}
+	return consts, extracted
+}
+
+// compileFlags returns Go expressions for values of flags and for identifiers used in flags and defines.
+// Identifiers are referred to by name (see generateArchConsts), so they must be defined on all archs in consts.
+func compileFlags(consts map[string]map[string]uint64, defines map[string]string, flags map[string][]string) (map[string][]string, map[string]string) {
 	vals := make(map[string]string)
 	for _, fvals := range flags {
 		for _, v := range fvals {
@@ -575,14 +708,31 @@ func compileFlags(includes []string, defines map[string]string, flags map[string
 	for k := range defines {
 		vals[k] = ""
 	}
-	valArray := make([]string, 0, len(vals))
 	for k := range vals {
-		valArray = append(valArray, k)
-	}
-	// TODO: should use target arch
-	flagVals := fetchValues("x86", valArray, includes, defines, []string{})
-	for i, f := range valArray {
-		vals[f] = flagVals[i]
+		if isIdentifier(k) {
+			wide := false
+			for arch, archConsts := range consts {
+				val, ok := archConsts[k]
+				if !ok {
+					failf("const %v is not defined in %v const files (run make extract)", k, arch)
+				}
+				if val > 1<<32-1 {
+					wide = true
+				}
+			}
+			vals[k] = k
+			if wide {
+				vals[k] = fmt.Sprintf("u64(%v)", k)
+			}
+			continue
+		}
+		if val, err := strconv.ParseUint(k, 0, 64); err == nil {
+			vals[k] = strconv.FormatUint(val, 10)
+		} else if val, err := strconv.ParseInt(k, 0, 64); err == nil {
+			vals[k] = strconv.FormatUint(uint64(val), 10)
+		} else {
+			failf("bad const value %v", k)
+		}
 	}
 	res := make(map[string][]string)
 	for fname, fvals := range flags {
@@ -614,182 +764,6 @@ func isIdentifier(s string) bool {
 	return true
 }
 
-func parse(in io.Reader) (includes []string, defines map[string]string, syscalls []Syscall, structs map[string]Struct, unnamed map[string][]string, flags map[string][]string) {
-	p := NewParser(in)
-	defines = make(map[string]string)
-	structs = make(map[string]Struct)
-	unnamed = make(map[string][]string)
-	flags = make(map[string][]string)
-	var str *Struct
-	for p.Scan() {
-		if p.EOF() || p.Char() == '#' {
-			continue
-		}
-		if str != nil {
-			// Parsing a struct.
-			if p.Char() == '}' || p.Char() == ']' {
-				p.Parse(p.Char())
-				for _, attr := range parseType1(p, unnamed, flags, "")[1:] {
-					if str.IsUnion {
-						switch attr {
-						case "varlen":
-							str.Varlen = true
-						default:
-							failf("unknown union %v attribute: %v", str.Name, attr)
-						}
-					} else {
-						switch attr {
-						case "packed":
-							str.Packed = true
-						case "align_1":
-							str.Align = 1
-						case "align_2":
-							str.Align = 2
-						case "align_4":
-							str.Align = 4
-						case "align_8":
-							str.Align = 8
-						default:
-							failf("unknown struct %v attribute: %v", str.Name, attr)
-						}
-					}
-				}
-				logf(2, "  Add struct %v", str.Name)
-				structs[str.Name] = *str
-				str = nil
-			} else {
-				p.SkipWs()
-				fld := []string{p.Ident()}
-				logf(3, "    Add field %v to struct %v", fld, str.Name)
-				fld = append(fld, parseType(p, unnamed, flags)...)
-				str.Flds = append(str.Flds, fld)
-			}
-		} else {
-			name := p.Ident()
-			if name == "include" {
-				p.Parse('<')
-				var include []byte
-				for {
-					ch := p.Char()
-					if ch == '>' {
-						break
-					}
-					p.Parse(ch)
-					include = append(include, ch)
-				}
-				p.Parse('>')
-				logf(2, "  Add #include file %v", string(include))
-				includes = append(includes, string(include))
-			} else if name == "define" {
-				key := p.Ident()
-				var val []byte
-				for !p.EOF() {
-					ch := p.Char()
-					p.Parse(ch)
-					val = append(val, ch)
-				}
-				if defines[key] != "" {
-					failf("%v define is defined multiple times", key)
-				}
-				logf(2, "  Add #define %v %v", key, val)
-				defines[key] = fmt.Sprintf("(%s)", val)
-			} else {
-				switch ch := p.Char(); ch {
-				case '(':
-					// syscall
-					p.Parse('(')
-					var args [][]string
-					for p.Char() != ')' {
-						arg := []string{p.Ident()}
-						arg = append(arg, parseType(p, unnamed, flags)...)
-						args = append(args, arg)
-						if p.Char() != ')' {
-							p.Parse(',')
-						}
-					}
-					p.Parse(')')
-					var ret []string
-					if !p.EOF() {
-						ret = parseType(p, unnamed, flags)
-					}
-					callName := name
-					if idx := strings.IndexByte(callName, '$'); idx != -1 {
-						callName = callName[:idx]
-					}
-					logf(2, "  Add system call %v", name)
-					syscalls = append(syscalls, Syscall{name, callName, args, ret})
-				case '=':
-					// flag
-					p.Parse('=')
-					vals := []string{p.Ident()}
-					for !p.EOF() {
-						p.Parse(',')
-						vals = append(vals, p.Ident())
-					}
-					logf(2, "  Add flag %v", name)
-					flags[name] = vals
-				case '{', '[':
-					p.Parse(ch)
-					if _, ok := structs[name]; ok {
-						failf("%v struct is defined multiple times", name)
-					}
-					str = &Struct{Name: name, IsUnion: ch == '['}
-				default:
-					failf("bad line (%v)", p.Str())
-				}
-			}
-		}
-		if !p.EOF() {
-			failf("trailing data (%v)", p.Str())
-		}
-	}
-	return
-}
-
-func parseType(p *Parser, unnamed map[string][]string, flags map[string][]string) []string {
-	return parseType1(p, unnamed, flags, p.Ident())
-}
-
-var (
-	unnamedSeq int
-	constSeq   int
-)
-
-func parseType1(p *Parser, unnamed map[string][]string, flags map[string][]string, name string) []string {
-	typ := []string{name}
-	if !p.EOF() && p.Char() == '[' {
-		p.Parse('[')
-		for {
-			id := p.Ident()
-			if p.Char() == '[' {
-				inner := parseType1(p, unnamed, flags, id)
-				id = fmt.Sprintf("unnamed%v", unnamedSeq)
-				unnamedSeq++
-				unnamed[id] = inner
-			}
-			typ = append(typ, id)
-			if p.Char() == ']' {
-				break
-			}
-			p.Parse(',')
-		}
-		p.Parse(']')
-	}
-	if name == "const" && len(typ) > 1 {
-		// Create a fake flag with the const value.
-		id := fmt.Sprintf("const_flag_%v", constSeq)
-		constSeq++
-		flags[id] = typ[1:2]
-	}
-	if name == "array" && len(typ) > 2 {
-		// Create a fake flag with the const value.
-		id := fmt.Sprintf("const_flag_%v", constSeq)
-		constSeq++
-		flags[id] = typ[2:3]
-	}
-	return typ
-}
-
 func writeSource(file string, data []byte) {
 	src, err := format.Source(data)
 	if err != nil {
@@ -0,0 +1,227 @@
+// Copyright 2015/2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package sysparser parses syscall description files (sys/*.txt).
+// It is shared between syz-sysgen that generates syscall tables from descriptions
+// and syz-extract that extracts values of constants used in descriptions from kernel headers.
+package sysparser
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Description is the result of parsing of a set of description files.
+type Description struct {
+	Includes []string          // header files for const extraction
+	Defines  map[string]string // const name -> C expression
+	Syscalls []Syscall
+	Structs  map[string]Struct
+	Unnamed  map[string][]string // names for nested inline types (e.g. ptr[in, array[int8]])
+	Flags    map[string][]string // flag name -> values (names of consts or numbers)
+}
+
+func Parse(in io.Reader) *Description {
+	includes, defines, syscalls, structs, unnamed, flags := parse(in)
+	return &Description{
+		Includes: includes,
+		Defines:  defines,
+		Syscalls: syscalls,
+		Structs:  structs,
+		Unnamed:  unnamed,
+		Flags:    flags,
+	}
+}
+
+type Syscall struct {
+	Name     string
+	CallName string
+	Args     [][]string
+	Ret      []string
+}
+
+type Struct struct {
+	Name    string
+	Flds    [][]string
+	IsUnion bool
+	Packed  bool
+	Varlen  bool
+	Align   int
+}
+
+func parse(in io.Reader) (includes []string, defines map[string]string, syscalls []Syscall, structs map[string]Struct, unnamed map[string][]string, flags map[string][]string) {
+	p := NewParser(in)
+	defines = make(map[string]string)
+	structs = make(map[string]Struct)
+	unnamed = make(map[string][]string)
+	flags = make(map[string][]string)
+	var str *Struct
+	for p.Scan() {
+		if p.EOF() || p.Char() == '#' {
+			continue
+		}
+		if str != nil {
+			// Parsing a struct.
+			if p.Char() == '}' || p.Char() == ']' {
+				p.Parse(p.Char())
+				for _, attr := range parseType1(p, unnamed, flags, "")[1:] {
+					if str.IsUnion {
+						switch attr {
+						case "varlen":
+							str.Varlen = true
+						default:
+							failf("unknown union %v attribute: %v", str.Name, attr)
+						}
+					} else {
+						switch attr {
+						case "packed":
+							str.Packed = true
+						case "align_1":
+							str.Align = 1
+						case "align_2":
+							str.Align = 2
+						case "align_4":
+							str.Align = 4
+						case "align_8":
+							str.Align = 8
+						default:
+							failf("unknown struct %v attribute: %v", str.Name, attr)
+						}
+					}
+				}
+				structs[str.Name] = *str
+				str = nil
+			} else {
+				p.SkipWs()
+				fld := []string{p.Ident()}
+				fld = append(fld, parseType(p, unnamed, flags)...)
+				str.Flds = append(str.Flds, fld)
+			}
+		} else {
+			name := p.Ident()
+			if name == "include" {
+				p.Parse('<')
+				var include []byte
+				for {
+					ch := p.Char()
+					if ch == '>' {
+						break
+					}
+					p.Parse(ch)
+					include = append(include, ch)
+				}
+				p.Parse('>')
+				includes = append(includes, string(include))
+			} else if name == "define" {
+				key := p.Ident()
+				var val []byte
+				for !p.EOF() {
+					ch := p.Char()
+					p.Parse(ch)
+					val = append(val, ch)
+				}
+				if defines[key] != "" {
+					failf("%v define is defined multiple times", key)
+				}
+				defines[key] = fmt.Sprintf("(%s)", val)
+			} else {
+				switch ch := p.Char(); ch {
+				case '(':
+					// syscall
+					p.Parse('(')
+					var args [][]string
+					for p.Char() != ')' {
+						arg := []string{p.Ident()}
+						arg = append(arg, parseType(p, unnamed, flags)...)
+						args = append(args, arg)
+						if p.Char() != ')' {
+							p.Parse(',')
+						}
+					}
+					p.Parse(')')
+					var ret []string
+					if !p.EOF() {
+						ret = parseType(p, unnamed, flags)
+					}
+					callName := name
+					if idx := strings.IndexByte(callName, '$'); idx != -1 {
+						callName = callName[:idx]
+					}
+					syscalls = append(syscalls, Syscall{name, callName, args, ret})
+				case '=':
+					// flag
+					p.Parse('=')
+					vals := []string{p.Ident()}
+					for !p.EOF() {
+						p.Parse(',')
+						vals = append(vals, p.Ident())
+					}
+					flags[name] = vals
+				case '{', '[':
+					p.Parse(ch)
+					if _, ok := structs[name]; ok {
+						failf("%v struct is defined multiple times", name)
+					}
+					str = &Struct{Name: name, IsUnion: ch == '['}
+				default:
+					failf("bad line (%v)", p.Str())
+				}
+			}
+		}
+		if !p.EOF() {
+			failf("trailing data (%v)", p.Str())
+		}
+	}
+	return
+}
+
+func parseType(p *Parser, unnamed map[string][]string, flags map[string][]string) []string {
+	return parseType1(p, unnamed, flags, p.Ident())
+}
+
+var (
+	unnamedSeq int
+	constSeq   int
+)
+
+func parseType1(p *Parser, unnamed map[string][]string, flags map[string][]string, name string) []string {
+	typ := []string{name}
+	if !p.EOF() && p.Char() == '[' {
+		p.Parse('[')
+		for {
+			id := p.Ident()
+			if p.Char() == '[' {
+				inner := parseType1(p, unnamed, flags, id)
+				id = fmt.Sprintf("unnamed%v", unnamedSeq)
+				unnamedSeq++
+				unnamed[id] = inner
+			}
+			typ = append(typ, id)
+			if p.Char() == ']' {
+				break
+			}
+			p.Parse(',')
+		}
+		p.Parse(']')
+	}
+	if name == "const" && len(typ) > 1 {
+		// Create a fake flag with the const value.
+		id := fmt.Sprintf("const_flag_%v", constSeq)
+		constSeq++
+		flags[id] = typ[1:2]
+	}
+	if name == "array" && len(typ) > 2 {
+		// Create a fake flag with the const value.
+		id := fmt.Sprintf("const_flag_%v", constSeq)
+		constSeq++
+		flags[id] = typ[2:3]
+	}
+	return typ
+}
+
+func failf(msg string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, msg+"\n", args...)
+	os.Exit(1)
+}
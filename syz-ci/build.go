@@ -0,0 +1,197 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/fileutil"
+)
+
+const (
+	gitTimeout   = 30 * time.Minute
+	buildTimeout = 3 * time.Hour
+)
+
+// run runs the command in dir and returns its combined output.
+func run(dir string, env []string, timeout time.Duration, bin string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return output, fmt.Errorf("'%v %v' failed: %v\n%s", bin, strings.Join(args, " "), err, tail(output))
+	}
+	return output, nil
+}
+
+// tail returns the last lines of command output for error messages.
+func tail(output []byte) []byte {
+	const max = 16 << 10
+	if len(output) > max {
+		output = output[len(output)-max:]
+	}
+	return output
+}
+
+// remoteCommit returns the current commit of branch in repo without fetching it.
+func remoteCommit(repo, branch string) (string, error) {
+	output, err := run("", nil, gitTimeout, "git", "ls-remote", repo, "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("branch %v is not found in %v", branch, repo)
+	}
+	return fields[0], nil
+}
+
+// checkout fetches branch from repo into dir (cloning it if necessary) and checks out commit.
+func checkout(dir, repo, branch, commit string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		os.RemoveAll(dir)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		if _, err := run(dir, nil, gitTimeout, "git", "init"); err != nil {
+			return err
+		}
+	}
+	if _, err := run(dir, nil, gitTimeout, "git", "fetch", "--force", repo, branch); err != nil {
+		return err
+	}
+	_, err := run(dir, nil, gitTimeout, "git", "checkout", "--force", commit)
+	return err
+}
+
+// syzkallerBuild is a snapshot of syzkaller binaries in workdir/syzkaller/commit/bin.
+type syzkallerBuild struct {
+	commit string
+	dir    string
+}
+
+// syzkallerBins are built by syz-ci, they must be enough for the manager with any config.
+var syzkallerBins = []string{"manager", "fuzzer", "executor", "execprog", "repro"}
+
+// pollSyzkaller builds syzkaller if the branch has changed, it returns nil if there is no new build.
+func (ci *CI) pollSyzkaller() *syzkallerBuild {
+	commit, err := remoteCommit(ci.cfg.Syzkaller_Repo, ci.cfg.Syzkaller_Branch)
+	if err != nil {
+		log.Printf("failed to poll syzkaller: %v", err)
+		return nil
+	}
+	if ci.syzkaller != nil && ci.syzkaller.commit == commit {
+		return nil
+	}
+	b := &syzkallerBuild{commit: commit, dir: filepath.Join(ci.cfg.Workdir, "syzkaller", commit)}
+	if _, err := os.Stat(filepath.Join(b.dir, "bin")); err == nil {
+		// Built before a restart of syz-ci.
+		return b
+	}
+	log.Printf("building syzkaller %v", commit)
+	start := time.Now()
+	gopath := filepath.Join(ci.cfg.Workdir, "syzkaller", "gopath")
+	src := filepath.Join(gopath, "src", "github.com", "google", "syzkaller")
+	if err := checkout(src, ci.cfg.Syzkaller_Repo, ci.cfg.Syzkaller_Branch, commit); err != nil {
+		log.Printf("failed to checkout syzkaller: %v", err)
+		return nil
+	}
+	env := []string{"GOPATH=" + gopath}
+	if _, err := run(src, env, buildTimeout, "make", syzkallerBins...); err != nil {
+		log.Printf("failed to build syzkaller %v: %v", commit, err)
+		return nil
+	}
+	tmp := b.dir + ".tmp"
+	os.RemoveAll(tmp)
+	if err := copyDir(filepath.Join(src, "bin"), filepath.Join(tmp, "bin")); err != nil {
+		log.Printf("failed to copy syzkaller binaries: %v", err)
+		return nil
+	}
+	if err := os.Rename(tmp, b.dir); err != nil {
+		log.Printf("failed to copy syzkaller binaries: %v", err)
+		return nil
+	}
+	log.Printf("built syzkaller %v in %v", commit, time.Since(start))
+	return b
+}
+
+// removeOld removes syzkaller builds that are not used by managers anymore.
+func (b *syzkallerBuild) removeOld(workdir string) {
+	files, err := ioutil.ReadDir(filepath.Join(workdir, "syzkaller"))
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if f.Name() != "gopath" && f.Name() != b.commit {
+			os.RemoveAll(filepath.Join(workdir, "syzkaller", f.Name()))
+		}
+	}
+}
+
+func copyDir(src, dst string) error {
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return err
+	}
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			if err := copyDir(filepath.Join(src, f.Name()), filepath.Join(dst, f.Name())); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fileutil.CopyFile(filepath.Join(src, f.Name()), filepath.Join(dst, f.Name()), false); err != nil {
+			return err
+		}
+		if err := os.Chmod(filepath.Join(dst, f.Name()), f.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildKernel checks out commit in kernelDir, builds the kernel with config and creates VM image
+// in kernelDir/image with imageCmd.
+func buildKernel(kernelDir, repo, branch, commit, config, imageCmd string, jobs int) error {
+	if err := checkout(kernelDir, repo, branch, commit); err != nil {
+		return err
+	}
+	if err := fileutil.CopyFile(config, filepath.Join(kernelDir, ".config"), false); err != nil {
+		return err
+	}
+	if _, err := run(kernelDir, nil, buildTimeout, "make", "olddefconfig"); err != nil {
+		return err
+	}
+	if _, err := run(kernelDir, nil, buildTimeout, "make", fmt.Sprintf("-j%v", jobs)); err != nil {
+		return err
+	}
+	if imageCmd == "" {
+		return nil
+	}
+	imageDir := filepath.Join(kernelDir, "image")
+	os.RemoveAll(imageDir)
+	if err := os.MkdirAll(imageDir, 0700); err != nil {
+		return err
+	}
+	env := []string{"KERNEL_DIR=" + kernelDir, "IMAGE_DIR=" + imageDir}
+	_, err := run(kernelDir, env, buildTimeout, "sh", "-c", imageCmd)
+	return err
+}
@@ -0,0 +1,306 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"text/template"
+	"time"
+)
+
+const (
+	// The manager backs up its state on /shutdown, which may take a while.
+	managerShutdownTimeout = 30 * time.Minute
+	managerKillTimeout     = time.Minute
+)
+
+// Manager is a syz-manager instance run by syz-ci.
+type Manager struct {
+	cfg   *Config
+	mc    *ManagerConfig
+	dir   string
+	state managerState
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	done    chan bool // closed when the manager process exits
+	http    string
+	apiKey  string
+	stopped bool // syz-ci is shutting down
+}
+
+// managerState is persisted in the manager dir, so that syz-ci restarts don't rebuild kernels.
+type managerState struct {
+	KernelCommit    string
+	KernelDir       string // kernel0 or kernel1
+	SyzkallerCommit string // of the running manager
+}
+
+type templateData struct {
+	KernelDir string
+	ImageDir  string
+}
+
+func newManager(cfg *Config, mc *ManagerConfig) *Manager {
+	mgr := &Manager{
+		cfg: cfg,
+		mc:  mc,
+		dir: filepath.Join(cfg.Workdir, "managers", mc.Name),
+	}
+	if err := os.MkdirAll(mgr.dir, 0700); err != nil {
+		log.Fatalf("failed to create manager dir: %v", err)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(mgr.dir, "state.json")); err == nil {
+		if err := json.Unmarshal(data, &mgr.state); err != nil {
+			log.Printf("%v: failed to parse state: %v", mc.Name, err)
+			mgr.state = managerState{}
+		}
+	}
+	return mgr
+}
+
+func (mgr *Manager) saveState() {
+	data, err := json.MarshalIndent(mgr.state, "", "\t")
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(mgr.dir, "state.json"), data, 0600); err != nil {
+		log.Printf("%v: failed to save state: %v", mgr.mc.Name, err)
+	}
+}
+
+// poll builds the kernel if the branch has changed and (re)starts the manager if the kernel
+// or syzkaller have changed or if the manager has exited.
+func (mgr *Manager) poll(syz *syzkallerBuild) {
+	name := mgr.mc.Name
+	kernelChanged := false
+	commit, err := remoteCommit(mgr.mc.Kernel_Repo, mgr.mc.Kernel_Branch)
+	if err != nil {
+		log.Printf("%v: failed to poll kernel: %v", name, err)
+	} else if commit != mgr.state.KernelCommit {
+		next := "kernel0"
+		if mgr.state.KernelDir == next {
+			next = "kernel1"
+		}
+		log.Printf("%v: building kernel %v", name, commit)
+		start := time.Now()
+		kernelDir := filepath.Join(mgr.dir, next)
+		buildErr := buildKernel(kernelDir, mgr.mc.Kernel_Repo, mgr.mc.Kernel_Branch, commit,
+			mgr.mc.Kernel_Config, mgr.mc.Image_Cmd, mgr.cfg.Jobs)
+		errorFile := filepath.Join(mgr.dir, "build-error.log")
+		if buildErr != nil {
+			log.Printf("%v: failed to build kernel %v (see %v)", name, commit, errorFile)
+			ioutil.WriteFile(errorFile, []byte(fmt.Sprintf("kernel %v:\n%v\n", commit, buildErr)), 0600)
+		} else {
+			log.Printf("%v: built kernel %v in %v", name, commit, time.Since(start))
+			os.Remove(errorFile)
+			mgr.state.KernelCommit, mgr.state.KernelDir = commit, next
+			mgr.saveState()
+			kernelChanged = true
+		}
+	}
+	if mgr.state.KernelDir == "" {
+		return
+	}
+	if !kernelChanged && syz.commit == mgr.state.SyzkallerCommit && mgr.running() {
+		return
+	}
+	mgr.stop()
+	if err := mgr.start(syz); err != nil {
+		log.Printf("%v: failed to start manager: %v", name, err)
+		return
+	}
+	mgr.state.SyzkallerCommit = syz.commit
+	mgr.saveState()
+}
+
+func (mgr *Manager) running() bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.cmd == nil {
+		return false
+	}
+	select {
+	case <-mgr.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// writeConfig creates syz-manager config from the template for the current kernel and syzkaller builds.
+func (mgr *Manager) writeConfig(syz *syzkallerBuild) (string, error) {
+	kernelDir := filepath.Join(mgr.dir, mgr.state.KernelDir)
+	tmpl, err := template.ParseFiles(mgr.mc.Manager_Config)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse manager config template: %v", err)
+	}
+	buf := new(bytes.Buffer)
+	data := &templateData{KernelDir: kernelDir, ImageDir: filepath.Join(kernelDir, "image")}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute manager config template: %v", err)
+	}
+	params := make(map[string]interface{})
+	if err := json.Unmarshal(buf.Bytes(), &params); err != nil {
+		return "", fmt.Errorf("failed to parse manager config: %v", err)
+	}
+	httpAddr, _ := getParam(params, "http").(string)
+	if httpAddr == "" {
+		return "", fmt.Errorf("manager config has no http param")
+	}
+	apiKey, _ := getParam(params, "api_key").(string)
+	if apiKey == "" {
+		key := make([]byte, 16)
+		if _, err := rand.Read(key); err != nil {
+			return "", err
+		}
+		apiKey = hex.EncodeToString(key)
+	}
+	setParam(params, "api_key", apiKey)
+	setParam(params, "workdir", filepath.Join(mgr.dir, "workdir"))
+	setParam(params, "vmlinux", filepath.Join(kernelDir, "vmlinux"))
+	setParam(params, "syzkaller", syz.dir)
+	setParam(params, "kernel_commit", mgr.state.KernelCommit)
+	setParam(params, "kernel_config", filepath.Join(kernelDir, ".config"))
+	if mgr.cfg.Upload != "" && getParam(params, "backup") == nil {
+		setParam(params, "backup", strings.TrimSuffix(mgr.cfg.Upload, "/")+"/"+mgr.mc.Name)
+	}
+	cfgData, err := json.MarshalIndent(params, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(mgr.dir, "manager.cfg")
+	if err := ioutil.WriteFile(file, cfgData, 0600); err != nil {
+		return "", err
+	}
+	mgr.http, mgr.apiKey = httpAddr, apiKey
+	return file, nil
+}
+
+// getParam and setParam access config params by name, case-insensitively as syz-manager parses them.
+func getParam(params map[string]interface{}, name string) interface{} {
+	for k, v := range params {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+func setParam(params map[string]interface{}, name string, v interface{}) {
+	for k := range params {
+		if strings.EqualFold(k, name) {
+			delete(params, k)
+		}
+	}
+	params[name] = v
+}
+
+func (mgr *Manager) start(syz *syzkallerBuild) error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.stopped {
+		return fmt.Errorf("shutting down")
+	}
+	cfgFile, err := mgr.writeConfig(syz)
+	if err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(filepath.Join(mgr.dir, "manager.log"), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	cmd := exec.Command(filepath.Join(syz.dir, "bin", "syz-manager"), "-config="+cfgFile)
+	cmd.Dir = mgr.dir
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	// The manager runs VMs as its children, so kill the whole process group if it hangs.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	log.Printf("%v: started manager on kernel %v, syzkaller %v", mgr.mc.Name, mgr.state.KernelCommit, syz.commit)
+	done := make(chan bool)
+	go func() {
+		err := cmd.Wait()
+		log.Printf("%v: manager exited: %v", mgr.mc.Name, err)
+		close(done)
+	}()
+	mgr.cmd, mgr.done = cmd, done
+	return nil
+}
+
+// stop gracefully shuts down the manager with the management API (the manager backs up its state),
+// and kills it if it does not exit in time.
+func (mgr *Manager) stop() {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.cmd == nil {
+		return
+	}
+	if err := mgr.shutdownRequest(); err != nil {
+		log.Printf("%v: shutdown request failed: %v", mgr.mc.Name, err)
+		syscall.Kill(-mgr.cmd.Process.Pid, syscall.SIGINT)
+	}
+	select {
+	case <-mgr.done:
+	case <-time.After(managerShutdownTimeout):
+		log.Printf("%v: manager does not exit, killing it", mgr.mc.Name)
+		syscall.Kill(-mgr.cmd.Process.Pid, syscall.SIGKILL)
+		select {
+		case <-mgr.done:
+		case <-time.After(managerKillTimeout):
+		}
+	}
+	mgr.cmd = nil
+}
+
+// stopForever stops the manager and prevents it from being started again.
+func (mgr *Manager) stopForever() {
+	mgr.mu.Lock()
+	mgr.stopped = true
+	mgr.mu.Unlock()
+	mgr.stop()
+}
+
+func (mgr *Manager) shutdownRequest() error {
+	host, port, err := net.SplitHostPort(mgr.http)
+	if err != nil {
+		return err
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	req, err := http.NewRequest("POST", "http://"+net.JoinHostPort(host, port)+"/shutdown", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+mgr.apiKey)
+	client := &http.Client{Timeout: managerShutdownTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%v: %s", resp.Status, body)
+	}
+	return nil
+}
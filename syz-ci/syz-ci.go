@@ -0,0 +1,166 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-ci continuously builds kernels and syzkaller and runs syz-manager instances on the latest builds.
+// It polls the syzkaller repo and kernel repos of the managed managers, when a repo changes,
+// it builds syzkaller or the kernel (and VM image) in the background and restarts the affected
+// managers on the new build. Managers back up corpus and crashes into the upload dir.
+//
+// Every manager has its own dir in workdir/managers/name with two kernel checkouts that are used
+// in turns: the next kernel is built in the checkout that is not used by the running manager,
+// so that the manager can symbolize reports with its vmlinux and kernel sources until it is restarted.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var flagConfig = flag.String("config", "", "configuration file")
+
+type Config struct {
+	Workdir     string
+	Poll_Period int    // minutes between polls of the repos (default: 15)
+	Jobs        int    // make -j for kernel builds (default: number of CPUs)
+	Upload      string // dir or gs:// bucket, every manager backs up corpus and crashes into its subdir
+
+	Syzkaller_Repo   string
+	Syzkaller_Branch string // default: master
+
+	Managers []*ManagerConfig
+}
+
+type ManagerConfig struct {
+	Name          string
+	Kernel_Repo   string
+	Kernel_Branch string // default: master
+	Kernel_Config string // kernel .config, olddefconfig is applied to it
+	// Command that creates VM image for the kernel (optional). It is run with sh -c in the kernel dir
+	// with KERNEL_DIR and IMAGE_DIR env vars, the image must be put into IMAGE_DIR.
+	Image_Cmd string
+	// syz-manager config template. It is a Go text/template with {{.KernelDir}} and {{.ImageDir}},
+	// syz-ci sets workdir, vmlinux, syzkaller, kernel_commit, kernel_config, api_key and backup params.
+	Manager_Config string
+}
+
+func main() {
+	flag.Parse()
+	cfg, err := parseConfig(*flagConfig)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	ci := newCI(cfg)
+	sigC := make(chan os.Signal, 2)
+	signal.Notify(sigC, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigC
+		log.Printf("shutting down managers...")
+		ci.stopAll()
+		os.Exit(0)
+	}()
+	ci.loop()
+}
+
+func parseConfig(file string) (*Config, error) {
+	if file == "" {
+		return nil, fmt.Errorf("supply config in -config flag")
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+	cfg := new(Config)
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	}
+	if cfg.Workdir == "" {
+		return nil, fmt.Errorf("config param workdir is empty")
+	}
+	if cfg.Workdir, err = filepath.Abs(cfg.Workdir); err != nil {
+		return nil, err
+	}
+	if cfg.Syzkaller_Repo == "" {
+		return nil, fmt.Errorf("config param syzkaller_repo is empty")
+	}
+	if cfg.Syzkaller_Branch == "" {
+		cfg.Syzkaller_Branch = "master"
+	}
+	if cfg.Poll_Period < 0 || cfg.Jobs < 0 {
+		return nil, fmt.Errorf("config params poll_period/jobs must not be negative")
+	}
+	if cfg.Poll_Period == 0 {
+		cfg.Poll_Period = 15
+	}
+	if cfg.Jobs == 0 {
+		cfg.Jobs = runtime.NumCPU()
+	}
+	if len(cfg.Managers) == 0 {
+		return nil, fmt.Errorf("no managers in config")
+	}
+	names := make(map[string]bool)
+	for i, mgr := range cfg.Managers {
+		if mgr.Name == "" || strings.ContainsAny(mgr.Name, "/ ") || names[mgr.Name] {
+			return nil, fmt.Errorf("manager #%v: bad or duplicate name '%v'", i, mgr.Name)
+		}
+		names[mgr.Name] = true
+		if mgr.Kernel_Repo == "" || mgr.Kernel_Config == "" || mgr.Manager_Config == "" {
+			return nil, fmt.Errorf("manager %v: kernel_repo, kernel_config and manager_config are required", mgr.Name)
+		}
+		if mgr.Kernel_Branch == "" {
+			mgr.Kernel_Branch = "master"
+		}
+		for _, file := range []string{mgr.Kernel_Config, mgr.Manager_Config} {
+			if _, err := os.Stat(file); err != nil {
+				return nil, fmt.Errorf("manager %v: %v", mgr.Name, err)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+type CI struct {
+	cfg       *Config
+	syzkaller *syzkallerBuild // the latest successful build
+	managers  []*Manager
+}
+
+func newCI(cfg *Config) *CI {
+	ci := &CI{cfg: cfg}
+	for _, mc := range cfg.Managers {
+		ci.managers = append(ci.managers, newManager(cfg, mc))
+	}
+	return ci
+}
+
+// loop polls the repos, builds what has changed and restarts managers on new builds.
+// Builds are done one at a time, managers keep running on the previous builds meanwhile.
+func (ci *CI) loop() {
+	for {
+		if b := ci.pollSyzkaller(); b != nil {
+			ci.syzkaller = b
+		}
+		if ci.syzkaller != nil {
+			for _, mgr := range ci.managers {
+				mgr.poll(ci.syzkaller)
+			}
+			ci.syzkaller.removeOld(ci.cfg.Workdir)
+		}
+		time.Sleep(time.Duration(ci.cfg.Poll_Period) * time.Minute)
+	}
+}
+
+func (ci *CI) stopAll() {
+	for _, mgr := range ci.managers {
+		mgr.stopForever()
+	}
+}
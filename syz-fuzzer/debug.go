@@ -0,0 +1,34 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"expvar"
+	"net/http"
+	_ "net/http/pprof"
+)
+
+// With -debug_http the fuzzer serves pprof profiles on /debug/pprof/ and expvar vars
+// (memstats, corpus size and queue lengths) on /debug/vars.
+func serveDebugHttp(addr string) {
+	expvar.Publish("corpus", expvar.Func(func() interface{} {
+		corpusMu.RLock()
+		defer corpusMu.RUnlock()
+		return len(corpus)
+	}))
+	expvar.Publish("queues", expvar.Func(func() interface{} {
+		triageMu.RLock()
+		defer triageMu.RUnlock()
+		return map[string]int{
+			"triage":     len(triage),
+			"candidates": len(candidates),
+		}
+	}))
+	go func() {
+		logf(0, "serving debug http on %v", addr)
+		if err := http.ListenAndServe(addr, nil); err != nil {
+			logf(0, "failed to serve debug http: %v", err)
+		}
+	}()
+}
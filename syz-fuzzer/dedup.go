@@ -0,0 +1,44 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"container/list"
+	"sync"
+)
+
+// execCache is an LRU set of hashes of recently executed programs.
+// Mutations frequently produce exact duplicates (especially right after corpus sync,
+// when all procs mutate the same few new inputs), executing them again is a waste of time.
+type execCache struct {
+	mu    sync.Mutex
+	size  int
+	lru   *list.List // of Sig, most recently used at front
+	items map[Sig]*list.Element
+}
+
+func newExecCache(size int) *execCache {
+	return &execCache{
+		size:  size,
+		lru:   list.New(),
+		items: make(map[Sig]*list.Element),
+	}
+}
+
+// seen returns true if sig was added recently, otherwise adds sig to the cache.
+func (c *execCache) seen(sig Sig) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[sig]; ok {
+		c.lru.MoveToFront(e)
+		return true
+	}
+	c.items[sig] = c.lru.PushFront(sig)
+	if c.lru.Len() > c.size {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.items, e.Value.(Sig))
+	}
+	return false
+}
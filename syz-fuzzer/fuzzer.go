@@ -12,12 +12,15 @@ import (
 	"crypto/sha1"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/rpc"
 	"net/rpc/jsonrpc"
 	"os"
+	"reflect"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -38,13 +41,21 @@ var (
 	flagExecutor = flag.String("executor", "", "path to executor binary")
 	flagManager  = flag.String("manager", "", "manager rpc address")
 	flagProcs    = flag.Int("procs", 1, "number of parallel test processes")
-	flagLeak     = flag.Bool("leak", false, "detect memory leaks")
 	flagV        = flag.Int("v", 0, "verbosity")
 	flagOutput   = flag.String("output", "stdout", "write programs to none/stdout/dmesg/file")
+	// Collide mode executes every program twice, so it is used only for a fraction of fuzzing programs
+	// (coverage is collected from the non-collided execution, so triage/minimization never collide).
+	flagCollideProb = flag.Float64("collide_prob", 0.3, "fraction of fuzzing programs executed in collide mode")
+	// Killing programs at random points exercises kernel exit paths for threads blocked in syscalls
+	// (otherwise they are hit only on timeouts). Killed programs give partial coverage, so don't do it too often.
+	flagKillProb  = flag.Float64("kill_prob", 0.05, "fraction of fuzzing programs killed at a random point")
+	flagDebugHttp = flag.String("debug_http", "", "address to serve pprof profiles and expvar vars on")
+	// The manager detects kernels that rebooted silently by boot messages after the marker.
+	flagBootMarker = flag.String("boot_marker", "", "unique marker of the fuzzer run written to kernel log on start")
 )
 
 const (
-	programLength = 30
+	execCacheSize = 64 << 10 // number of recently executed program hashes to remember
 )
 
 type Sig [sha1.Size]byte
@@ -54,9 +65,15 @@ func hash(data []byte) Sig {
 }
 
 type Input struct {
-	p     *prog.Prog
-	call  int
-	cover cover.Cover
+	p         *prog.Prog
+	call      int
+	cover     cover.Cover
+	candidate string // ID of the candidate that produced the input, if any
+}
+
+type Candidate struct {
+	p  *prog.Prog
+	id string
 }
 
 var (
@@ -71,21 +88,39 @@ var (
 	corpus       []*prog.Prog
 	corpusHashes map[Sig]struct{}
 
-	triageMu   sync.RWMutex
-	triage     []Input
-	candidates []*prog.Prog
-
-	gate *ipc.Gate
+	triageMu          sync.RWMutex
+	triage            []Input
+	candidates        []Candidate
+	pendingCandidates map[string]int // number of unprocessed triage inputs per candidate (+1 for the candidate)
+	doneCandidates    []string       // candidates to acknowledge in the next poll
+
+	ctMu          sync.RWMutex
+	ct            *prog.ChoiceTable
+	ctPrios       [][]float32
+	ctNGrams      []prog.NGram
+	ctCalls       map[*sys.Call]bool
+	ctBudget      prog.Budget
+	ctWeights     prog.MutationWeights
+	ctCallWeights []float32
+
+	gate       *ipc.Gate
+	execHashes *execCache
+	objects    *objectTracker
 
 	statExecGen       uint64
 	statExecFuzz      uint64
 	statExecCandidate uint64
 	statExecTriage    uint64
 	statExecMinimize  uint64
+	statExecDedup     uint64
 	statNewInput      uint64
 
-	allTriaged uint32
-	noCover    bool
+	collectCallStats bool
+	callExecs        []uint64 // per call ID executions in generated/mutated programs
+	callNewCover     []uint64 // per call ID executions that gave new coverage
+
+	noCover     bool
+	coverFilter cover.Filter
 )
 
 func main() {
@@ -98,54 +133,110 @@ func main() {
 		os.Exit(1)
 	}
 	logf(0, "fuzzer started, log level %v", *flagV)
+	if *flagBootMarker != "" {
+		writeBootMarker(*flagBootMarker)
+	}
+	if *flagDebugHttp != "" {
+		serveDebugHttp(*flagDebugHttp)
+	}
 
 	corpusCover = make([]cover.Cover, sys.CallCount)
 	maxCover = make([]cover.Cover, sys.CallCount)
 	corpusHashes = make(map[Sig]struct{})
+	pendingCandidates = make(map[string]int)
+	execHashes = newExecCache(execCacheSize)
+	objects = newObjectTracker()
 
 	logf(0, "dialing manager at %v", *flagManager)
 	conn, err := jsonrpc.Dial("tcp", *flagManager)
 	if err != nil {
-		panic(err)
+		exitf(ExitManagerUnreachable, "failed to dial %v: %v", *flagManager, err)
 	}
 	manager = conn
-	a := &ConnectArgs{*flagName}
+	modules := loadedModules()
+	a := &ConnectArgs{Name: *flagName, Revision: sys.Revision, Modules: modules, KernelVersion: kernelVersion()}
 	r := &ConnectRes{}
 	if err := manager.Call("Manager.Connect", a, r); err != nil {
-		panic(err)
+		if strings.Contains(err.Error(), ErrRevisionMismatch) {
+			exitf(ExitRevisionMismatch, "%v", err)
+		}
+		exitf(ExitManagerUnreachable, "Manager.Connect failed: %v", err)
 	}
-	calls := buildCallList(r.EnabledCalls)
-	ct := prog.BuildChoiceTable(r.Prios, calls)
-
-	kmemleakInit()
+	if err := r.Budget.Validate(); err != nil {
+		panic(fmt.Sprintf("bad program budget: %v", err))
+	}
+	if err := r.Weights.Validate(); err != nil {
+		panic(fmt.Sprintf("bad mutation weights: %v", err))
+	}
+	if r.GeneratePeriod <= 0 || r.MutateLength <= 0 {
+		panic(fmt.Sprintf("bad generate period %v or mutate length %v", r.GeneratePeriod, r.MutateLength))
+	}
+	calls, unsupported, transitive := buildCallList(r.EnabledCalls)
+	ctPrios, ctNGrams, ctCalls, ctBudget, ctWeights = r.Prios, r.NGrams, calls, r.Budget, r.Weights
+	setCallWeights(nil)
+	programLength := r.ProgramLength
+	// Splicing inserts calls of another corpus program before mutation (see prog.Splice),
+	// this combines resources and syscall sequences found by different inputs.
+	spliceProb, generatePeriod, mutateLength := r.SpliceProb, r.GeneratePeriod, r.MutateLength
+	collectCallStats = r.CallStats
+	callExecs = make([]uint64, len(sys.Calls))
+	callNewCover = make([]uint64, len(sys.Calls))
+	coverFilter = r.CoverFilter
 
 	flags, timeout, err := ipc.DefaultFlags()
 	if err != nil {
 		panic(err)
 	}
+	if err := ipc.KmemleakInit(flags&ipc.FlagLeak != 0); err != nil {
+		log.Fatalf("BUG: %v", err)
+	}
 	noCover = flags&ipc.FlagCover == 0
+	features := host.DetectFeatures()
+	ca := &CheckArgs{
+		Name:                 *flagName,
+		Kcov:                 features.Kcov,
+		Debugfs:              features.Debugfs,
+		Kasan:                features.Kasan,
+		Kallsyms:             features.Kallsyms,
+		UnsupportedCalls:     unsupported,
+		TransitivelyDisabled: transitive,
+	}
+	if r.NeedKallsyms {
+		if data, err := ioutil.ReadFile("/proc/kallsyms"); err == nil {
+			ca.KallsymsData = cover.KallsymsText(data)
+		}
+	}
+	if err := manager.Call("Manager.Check", ca, nil); err != nil {
+		exitf(ExitManagerUnreachable, "Manager.Check failed: %v", err)
+	}
 	if !noCover {
 		fd, err := syscall.Open("/sys/kernel/debug/kcov", syscall.O_RDWR, 0)
 		if err != nil {
-			log.Fatalf("BUG: /sys/kernel/debug/kcov is missing (%v). Enable CONFIG_KCOV and mount debugfs.", err)
+			exitf(ExitKcovUnavailable, "/sys/kernel/debug/kcov is missing (%v). Enable CONFIG_KCOV and mount debugfs.", err)
 		}
 		syscall.Close(fd)
 	}
-	leakCallback := func() {
-		if atomic.LoadUint32(&allTriaged) != 0 {
-			// Scan for leaks once in a while (it is damn slow).
-			kmemleakScan(true)
+	var leakCallback func()
+	if flags&ipc.FlagLeak != 0 {
+		// All procs are stopped while the callback runs, so leaks are caused by
+		// the programs logged since the previous scan.
+		leakCallback = func() {
+			leaks, err := ipc.KmemleakScan()
+			if err != nil {
+				log.Fatalf("failed to scan for leaks: %v", err)
+			}
+			if len(leaks) != 0 {
+				// BUG in output should be recognized by manager.
+				logf(0, "%s", leaks)
+			}
 		}
 	}
-	if !*flagLeak {
-		leakCallback = nil
-	}
 	gate = ipc.NewGate(2**flagProcs, leakCallback)
 	envs := make([]*ipc.Env, *flagProcs)
 	for pid := 0; pid < *flagProcs; pid++ {
 		env, err := ipc.MakeEnv(*flagExecutor, timeout, flags)
 		if err != nil {
-			panic(err)
+			exitf(ExitExecutorFailed, "failed to create executor env: %v", err)
 		}
 		envs[pid] = env
 
@@ -165,14 +256,20 @@ func main() {
 						triage = triage[:last]
 						triageMu.Unlock()
 						logf(1, "triaging : %s", inp.p)
+						env.SetCollide(false)
+						env.SetKill(false)
 						triageInput(pid, env, inp)
 						continue
 					} else if len(candidates) != 0 {
 						last := len(candidates) - 1
-						p := candidates[last]
+						c := candidates[last]
 						candidates = candidates[:last]
+						pendingCandidates[c.id]++
 						triageMu.Unlock()
-						execute(pid, env, p, &statExecCandidate)
+						env.SetCollide(false)
+						env.SetKill(false)
+						execute(pid, env, c.p, &statExecCandidate, c.id)
+						candidateProcessed(c.id)
 						continue
 					} else {
 						triageMu.Unlock()
@@ -181,22 +278,43 @@ func main() {
 					triageMu.RUnlock()
 				}
 
+				env.SetCollide(rnd.Float64() < *flagCollideProb)
+				env.SetKill(rnd.Float64() < *flagKillProb)
+				ctMu.RLock()
+				ct, calls := ct, ctCalls
+				ctMu.RUnlock()
 				corpusMu.RLock()
-				if len(corpus) == 0 || i%10 == 0 {
+				var p0, p1 *prog.Prog
+				if len(corpus) != 0 {
+					p0 = corpus[rnd.Intn(len(corpus))]
+					if len(corpus) > 1 && rnd.Float64() < spliceProb {
+						p1 = corpus[rnd.Intn(len(corpus))]
+					}
+				}
+				// Calls can be disabled at runtime, don't mutate programs that contain them.
+				if p0 != nil && !enabledProg(p0, calls) {
+					p0 = nil
+				}
+				if p1 != nil && !enabledProg(p1, calls) {
+					p1 = nil
+				}
+				if p0 == nil || i%generatePeriod == 0 {
 					corpusMu.RUnlock()
 					p := prog.Generate(rnd, programLength, ct)
 					logf(1, "#%v: generated: %s", i, p)
-					execute(pid, env, p, &statExecGen)
-					p.Mutate(rnd, programLength, ct)
+					executeNew(pid, env, p, &statExecGen)
+					p.Mutate(rnd, mutateLength, ct)
 					logf(1, "#%v: mutated: %s", i, p)
-					execute(pid, env, p, &statExecFuzz)
+					executeNew(pid, env, p, &statExecFuzz)
 				} else {
-					p0 := corpus[rnd.Intn(len(corpus))]
 					corpusMu.RUnlock()
 					p := p0.Clone()
-					p.Mutate(rs, programLength, ct)
+					if p1 != nil {
+						p.Splice(rs, p1, mutateLength, ct)
+					}
+					p.Mutate(rs, mutateLength, ct)
 					logf(1, "#%v: mutated: %s <- %s", i, p, p0)
-					execute(pid, env, p, &statExecFuzz)
+					executeNew(pid, env, p, &statExecFuzz)
 				}
 			}
 		}()
@@ -218,10 +336,14 @@ func main() {
 			}
 			triageMu.RUnlock()
 
+			triageMu.Lock()
 			a := &PollArgs{
-				Name:  *flagName,
-				Stats: make(map[string]uint64),
+				Name:           *flagName,
+				Stats:          make(map[string]uint64),
+				DoneCandidates: doneCandidates,
 			}
+			doneCandidates = nil
+			triageMu.Unlock()
 			for _, env := range envs {
 				a.Stats["exec total"] += atomic.SwapUint64(&env.StatExecs, 0)
 				a.Stats["executor restarts"] += atomic.SwapUint64(&env.StatRestarts, 0)
@@ -231,37 +353,63 @@ func main() {
 			a.Stats["exec candidate"] = atomic.SwapUint64(&statExecCandidate, 0)
 			a.Stats["exec triage"] = atomic.SwapUint64(&statExecTriage, 0)
 			a.Stats["exec minimize"] = atomic.SwapUint64(&statExecMinimize, 0)
+			a.Stats["exec dedup"] = atomic.SwapUint64(&statExecDedup, 0)
 			a.Stats["fuzzer new inputs"] = atomic.SwapUint64(&statNewInput, 0)
+			if collectCallStats {
+				a.CallStats = make(map[string]CallStat)
+				for id, c := range sys.Calls {
+					st := CallStat{
+						Execs:    atomic.SwapUint64(&callExecs[id], 0),
+						NewCover: atomic.SwapUint64(&callNewCover[id], 0),
+					}
+					if st.Execs != 0 {
+						a.CallStats[c.Name] = st
+					}
+				}
+			}
+			objects.flush(a.Stats)
+			if mods := loadedModules(); !reflect.DeepEqual(mods, modules) {
+				// Modules loaded on demand (e.g. by socket creation) or reloaded at a different address.
+				a.Modules = mods
+				modules = mods
+			}
 			r := &PollRes{}
 			if err := manager.Call("Manager.Poll", a, r); err != nil {
-				panic(err)
+				exitf(ExitManagerUnreachable, "Manager.Poll failed: %v", err)
 			}
 			for _, inp := range r.NewInputs {
 				addInput(inp)
 			}
-			for _, data := range r.Candidates {
-				p, err := prog.Deserialize(data)
+			if r.CallWeights != nil {
+				setCallWeights(r.CallWeights)
+			}
+			if r.EnabledCalls != "" {
+				calls, _, _ := buildCallList(r.EnabledCalls)
+				logf(0, "enabled calls changed: %v calls", len(calls))
+				ctMu.Lock()
+				ctCalls = calls
+				ctMu.Unlock()
+				setCallWeights(ctCallWeights)
+			}
+			for _, c := range r.Candidates {
+				p, err := prog.Deserialize(c.Prog)
 				if err != nil {
 					panic(err)
 				}
 				if noCover {
 					corpusMu.Lock()
 					corpus = append(corpus, p)
+					corpusHashes[hash(c.Prog)] = struct{}{}
 					corpusMu.Unlock()
+					triageMu.Lock()
+					doneCandidates = append(doneCandidates, c.ID)
+					triageMu.Unlock()
 				} else {
 					triageMu.Lock()
-					candidates = append(candidates, p)
+					candidates = append(candidates, Candidate{p, c.ID})
 					triageMu.Unlock()
 				}
 			}
-			if len(r.Candidates) == 0 {
-				if atomic.LoadUint32(&allTriaged) == 0 {
-					if *flagLeak {
-						kmemleakScan(false)
-					}
-					atomic.StoreUint32(&allTriaged, 1)
-				}
-			}
 			if len(r.NewInputs) == 0 && len(r.Candidates) == 0 {
 				lastPoll = time.Now()
 			}
@@ -269,7 +417,36 @@ func main() {
 	}
 }
 
-func buildCallList(enabledCalls string) map[*sys.Call]bool {
+// setCallWeights rebuilds the choice table with the given call weights.
+func setCallWeights(weights []float32) {
+	if weights != nil && len(weights) != len(sys.Calls) {
+		panic(fmt.Sprintf("got %v call weights for %v calls", len(weights), len(sys.Calls)))
+	}
+	ctCallWeights = weights
+	newCt := prog.BuildChoiceTable(prog.ApplyCallWeights(ctPrios, weights), ctCalls)
+	newCt.SetBudget(ctBudget)
+	newCt.SetMutationWeights(ctWeights)
+	if len(ctNGrams) != 0 {
+		newCt.SetNGrams(ctNGrams)
+	}
+	ctMu.Lock()
+	ct = newCt
+	ctMu.Unlock()
+}
+
+// enabledProg returns true if all calls of p are in calls.
+func enabledProg(p *prog.Prog, calls map[*sys.Call]bool) bool {
+	for _, c := range p.Calls {
+		if !calls[c.Meta] {
+			return false
+		}
+	}
+	return true
+}
+
+// buildCallList returns enabled calls that the kernel supports,
+// and names of unsupported and transitively disabled calls.
+func buildCallList(enabledCalls string) (map[*sys.Call]bool, []string, []string) {
 	calls := make(map[*sys.Call]bool)
 	if enabledCalls != "" {
 		for _, id := range strings.Split(enabledCalls, ",") {
@@ -285,6 +462,7 @@ func buildCallList(enabledCalls string) map[*sys.Call]bool {
 		}
 	}
 
+	var unsupported, transitive []string
 	if supp, err := host.DetectSupportedSyscalls(); err != nil {
 		logf(0, "failed to detect host supported syscalls: %v", err)
 	} else {
@@ -292,6 +470,7 @@ func buildCallList(enabledCalls string) map[*sys.Call]bool {
 			if !supp[c] {
 				logf(1, "disabling unsupported syscall: %v", c.Name)
 				delete(calls, c)
+				unsupported = append(unsupported, c.Name)
 			}
 		}
 	}
@@ -301,9 +480,48 @@ func buildCallList(enabledCalls string) map[*sys.Call]bool {
 		if !trans[c] {
 			logf(1, "disabling transitively unsupported syscall: %v", c.Name)
 			delete(calls, c)
+			transitive = append(transitive, c.Name)
 		}
 	}
-	return calls
+	sort.Strings(unsupported)
+	sort.Strings(transitive)
+	return calls, unsupported, transitive
+}
+
+// loadedModules returns kernel modules with their load addresses,
+// so that manager can attribute coverage to modules.
+func loadedModules() []cover.Module {
+	data, err := ioutil.ReadFile("/proc/modules")
+	if err != nil {
+		return nil
+	}
+	modules, err := cover.ParseModules(data)
+	if err != nil {
+		logf(0, "failed to parse /proc/modules: %v", err)
+		return nil
+	}
+	return modules
+}
+
+// writeBootMarker writes marker to the kernel log, so that it is in the console output of the kernel
+// that runs the fuzzer, and to stdout for VM types that don't stream the kernel console.
+// Manager treats boot messages after the marker as a reboot of the kernel.
+func writeBootMarker(marker string) {
+	msg := fmt.Sprintf("syzkaller: boot marker %v\n", marker)
+	if fd, err := syscall.Open("/dev/kmsg", syscall.O_WRONLY, 0); err == nil {
+		syscall.Write(fd, []byte(msg))
+		syscall.Close(fd)
+	}
+	logf(0, "%v", strings.TrimSpace(msg))
+}
+
+// kernelVersion returns the kernel version string, manager archives it with crashes.
+func kernelVersion() string {
+	data, err := ioutil.ReadFile("/proc/version")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
 }
 
 func addInput(inp RpcInput) {
@@ -312,13 +530,18 @@ func addInput(inp RpcInput) {
 	coverMu.Lock()
 	defer coverMu.Unlock()
 
-	if noCover {
-		panic("should not be called when coverage is disabled")
-	}
 	p, err := prog.Deserialize(inp.Prog)
 	if err != nil {
 		panic(err)
 	}
+	if noCover {
+		sig := hash(inp.Prog)
+		if _, ok := corpusHashes[sig]; !ok {
+			corpus = append(corpus, p)
+			corpusHashes[sig] = struct{}{}
+		}
+		return
+	}
 	if inp.CallIndex < 0 || inp.CallIndex >= len(p.Calls) {
 		panic("bad call index")
 	}
@@ -327,7 +550,10 @@ func addInput(inp RpcInput) {
 	if _, ok := corpusHashes[sig]; ok {
 		return
 	}
-	cov := cover.Canonicalize(inp.Cover)
+	cov, err := cover.Decompress(inp.Cover)
+	if err != nil {
+		panic(err)
+	}
 	diff := cover.Difference(cov, maxCover[call.CallID])
 	diff = cover.Difference(diff, flakes)
 	if len(diff) == 0 {
@@ -343,6 +569,9 @@ func triageInput(pid int, env *ipc.Env, inp Input) {
 	if noCover {
 		panic("should not be called when coverage is disabled")
 	}
+	if inp.candidate != "" {
+		defer candidateProcessed(inp.candidate)
+	}
 
 	call := inp.p.Calls[inp.call].Meta
 	coverMu.RLock()
@@ -362,7 +591,7 @@ func triageInput(pid int, env *ipc.Env, inp Input) {
 
 	minCover := inp.cover
 	for i := 0; i < 3; i++ {
-		allCover := execute1(pid, env, inp.p, &statExecTriage)
+		allCover, _ := execute1(pid, env, inp.p, &statExecTriage)
 		if len(allCover[inp.call]) == 0 {
 			// The call was not executed. Happens sometimes, reason unknown.
 			continue
@@ -384,7 +613,7 @@ func triageInput(pid int, env *ipc.Env, inp Input) {
 		return
 	}
 	inp.p, inp.call = prog.Minimize(inp.p, inp.call, func(p1 *prog.Prog, call1 int) bool {
-		allCover := execute1(pid, env, p1, &statExecMinimize)
+		allCover, _ := execute1(pid, env, p1, &statExecMinimize)
 		coverMu.RLock()
 		defer coverMu.RUnlock()
 
@@ -403,9 +632,17 @@ func triageInput(pid int, env *ipc.Env, inp Input) {
 	atomic.AddUint64(&statNewInput, 1)
 	data := inp.p.Serialize()
 	logf(2, "added new input for %v to corpus:\n%s", call.CallName, data)
-	a := &NewInputArgs{*flagName, RpcInput{call.CallName, data, inp.call, []uint32(inp.cover)}}
+	a := &NewInputArgs{
+		Name: *flagName,
+		RpcInput: RpcInput{
+			Call:      call.CallName,
+			Prog:      data,
+			CallIndex: inp.call,
+			Cover:     cover.Compress(inp.cover),
+		},
+	}
 	if err := manager.Call("Manager.NewInput", a, nil); err != nil {
-		panic(err)
+		exitf(ExitManagerUnreachable, "Manager.NewInput failed: %v", err)
 	}
 
 	corpusMu.Lock()
@@ -418,35 +655,113 @@ func triageInput(pid int, env *ipc.Env, inp Input) {
 	corpusHashes[hash(data)] = struct{}{}
 }
 
-func execute(pid int, env *ipc.Env, p *prog.Prog, stat *uint64) {
-	allCover := execute1(pid, env, p, stat)
+// executeNew executes a generated/mutated program unless the same program was executed recently.
+func executeNew(pid int, env *ipc.Env, p *prog.Prog, stat *uint64) {
+	if execHashes.seen(hash(p.Serialize())) {
+		atomic.AddUint64(&statExecDedup, 1)
+		return
+	}
+	execute(pid, env, p, stat, "")
+}
+
+// execute executes the program and queues calls that give new coverage for triage.
+// candidate is ID of the candidate that is executed (if any).
+func execute(pid int, env *ipc.Env, p *prog.Prog, stat *uint64, candidate string) {
+	allCover, errnos := execute1(pid, env, p, stat)
+	if noCover {
+		noCoverInput(p, errnos)
+		return
+	}
+	// Only generated and mutated programs say something about coverage yield of calls.
+	yield := collectCallStats && (stat == &statExecGen || stat == &statExecFuzz)
 	coverMu.RLock()
 	defer coverMu.RUnlock()
 	for i, cov := range allCover {
+		c := p.Calls[i].Meta
+		if yield {
+			atomic.AddUint64(&callExecs[c.ID], 1)
+		}
 		if len(cov) == 0 {
 			continue
 		}
-		c := p.Calls[i].Meta
 		diff := cover.Difference(cov, maxCover[c.CallID])
 		diff = cover.Difference(diff, flakes)
 		if len(diff) != 0 {
+			if yield {
+				atomic.AddUint64(&callNewCover[c.ID], 1)
+			}
 			coverMu.RUnlock()
 			coverMu.Lock()
 			maxCover[c.CallID] = cover.Union(maxCover[c.CallID], diff)
 			coverMu.Unlock()
 			coverMu.RLock()
 
-			inp := Input{p.Clone(), i, cover.Copy(cov)}
+			inp := Input{p.Clone(), i, cover.Copy(cov), candidate}
 			triageMu.Lock()
 			triage = append(triage, inp)
+			if candidate != "" {
+				pendingCandidates[candidate]++
+			}
 			triageMu.Unlock()
 		}
 	}
 }
 
+// candidateProcessed is called when a candidate or a triage input that it produced is processed.
+// The candidate is acknowledged to manager when all of them are processed, so that
+// if the VM crashes before that, manager gives the candidate to another fuzzer.
+func candidateProcessed(id string) {
+	triageMu.Lock()
+	defer triageMu.Unlock()
+	pendingCandidates[id]--
+	if pendingCandidates[id] == 0 {
+		delete(pendingCandidates, id)
+		doneCandidates = append(doneCandidates, id)
+	}
+}
+
+// noCoverInput adds the program to corpus if all its calls succeeded.
+// Without coverage this is the only feedback available: such programs get past
+// argument checks and make better material for mutation than random programs.
+func noCoverInput(p *prog.Prog, errnos []int) {
+	if len(errnos) != len(p.Calls) {
+		return
+	}
+	for _, errno := range errnos {
+		if errno != 0 {
+			return
+		}
+	}
+	data := p.Serialize()
+	sig := hash(data)
+	corpusMu.Lock()
+	if _, ok := corpusHashes[sig]; ok {
+		corpusMu.Unlock()
+		return
+	}
+	corpus = append(corpus, p.Clone())
+	corpusHashes[sig] = struct{}{}
+	corpusMu.Unlock()
+
+	atomic.AddUint64(&statNewInput, 1)
+	last := len(p.Calls) - 1
+	logf(2, "added new input to corpus:\n%s", data)
+	a := &NewInputArgs{
+		Name: *flagName,
+		RpcInput: RpcInput{
+			Call:      p.Calls[last].Meta.CallName,
+			Prog:      data,
+			CallIndex: last,
+		},
+	}
+	if err := manager.Call("Manager.NewInput", a, nil); err != nil {
+		exitf(ExitManagerUnreachable, "Manager.NewInput failed: %v", err)
+	}
+}
+
 var logMu sync.Mutex
 
-func execute1(pid int, env *ipc.Env, p *prog.Prog, stat *uint64) []cover.Cover {
+func execute1(pid int, env *ipc.Env, p *prog.Prog, stat *uint64) ([]cover.Cover, []int) {
 	if false {
 		// For debugging, this function must not be executed with locks held.
 		corpusMu.Lock()
@@ -491,16 +806,16 @@ func execute1(pid int, env *ipc.Env, p *prog.Prog, stat *uint64) []cover.Cover {
 retry:
 	atomic.AddUint64(stat, 1)
 	output, rawCover, errnos, failed, hanged, err := env.Exec(p)
-	_ = errnos
 	if failed {
 		// BUG in output should be recognized by manager.
-		logf(0, "BUG: executor-detected bug:\n%s", output)
+		// Output goes first, because it can contain a more specific report (e.g. BUG: memory leak).
+		logf(0, "%s\nBUG: executor-detected bug", output)
 		// Don't return any cover so that the input is not added to corpus.
-		return make([]cover.Cover, len(p.Calls))
+		return make([]cover.Cover, len(p.Calls)), nil
 	}
 	if err != nil {
 		if try > 10 {
-			panic(err)
+			exitf(ExitExecutorFailed, "%v", err)
 		}
 		try++
 		logf(4, "fuzzer detected executor failure='%v', retrying #%d\n", err, (try + 1))
@@ -509,11 +824,20 @@ retry:
 		goto retry
 	}
 	logf(2, "result failed=%v hanged=%v:\n%v\n", failed, hanged, string(output))
+	objects.sample(env.Objects())
 	cov := make([]cover.Cover, len(p.Calls))
 	for i, c := range rawCover {
-		cov[i] = cover.Cover(c)
+		cov[i] = coverFilter.Apply(cover.Cover(c))
 	}
-	return cov
+	return cov, errnos
+}
+
+// exitf reports a failure that is not a kernel bug to the manager (see FuzzerExit) and exits.
+func exitf(e FuzzerExit, msg string, args ...interface{}) {
+	details := strings.Replace(fmt.Sprintf(msg, args...), "\n", " ", -1)
+	logMu.Lock()
+	fmt.Fprintf(os.Stderr, "%v%v: %v\n", FuzzerExitPrefix, e.Reason, details)
+	os.Exit(e.Status)
 }
 
 func logf(v int, msg string, args ...interface{}) {
@@ -521,76 +845,3 @@ func logf(v int, msg string, args ...interface{}) {
 		log.Printf(msg, args...)
 	}
 }
-
-func kmemleakInit() {
-	fd, err := syscall.Open("/sys/kernel/debug/kmemleak", syscall.O_RDWR, 0)
-	if err != nil {
-		if *flagLeak {
-			log.Fatalf("BUG: /sys/kernel/debug/kmemleak is missing (%v). Enable CONFIG_KMEMLEAK and mount debugfs.", err)
-		} else {
-			return
-		}
-	}
-	defer syscall.Close(fd)
-	what := "scan=off"
-	if !*flagLeak {
-		what = "off"
-	}
-	if _, err := syscall.Write(fd, []byte(what)); err != nil {
-		// kmemleak returns EBUSY when kmemleak is already turned off.
-		if err != syscall.EBUSY {
-			panic(err)
-		}
-	}
-}
-
-var kmemleakBuf []byte
-
-func kmemleakScan(report bool) {
-	fd, err := syscall.Open("/sys/kernel/debug/kmemleak", syscall.O_RDWR, 0)
-	if err != nil {
-		panic(err)
-	}
-	defer syscall.Close(fd)
-	// Kmemleak has false positives. To mitigate most of them, it checksums
-	// potentially leaked objects, and reports them only on the next scan
-	// iff the checksum does not change. Because of that we do the following
-	// intricate dance:
-	// Scan, sleep, scan again. At this point we can get some leaks.
-	// If there are leaks, we sleep and scan again, this can remove
-	// false leaks. Then, read kmemleak again. If we get leaks now, then
-	// hopefully these are true positives during the previous testing cycle.
-	if _, err := syscall.Write(fd, []byte("scan")); err != nil {
-		panic(err)
-	}
-	time.Sleep(time.Second)
-	if _, err := syscall.Write(fd, []byte("scan")); err != nil {
-		panic(err)
-	}
-	if report {
-		if kmemleakBuf == nil {
-			kmemleakBuf = make([]byte, 128<<10)
-		}
-		n, err := syscall.Read(fd, kmemleakBuf)
-		if err != nil {
-			panic(err)
-		}
-		if n != 0 {
-			time.Sleep(time.Second)
-			if _, err := syscall.Write(fd, []byte("scan")); err != nil {
-				panic(err)
-			}
-			n, err := syscall.Read(fd, kmemleakBuf)
-			if err != nil {
-				panic(err)
-			}
-			if n != 0 {
-				// BUG in output should be recognized by manager.
-				logf(0, "BUG: memory leak:\n%s\n", kmemleakBuf[:n])
-			}
-		}
-	}
-	if _, err := syscall.Write(fd, []byte("clear")); err != nil {
-		panic(err)
-	}
-}
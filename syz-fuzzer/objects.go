@@ -0,0 +1,70 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sync"
+
+	"github.com/google/syzkaller/ipc"
+)
+
+// objectTracker accounts kernel objects that programs can leave behind after exit
+// (open files, sockets, SysV IPC objects). These leaks are usually not found by kmemleak
+// because the objects are still referenced from global lists.
+// The executor counts objects after every test process exits (see ipc.FlagObjects), but some counts
+// are shared with concurrently running programs (e.g. open files are system-wide),
+// so we look only at the minimum count observed after program executions during a poll period.
+// If the minimum grows over time, objects outlive programs that created them.
+type objectTracker struct {
+	mu    sync.Mutex
+	min   []uint64 // minimum counts observed during the current period
+	floor []uint64 // highest minimum reported so far
+	valid []bool   // min is set for the current period
+	init  []bool   // floor is set
+}
+
+func newObjectTracker() *objectTracker {
+	n := len(ipc.ObjectKinds)
+	return &objectTracker{
+		min:   make([]uint64, n),
+		floor: make([]uint64, n),
+		valid: make([]bool, n),
+		init:  make([]bool, n),
+	}
+}
+
+// sample is called with object counts (see ipc.Env.Objects) after every program execution.
+func (t *objectTracker) sample(counts []uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, v := range counts {
+		if v == ipc.ObjectUnknown {
+			continue
+		}
+		if !t.valid[i] || v < t.min[i] {
+			t.min[i] = v
+			t.valid[i] = true
+		}
+	}
+}
+
+// flush adds growth of the minimum counts since the previous flush to stats as "leaked <kind>".
+// The first period establishes the baseline.
+func (t *objectTracker) flush(stats map[string]uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, kind := range ipc.ObjectKinds {
+		if !t.valid[i] {
+			continue
+		}
+		if !t.init[i] {
+			t.floor[i] = t.min[i]
+			t.init[i] = true
+		} else if t.min[i] > t.floor[i] {
+			stats["leaked "+kind] += t.min[i] - t.floor[i]
+			t.floor[i] = t.min[i]
+		}
+		t.valid[i] = false
+	}
+}
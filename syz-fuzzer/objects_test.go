@@ -0,0 +1,92 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/syzkaller/ipc"
+)
+
+func TestObjectTracker(t *testing.T) {
+	const unknown = ipc.ObjectUnknown
+	tests := []struct {
+		name    string
+		periods [][][]uint64        // samples in every poll period
+		leaked  []map[string]uint64 // stats after every flush
+	}{
+		{
+			name: "baseline",
+			periods: [][][]uint64{
+				{{10, 5, 0, 0, 0}, {12, 6, 1, 0, 0}},
+			},
+			leaked: []map[string]uint64{{}},
+		},
+		{
+			name: "growing minimum",
+			periods: [][][]uint64{
+				{{10, 5, 0, 0, 0}},
+				{{13, 5, 1, 0, 0}, {11, 7, 2, 0, 0}},
+				{{14, 5, 1, 0, 0}},
+			},
+			leaked: []map[string]uint64{
+				{},
+				{"leaked files": 1, "leaked shm": 1},
+				{"leaked files": 3},
+			},
+		},
+		{
+			name: "spikes of concurrent programs",
+			periods: [][][]uint64{
+				{{10, 5, 0, 0, 0}},
+				{{100, 50, 9, 9, 9}, {10, 5, 0, 0, 0}},
+			},
+			leaked: []map[string]uint64{{}, {}},
+		},
+		{
+			name: "drop and regrow",
+			periods: [][][]uint64{
+				{{10, 5, 0, 0, 0}},
+				{{8, 5, 0, 0, 0}},
+				{{11, 5, 0, 0, 0}},
+			},
+			// The floor is the highest reported minimum, so regrowth up to it is not reported again.
+			leaked: []map[string]uint64{{}, {}, {"leaked files": 1}},
+		},
+		{
+			name: "unknown counts",
+			periods: [][][]uint64{
+				{{10, unknown, 0, 0, 0}},
+				{{10, 5, 0, 0, unknown}},
+				{{10, 6, 0, 0, 2}, {unknown, unknown, unknown, unknown, unknown}},
+			},
+			// The sockets baseline is established in the first period they are known.
+			leaked: []map[string]uint64{{}, {}, {"leaked sockets": 1, "leaked sem": 2}},
+		},
+		{
+			name: "no samples",
+			periods: [][][]uint64{
+				{{10, 5, 0, 0, 0}},
+				{},
+				{nil},
+				{{12, 5, 0, 0, 0}},
+			},
+			leaked: []map[string]uint64{{}, {}, {}, {"leaked files": 2}},
+		},
+	}
+	for _, test := range tests {
+		tracker := newObjectTracker()
+		for i, period := range test.periods {
+			for _, counts := range period {
+				tracker.sample(counts)
+			}
+			stats := make(map[string]uint64)
+			tracker.flush(stats)
+			if !reflect.DeepEqual(stats, test.leaked[i]) {
+				t.Errorf("%v: period #%v: got %v, want %v", test.name, i, stats, test.leaked[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+)
+
+// API for deployment tooling. All requests must be POSTs with "Authorization: Bearer <api_key>" header,
+// the API is disabled if api_key config param is not set.
+func (mgr *Manager) initAPI() {
+	http.HandleFunc("/reload", mgr.apiHandler(mgr.reload))
+	http.HandleFunc("/pause", mgr.apiHandler(mgr.pause))
+	http.HandleFunc("/resume", mgr.apiHandler(mgr.resume))
+	http.HandleFunc("/shutdown", mgr.apiHandler(mgr.shutdownAndFlush))
+	http.HandleFunc("/test_patch", mgr.httpTestPatch)
+	http.HandleFunc("/test_repro", mgr.httpTestRepro)
+}
+
+// apiAuthorized checks that r is an authorized API request and replies with an error otherwise.
+func (mgr *Manager) apiAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	if mgr.cfg.Api_Key == "" {
+		http.Error(w, "api is disabled (set api_key config param)", http.StatusForbidden)
+		return false
+	}
+	auth := []byte(r.Header.Get("Authorization"))
+	if subtle.ConstantTimeCompare(auth, []byte("Bearer "+mgr.cfg.Api_Key)) != 1 {
+		http.Error(w, "bad api key", http.StatusUnauthorized)
+		return false
+	}
+	if r.Method != "POST" {
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return false
+	}
+	logf(0, "api request %v from %v", r.URL.Path, r.RemoteAddr)
+	return true
+}
+
+func (mgr *Manager) apiHandler(fn func() (string, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !mgr.apiAuthorized(w, r) {
+			return
+		}
+		msg, err := fn()
+		if err != nil {
+			logf(0, "api request %v failed: %v", r.URL.Path, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		logf(0, "%v", msg)
+		fmt.Fprintf(w, "%v\n", msg)
+		if atomic.LoadUint32(&mgr.shutdown) != 0 {
+			// Make sure the client gets the response before the manager exits.
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+			mgr.exit()
+		}
+	}
+}
+
+// pause stops all VMs and waits until they are stopped, new VMs are not started until resume.
+func (mgr *Manager) pause() (string, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if !mgr.paused {
+		mgr.paused = true
+		mgr.resumeC = make(chan bool)
+		close(mgr.stopC)
+	}
+	for mgr.active != 0 {
+		mgr.idle.Wait()
+	}
+	return "paused, all VMs are stopped", nil
+}
+
+func (mgr *Manager) resume() (string, error) {
+	if atomic.LoadUint32(&mgr.shutdown) != 0 {
+		return "", fmt.Errorf("shutting down")
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if !mgr.paused {
+		return "not paused", nil
+	}
+	mgr.paused = false
+	mgr.stopC = make(chan bool)
+	close(mgr.resumeC)
+	return "resumed", nil
+}
+
+// shutdownAndFlush stops all VMs, does the final backup, after that the manager exits.
+// Corpus and crashes are persisted as they are found, so they don't need flushing,
+// triage state and covered functions are saved.
+func (mgr *Manager) shutdownAndFlush() (string, error) {
+	if atomic.LoadUint32(&mgr.shutdown) != 0 {
+		return "", fmt.Errorf("already shutting down")
+	}
+	if _, err := mgr.pause(); err != nil {
+		return "", err
+	}
+	atomic.StoreUint32(&mgr.shutdown, 1)
+	mgr.saveTriage()
+	mgr.saveFuncs()
+	if mgr.cfg.Backup != "" {
+		if err := mgr.backup(); err != nil {
+			return "", err
+		}
+	}
+	mgr.flushed = true
+	return "all VMs are stopped and state is flushed, exiting", nil
+}
+
+func (mgr *Manager) exit() {
+	mgr.exitOnce.Do(func() {
+		close(mgr.exitC)
+	})
+}
+
+// waitResumed blocks while the manager is paused (unless it is exiting).
+func (mgr *Manager) waitResumed() {
+	mgr.mu.Lock()
+	paused, resumeC := mgr.paused, mgr.resumeC
+	mgr.mu.Unlock()
+	if paused {
+		select {
+		case <-resumeC:
+		case <-mgr.exitC:
+		}
+	}
+}
+
+// instanceStarted registers a running instance, the returned channel is closed when it needs to stop.
+func (mgr *Manager) instanceStarted() <-chan bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.active++
+	return mgr.stopC
+}
+
+func (mgr *Manager) instanceStopped() {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.active--
+	mgr.idle.Broadcast()
+}
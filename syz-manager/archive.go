@@ -0,0 +1,68 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// With Console_Archive_Size raw console output of every VM is appended to
+// workdir/instances/<vm>/console.log, so the complete history of the instance is available
+// when a crash log contains only the last Crash_Context_Before KB of output.
+// Unlike workdir/console/<vm>.log (Crash_Full_Log) the file is not truncated when the VM
+// is restarted, every start is marked with a line in the output instead.
+// The file is rotated the same way as the manager log files (see logfiles.go).
+
+type consoleArchive struct {
+	lf      *logFile
+	maxSize int64
+	count   int
+}
+
+// openConsoleArchive opens the console archive for VM name,
+// it returns nil if archiving is disabled or the file can't be opened.
+func (mgr *Manager) openConsoleArchive(name string) *consoleArchive {
+	if mgr.cfg.Console_Archive_Size == 0 {
+		return nil
+	}
+	dir := filepath.Join(mgr.cfg.Workdir, "instances", name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		vmLogf(0, name, "failed to create console archive dir: %v", err)
+		return nil
+	}
+	a := &consoleArchive{
+		lf:      &logFile{path: filepath.Join(dir, "console.log")},
+		maxSize: int64(mgr.cfg.Console_Archive_Size) << 20,
+		count:   mgr.cfg.Console_Archive_Count,
+	}
+	a.lf.open(os.O_WRONLY | os.O_CREATE | os.O_APPEND)
+	if a.lf.f == nil {
+		return nil
+	}
+	a.write([]byte(fmt.Sprintf("\nsyzkaller: instance started at %v\n", time.Now().Format(logTimeFormat))))
+	return a
+}
+
+func (a *consoleArchive) write(out []byte) {
+	if a.lf.f == nil {
+		return
+	}
+	if a.lf.size != 0 && a.lf.size+int64(len(out)) > a.maxSize {
+		a.lf.rotate(a.count)
+		if a.lf.f == nil {
+			return
+		}
+	}
+	n, _ := a.lf.f.Write(out)
+	a.lf.size += int64(n)
+}
+
+func (a *consoleArchive) close() {
+	if a.lf.f != nil {
+		a.lf.f.Close()
+	}
+}
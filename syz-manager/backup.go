@@ -0,0 +1,172 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/fileutil"
+)
+
+// backupDirs are workdir subdirs that accumulate over time and are worth backing up,
+// everything else in workdir is temporary.
+var backupDirs = []string{"corpus", "crashes", "funcs", "poisoned"}
+
+// restoreBackup replaces corpus, crashes, covered functions and poisoned programs in workdir
+// with their copies from cfg.Backup. Every dir is synced into a fresh dir which is then swapped in,
+// so programs that were removed by minimization are not resurrected and a failed restore
+// leaves the workdir intact.
+func (mgr *Manager) restoreBackup() {
+	for _, dir := range backupDirs {
+		if err := restoreDir(backupPath(mgr.cfg.Backup, dir), filepath.Join(mgr.cfg.Workdir, dir)); err != nil {
+			logf(0, "failed to restore %v from backup: %v", dir, err)
+			continue
+		}
+		logf(0, "restored %v from %v", dir, mgr.cfg.Backup)
+	}
+}
+
+func restoreDir(backup, local string) error {
+	fresh := local + ".restore"
+	old := local + ".old"
+	os.RemoveAll(fresh)
+	os.RemoveAll(old)
+	if err := syncDir(backup, fresh, local); err != nil {
+		os.RemoveAll(fresh)
+		return err
+	}
+	if err := os.Rename(local, old); err != nil && !os.IsNotExist(err) {
+		os.RemoveAll(fresh)
+		return err
+	}
+	if err := os.Rename(fresh, local); err != nil {
+		os.Rename(old, local)
+		return err
+	}
+	os.RemoveAll(old)
+	return nil
+}
+
+// backup makes cfg.Backup a copy of corpus, crashes, covered functions and poisoned programs,
+// files that were deleted from workdir (e.g. by corpus minimization) are deleted from the backup.
+// The backup is restored on startup before any backups, so an empty workdir does not wipe it.
+func (mgr *Manager) backup() error {
+	for _, dir := range backupDirs {
+		local := filepath.Join(mgr.cfg.Workdir, dir)
+		if _, err := os.Stat(local); os.IsNotExist(err) {
+			continue
+		}
+		if err := syncDir(local, backupPath(mgr.cfg.Backup, dir), ""); err != nil {
+			return fmt.Errorf("failed to backup %v: %v", dir, err)
+		}
+	}
+	return nil
+}
+
+func (mgr *Manager) backupLoop() {
+	for {
+		time.Sleep(time.Duration(mgr.cfg.Backup_Period) * time.Minute)
+		start := time.Now()
+		if err := mgr.backup(); err != nil {
+			logf(0, "%v", err)
+			continue
+		}
+		logf(1, "backed up workdir to %v in %v", mgr.cfg.Backup, time.Since(start))
+	}
+}
+
+func backupPath(backup, dir string) string {
+	return strings.TrimSuffix(backup, "/") + "/" + dir
+}
+
+// syncDir makes dst dir an exact copy of src dir (files that are not present in src are deleted from dst),
+// one of them can be remote. Unchanged files are hard linked from local dir linkDest (if set) instead of copying.
+func syncDir(src, dst, linkDest string) error {
+	var cmd *exec.Cmd
+	switch {
+	case strings.HasPrefix(src, "gs://") || strings.HasPrefix(dst, "gs://"):
+		if !strings.HasPrefix(dst, "gs://") {
+			if err := os.MkdirAll(dst, 0700); err != nil {
+				return err
+			}
+		}
+		cmd = exec.Command("gsutil", "-m", "-q", "rsync", "-r", "-d", src, dst)
+	case isRemote(src) || isRemote(dst):
+		args := []string{"-a", "--delete"}
+		if linkDest != "" {
+			abs, err := filepath.Abs(linkDest)
+			if err != nil {
+				return err
+			}
+			args = append(args, "--link-dest="+abs)
+		}
+		cmd = exec.Command("rsync", append(args, src+"/", dst+"/")...)
+	default:
+		return mirrorDir(src, dst)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v failed: %v\n%s", strings.Join(cmd.Args, " "), err, out)
+	}
+	return nil
+}
+
+// isRemote says if path is a remote rsync location (host:path or rsync://host/path).
+func isRemote(path string) bool {
+	colon := strings.IndexByte(path, ':')
+	slash := strings.IndexByte(path, '/')
+	return colon != -1 && (slash == -1 || colon < slash)
+}
+
+// mirrorDir syncs local dirs without external tools, files are compared by size and modification time.
+func mirrorDir(src, dst string) error {
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return err
+	}
+	present := make(map[string]bool)
+	for _, f := range files {
+		present[f.Name()] = true
+		from, to := filepath.Join(src, f.Name()), filepath.Join(dst, f.Name())
+		if f.IsDir() {
+			if st, err := os.Stat(to); err == nil && !st.IsDir() {
+				os.Remove(to)
+			}
+			if err := mirrorDir(from, to); err != nil {
+				return err
+			}
+			continue
+		}
+		if st, err := os.Stat(to); err == nil && st.Size() == f.Size() && st.ModTime().Equal(f.ModTime()) {
+			continue
+		}
+		os.RemoveAll(to)
+		if err := fileutil.CopyFile(from, to, false); err != nil {
+			return err
+		}
+		if err := os.Chtimes(to, f.ModTime(), f.ModTime()); err != nil {
+			return err
+		}
+	}
+	existing, err := ioutil.ReadDir(dst)
+	if err != nil {
+		return err
+	}
+	for _, f := range existing {
+		if !present[f.Name()] {
+			if err := os.RemoveAll(filepath.Join(dst, f.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
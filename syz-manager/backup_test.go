@@ -0,0 +1,119 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/syzkaller/config"
+)
+
+func TestBackupRestore(t *testing.T) {
+	workdir, err := ioutil.TempDir("", "syz-manager-workdir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workdir)
+	backup, err := ioutil.TempDir("", "syz-manager-backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(backup)
+	mgr := &Manager{cfg: &config.Config{Workdir: workdir, Backup: backup}}
+
+	write := func(file, data string) {
+		file = filepath.Join(workdir, file)
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := func(dir string, want map[string]string) {
+		got := make(map[string]string)
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rel, _ := filepath.Rel(dir, path)
+			got[rel] = string(data)
+			return nil
+		})
+		if !reflect.DeepEqual(got, want) {
+			var names []string
+			for name := range got {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			t.Fatalf("bad contents of %v: %v\ngot: %+v\nwant: %+v", dir, names, got, want)
+		}
+	}
+
+	write("corpus/a", "prog a")
+	write("corpus/b", "prog b")
+	write("crashes/1/description", "crash 1")
+	write("instance-0", "temporary")
+	if err := mgr.backup(); err != nil {
+		t.Fatal(err)
+	}
+	check(backup, map[string]string{
+		"corpus/a":              "prog a",
+		"corpus/b":              "prog b",
+		"crashes/1/description": "crash 1",
+	})
+
+	// Minimization removes programs, they must be removed from the backup as well.
+	os.Remove(filepath.Join(workdir, "corpus", "a"))
+	write("corpus/c", "prog c")
+	write("crashes/1/description", "crash 1 updated")
+	if err := mgr.backup(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"corpus/b":              "prog b",
+		"corpus/c":              "prog c",
+		"crashes/1/description": "crash 1 updated",
+	}
+	check(backup, want)
+
+	// A damaged workdir is replaced with the backup, removed programs are not resurrected.
+	os.Remove(filepath.Join(workdir, "corpus", "b"))
+	write("corpus/a", "prog a")
+	os.RemoveAll(filepath.Join(workdir, "crashes"))
+	mgr.restoreBackup()
+	want["instance-0"] = "temporary"
+	check(workdir, want)
+
+	// Restore from a missing backup leaves the workdir intact.
+	os.RemoveAll(filepath.Join(backup, "corpus"))
+	mgr.restoreBackup()
+	check(workdir, want)
+}
+
+func TestIsRemote(t *testing.T) {
+	tests := map[string]bool{
+		"/backup/syzkaller":         false,
+		"backup/dir:with:colons":    false,
+		"host:backup":               true,
+		"user@host:/backup":         true,
+		"rsync://host/module/path":  true,
+		"./relative/path":           false,
+		"host.example.com:/backups": true,
+	}
+	for path, want := range tests {
+		if got := isRemote(path); got != want {
+			t.Errorf("isRemote(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Every crash description is a bug with a status (see bugStatuses), an assignee and an audit history
+// of changes, /crashes shows them. New bugs are assigned to the first maintainer of the guilty file
+// when the crash is symbolized (see kernel_src). Bugs are changed with authorized POST /bug API requests
+// (title, status, assignee, fix_commit, dup_of and comment form values), GET /bug?title=... returns a bug
+// with its history as JSON. A bug with a fix commit is moved to fixed automatically if the commit is
+// in kernel_commit of kernel_src (checked when the fix commit is set and on startup, so that restarts
+// with a new kernel close fixed bugs). Bugs are stored in workdir/bugs.json.
+
+var bugStatuses = []string{"new", "triaged", "reported", "fixed", "invalid", "dup"}
+
+type Bug struct {
+	Status    string
+	Assignee  string     `json:",omitempty"`
+	FixCommit string     `json:",omitempty"`
+	DupOf     string     `json:",omitempty"` // title of the original bug for dup status
+	Notified  bool       `json:",omitempty"` // notify_cmd was run for the bug (see reproduce.go)
+	History   []BugEvent `json:",omitempty"`
+}
+
+type BugEvent struct {
+	Time    time.Time
+	Who     string // remote address of the API request, "syz-manager" for automatic changes
+	Change  string
+	Comment string `json:",omitempty"`
+}
+
+func (mgr *Manager) bugsFile() string {
+	return filepath.Join(mgr.cfg.Workdir, "bugs.json")
+}
+
+func (mgr *Manager) loadBugs() {
+	mgr.bugs = make(map[string]*Bug)
+	data, err := ioutil.ReadFile(mgr.bugsFile())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fatalf("failed to read bugs: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &mgr.bugs); err != nil {
+		fatalf("failed to parse %v: %v", mgr.bugsFile(), err)
+	}
+	changed := false
+	for title, bug := range mgr.bugs {
+		if mgr.checkFixed(title, bug) {
+			changed = true
+		}
+	}
+	if changed {
+		if err := mgr.saveBugs(); err != nil {
+			fatalf("failed to save bugs: %v", err)
+		}
+	}
+}
+
+// saveBugs writes bugs to workdir/bugs.json, mgr.mu must be held.
+func (mgr *Manager) saveBugs() error {
+	data, err := json.MarshalIndent(mgr.bugs, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp := mgr.bugsFile() + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0660); err != nil {
+		return err
+	}
+	return os.Rename(tmp, mgr.bugsFile())
+}
+
+// bug returns bug with the title, it is created with new status if it does not exist. mgr.mu must be held.
+func (mgr *Manager) bug(title string) *Bug {
+	bug := mgr.bugs[title]
+	if bug == nil {
+		bug = &Bug{Status: "new"}
+		mgr.bugs[title] = bug
+	}
+	return bug
+}
+
+func (bug *Bug) change(who, comment, msg string, args ...interface{}) {
+	bug.History = append(bug.History, BugEvent{
+		Time:    time.Now(),
+		Who:     who,
+		Change:  fmt.Sprintf(msg, args...),
+		Comment: comment,
+	})
+}
+
+// checkFixed moves bug with a fix commit to fixed if the commit is in kernel_commit,
+// it returns whether the bug has changed. mgr.mu must be held (or not needed yet).
+func (mgr *Manager) checkFixed(title string, bug *Bug) bool {
+	if bug.FixCommit == "" || bug.Status == "fixed" || bug.Status == "invalid" || bug.Status == "dup" ||
+		mgr.cfg.Kernel_Src == "" || mgr.cfg.Kernel_Commit == "" {
+		return false
+	}
+	fixed, err := gitIsAncestor(mgr.cfg.Kernel_Src, bug.FixCommit, mgr.cfg.Kernel_Commit)
+	if err != nil {
+		logf(0, "failed to check fix commit of '%v': %v", title, err)
+		return false
+	}
+	if !fixed {
+		return false
+	}
+	logf(0, "bug '%v' is fixed: %v is in %v", title, bug.FixCommit, mgr.cfg.Kernel_Commit)
+	bug.change("syz-manager", "", "status %v -> fixed: fix commit is in kernel commit %v", bug.Status, mgr.cfg.Kernel_Commit)
+	bug.Status = "fixed"
+	return true
+}
+
+// gitIsAncestor says if commit is an ancestor of (or is) head in git repo dir.
+func gitIsAncestor(dir, commit, head string) (bool, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", commit, head)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return true, nil
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("git merge-base failed: %v\n%s", err, output)
+}
+
+// autoAssignBug assigns the bug of symbolized crash log file to the first maintainer of the guilty file
+// if the bug is new and is not assigned yet.
+func (mgr *Manager) autoAssignBug(file string) {
+	maintainers := loadGuilty(file).Maintainers
+	if maintainers == "" {
+		return
+	}
+	title, err := crashDesc(file)
+	if err != nil {
+		logf(0, "%v", err)
+		return
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	bug := mgr.bug(title)
+	if bug.Status != "new" || bug.Assignee != "" {
+		return
+	}
+	bug.Assignee = strings.TrimSpace(strings.Split(maintainers, ",")[0])
+	bug.change("syz-manager", "", "assigned to %v (maintainer of the guilty file)", bug.Assignee)
+	if err := mgr.saveBugs(); err != nil {
+		logf(0, "failed to save bugs: %v", err)
+	}
+}
+
+func (mgr *Manager) httpBug(w http.ResponseWriter, r *http.Request) {
+	title := r.FormValue("title")
+	if title == "" {
+		http.Error(w, "title is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method != "POST" {
+		mgr.mu.Lock()
+		data, err := json.MarshalIndent(mgr.bugs[title], "", "\t")
+		mgr.mu.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+		return
+	}
+	if !mgr.apiAuthorized(w, r) {
+		return
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if err := mgr.updateBug(title, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := mgr.saveBugs(); err != nil {
+		http.Error(w, fmt.Sprintf("failed to save bugs: %v", err), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "bug '%v': %v\n", title, mgr.bugs[title].Status)
+}
+
+// updateBug applies changes requested by API request r to bug title, mgr.mu must be held.
+func (mgr *Manager) updateBug(title string, r *http.Request) error {
+	status, dupOf := r.FormValue("status"), r.FormValue("dup_of")
+	if status != "" {
+		known := false
+		for _, s := range bugStatuses {
+			known = known || s == status
+		}
+		if !known {
+			return fmt.Errorf("unknown status %v, must be one of %v", status, strings.Join(bugStatuses, "/"))
+		}
+	}
+	if (status == "dup") != (dupOf != "") {
+		return fmt.Errorf("dup_of must be set for dup status only")
+	}
+	if dupOf == title {
+		return fmt.Errorf("bug can't be a dup of itself")
+	}
+	who, comment := r.RemoteAddr, r.FormValue("comment")
+	bug := mgr.bug(title)
+	events := len(bug.History)
+	if assignee, ok := r.Form["assignee"]; ok && assignee[0] != bug.Assignee {
+		bug.change(who, comment, "assignee %q -> %q", bug.Assignee, assignee[0])
+		bug.Assignee = assignee[0]
+	}
+	if fix := r.FormValue("fix_commit"); fix != "" && fix != bug.FixCommit {
+		bug.change(who, comment, "fix commit %v", fix)
+		bug.FixCommit = fix
+	}
+	if status != "" && (status != bug.Status || dupOf != bug.DupOf) {
+		if status == "dup" {
+			bug.change(who, comment, "status %v -> dup of '%v'", bug.Status, dupOf)
+		} else {
+			bug.change(who, comment, "status %v -> %v", bug.Status, status)
+		}
+		bug.Status, bug.DupOf = status, dupOf
+	}
+	if comment != "" && len(bug.History) == events {
+		bug.change(who, comment, "comment")
+	}
+	mgr.checkFixed(title, bug)
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/syzkaller/sys"
+	"github.com/google/syzkaller/vm"
+)
+
+// Every saved crash log crash-xxx is accompanied by crash-xxx.build.json that describes what
+// produced the crash: kernel version, commit and config, syzkaller revision and the fuzzer command line.
+// The kernel config is read on startup (Kernel_Config or .config next to vmlinux) and archived
+// in crashdir once as kernel-config-<sha1>, build info refers to it by name.
+
+const buildInfoSuffix = ".build.json"
+
+// gitRevision is the syzkaller commit the manager was built from (set by Makefile with -ldflags).
+var gitRevision string
+
+type CrashBuildInfo struct {
+	Time                 time.Time `json:"time"`
+	VM                   string    `json:"vm"`
+	KernelVersion        string    `json:"kernel_version,omitempty"` // /proc/version in VMs
+	KernelCommit         string    `json:"kernel_commit,omitempty"`  // Kernel_Commit from the config
+	KernelBuild          string    `json:"kernel_build,omitempty"`   // hash of vmlinux
+	KernelConfig         string    `json:"kernel_config,omitempty"`  // archived kernel config file in crashdir
+	KernelCmdline        string    `json:"kernel_cmdline,omitempty"`
+	SyzkallerRevision    string    `json:"syzkaller_revision,omitempty"`
+	DescriptionsRevision string    `json:"descriptions_revision"`
+	FuzzerBuild          string    `json:"fuzzer_build"` // checksum of fuzzer/executor binaries
+	FuzzerCommand        string    `json:"fuzzer_command"`
+}
+
+func (mgr *Manager) initBuildInfo() {
+	file := mgr.kernelConfigFile()
+	if file == "" {
+		return
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if mgr.cfg.Kernel_Config != "" {
+			fatalf("failed to read kernel config: %v", err)
+		}
+		return
+	}
+	hash := sha1.Sum(data)
+	name := "kernel-config-" + hex.EncodeToString(hash[:])
+	if _, err := os.Stat(filepath.Join(mgr.crashdir, name)); err != nil {
+		if err := ioutil.WriteFile(filepath.Join(mgr.crashdir, name), data, 0640); err != nil {
+			logf(0, "failed to archive kernel config: %v", err)
+			return
+		}
+	}
+	mgr.kernelConfig = name
+}
+
+// saveBuildInfo writes build info for crash log crashFile saved on VM vmCfg.
+func (mgr *Manager) saveBuildInfo(crashFile string, vmCfg *vm.Config, build *build, fuzzerCmd string) {
+	mgr.mu.Lock()
+	kernelVersion := mgr.kernelVersion
+	mgr.mu.Unlock()
+	info := &CrashBuildInfo{
+		Time:                 time.Now(),
+		VM:                   vmCfg.Name,
+		KernelVersion:        kernelVersion,
+		KernelCommit:         mgr.cfg.Kernel_Commit,
+		KernelBuild:          mgr.kernelBuild,
+		KernelConfig:         mgr.kernelConfig,
+		KernelCmdline:        vmCfg.Cmdline,
+		SyzkallerRevision:    gitRevision,
+		DescriptionsRevision: sys.Revision,
+		FuzzerBuild:          build.checksum,
+		FuzzerCommand:        fuzzerCmd,
+	}
+	data, err := json.MarshalIndent(info, "", "\t")
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(crashFile+buildInfoSuffix, data, 0660); err != nil {
+		logf(0, "failed to write build info: %v", err)
+	}
+}
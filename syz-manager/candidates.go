@@ -0,0 +1,107 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+
+	. "github.com/google/syzkaller/rpctype"
+)
+
+// Candidates handed to a fuzzer stay in Fuzzer.candidates until the fuzzer acknowledges them in Poll
+// (after the candidate and all inputs it produced are triaged). If the VM dies before that,
+// unacknowledged candidates are given to other fuzzers. Candidates of VMs that crashed or hung
+// are suspects and are handed out alone, so that the next VM death can be attributed to them. A suspect that
+// killed its VM alone maxCandidateRedelivery times is poisoned: it crashes the kernel or wedges
+// the executor itself, so it is saved to workdir/poisoned and is never handed out again
+// (including after restarts).
+
+const maxCandidateRedelivery = 3
+
+// addCandidate queues a program for triage, unless the same program is already queued,
+// handed to a fuzzer, triaged or poisoned.
+func (mgr *Manager) addCandidate(data []byte) {
+	sig := hash(data)
+	if mgr.candidateSigs[sig] {
+		return
+	}
+	if _, ok := mgr.poisoned.m[sig]; ok {
+		return
+	}
+	mgr.candidateSigs[sig] = true
+	mgr.candidates = append(mgr.candidates, data)
+}
+
+// pollCandidates hands out up to n candidates to fuzzer f. Candidates that contain calls
+// disabled at runtime are moved to mgr.heldCandidates instead (see syscalls.go).
+func (mgr *Manager) pollCandidates(f *Fuzzer, n int) []RpcCandidate {
+	var res []RpcCandidate
+	enabled := mgr.pollCalls()
+	for len(res) < n && len(mgr.candidates) > 0 {
+		last := len(mgr.candidates) - 1
+		data := mgr.candidates[last]
+		if enabled != nil && !callsEnabled(data, enabled) {
+			mgr.candidates = mgr.candidates[:last]
+			mgr.heldCandidates = append(mgr.heldCandidates, data)
+			continue
+		}
+		sig := hash(data)
+		id := hex.EncodeToString(sig[:])
+		suspect := mgr.redelivered[id] != 0
+		if suspect && len(f.candidates) != 0 {
+			break
+		}
+		mgr.candidates = mgr.candidates[:last]
+		f.candidates[id] = data
+		res = append(res, RpcCandidate{ID: id, Prog: data})
+		if suspect {
+			break
+		}
+	}
+	if len(mgr.candidates) == 0 {
+		mgr.candidates = nil
+	}
+	return res
+}
+
+// doneCandidates handles candidate acknowledgments from fuzzer f.
+func (mgr *Manager) doneCandidates(f *Fuzzer, ids []string) {
+	for _, id := range ids {
+		delete(f.candidates, id)
+		delete(mgr.redelivered, id)
+	}
+}
+
+// requeueCandidates returns candidates that fuzzer f has not acknowledged to the queue.
+// The candidates are counted as suspects only if the VM crashed or hung: VMs that are stopped
+// (pause/shutdown) or restarted on purpose (build switch, instance lifetime) say nothing about them.
+func (mgr *Manager) requeueCandidates(f *Fuzzer, crashed bool) {
+	alone := len(f.candidates) == 1
+	for id, data := range f.candidates {
+		delete(f.candidates, id)
+		if !crashed {
+			mgr.candidates = append(mgr.candidates, data)
+			continue
+		}
+		mgr.redelivered[id]++
+		if alone && mgr.redelivered[id] > maxCandidateRedelivery {
+			logf(0, "poisoned candidate %v: its VM died %v times", id, mgr.redelivered[id])
+			delete(mgr.redelivered, id)
+			mgr.poisoned.add(data)
+			mgr.stats["poisoned candidates"]++
+			continue
+		}
+		vmLogf(1, f.name, "redelivering candidate %v", id)
+		mgr.candidates = append(mgr.candidates, data)
+	}
+}
+
+// untriaged returns number of candidates that are queued, held or handed to fuzzers.
+func (mgr *Manager) untriaged() int {
+	n := len(mgr.candidates) + len(mgr.heldCandidates)
+	for _, f := range mgr.fuzzers {
+		n += len(f.candidates)
+	}
+	return n
+}
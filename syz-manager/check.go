@@ -0,0 +1,97 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/google/syzkaller/rpctype"
+	"github.com/google/syzkaller/sys"
+)
+
+// On startup every fuzzer checks the machine (kcov, debugfs, KASAN, kallsyms and syscalls
+// the kernel does not support) and reports the result with Manager.Check. The manager
+// takes the first result: without kcov it refuses to run with coverage instead of fuzzing
+// with no feedback, it disables unsupported calls for all fuzzers, and the main page shows the result.
+
+func (mgr *Manager) Check(a *CheckArgs, r *int) error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if len(a.KallsymsData) != 0 && mgr.kallsyms == nil {
+		mgr.loadKallsyms(a.Name, a.KallsymsData)
+	}
+	a.KallsymsData = nil
+	if mgr.checkResult != nil {
+		return nil
+	}
+	logf(0, "machine check from %v: kcov=%v debugfs=%v kasan=%v kallsyms=%v, %v unsupported calls, %v transitively disabled",
+		a.Name, a.Kcov, a.Debugfs, a.Kasan, a.Kallsyms, len(a.UnsupportedCalls), len(a.TransitivelyDisabled))
+	if mgr.cfg.Cover && !a.Kcov {
+		fatalf("machine check failed: /sys/kernel/debug/kcov is not available in the VM " +
+			"(build the kernel with CONFIG_KCOV and CONFIG_DEBUG_FS and mount debugfs, or set \"cover\": false)")
+	}
+	if !a.Kasan {
+		logf(0, "kernel is built without CONFIG_KASAN, memory safety bugs will mostly go unnoticed")
+	}
+	if !a.Kallsyms {
+		logf(0, "/proc/kallsyms is not readable, syscall support is not detected")
+	}
+	mgr.checkResult = a
+	mgr.disableCalls(append(append([]string{}, a.UnsupportedCalls...), a.TransitivelyDisabled...))
+	return nil
+}
+
+// disableCalls removes calls from the enabled calls sent to fuzzers.
+func (mgr *Manager) disableCalls(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	enabled := mgr.enabledCalls()
+	for _, name := range names {
+		if c := sys.CallMap[name]; c != nil {
+			delete(enabled, c.ID)
+		}
+	}
+	if len(enabled) == 0 {
+		fatalf("machine check failed: none of the enabled syscalls are supported by the kernel")
+	}
+	mgr.enabledSyscalls = encodeCalls(enabled)
+}
+
+// encodeCalls returns comma-separated IDs of calls, the format of ConnectRes.EnabledCalls.
+func encodeCalls(calls map[int]bool) string {
+	buf := new(bytes.Buffer)
+	for _, c := range sys.Calls {
+		if calls[c.ID] {
+			fmt.Fprintf(buf, ",%v", c.ID)
+		}
+	}
+	if buf.Len() == 0 {
+		return ""
+	}
+	return buf.String()[1:]
+}
+
+// enabledCalls returns IDs of calls that are currently enabled for fuzzers.
+func (mgr *Manager) enabledCalls() map[int]bool {
+	enabled := make(map[int]bool)
+	if mgr.enabledSyscalls == "" {
+		for _, c := range sys.Calls {
+			enabled[c.ID] = true
+		}
+		return enabled
+	}
+	for _, id := range strings.Split(mgr.enabledSyscalls, ",") {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			panic(err)
+		}
+		enabled[n] = true
+	}
+	return enabled
+}
@@ -0,0 +1,300 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/cover"
+	"github.com/google/syzkaller/fileutil"
+)
+
+// Covered kernel functions are saved per kernel build (identified by hash of vmlinux)
+// into workdir/funcs, so that when the manager is restarted with a new kernel
+// /cover_delta can show functions that are not reachable anymore and newly reachable ones.
+// Current functions come from mgr.funcsCache shared with /covered_funcs.
+
+const funcsSavePeriod = time.Hour
+
+// initFuncs identifies the kernel build and finds the previous build the manager ran.
+func (mgr *Manager) initFuncs() {
+	mgr.funcsdir = filepath.Join(mgr.cfg.Workdir, "funcs")
+	os.MkdirAll(mgr.funcsdir, 0700)
+	if mgr.cfg.Vmlinux == "" {
+		return
+	}
+	id, err := fileutil.Hash(mgr.cfg.Vmlinux)
+	if err != nil {
+		logf(0, "failed to hash vmlinux: %v", err)
+		return
+	}
+	mgr.kernelBuild = id
+	files, err := ioutil.ReadDir(mgr.funcsdir)
+	if err != nil {
+		logf(0, "failed to read funcs dir: %v", err)
+		return
+	}
+	var last time.Time
+	for _, f := range files {
+		if f.Name() != id && !strings.HasSuffix(f.Name(), ".tmp") && f.ModTime().After(last) {
+			mgr.prevKernelBuild = f.Name()
+			last = f.ModTime()
+		}
+	}
+	if mgr.prevKernelBuild != "" {
+		logf(0, "kernel build %v, previous build %v", id, mgr.prevKernelBuild)
+	}
+	go func() {
+		for {
+			time.Sleep(funcsSavePeriod)
+			mgr.saveFuncs()
+		}
+	}()
+}
+
+// saveFuncs saves functions covered by the corpus for the current kernel build.
+// Nothing is saved until the corpus is triaged, otherwise a restart with the same kernel
+// would overwrite the saved functions with partial coverage.
+func (mgr *Manager) saveFuncs() {
+	if mgr.kernelBuild == "" {
+		return
+	}
+	cur, err := mgr.currentFuncs()
+	if err != nil {
+		logf(0, "%v", err)
+		return
+	}
+	if cur.Candidates != 0 || len(cur.Funcs) == 0 {
+		return
+	}
+	if err := writeFuncs(filepath.Join(mgr.funcsdir, mgr.kernelBuild), cur.Funcs); err != nil {
+		logf(0, "failed to save covered functions: %v", err)
+	}
+}
+
+// coreCover returns corpus coverage in the core kernel (module coverage depends on load addresses).
+func (mgr *Manager) coreCover() cover.Cover {
+	var cov cover.Cover
+	for _, c := range mgr.corpusCover {
+		cov = cover.Union(cov, c)
+	}
+	if len(mgr.modules) != 0 {
+		cov = cover.SplitByModule(cov, mgr.modules)[cover.CoreKernel]
+	}
+	return cov
+}
+
+func (mgr *Manager) httpCoverDelta(w http.ResponseWriter, r *http.Request) {
+	if mgr.prevKernelBuild == "" {
+		http.Error(w, "no coverage for previous kernel builds", http.StatusNotFound)
+		return
+	}
+	prev, err := readFuncs(filepath.Join(mgr.funcsdir, mgr.prevKernelBuild))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read covered functions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	funcs, err := mgr.currentFuncs()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := &UICoverDelta{
+		Build:      mgr.kernelBuild,
+		PrevBuild:  mgr.prevKernelBuild,
+		Candidates: funcs.Candidates,
+	}
+	cur := make(map[string]bool)
+	for _, fn := range funcs.Funcs {
+		cur[fn] = true
+	}
+	for fn := range prev {
+		if !cur[fn] {
+			data.Lost = append(data.Lost, fn)
+		}
+	}
+	for fn := range cur {
+		if !prev[fn] {
+			data.New = append(data.New, fn)
+		}
+	}
+	sort.Strings(data.Lost)
+	sort.Strings(data.New)
+	data.Covered, data.PrevCovered = len(cur), len(prev)
+	if err := coverDeltaTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// vmlinuxFuncs returns names of functions that contain PCs from cov.
+// vmlinux does not change while the manager runs, so only PCs that are not in mgr.funcsCache.pcs yet
+// are symbolized. mgr.funcsCache.mu must be held.
+func (mgr *Manager) vmlinuxFuncs(cov []uint32) (map[string]bool, error) {
+	c := &mgr.funcsCache
+	if c.pcs == nil {
+		c.pcs = make(map[uint32]string)
+	}
+	var pcs []uint32
+	for _, pc := range cov {
+		if _, ok := c.pcs[pc]; !ok {
+			pcs = append(pcs, pc)
+		}
+	}
+	if len(pcs) != 0 {
+		names, err := symbolizeFuncs(mgr.cfg.Vmlinux, pcs)
+		if err != nil {
+			return nil, err
+		}
+		for i, pc := range pcs {
+			c.pcs[pc] = names[i]
+		}
+	}
+	funcs := make(map[string]bool)
+	for _, pc := range cov {
+		if fn := c.pcs[pc]; fn != "" {
+			funcs[fn] = true
+		}
+	}
+	return funcs, nil
+}
+
+// symbolizeFuncs returns names of functions that contain PCs, "" for unknown PCs.
+func symbolizeFuncs(vmlinux string, pcs []uint32) ([]string, error) {
+	base, err := getVmOffset(vmlinux)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("addr2line", "-a", "-f", "-e", vmlinux)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	defer stdin.Close()
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	defer stdout.Close()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	defer cmd.Wait()
+	go func() {
+		for _, pc := range pcs {
+			fmt.Fprintf(stdin, "0x%x\n", cover.RestorePC(pc, base)-1)
+		}
+		stdin.Close()
+	}()
+	funcs, err := parseFuncs(stdout)
+	if err != nil {
+		return nil, err
+	}
+	if len(funcs) != len(pcs) {
+		return nil, fmt.Errorf("addr2line returned %v functions for %v PCs", len(funcs), len(pcs))
+	}
+	return funcs, nil
+}
+
+// parseFuncs parses output of addr2line -a -f: every address line is followed
+// by function name and file:line. It returns function of every address, "" for unknown ("??").
+// Function names are shared between addresses to save memory.
+func parseFuncs(r io.Reader) ([]string, error) {
+	var funcs []string
+	names := make(map[string]string)
+	s := bufio.NewScanner(r)
+	addr := false
+	for s.Scan() {
+		ln := s.Text()
+		if strings.HasPrefix(ln, "0x") {
+			if addr {
+				funcs = append(funcs, "")
+			}
+			addr = true
+			continue
+		}
+		if !addr {
+			continue
+		}
+		addr = false
+		if ln == "??" {
+			funcs = append(funcs, "")
+			continue
+		}
+		if names[ln] == "" {
+			names[ln] = ln
+		}
+		funcs = append(funcs, names[ln])
+	}
+	if addr {
+		funcs = append(funcs, "")
+	}
+	return funcs, s.Err()
+}
+
+func readFuncs(file string) (map[string]bool, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	funcs := make(map[string]bool)
+	for _, fn := range strings.Split(string(data), "\n") {
+		if fn != "" {
+			funcs[fn] = true
+		}
+	}
+	return funcs, nil
+}
+
+// writeFuncs writes sorted function names.
+func writeFuncs(file string, names []string) error {
+	tmp := file + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strings.Join(names, "\n")+"\n"), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+type UICoverDelta struct {
+	Build       string
+	PrevBuild   string
+	Candidates  int
+	Covered     int
+	PrevCovered int
+	Lost        []string
+	New         []string
+}
+
+var coverDeltaTemplate = template.Must(template.New("").Parse(`
+<!doctype html>
+<html>
+<head>
+    <title>syzkaller coverage delta</title>
+</head>
+<body>
+Kernel build {{.Build}}: {{.Covered}} covered functions <br>
+Previous build {{.PrevBuild}}: {{.PrevCovered}} covered functions <br>
+{{if .Candidates}}<b>Corpus is not triaged yet ({{.Candidates}} candidates), lost functions can be reached later.</b> <br>{{end}}
+<br>
+<b>Lost functions: {{len .Lost}}</b> <br>
+{{range $fn := .Lost}}
+	<span style='color:red'>{{$fn}}</span> <br>
+{{end}}
+<br>
+<b>New functions: {{len .New}}</b> <br>
+{{range $fn := .New}}
+	{{$fn}} <br>
+{{end}}
+</body></html>
+`))
@@ -0,0 +1,44 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFuncs(t *testing.T) {
+	tests := []struct {
+		output string
+		want   []string
+	}{
+		{"", nil},
+		{
+			"0xffffffff81000010\nfoo\n/src/foo.c:10\n" +
+				"0xffffffff81000020\nbar\n/src/bar.c:20\n" +
+				"0xffffffff81000030\nfoo\n/src/foo.c:12\n",
+			[]string{"foo", "bar", "foo"},
+		},
+		{
+			"0xffffffff81000010\n??\n??:0\n" +
+				"0xffffffff81000020\nbar\n/src/bar.c:20\n",
+			[]string{"", "bar"},
+		},
+		{
+			// Truncated output: addresses without function.
+			"0xffffffff81000010\n0xffffffff81000020\nbar\n??:?\n0xffffffff81000030\n",
+			[]string{"", "bar", ""},
+		},
+	}
+	for i, test := range tests {
+		got, err := parseFuncs(strings.NewReader(test.output))
+		if err != nil {
+			t.Fatalf("test #%v: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test #%v: got %q, want %q", i, got, test.want)
+		}
+	}
+}
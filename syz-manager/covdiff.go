@@ -0,0 +1,190 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/sys"
+)
+
+// /covered_funcs exports kernel functions covered by the corpus (core kernel only) as JSON,
+// /cover_diff?peer=N fetches them from the N-th manager in Cover_Peers and shows functions
+// covered only by this manager or only by the peer, e.g. to find out why a vendor kernel
+// gets less coverage than upstream with the same descriptions.
+// Symbolization of coverage is slow, so covered functions are cached until size of corpus coverage changes
+// and with vmlinux functions of symbolized PCs are cached for the kernel build, so that only new coverage
+// is passed to addr2line.
+
+const coverPeerTimeout = 10 * time.Minute
+
+type CoveredFuncs struct {
+	Name        string   `json:"name"`         // manager name (HTTP address)
+	KernelBuild string   `json:"kernel_build"` // hash of vmlinux
+	Revision    string   `json:"revision"`     // descriptions revision
+	Candidates  int      `json:"candidates"`   // corpus is not triaged yet if not 0
+	Funcs       []string `json:"funcs"`        // sorted
+}
+
+type funcsCache struct {
+	mu    sync.Mutex
+	size  int // size of corpus coverage the funcs are computed for
+	funcs []string
+	pcs   map[uint32]string // function of every PC symbolized with addr2line ("" if unknown)
+}
+
+// currentFuncs returns sorted functions covered by the corpus.
+func (mgr *Manager) currentFuncs() (*CoveredFuncs, error) {
+	mgr.mu.Lock()
+	res := &CoveredFuncs{
+		Name:        mgr.cfg.Http,
+		KernelBuild: mgr.kernelBuild,
+		Revision:    sys.Revision,
+		Candidates:  mgr.untriaged(),
+	}
+	cov := mgr.coreCover()
+	mgr.mu.Unlock()
+
+	c := &mgr.funcsCache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.funcs == nil || c.size != len(cov) {
+		funcs, err := mgr.coveredFuncs(cov)
+		if err != nil {
+			return nil, fmt.Errorf("failed to symbolize coverage: %v", err)
+		}
+		c.funcs = []string{}
+		for fn := range funcs {
+			c.funcs = append(c.funcs, fn)
+		}
+		sort.Strings(c.funcs)
+		c.size = len(cov)
+	}
+	res.Funcs = c.funcs
+	return res, nil
+}
+
+func (mgr *Manager) httpCoveredFuncs(w http.ResponseWriter, r *http.Request) {
+	funcs, err := mgr.currentFuncs()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(funcs); err != nil {
+		logf(0, "failed to write covered funcs: %v", err)
+	}
+}
+
+func fetchCoveredFuncs(peer string) (*CoveredFuncs, error) {
+	client := &http.Client{Timeout: coverPeerTimeout}
+	resp, err := client.Get(strings.TrimSuffix(peer, "/") + "/covered_funcs")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%v returned %v", peer, resp.Status)
+	}
+	funcs := new(CoveredFuncs)
+	if err := json.NewDecoder(resp.Body).Decode(funcs); err != nil {
+		return nil, fmt.Errorf("failed to parse covered funcs of %v: %v", peer, err)
+	}
+	sort.Strings(funcs.Funcs)
+	return funcs, nil
+}
+
+func (mgr *Manager) httpCoverDiff(w http.ResponseWriter, r *http.Request) {
+	var peer string
+	for i, p := range mgr.cfg.Cover_Peers {
+		if r.FormValue("peer") == fmt.Sprint(i) {
+			peer = p
+		}
+	}
+	if peer == "" {
+		http.Error(w, "unknown peer (see cover_peers config param)", http.StatusBadRequest)
+		return
+	}
+	type result struct {
+		funcs *CoveredFuncs
+		err   error
+	}
+	peerRes := make(chan result, 1)
+	go func() {
+		funcs, err := fetchCoveredFuncs(peer)
+		peerRes <- result{funcs, err}
+	}()
+	local, err := mgr.currentFuncs()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	res := <-peerRes
+	if res.err != nil {
+		http.Error(w, fmt.Sprintf("failed to get covered functions from %v: %v", peer, res.err), http.StatusBadGateway)
+		return
+	}
+	remote := res.funcs
+	data := &UICoverDiff{Local: local, Peer: remote, PeerAddr: peer}
+	data.OnlyLocal = funcsDifference(local.Funcs, remote.Funcs)
+	data.OnlyPeer = funcsDifference(remote.Funcs, local.Funcs)
+	if err := coverDiffTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// funcsDifference returns functions from sorted a that are not in sorted b.
+func funcsDifference(a, b []string) []string {
+	var res []string
+	j := 0
+	for _, fn := range a {
+		for j < len(b) && b[j] < fn {
+			j++
+		}
+		if j == len(b) || b[j] != fn {
+			res = append(res, fn)
+		}
+	}
+	return res
+}
+
+type UICoverDiff struct {
+	Local     *CoveredFuncs
+	Peer      *CoveredFuncs
+	PeerAddr  string
+	OnlyLocal []string
+	OnlyPeer  []string
+}
+
+var coverDiffTemplate = template.Must(template.New("").Parse(`
+<!doctype html>
+<html>
+<head>
+    <title>syzkaller coverage diff</title>
+</head>
+<body>
+This manager ({{.Local.Name}}): {{len .Local.Funcs}} covered functions, kernel build {{.Local.KernelBuild}} <br>
+Peer {{.PeerAddr}}: {{len .Peer.Funcs}} covered functions, kernel build {{.Peer.KernelBuild}} <br>
+{{if ne .Local.Revision .Peer.Revision}}<b>Descriptions differ: revision {{.Local.Revision}} vs {{.Peer.Revision}}.</b> <br>{{end}}
+{{if .Local.Candidates}}<b>Corpus of this manager is not triaged yet ({{.Local.Candidates}} candidates).</b> <br>{{end}}
+{{if .Peer.Candidates}}<b>Corpus of the peer is not triaged yet ({{.Peer.Candidates}} candidates).</b> <br>{{end}}
+<br>
+<b>Covered only by the peer: {{len .OnlyPeer}}</b> <br>
+{{range $fn := .OnlyPeer}}
+	<span style='color:red'>{{$fn}}</span> <br>
+{{end}}
+<br>
+<b>Covered only by this manager: {{len .OnlyLocal}}</b> <br>
+{{range $fn := .OnlyLocal}}
+	{{$fn}} <br>
+{{end}}
+</body></html>
+`))
@@ -23,16 +23,18 @@ type LineInfo struct {
 	line int
 }
 
-func generateCoverHtml(w io.Writer, vmlinux string, cov []uint32) error {
-	if len(cov) == 0 {
+// generateCoverHtml generates coverage report for PCs in object file obj.
+// If section is not empty, PCs are offsets in the section (e.g. .text of a kernel module).
+func generateCoverHtml(w io.Writer, obj, section string, pcs []uint64) error {
+	if len(pcs) == 0 {
 		return fmt.Errorf("No coverage data available")
 	}
-	info, prefix, err := symbolize(vmlinux, cov)
+	info, prefix, err := symbolize(obj, section, pcs)
 	if err != nil {
 		return err
 	}
 	if len(info) == 0 {
-		return fmt.Errorf("'%s' does not have debug info (set CONFIG_DEBUG_INFO=y)", vmlinux)
+		return fmt.Errorf("'%s' does not have debug info (set CONFIG_DEBUG_INFO=y)", obj)
 	}
 
 	var d templateData
@@ -145,12 +147,25 @@ func getVmOffset(vmlinux string) (uint32, error) {
 	return addr, nil
 }
 
-func symbolize(vmlinux string, cov []uint32) ([]LineInfo, string, error) {
+// kernelPCs restores full addresses of core kernel coverage PCs.
+func kernelPCs(vmlinux string, cov []uint32) ([]uint64, error) {
 	base, err := getVmOffset(vmlinux)
 	if err != nil {
-		return nil, "", err
+		return nil, err
+	}
+	pcs := make([]uint64, len(cov))
+	for i, pc := range cov {
+		pcs[i] = cover.RestorePC(pc, base)
+	}
+	return pcs, nil
+}
+
+func symbolize(obj, section string, pcs []uint64) ([]LineInfo, string, error) {
+	args := []string{"-a", "-i"}
+	if section != "" {
+		args = append(args, "-j", section)
 	}
-	cmd := exec.Command("addr2line", "-a", "-i", "-e", vmlinux)
+	cmd := exec.Command("addr2line", append(args, "-e", obj)...)
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return nil, "", err
@@ -166,15 +181,15 @@ func symbolize(vmlinux string, cov []uint32) ([]LineInfo, string, error) {
 	}
 	defer cmd.Wait()
 	go func() {
-		for _, pc := range cov {
-			fmt.Fprintf(stdin, "0x%x\n", cover.RestorePC(pc, base)-1)
+		for _, pc := range pcs {
+			fmt.Fprintf(stdin, "0x%x\n", pc-1)
 		}
 		stdin.Close()
 	}()
 	var info []LineInfo
 	prefix := ""
 	s := bufio.NewScanner(stdout)
-	var pc uint32
+	var pc uint64
 	for s.Scan() {
 		ln := s.Text()
 		if len(ln) > 3 && ln[0] == '0' && ln[1] == 'x' {
@@ -182,7 +197,7 @@ func symbolize(vmlinux string, cov []uint32) ([]LineInfo, string, error) {
 			if err != nil {
 				return nil, "", fmt.Errorf("failed to parse pc in addr2line output: %v", err)
 			}
-			pc = uint32(v) + 1
+			pc = v + 1
 			continue
 		}
 		colon := strings.IndexByte(ln, ':')
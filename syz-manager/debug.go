@@ -0,0 +1,68 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"expvar"
+	_ "net/http/pprof"
+	"time"
+
+	"github.com/google/syzkaller/cover"
+)
+
+// Profiling of a live manager: net/http/pprof serves CPU/heap/goroutine profiles on /debug/pprof/
+// (e.g. go tool pprof http://manager/debug/pprof/profile) and expvar serves /debug/vars
+// (memstats, cmdline and the manager vars below) on the manager http address.
+// Fuzzers serve the same endpoints on fuzzer_debug_port inside of VMs if it is set.
+
+func (mgr *Manager) initExpvar() {
+	expvar.Publish("stats", expvar.Func(func() interface{} {
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		stats := make(map[string]uint64, len(mgr.stats))
+		for k, v := range mgr.stats {
+			stats[k] = v
+		}
+		return stats
+	}))
+	expvar.Publish("corpus", expvar.Func(func() interface{} {
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		return len(mgr.corpus)
+	}))
+	expvar.Publish("cover", expvar.Func(func() interface{} {
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		var cov cover.Cover
+		for _, c := range mgr.corpusCover {
+			cov = cover.Union(cov, c)
+		}
+		return len(cov)
+	}))
+	expvar.Publish("crash_types", expvar.Func(func() interface{} {
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		return len(mgr.crashTypes)
+	}))
+	expvar.Publish("group_stats", expvar.Func(func() interface{} {
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		res := make(map[string]map[string]uint64)
+		for group, stats := range mgr.groupStats {
+			res[group] = make(map[string]uint64)
+			for k, v := range stats {
+				res[group][k] = v
+			}
+		}
+		return res
+	}))
+	expvar.Publish("uptime", expvar.Func(func() interface{} {
+		return time.Since(mgr.startTime).String()
+	}))
+}
+
+// timeStat adds time since start to stat in milliseconds, mgr.mu must be held.
+func (mgr *Manager) timeStat(stat string, start time.Time) {
+	mgr.stats[stat] += uint64(time.Since(start) / time.Millisecond)
+}
@@ -0,0 +1,131 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"debug/elf"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/syzkaller/cover"
+)
+
+// With Focus_Files/Focus_Functions the manager resolves the focus into PC ranges of vmlinux functions
+// and sends them to fuzzers, fuzzers drop coverage outside of the ranges before triage,
+// so only coverage of the focused code grows corpus. A function is in focus if its name matches
+// one of Focus_Functions or if any of its coverage points comes from one of Focus_Files
+// (so functions with focused code inlined into them are in focus too). Module coverage is ignored.
+
+func (mgr *Manager) initFocus() {
+	if len(mgr.cfg.Focus_Files)+len(mgr.cfg.Focus_Functions) == 0 {
+		return
+	}
+	filter, funcs, err := focusFilter(mgr.cfg.Vmlinux, mgr.cfg.Focus_Files, mgr.cfg.Focus_Functions)
+	if err != nil {
+		fatalf("failed to resolve coverage focus: %v", err)
+	}
+	if funcs == 0 {
+		fatalf("coverage focus does not match any functions in %v", mgr.cfg.Vmlinux)
+	}
+	logf(0, "coverage focus: %v functions", funcs)
+	mgr.coverFilter = filter
+}
+
+type funcSymbol struct {
+	name  string
+	start uint64
+	end   uint64
+}
+
+type funcSymbolArray []funcSymbol
+
+func (a funcSymbolArray) Len() int           { return len(a) }
+func (a funcSymbolArray) Less(i, j int) bool { return a[i].start < a[j].start }
+func (a funcSymbolArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// focusFilter returns PC ranges of focused functions and number of the functions.
+func focusFilter(vmlinux string, files, funcs []string) (cover.Filter, int, error) {
+	syms, err := funcSymbols(vmlinux)
+	if err != nil {
+		return nil, 0, err
+	}
+	matched := make([]bool, len(syms))
+	for _, fn := range funcs {
+		re, err := regexp.Compile(fn)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i, sym := range syms {
+			if re.MatchString(sym.name) {
+				matched[i] = true
+			}
+		}
+	}
+	if len(files) != 0 {
+		pcs, err := coverCallsites(vmlinux)
+		if err != nil {
+			return nil, 0, err
+		}
+		srcs, err := pcFiles(vmlinux, pcs)
+		if err != nil {
+			return nil, 0, err
+		}
+		all := make(map[string]int)
+		for _, f := range srcs {
+			if f != "" {
+				all[f]++
+			}
+		}
+		prefix := commonDir(all)
+		for i, pc := range pcs {
+			if !focusFile(strings.TrimPrefix(srcs[i], prefix), files) {
+				continue
+			}
+			idx := sort.Search(len(syms), func(i int) bool { return syms[i].end > pc })
+			if idx < len(syms) && syms[idx].start <= pc {
+				matched[idx] = true
+			}
+		}
+	}
+	var ranges []cover.Range
+	for i, sym := range syms {
+		if matched[i] {
+			ranges = append(ranges, cover.Range{Start: uint32(sym.start), End: uint32(sym.end)})
+		}
+	}
+	return cover.MakeFilter(ranges), len(ranges), nil
+}
+
+// funcSymbols returns function symbols of vmlinux sorted by address.
+func funcSymbols(vmlinux string) (funcSymbolArray, error) {
+	f, err := elf.Open(vmlinux)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	symbols, err := f.Symbols()
+	if err != nil {
+		return nil, err
+	}
+	var syms funcSymbolArray
+	for _, s := range symbols {
+		if elf.ST_TYPE(s.Info) == elf.STT_FUNC && s.Size != 0 {
+			syms = append(syms, funcSymbol{s.Name, s.Value, s.Value + s.Size})
+		}
+	}
+	sort.Sort(syms)
+	return syms, nil
+}
+
+// focusFile returns whether file (relative to the kernel source dir) is one of files or is in one of dirs.
+func focusFile(file string, focus []string) bool {
+	for _, f := range focus {
+		f = strings.TrimSuffix(f, "/")
+		if file == f || strings.HasPrefix(file, f+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/syzkaller/fileutil"
+)
+
+// Crash logs contain only Crash_Context_Before/Crash_Context_After KB of console output around
+// the crash report. With Crash_Full_Log console output of every VM is also written to
+// workdir/console/<vm>.log (truncated when the VM is restarted), and when a crash with a title
+// that was not seen before is saved, the complete log is kept next to the crash log as crash-xxx.full.
+// Titles that already have a full log are collected from crashdir on startup.
+
+const fullLogSuffix = ".full"
+
+func (mgr *Manager) initFullLogs() {
+	if !mgr.cfg.Crash_Full_Log {
+		return
+	}
+	os.MkdirAll(filepath.Join(mgr.cfg.Workdir, "console"), 0700)
+	files, err := ioutil.ReadDir(mgr.crashdir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name(), "crash-") || !strings.HasSuffix(f.Name(), fullLogSuffix) {
+			continue
+		}
+		desc, err := crashDesc(filepath.Join(mgr.crashdir, strings.TrimSuffix(f.Name(), fullLogSuffix)))
+		if err != nil {
+			continue
+		}
+		mgr.fullLogTitles[desc] = true
+	}
+}
+
+// createConsoleLog truncates and opens the console log file for VM name,
+// it returns nil if full logs are disabled.
+func (mgr *Manager) createConsoleLog(name string) *os.File {
+	if !mgr.cfg.Crash_Full_Log {
+		return nil
+	}
+	f, err := os.Create(filepath.Join(mgr.cfg.Workdir, "console", name+".log"))
+	if err != nil {
+		vmLogf(0, name, "failed to create console log: %v", err)
+		return nil
+	}
+	return f
+}
+
+// saveFullLog copies console log of the instance next to crashFile if it's the first crash with the title.
+func (mgr *Manager) saveFullLog(console *os.File, title, crashFile string) {
+	if console == nil {
+		return
+	}
+	mgr.mu.Lock()
+	seen := mgr.fullLogTitles[title]
+	mgr.fullLogTitles[title] = true
+	mgr.mu.Unlock()
+	if seen {
+		return
+	}
+	if err := fileutil.CopyFile(console.Name(), crashFile+fullLogSuffix, false); err != nil {
+		logf(0, "failed to save full log for '%v': %v", title, err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sort"
+)
+
+// A/B fuzzer groups: instances can be tagged with a group in fuzzer_overrides together with the flags
+// under test (e.g. "-collide_prob=0"), all groups share the corpus. In addition to the global stats,
+// the manager breaks down stats of fuzzers (executions, new inputs, etc) and crashes per group,
+// they are shown on the main page and are published as "group_stats" on /debug/vars.
+
+type UIGroup struct {
+	Name      string
+	Instances int
+	Stats     []UIStat
+}
+
+// setInstanceGroup records the group of an instance when it starts, mgr.mu must be held.
+func (mgr *Manager) setInstanceGroup(name, group string) {
+	if group == "" {
+		delete(mgr.instanceGroups, name)
+		return
+	}
+	mgr.instanceGroups[name] = group
+	if mgr.groupStats[group] == nil {
+		mgr.groupStats[group] = make(map[string]uint64)
+	}
+}
+
+// groupStat adds v to stat of the group of the instance, mgr.mu must be held.
+func (mgr *Manager) groupStat(name, stat string, v uint64) {
+	if group := mgr.instanceGroups[name]; group != "" {
+		mgr.groupStats[group][stat] += v
+	}
+}
+
+// uiGroups returns stats of all groups, mgr.mu must be held.
+func (mgr *Manager) uiGroups(secs uint64) []UIGroup {
+	var groups []UIGroup
+	for name, stats := range mgr.groupStats {
+		g := UIGroup{Name: name}
+		for _, group := range mgr.instanceGroups {
+			if group == name {
+				g.Instances++
+			}
+		}
+		for k, v := range stats {
+			g.Stats = append(g.Stats, UIStat{Name: k, Value: statValue(v, secs)})
+		}
+		sort.Sort(UIStatArray(g.Stats))
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
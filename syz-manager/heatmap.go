@@ -0,0 +1,322 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/syzkaller/cover"
+)
+
+// /cover_dirs shows corpus coverage aggregated by kernel source directory:
+// the number of covered coverage points (calls to __sanitizer_cov_trace_pc) out of all points
+// in each subdirectory of the given directory, colored from red (not covered) to green.
+
+// coverPoints returns per-file number of coverage points in vmlinux.
+// Disassembling and symbolizing the whole kernel is slow, so the result is computed once.
+func (mgr *Manager) coverPoints() (map[string]int, error) {
+	if mgr.cfg.Vmlinux == "" {
+		return nil, fmt.Errorf("coverage by directory requires vmlinux")
+	}
+	mgr.pointsOnce.Do(func() {
+		pcs, err := coverCallsites(mgr.cfg.Vmlinux)
+		if err != nil {
+			mgr.pointsErr = err
+			return
+		}
+		mgr.points, mgr.pointsErr = fileCounts(mgr.cfg.Vmlinux, pcs)
+	})
+	return mgr.points, mgr.pointsErr
+}
+
+// coverCallsites returns addresses of calls to __sanitizer_cov_trace_pc in vmlinux.
+func coverCallsites(vmlinux string) ([]uint64, error) {
+	cmd := exec.Command("objdump", "-d", "--no-show-raw-insn", vmlinux)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	defer stdout.Close()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	defer cmd.Wait()
+	var pcs []uint64
+	s := bufio.NewScanner(stdout)
+	for s.Scan() {
+		ln := s.Text()
+		if !strings.HasSuffix(ln, "<__sanitizer_cov_trace_pc>") || !strings.Contains(ln, "call") {
+			continue
+		}
+		colon := strings.IndexByte(ln, ':')
+		if colon == -1 {
+			continue
+		}
+		pc, err := strconv.ParseUint(strings.TrimSpace(ln[:colon]), 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pc in objdump output: %v", err)
+		}
+		pcs = append(pcs, pc)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(pcs) == 0 {
+		return nil, fmt.Errorf("'%s' does not have coverage instrumentation (set CONFIG_KCOV=y)", vmlinux)
+	}
+	return pcs, nil
+}
+
+// fileCounts returns number of pcs per source file.
+func fileCounts(vmlinux string, pcs []uint64) (map[string]int, error) {
+	srcs, err := pcFiles(vmlinux, pcs)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]int)
+	for _, f := range srcs {
+		if f != "" {
+			files[f]++
+		}
+	}
+	return files, nil
+}
+
+// pcFiles returns source file of every pc ("" if it is unknown).
+func pcFiles(vmlinux string, pcs []uint64) ([]string, error) {
+	cmd := exec.Command("addr2line", "-a", "-e", vmlinux)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	defer stdin.Close()
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	defer stdout.Close()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	defer cmd.Wait()
+	go func() {
+		for _, pc := range pcs {
+			fmt.Fprintf(stdin, "0x%x\n", pc)
+		}
+		stdin.Close()
+	}()
+	srcs, err := parsePCFiles(stdout)
+	if err != nil {
+		return nil, err
+	}
+	if len(srcs) != len(pcs) {
+		return nil, fmt.Errorf("addr2line returned %v locations for %v pcs", len(srcs), len(pcs))
+	}
+	return srcs, nil
+}
+
+// parsePCFiles parses output of addr2line -a: every address line is followed by file:line.
+func parsePCFiles(r io.Reader) ([]string, error) {
+	var srcs []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		ln := s.Text()
+		if strings.HasPrefix(ln, "0x") {
+			continue
+		}
+		file := ""
+		if colon := strings.IndexByte(ln, ':'); colon > 0 && ln[:colon] != "??" {
+			file = ln[:colon]
+		}
+		srcs = append(srcs, file)
+	}
+	return srcs, s.Err()
+}
+
+type dirCover struct {
+	total   int
+	covered int
+	file    bool
+}
+
+// aggregateDirs sums per-file counts into direct children (subdirs and files) of dir.
+// File names are relative to the kernel source dir, dir is "" for the top dir.
+func aggregateDirs(total, covered map[string]int, dir string) map[string]*dirCover {
+	if dir != "" {
+		dir += "/"
+	}
+	res := make(map[string]*dirCover)
+	add := func(file string, n int, isTotal bool) {
+		if !strings.HasPrefix(file, dir) {
+			return
+		}
+		name := file[len(dir):]
+		isFile := true
+		if slash := strings.IndexByte(name, '/'); slash != -1 {
+			name = name[:slash]
+			isFile = false
+		}
+		d := res[name]
+		if d == nil {
+			d = &dirCover{file: isFile}
+			res[name] = d
+		}
+		if isTotal {
+			d.total += n
+		} else {
+			d.covered += n
+		}
+	}
+	for f, n := range total {
+		add(f, n, true)
+	}
+	for f, n := range covered {
+		add(f, n, false)
+	}
+	return res
+}
+
+// commonDir returns the longest common dir prefix of file names (with the trailing slash).
+func commonDir(files map[string]int) string {
+	prefix := ""
+	first := true
+	for f := range files {
+		if first {
+			prefix, first = f, false
+			continue
+		}
+		i := 0
+		for ; i < len(prefix) && i < len(f); i++ {
+			if prefix[i] != f[i] {
+				break
+			}
+		}
+		prefix = prefix[:i]
+	}
+	return prefix[:strings.LastIndexByte(prefix, '/')+1]
+}
+
+// trimDir strips prefix from file names, files outside of prefix are dropped.
+func trimDir(files map[string]int, prefix string) map[string]int {
+	res := make(map[string]int)
+	for f, n := range files {
+		if strings.HasPrefix(f, prefix) {
+			res[f[len(prefix):]] += n
+		}
+	}
+	return res
+}
+
+func (mgr *Manager) httpCoverDirs(w http.ResponseWriter, r *http.Request) {
+	points, err := mgr.coverPoints()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to find coverage points: %v", err), http.StatusInternalServerError)
+		return
+	}
+	mgr.mu.Lock()
+	cov := mgr.coreCover()
+	mgr.mu.Unlock()
+	base, err := getVmOffset(mgr.cfg.Vmlinux)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to symbolize coverage: %v", err), http.StatusInternalServerError)
+		return
+	}
+	pcs := make([]uint64, len(cov))
+	for i, pc := range cov {
+		pcs[i] = cover.RestorePC(pc, base) - 1
+	}
+	covered, err := fileCounts(mgr.cfg.Vmlinux, pcs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to symbolize coverage: %v", err), http.StatusInternalServerError)
+		return
+	}
+	prefix := commonDir(points)
+	total := trimDir(points, prefix)
+	coveredRel := trimDir(covered, prefix)
+
+	dir := strings.Trim(r.FormValue("dir"), "/")
+	data := &UICoverDirs{Dir: dir}
+	if slash := strings.LastIndexByte(dir, '/'); slash != -1 {
+		data.Parent = dir[:slash]
+	}
+	for name, d := range aggregateDirs(total, coveredRel, dir) {
+		path := name
+		if dir != "" {
+			path = dir + "/" + name
+		}
+		percent := 0
+		if d.total != 0 {
+			percent = d.covered * 100 / d.total
+		}
+		data.Entries = append(data.Entries, UICoverDir{
+			Name:    name,
+			Path:    path,
+			File:    d.file,
+			Total:   d.total,
+			Covered: d.covered,
+			Percent: percent,
+			Color:   template.CSS(fmt.Sprintf("hsl(%v, 80%%, 75%%)", percent*120/100)),
+		})
+	}
+	sort.Sort(UICoverDirArray(data.Entries))
+	if err := coverDirsTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+type UICoverDirs struct {
+	Dir     string
+	Parent  string
+	Entries []UICoverDir
+}
+
+type UICoverDir struct {
+	Name    string
+	Path    string
+	File    bool
+	Total   int
+	Covered int
+	Percent int
+	Color   template.CSS
+}
+
+type UICoverDirArray []UICoverDir
+
+func (a UICoverDirArray) Len() int           { return len(a) }
+func (a UICoverDirArray) Less(i, j int) bool { return a[i].Total > a[j].Total }
+func (a UICoverDirArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+var coverDirsTemplate = template.Must(template.New("").Parse(`
+<!doctype html>
+<html>
+<head>
+    <title>syzkaller coverage by directory</title>
+    <style>
+        td { padding: 2px 8px; }
+    </style>
+</head>
+<body>
+<b>Coverage of {{if .Dir}}{{.Dir}}{{else}}kernel{{end}}</b>
+{{if .Dir}}(<a href='/cover_dirs?dir={{.Parent}}'>up</a>){{end}} <br>
+<table>
+<tr><th>Name</th><th>Covered</th><th>Total</th><th>%</th></tr>
+{{range $e := .Entries}}
+<tr style='background-color: {{$e.Color}}'>
+	<td>{{if $e.File}}{{$e.Name}}{{else}}<a href='/cover_dirs?dir={{$e.Path}}'>{{$e.Name}}/</a>{{end}}</td>
+	<td>{{$e.Covered}}</td>
+	<td>{{$e.Total}}</td>
+	<td>{{$e.Percent}}</td>
+</tr>
+{{end}}
+</table>
+</body></html>
+`))
@@ -4,26 +4,48 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 	"html/template"
+	"io/ioutil"
 	"net/http"
-	_ "net/http/pprof"
+	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
 	"github.com/google/syzkaller/cover"
 	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/repro"
+	. "github.com/google/syzkaller/rpctype"
 	"github.com/google/syzkaller/sys"
 )
 
 func (mgr *Manager) initHttp() {
 	http.HandleFunc("/", mgr.httpInfo)
 	http.HandleFunc("/corpus", mgr.httpCorpus)
+	http.HandleFunc("/pin", mgr.httpPin)
 	http.HandleFunc("/cover", mgr.httpCover)
 	http.HandleFunc("/prio", mgr.httpPrio)
+	http.HandleFunc("/crashes", mgr.httpCrashes)
+	http.HandleFunc("/bug", mgr.httpBug)
+	http.HandleFunc("/cover_delta", mgr.httpCoverDelta)
+	http.HandleFunc("/covered_funcs", mgr.httpCoveredFuncs)
+	http.HandleFunc("/cover_diff", mgr.httpCoverDiff)
+	http.HandleFunc("/cover_dirs", mgr.httpCoverDirs)
+	http.HandleFunc("/crash_signatures", mgr.httpSignatures)
+	http.HandleFunc("/instances", mgr.httpInstances)
+	http.HandleFunc("/log", mgr.httpLog)
+	http.HandleFunc("/log_stream", mgr.httpLogStream)
+	http.HandleFunc("/patch_jobs", mgr.httpPatchJobs)
+	http.HandleFunc("/repro_tests", mgr.httpReproTests)
+	http.HandleFunc("/syscalls", mgr.httpSyscalls)
+	mgr.initAPI()
+	mgr.initExpvar()
 	logf(0, "serving http on http://%v", mgr.cfg.Http)
 	go http.ListenAndServe(mgr.cfg.Http, nil)
 }
@@ -37,6 +59,11 @@ func (mgr *Manager) httpInfo(w http.ResponseWriter, r *http.Request) {
 		CorpusSize:  len(mgr.corpus),
 		TriageQueue: len(mgr.candidates),
 		Uptime:      fmt.Sprintf("%v", uptime),
+
+		PrevKernelBuild: mgr.prevKernelBuild,
+		CoverPeers:      mgr.cfg.Cover_Peers,
+		Check:           mgr.checkResult,
+		SeccompBlocked:  mgr.seccompBlocked,
 	}
 
 	type CallCov struct {
@@ -46,12 +73,11 @@ func (mgr *Manager) httpInfo(w http.ResponseWriter, r *http.Request) {
 	calls := make(map[string]*CallCov)
 	for _, inp := range mgr.corpus {
 		if calls[inp.Call] == nil {
-			calls[inp.Call] = new(CallCov)
+			// Corpus cover of a call is the union of cover of its inputs.
+			calls[inp.Call] = &CallCov{cov: mgr.corpusCover[sys.CallID[inp.Call]]}
 		}
-		cc := calls[inp.Call]
-		cc.count++
-		cc.cov = cover.Union(cc.cov, cover.Cover(inp.Cover))
-		data.CorpusCoverMem += len(inp.Cover) * int(unsafe.Sizeof(inp.Cover[0]))
+		calls[inp.Call].count++
+		data.CorpusCoverMem += len(inp.Cover)
 	}
 	for _, cov := range mgr.corpusCover {
 		data.CallCoverMem += len(cov) * int(unsafe.Sizeof(cov[0]))
@@ -59,18 +85,10 @@ func (mgr *Manager) httpInfo(w http.ResponseWriter, r *http.Request) {
 
 	secs := uint64(uptime) / 1e9
 	for k, v := range mgr.stats {
-		val := fmt.Sprintf("%v", v)
-		if x := v / secs; x >= 10 {
-			val += fmt.Sprintf(" (%v/sec)", x)
-		} else if x := v * 60 / secs; x >= 10 {
-			val += fmt.Sprintf(" (%v/min)", x)
-		} else {
-			x := v * 60 * 60 / secs
-			val += fmt.Sprintf(" (%v/hour)", x)
-		}
-		data.Stats = append(data.Stats, UIStat{Name: k, Value: val})
+		data.Stats = append(data.Stats, UIStat{Name: k, Value: statValue(v, secs)})
 	}
 	sort.Sort(UIStatArray(data.Stats))
+	data.Groups = mgr.uiGroups(secs)
 
 	var cov cover.Cover
 	for c, cc := range calls {
@@ -79,12 +97,32 @@ func (mgr *Manager) httpInfo(w http.ResponseWriter, r *http.Request) {
 	}
 	sort.Sort(UICallTypeArray(data.Calls))
 	data.CoverSize = len(cov)
+	if len(mgr.modules) != 0 {
+		modCov := cover.SplitByModule(cov, mgr.modules)
+		for _, name := range cover.ModuleNames(mgr.modules) {
+			data.Modules = append(data.Modules, UIModule{name, len(modCov[name])})
+		}
+	}
 
 	if err := htmlTemplate.Execute(w, data); err != nil {
 		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
 	}
 }
 
+// statValue formats a stat with its rate over secs.
+func statValue(v, secs uint64) string {
+	val := fmt.Sprintf("%v", v)
+	if x := v / secs; x >= 10 {
+		val += fmt.Sprintf(" (%v/sec)", x)
+	} else if x := v * 60 / secs; x >= 10 {
+		val += fmt.Sprintf(" (%v/min)", x)
+	} else {
+		x := v * 60 * 60 / secs
+		val += fmt.Sprintf(" (%v/hour)", x)
+	}
+	return val
+}
+
 func (mgr *Manager) httpCorpus(w http.ResponseWriter, r *http.Request) {
 	mgr.mu.Lock()
 	defer mgr.mu.Unlock()
@@ -99,11 +137,16 @@ func (mgr *Manager) httpCorpus(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			http.Error(w, fmt.Sprintf("failed to deserialize program: %v", err), http.StatusInternalServerError)
 		}
+		sig := hash(inp.Prog)
+		note, pinned := mgr.pinned[sig]
 		data = append(data, UIInput{
-			Short: p.String(),
-			Full:  string(inp.Prog),
-			Cover: len(inp.Cover),
-			N:     i,
+			Short:  p.String(),
+			Full:   string(inp.Prog),
+			Cover:  len(cover.MustDecompress(inp.Cover)),
+			N:      i,
+			Sig:    hex.EncodeToString(sig[:]),
+			Pinned: pinned,
+			Note:   note,
 		})
 	}
 	sort.Sort(UIInputArray(data))
@@ -120,16 +163,33 @@ func (mgr *Manager) httpCover(w http.ResponseWriter, r *http.Request) {
 	var cov cover.Cover
 	call := r.FormValue("call")
 	if n, err := strconv.Atoi(call); err == nil && n < len(mgr.corpus) {
-		cov = mgr.corpus[n].Cover
+		cov = cover.MustDecompress(mgr.corpus[n].Cover)
 	} else {
 		for _, inp := range mgr.corpus {
 			if call == "" || call == inp.Call {
-				cov = cover.Union(cov, cover.Cover(inp.Cover))
+				cov = cover.Union(cov, cover.MustDecompress(inp.Cover))
 			}
 		}
 	}
 
-	if err := generateCoverHtml(w, mgr.cfg.Vmlinux, cov); err != nil {
+	if module := r.FormValue("module"); module != "" {
+		cov = cover.SplitByModule(cov, mgr.modules)[module]
+		if module != cover.CoreKernel {
+			mgr.moduleCover(w, module, cov)
+			runtime.GC()
+			return
+		}
+	}
+
+	if mgr.cfg.Vmlinux == "" {
+		mgr.kallsymsCover(w, cov)
+		return
+	}
+	pcs, err := kernelPCs(mgr.cfg.Vmlinux, cov)
+	if err == nil {
+		err = generateCoverHtml(w, mgr.cfg.Vmlinux, "", pcs)
+	}
+	if err != nil {
 		http.Error(w, fmt.Sprintf("failed to generate coverage profile: %v", err), http.StatusInternalServerError)
 	}
 	runtime.GC()
@@ -139,7 +199,6 @@ func (mgr *Manager) httpPrio(w http.ResponseWriter, r *http.Request) {
 	mgr.mu.Lock()
 	defer mgr.mu.Unlock()
 
-	mgr.minimizeCorpus()
 	call := r.FormValue("call")
 	idx := -1
 	for i, c := range sys.Calls {
@@ -164,6 +223,189 @@ func (mgr *Manager) httpPrio(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// httpCrashes lists crashes grouped by guilty function (see crashGroup) and sorted by reproducibility score
+// (results of syz-repro runs on crash logs), optionally filtered by min_score.
+// With fixed=0 crashes whose reproducer does not crash the latest re-tested kernel are hidden,
+// with group=title crashes are grouped by description only.
+func (mgr *Manager) httpCrashes(w http.ResponseWriter, r *http.Request) {
+	hideFixed := r.FormValue("fixed") == "0"
+	status := r.FormValue("status")
+	byTitle := r.FormValue("group") == "title"
+	minScore := -1.0
+	if v := r.FormValue("min_score"); v != "" {
+		var err error
+		if minScore, err = strconv.ParseFloat(v, 64); err != nil {
+			http.Error(w, fmt.Sprintf("bad min_score: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	files, err := ioutil.ReadDir(mgr.crashdir)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read crashes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	type Group struct {
+		UICrash
+		res        repro.Result
+		retests    []repro.Retest // of the last crash log with re-tested reproducer
+		retestFile string
+		cause, fix *repro.Bisection // the latest syz-bisect results of crash logs in the group
+		titles     map[string]bool
+		lastDesc   string // description of the last crash log
+	}
+	groups := make(map[string]*Group)
+	for _, f := range files {
+		if !isCrashLog(f.Name()) {
+			continue
+		}
+		file := filepath.Join(mgr.crashdir, f.Name())
+		desc, err := crashDesc(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		res, err := repro.Load(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		key := desc
+		if !byTitle {
+			key = crashGroup(desc, loadGuilty(file).Func)
+		}
+		g := groups[key]
+		if g == nil {
+			g = &Group{UICrash: UICrash{Desc: key}, titles: make(map[string]bool)}
+			groups[key] = g
+		}
+		g.titles[desc] = true
+		g.Count++
+		if f.Name() > g.Last {
+			g.Last, g.lastDesc = f.Name(), desc
+		}
+		g.res.Add(res)
+		retests, err := repro.LoadRetests(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(retests) != 0 && f.Name() > g.retestFile {
+			g.retests, g.retestFile = retests, f.Name()
+		}
+		bisections, err := repro.LoadBisections(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if b := repro.LastBisection(bisections, false); b != nil && (g.cause == nil || b.Time.After(g.cause.Time)) {
+			g.cause = b
+		}
+		if b := repro.LastBisection(bisections, true); b != nil && (g.fix == nil || b.Time.After(g.fix.Time)) {
+			g.fix = b
+		}
+	}
+	var data []UICrash
+	for _, g := range groups {
+		g.score = g.res.Score()
+		if g.score < minScore {
+			continue
+		}
+		if fixed := repro.FixedSince(g.retests); fixed != nil {
+			if hideFixed {
+				continue
+			}
+			g.Fixed = fmt.Sprintf("no longer reproducing since build %.12v", fixed.KernelBuild)
+			if fixed.KernelCommit != "" {
+				g.Fixed += fmt.Sprintf(" (commit %.12v)", fixed.KernelCommit)
+			}
+			g.Fixed += fixed.Time.Format(" on 2006-01-02")
+		}
+		g.Cause, g.FixCommit = formatBisection(g.cause), formatBisection(g.fix)
+		g.Status, g.Title = "new", g.lastDesc
+		mgr.mu.Lock()
+		if bug := mgr.bugs[g.lastDesc]; bug != nil {
+			g.Status, g.Assignee = bug.Status, bug.Assignee
+		}
+		mgr.mu.Unlock()
+		if status != "" && g.Status != status {
+			continue
+		}
+		g.Repro = g.res.String()
+		guilty := loadGuilty(filepath.Join(mgr.crashdir, g.Last))
+		g.Guilty, g.GuiltyFunc, g.Maintainers = guilty.File, guilty.Func, guilty.Maintainers
+		if len(g.titles) > 1 {
+			for title := range g.titles {
+				g.Titles = append(g.Titles, title)
+			}
+			sort.Strings(g.Titles)
+		}
+		data = append(data, g.UICrash)
+	}
+	sort.Sort(UICrashArray(data))
+
+	if err := crashesTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// crashGroup returns the key crashes are grouped by on /crashes: the crash kind from the description
+// (e.g. "KASAN: use-after-free Read" in "KASAN: use-after-free Read in foo") with the guilty function,
+// so that crashes of the same bug whose top frames differ (e.g. because of inlining) are grouped together.
+// Crashes without the guilty function are grouped by description.
+func crashGroup(desc, guiltyFunc string) string {
+	i := strings.LastIndex(desc, " in ")
+	if guiltyFunc == "" || i == -1 {
+		return desc
+	}
+	return desc[:i] + " in " + guiltyFunc
+}
+
+// isCrashLog says if file name in crashdir is a crash log rather than a file saved along with it.
+func isCrashLog(name string) bool {
+	if !strings.HasPrefix(name, "crash-") {
+		return false
+	}
+	for _, suffix := range []string{repro.Suffix, repro.ProgSuffix, repro.RetestSuffix, repro.BisectSuffix, symbolizedSuffix,
+		signatureSuffix, fullLogSuffix, buildInfoSuffix, programsSuffix, vmcoreSuffix} {
+		if strings.HasSuffix(name, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// crashDesc returns crash description from a crash log saved by runInstance
+// (it is the last line of the log).
+// formatBisection returns the found commit (or the suspects) of bisection result b for /crashes.
+func formatBisection(b *repro.Bisection) string {
+	switch {
+	case b == nil:
+		return ""
+	case b.Commit != "":
+		return fmt.Sprintf("%.12v %v", b.Commit, b.Title)
+	default:
+		return fmt.Sprintf("one of %v commits", len(b.Suspects))
+	}
+}
+
+func crashDesc(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	const tail = 4 << 10
+	if st, err := f.Stat(); err == nil && st.Size() > tail {
+		f.Seek(-tail, os.SEEK_END)
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %v: %v", file, err)
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	return lines[len(lines)-1], nil
+}
+
 type UIData struct {
 	CorpusSize     int
 	TriageQueue    int
@@ -172,7 +414,19 @@ type UIData struct {
 	CallCoverMem   int
 	Uptime         string
 	Stats          []UIStat
+	Groups         []UIGroup
 	Calls          []UICallType
+	Modules        []UIModule
+
+	PrevKernelBuild string
+	CoverPeers      []string
+	Check           *CheckArgs
+	SeccompBlocked  []string
+}
+
+type UIModule struct {
+	Name  string
+	Cover int
 }
 
 type UIStat struct {
@@ -186,12 +440,33 @@ type UICallType struct {
 	Cover  int
 }
 
+type UICrash struct {
+	Desc        string
+	Count       int
+	Last        string // name of the last log file
+	Repro       string
+	Guilty      string // guilty file of the last crash, if symbolized
+	GuiltyFunc  string
+	Maintainers string   // of the guilty file
+	Titles      []string // crash descriptions in the group if there are several
+	Fixed       string   // set if the reproducer does not crash the latest re-tested kernel
+	Cause       string   // the first bad commit found by syz-bisect -crash
+	FixCommit   string   // the fixing commit found by syz-bisect -crash -fix
+	Title       string   // description of the last crash, the bug Status and Assignee are for
+	Status      string
+	Assignee    string
+	score       float64
+}
+
 type UIInput struct {
-	Short string
-	Full  string
-	Calls int
-	Cover int
-	N     int
+	Short  string
+	Full   string
+	Calls  int
+	Cover  int
+	N      int
+	Sig    string
+	Pinned bool
+	Note   string
 }
 
 type UICallTypeArray []UICallType
@@ -206,6 +481,17 @@ func (a UIInputArray) Len() int           { return len(a) }
 func (a UIInputArray) Less(i, j int) bool { return a[i].Cover > a[j].Cover }
 func (a UIInputArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
+type UICrashArray []UICrash
+
+func (a UICrashArray) Len() int { return len(a) }
+func (a UICrashArray) Less(i, j int) bool {
+	if a[i].score != a[j].score {
+		return a[i].score > a[j].score
+	}
+	return a[i].Count > a[j].Count
+}
+func (a UICrashArray) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
 type UIStatArray []UIStat
 
 func (a UIStatArray) Len() int           { return len(a) }
@@ -223,13 +509,44 @@ Uptime: {{.Uptime}}<br>
 Corpus: {{.CorpusSize}}<br>
 Triage queue len: {{.TriageQueue}}<br>
 Cover mem: {{.CorpusCoverMem}} + {{.CallCoverMem}} <br>
-{{if .CoverSize}}<a href='/cover'>Cover: {{.CoverSize}}</a> <br>{{end}}
+{{if .CoverSize}}<a href='/cover'>Cover: {{.CoverSize}}</a> (<a href='/cover_dirs'>by directory</a>) <br>{{end}}
+<a href='/crashes'>Crashes</a> <br>
+<a href='/instances'>Instances</a> <br>
+<a href='/syscalls'>Syscalls</a> <br>
+<a href='/log'>Live log</a> <br>
+<a href='/debug/pprof/'>Profiles</a> (<a href='/debug/vars'>vars</a>) <br>
+{{if .PrevKernelBuild}}<a href='/cover_delta'>Coverage delta with previous kernel</a> <br>{{end}}
+{{range $i, $p := .CoverPeers}}<a href='/cover_diff?peer={{$i}}'>Coverage diff with {{$p}}</a> <br>{{end}}
+<br>
+{{with .Check}}
+Machine check: kcov {{.Kcov}}, debugfs {{.Debugfs}}, kasan {{.Kasan}}, kallsyms {{.Kallsyms}} <br>
+{{if .UnsupportedCalls}}Unsupported calls: {{range $c := .UnsupportedCalls}}{{$c}} {{end}}<br>{{end}}
+{{if .TransitivelyDisabled}}Transitively disabled calls: {{range $c := .TransitivelyDisabled}}{{$c}} {{end}}<br>{{end}}
+<br>
+{{end}}
+{{if .SeccompBlocked}}
+Blocked by seccomp profile: {{range $c := .SeccompBlocked}}{{$c}} {{end}}<br>
 <br>
+{{end}}
+{{if .Modules}}
+Modules: <br>
+{{range $m := $.Modules}}
+	{{$m.Name}} <a href='/cover?module={{$m.Name}}'>cover:{{$m.Cover}}</a><br>
+{{end}}
+<br>
+{{end}}
 Stats: <br>
 {{range $stat := $.Stats}}
 	{{$stat.Name}}: {{$stat.Value}}<br>
 {{end}}
 <br>
+{{range $g := $.Groups}}
+Group {{$g.Name}} ({{$g.Instances}} instances): <br>
+{{range $stat := $g.Stats}}
+	{{$stat.Name}}: {{$stat.Value}}<br>
+{{end}}
+<br>
+{{end}}
 {{range $c := $.Calls}}
 	{{$c.Name}} <a href='/corpus?call={{$c.Name}}'>inputs:{{$c.Inputs}}</a> <a href='/cover?call={{$c.Name}}'>cover:{{$c.Cover}}</a> <a href='/prio?call={{$c.Name}}'>prio</a> <br>
 {{end}}
@@ -244,7 +561,31 @@ var corpusTemplate = template.Must(template.New("").Parse(`
 </head>
 <body>
 {{range $c := $}}
-	<span title="{{$c.Full}}">{{$c.Short}}</span> <a href='/cover?call={{$c.N}}'>cover:{{$c.Cover}}</a> <br>
+	<form action="/pin" method="post" style="margin:0">
+	<span title="{{$c.Full}}">{{$c.Short}}</span> <a href='/cover?call={{$c.N}}'>cover:{{$c.Cover}}</a>
+	<input type="hidden" name="sig" value="{{$c.Sig}}">
+	{{if $c.Pinned}}
+		<b>pinned</b>{{if $c.Note}}: {{$c.Note}}{{end}}
+		<input type="hidden" name="unpin" value="1"> <input type="submit" value="unpin">
+	{{else}}
+		<input name="note" placeholder="note" size="20"> <input type="submit" value="pin">
+	{{end}}
+	</form>
+{{end}}
+</body></html>
+`))
+
+var crashesTemplate = template.Must(template.New("").Parse(`
+<!doctype html>
+<html>
+<head>
+    <title>syzkaller crashes</title>
+</head>
+<body>
+<a href='/crashes?min_score=0.5'>reliably reproducible</a> <a href='/crashes?min_score=0'>tried to reproduce</a> <a href='/crashes?fixed=0'>not fixed</a> <a href='/crashes'>all</a> <a href='/crashes?group=title'>by title</a> <a href='/crashes?status=new'>new</a> <a href='/crashes?status=triaged'>triaged</a> <br> <br>
+{{range $c := $}}
+	{{$c.Desc}}: count {{$c.Count}}, last {{$c.Last}}, reproducibility {{$c.Repro}}{{if $c.Guilty}}, guilty file {{$c.Guilty}}{{end}}{{if $c.GuiltyFunc}}, guilty function {{$c.GuiltyFunc}}{{end}}{{if $c.Maintainers}}, maintainers {{$c.Maintainers}}{{end}}{{if $c.Fixed}}, <b>{{$c.Fixed}}</b>{{end}}{{if $c.Cause}}, caused by {{$c.Cause}}{{end}}{{if $c.FixCommit}}, fixed by {{$c.FixCommit}}{{end}}, <a href='/bug?title={{$c.Title}}'>{{$c.Status}}</a>{{if $c.Assignee}} ({{$c.Assignee}}){{end}} <br>
+	{{range $t := $c.Titles}}&nbsp;&nbsp;{{$t}}<br>{{end}}
 {{end}}
 </body></html>
 `))
@@ -0,0 +1,182 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// The manager tracks the live status of every VM instance (by VM name) for the /instances page:
+// the state, when the VM was booted, when it last printed "executing program",
+// execution rate reported by its fuzzer and the last error (failed boot or crash).
+// The status is guarded by its own mutex, because the output loop updates it often.
+
+const (
+	stateBooting      = "booting"
+	stateFuzzing      = "fuzzing"
+	stateRestarting   = "restarting"
+	stateCrashed      = "rebooting after crash"
+	stateFuzzerFailed = "rebooting after fuzzer failure"
+	stateStopped      = "stopped"
+)
+
+type instanceStatus struct {
+	state     string
+	stateTime time.Time
+	bootTime  time.Time
+	lastExec  time.Time
+	execs     uint64 // programs executed by the current fuzzer
+	execRate  float64
+	pollTime  time.Time
+	lastError string
+	errorTime time.Time
+}
+
+// instance returns status of instance name, mgr.instMu must be held.
+func (mgr *Manager) instance(name string) *instanceStatus {
+	if mgr.instances == nil {
+		mgr.instances = make(map[string]*instanceStatus)
+	}
+	inst := mgr.instances[name]
+	if inst == nil {
+		inst = new(instanceStatus)
+		mgr.instances[name] = inst
+	}
+	return inst
+}
+
+func (mgr *Manager) setInstanceState(name, state string) {
+	mgr.instMu.Lock()
+	defer mgr.instMu.Unlock()
+	inst := mgr.instance(name)
+	if inst.state == state {
+		return
+	}
+	now := time.Now()
+	inst.state, inst.stateTime = state, now
+	switch state {
+	case stateBooting:
+		inst.bootTime = now
+		inst.execs, inst.execRate, inst.pollTime = 0, 0, time.Time{}
+	case stateFuzzing:
+		inst.pollTime = now
+	}
+}
+
+// instanceFailed records error of instance name (e.g. a failed boot or a crash).
+func (mgr *Manager) instanceFailed(name, state, err string) {
+	mgr.setInstanceState(name, state)
+	mgr.instMu.Lock()
+	defer mgr.instMu.Unlock()
+	inst := mgr.instance(name)
+	inst.lastError, inst.errorTime = err, time.Now()
+}
+
+// instanceExited marks instance name as restarting, unless its state says why it has exited.
+func (mgr *Manager) instanceExited(name string) {
+	mgr.instMu.Lock()
+	state := mgr.instance(name).state
+	mgr.instMu.Unlock()
+	if state == stateBooting || state == stateFuzzing {
+		mgr.setInstanceState(name, stateRestarting)
+	}
+}
+
+func (mgr *Manager) instanceExecuting(name string) {
+	mgr.instMu.Lock()
+	defer mgr.instMu.Unlock()
+	mgr.instance(name).lastExec = time.Now()
+}
+
+// instancePolled accounts execs reported by the fuzzer of instance name in Poll.
+func (mgr *Manager) instancePolled(name string, execs uint64) {
+	mgr.instMu.Lock()
+	defer mgr.instMu.Unlock()
+	inst := mgr.instance(name)
+	now := time.Now()
+	if !inst.pollTime.IsZero() {
+		if d := now.Sub(inst.pollTime); d > 0 {
+			inst.execRate = float64(execs) / d.Seconds()
+		}
+	}
+	inst.execs += execs
+	inst.pollTime = now
+}
+
+func (mgr *Manager) httpInstances(w http.ResponseWriter, r *http.Request) {
+	mgr.instMu.Lock()
+	var data []UIInstance
+	now := time.Now()
+	ago := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return now.Sub(t).Truncate(time.Second).String()
+	}
+	for name, inst := range mgr.instances {
+		ui := UIInstance{
+			Name:      name,
+			State:     inst.state,
+			StateTime: ago(inst.stateTime),
+			Uptime:    ago(inst.bootTime),
+			LastExec:  ago(inst.lastExec),
+			Execs:     inst.execs,
+			ExecRate:  fmt.Sprintf("%.1f", inst.execRate),
+			LastError: inst.lastError,
+			ErrorTime: ago(inst.errorTime),
+		}
+		if inst.state != stateFuzzing {
+			ui.ExecRate = "-"
+		}
+		data = append(data, ui)
+	}
+	mgr.instMu.Unlock()
+	sort.Sort(UIInstanceArray(data))
+
+	if err := instancesTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+type UIInstance struct {
+	Name      string
+	State     string
+	StateTime string
+	Uptime    string
+	LastExec  string
+	Execs     uint64
+	ExecRate  string
+	LastError string
+	ErrorTime string
+}
+
+type UIInstanceArray []UIInstance
+
+func (a UIInstanceArray) Len() int           { return len(a) }
+func (a UIInstanceArray) Less(i, j int) bool { return a[i].Name < a[j].Name }
+func (a UIInstanceArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+var instancesTemplate = template.Must(template.New("").Parse(`
+<!doctype html>
+<html>
+<head>
+    <title>syzkaller instances</title>
+</head>
+<body>
+<table>
+<tr><th>name</th><th>state</th><th>for</th><th>uptime</th><th>since last exec</th><th>execs</th><th>execs/sec</th><th>last error</th></tr>
+{{range $i := $}}
+<tr>
+	<td>{{$i.Name}}</td><td>{{$i.State}}</td><td>{{$i.StateTime}}</td><td>{{$i.Uptime}}</td>
+	<td>{{$i.LastExec}}</td><td>{{$i.Execs}}</td><td>{{$i.ExecRate}}</td>
+	<td>{{if $i.LastError}}{{$i.LastError}} ({{$i.ErrorTime}} ago){{end}}</td>
+</tr>
+{{end}}
+</table>
+</body></html>
+`))
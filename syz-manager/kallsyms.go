@@ -0,0 +1,152 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/syzkaller/cover"
+)
+
+// Without vmlinux (e.g. only a stripped vendor kernel is available) kernel symbols are taken
+// from the VMs: the first fuzzer that connects sends text symbols from /proc/kallsyms with
+// its machine check, the snapshot is saved to workdir/kallsyms for reference. It is pulled again
+// on every start of the manager, because addresses change with the kernel. The snapshot is used
+// for function-level coverage (/cover lists covered PCs per function, /covered_funcs) and to find
+// frames of crash reports for the guilty function and crash signatures (without source locations).
+// Features that need debug info (source coverage, /cover_dirs, coverage focus) require vmlinux.
+
+// loadKallsyms parses the kallsyms snapshot sent by fuzzer name, mgr.mu must be held.
+func (mgr *Manager) loadKallsyms(name string, data []byte) {
+	syms := cover.ParseKallsyms(data)
+	if len(syms) == 0 {
+		logf(0, "kallsyms from %v have no symbols (kptr_restrict?)", name)
+		return
+	}
+	logf(0, "loaded %v kernel symbols from %v", len(syms), name)
+	mgr.kallsyms = syms
+	if err := ioutil.WriteFile(filepath.Join(mgr.cfg.Workdir, "kallsyms"), data, 0600); err != nil {
+		logf(0, "failed to save kallsyms: %v", err)
+	}
+}
+
+// kallsymsFuncs returns function symbols from the kallsyms snapshot by name,
+// it's used by the crash symbolizer if there is no vmlinux.
+func (mgr *Manager) kallsymsFuncs() map[string][]funcSymbol {
+	mgr.mu.Lock()
+	syms := mgr.kallsyms
+	mgr.mu.Unlock()
+	if syms == nil {
+		return nil
+	}
+	res := make(map[string][]funcSymbol)
+	for _, s := range syms {
+		res[s.Name] = append(res[s.Name], funcSymbol{s.Name, s.Start, s.End})
+	}
+	return res
+}
+
+// coveredFuncs returns names of functions that contain PCs from cov
+// using vmlinux or the kallsyms snapshot. mgr.funcsCache.mu must be held.
+func (mgr *Manager) coveredFuncs(cov []uint32) (map[string]bool, error) {
+	if mgr.cfg.Vmlinux != "" {
+		return mgr.vmlinuxFuncs(cov)
+	}
+	mgr.mu.Lock()
+	syms := mgr.kallsyms
+	mgr.mu.Unlock()
+	if syms == nil {
+		return nil, fmt.Errorf("no vmlinux and no kallsyms from VMs yet")
+	}
+	funcs := make(map[string]bool)
+	for _, pc := range kallsymsPCs(syms, cov) {
+		if i := cover.SymbolAt(syms, pc-1); i != -1 {
+			funcs[syms[i].Name] = true
+		}
+	}
+	return funcs, nil
+}
+
+// kallsymsPCs restores full addresses of coverage PCs using address of the first symbol.
+func kallsymsPCs(syms []cover.Symbol, cov []uint32) []uint64 {
+	base := uint32(syms[0].Start >> 32)
+	pcs := make([]uint64, len(cov))
+	for i, pc := range cov {
+		pcs[i] = cover.RestorePC(pc, base)
+	}
+	return pcs
+}
+
+// kallsymsCover writes function-level coverage report for PCs in cov, mgr.mu must be held.
+func (mgr *Manager) kallsymsCover(w http.ResponseWriter, cov []uint32) {
+	if len(mgr.kallsyms) == 0 {
+		http.Error(w, "no vmlinux and no kallsyms from VMs yet", http.StatusServiceUnavailable)
+		return
+	}
+	data := &UIKallsymsCover{Total: len(cov)}
+	funcs := make(map[int]int)
+	for _, pc := range kallsymsPCs(mgr.kallsyms, cov) {
+		if i := cover.SymbolAt(mgr.kallsyms, pc-1); i != -1 {
+			funcs[i]++
+		} else {
+			data.Unknown++
+		}
+	}
+	for i, n := range funcs {
+		s := mgr.kallsyms[i]
+		data.Funcs = append(data.Funcs, UIKallsymsFunc{s.Name, s.Module, n})
+	}
+	sort.Slice(data.Funcs, func(i, j int) bool {
+		f1, f2 := data.Funcs[i], data.Funcs[j]
+		if f1.Module != f2.Module {
+			return f1.Module < f2.Module
+		}
+		return f1.Name < f2.Name
+	})
+	if err := kallsymsCoverTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+type UIKallsymsCover struct {
+	Total   int
+	Unknown int
+	Funcs   []UIKallsymsFunc
+}
+
+type UIKallsymsFunc struct {
+	Name    string
+	Module  string
+	Covered int
+}
+
+var kallsymsCoverTemplate = template.Must(template.New("").Parse(`
+<!doctype html>
+<html>
+<head>
+    <title>syzkaller coverage</title>
+    <style>
+        td { padding: 2px 8px; }
+    </style>
+</head>
+<body>
+<b>Coverage by function</b> (symbols from /proc/kallsyms, there is no vmlinux):
+{{.Total}} PCs in {{len .Funcs}} functions{{if .Unknown}}, {{.Unknown}} PCs outside of known symbols{{end}} <br>
+<table>
+<tr><th>Function</th><th>Module</th><th>Covered PCs</th></tr>
+{{range $f := .Funcs}}
+<tr>
+	<td>{{$f.Name}}</td>
+	<td>{{$f.Module}}</td>
+	<td>{{$f.Covered}}</td>
+</tr>
+{{end}}
+</table>
+</body></html>
+`))
@@ -0,0 +1,143 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/vm"
+)
+
+// With Kdump_Kernel the crash kernel is copied into every VM and loaded with kexec -p before fuzzing,
+// so that a panicking kernel boots into the crash kernel instead of dying. When a VM with a crash
+// goes down, the manager waits for the crash kernel to come up, saves /proc/vmcore with makedumpfile
+// (compressed, without zero, cache, user and free pages) and copies it next to the crash log as crash-xxx.vmcore.
+// Dumps are large, so only the first crash with every title gets one (titles are collected from crashdir on startup).
+// The kernel must be booted with crashkernel= (e.g. crashkernel=256M) and panic_on_oops=1,
+// the image needs kexec-tools, makedumpfile and sshd that is started in the crash kernel too.
+
+const (
+	vmcoreSuffix     = ".vmcore"
+	kdumpBootTimeout = 5 * time.Minute  // for the crash kernel to boot and start sshd
+	kdumpSaveTimeout = 20 * time.Minute // for the whole dump
+	kdumpFile        = "/vmcore.syz"
+)
+
+func (mgr *Manager) initKdump() {
+	if mgr.cfg.Kdump_Kernel == "" {
+		return
+	}
+	files, err := ioutil.ReadDir(mgr.crashdir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name(), "crash-") || !strings.HasSuffix(f.Name(), vmcoreSuffix) {
+			continue
+		}
+		desc, err := crashDesc(filepath.Join(mgr.crashdir, strings.TrimSuffix(f.Name(), vmcoreSuffix)))
+		if err != nil {
+			continue
+		}
+		mgr.vmcoreTitles[desc] = true
+	}
+}
+
+// setupKdump loads the crash kernel in the VM. It returns false if the VM type can't retrieve dumps.
+func (mgr *Manager) setupKdump(ctx context.Context, inst vm.Instance) (bool, error) {
+	if _, ok := inst.(vm.Fetcher); !ok {
+		return false, nil
+	}
+	kernel, err := inst.Copy(ctx, mgr.cfg.Kdump_Kernel)
+	if err != nil {
+		return false, err
+	}
+	// The crash kernel gets the command line of the current kernel without crashkernel=
+	// and with the usual options for kdump kernels.
+	cmd := fmt.Sprintf(`kexec -p %v --command-line="$(sed 's/crashkernel=[^ ]*//' /proc/cmdline) irqpoll nr_cpus=1 reset_devices"`,
+		kernel)
+	if out, err := runVMCommand(ctx, inst, time.Minute, cmd); err != nil {
+		return false, fmt.Errorf("kexec failed: %v\n%s", err, out)
+	}
+	return true, nil
+}
+
+// saveVmcore saves dump of the crashed kernel of VM name next to crashFile, if it's the first crash with the title.
+// It is called when the VM goes down after the crash.
+func (mgr *Manager) saveVmcore(ctx context.Context, inst vm.Instance, name, title, crashFile string) {
+	mgr.mu.Lock()
+	seen := mgr.vmcoreTitles[title]
+	mgr.vmcoreTitles[title] = true
+	mgr.mu.Unlock()
+	if seen {
+		return
+	}
+	saved := false
+	defer func() {
+		if !saved {
+			mgr.mu.Lock()
+			mgr.vmcoreTitles[title] = false
+			mgr.mu.Unlock()
+		}
+	}()
+	ctx, cancel := context.WithTimeout(ctx, kdumpSaveTimeout)
+	defer cancel()
+	// Commands fail until the crash kernel boots, if the crashed kernel is still alive there is no /proc/vmcore.
+	vmLogf(0, name, "waiting for the crash kernel")
+	for deadline := time.Now().Add(kdumpBootTimeout); ; {
+		out, _ := runVMCommand(ctx, inst, 30*time.Second, "test -e /proc/vmcore && echo SYZ_VMCORE=yes || echo SYZ_VMCORE=no")
+		if bytes.Contains(out, []byte("SYZ_VMCORE=yes")) {
+			break
+		}
+		if bytes.Contains(out, []byte("SYZ_VMCORE=no")) || time.Now().After(deadline) || ctx.Err() != nil {
+			vmLogf(0, name, "crash kernel did not boot, no vmcore")
+			return
+		}
+		vm.Sleep(ctx, 10*time.Second)
+	}
+	cmd := fmt.Sprintf("rm -f %v && makedumpfile -c -d 31 /proc/vmcore %v", kdumpFile, kdumpFile)
+	if out, err := runVMCommand(ctx, inst, kdumpSaveTimeout, cmd); err != nil {
+		vmLogf(0, name, "makedumpfile failed: %v\n%s", err, out)
+		return
+	}
+	if err := inst.(vm.Fetcher).CopyFrom(ctx, kdumpFile, crashFile+vmcoreSuffix); err != nil {
+		vmLogf(0, name, "failed to copy vmcore: %v", err)
+		return
+	}
+	saved = true
+	vmLogf(0, name, "saved vmcore to %v", filepath.Base(crashFile)+vmcoreSuffix)
+	mgr.mu.Lock()
+	mgr.stats["vmcores"]++
+	mgr.mu.Unlock()
+}
+
+// runVMCommand runs cmd in the VM and returns its output (for some VM types mixed with console output).
+func runVMCommand(ctx context.Context, inst vm.Instance, timeout time.Duration, cmd string) ([]byte, error) {
+	outc, errc, err := inst.Run(ctx, timeout, cmd)
+	if err != nil {
+		return nil, err
+	}
+	var out []byte
+	for {
+		select {
+		case data := <-outc:
+			out = append(out, data...)
+		case err := <-errc:
+			for {
+				select {
+				case data := <-outc:
+					out = append(out, data...)
+				default:
+					return out, err
+				}
+			}
+		}
+	}
+}
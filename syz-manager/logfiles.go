@@ -0,0 +1,165 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/config"
+)
+
+// Manager messages with verbosity up to Log_Verbosity are written to workdir/logs/manager.log,
+// messages about a VM (vmLogf) and console output of the VM are also written to workdir/logs/<vm>.log.
+// Every line is a key=value record:
+//	time=2017-01-02T15:04:05.000 level=info v=0 vm=vm-3 msg="lost connection: EOF"
+//	time=2017-01-02T15:04:05.000 src=console vm=vm-3 msg="[   12.345678] BUG: ..."
+// Files are rotated (x.log -> x.log.1 -> x.log.2 ...) when they reach Log_Rotate_Size MB.
+
+const logTimeFormat = "2006-01-02T15:04:05.000"
+
+type logFiles struct {
+	mu        sync.Mutex
+	dir       string
+	verbosity int
+	maxSize   int64
+	count     int
+	files     map[string]*logFile // by VM name, "" is manager.log
+	partial   map[string][]byte   // incomplete console lines per VM
+}
+
+type logFile struct {
+	path string
+	f    *os.File // nil if the file can't be opened
+	size int64
+}
+
+// fileLog is nil until the manager config is loaded.
+var fileLog *logFiles
+
+func initLogFiles(cfg *config.Config) {
+	dir := filepath.Join(cfg.Workdir, "logs")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		log.Printf("failed to create logs dir: %v", err)
+		return
+	}
+	fileLog = &logFiles{
+		dir:       dir,
+		verbosity: cfg.Log_Verbosity,
+		maxSize:   int64(cfg.Log_Rotate_Size) << 20,
+		count:     cfg.Log_Rotate_Count,
+		files:     make(map[string]*logFile),
+		partial:   make(map[string][]byte),
+	}
+}
+
+// log writes message with verbosity v to manager.log and, if vm is not empty, to the VM log.
+func (l *logFiles) log(v int, vm, msg string, args ...interface{}) {
+	if l == nil || v > l.verbosity {
+		return
+	}
+	level := "info"
+	if v > 0 {
+		level = "debug"
+	}
+	l.record(vm, fmt.Sprintf("level=%v v=%v", level, v), fmt.Sprintf(msg, args...))
+}
+
+func (l *logFiles) fatal(msg string, args ...interface{}) {
+	if l == nil {
+		return
+	}
+	l.record("", "level=fatal", fmt.Sprintf(msg, args...))
+}
+
+func (l *logFiles) record(vm, fields, msg string) {
+	line := fmt.Sprintf("time=%v %v", time.Now().Format(logTimeFormat), fields)
+	if vm != "" {
+		line += " vm=" + vm
+	}
+	line += " msg=" + strconv.Quote(strings.TrimRight(msg, "\n")) + "\n"
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.write("", line)
+	if vm != "" {
+		l.write(vm, line)
+	}
+}
+
+// console writes complete lines of console output of VM name to the VM log.
+func (l *logFiles) console(name string, out []byte) {
+	if l == nil {
+		return
+	}
+	now := time.Now().Format(logTimeFormat)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	data := append(l.partial[name], out...)
+	pos := bytes.LastIndexByte(data, '\n') + 1
+	if pos == 0 && len(data) >= streamMaxPartial {
+		pos = len(data)
+	}
+	l.partial[name] = append([]byte{}, data[pos:]...)
+	if pos == 0 {
+		return
+	}
+	buf := new(bytes.Buffer)
+	for _, ln := range strings.Split(strings.TrimRight(string(data[:pos]), "\n"), "\n") {
+		fmt.Fprintf(buf, "time=%v src=console vm=%v msg=%v\n", now, name, strconv.Quote(strings.TrimRight(ln, "\r")))
+	}
+	l.write(name, buf.String())
+}
+
+// write appends data to the log file of VM name, l.mu must be held.
+func (l *logFiles) write(name, data string) {
+	lf := l.files[name]
+	if lf == nil {
+		file := "manager.log"
+		if name != "" {
+			file = name + ".log"
+		}
+		lf = &logFile{path: filepath.Join(l.dir, file)}
+		lf.open(os.O_WRONLY | os.O_CREATE | os.O_APPEND)
+		l.files[name] = lf
+	}
+	if lf.f == nil {
+		return
+	}
+	if lf.size != 0 && lf.size+int64(len(data)) > l.maxSize {
+		lf.rotate(l.count)
+	}
+	n, _ := lf.f.WriteString(data)
+	lf.size += int64(n)
+}
+
+func (lf *logFile) open(flags int) {
+	f, err := os.OpenFile(lf.path, flags, 0600)
+	if err != nil {
+		log.Printf("failed to open log file: %v", err)
+		return
+	}
+	lf.f = f
+	lf.size = 0
+	if st, err := f.Stat(); err == nil {
+		lf.size = st.Size()
+	}
+}
+
+func (lf *logFile) rotate(count int) {
+	lf.f.Close()
+	lf.f = nil
+	os.Remove(fmt.Sprintf("%v.%v", lf.path, count))
+	for i := count - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%v.%v", lf.path, i), fmt.Sprintf("%v.%v", lf.path, i+1))
+	}
+	os.Rename(lf.path, lf.path+".1")
+	lf.open(os.O_WRONLY | os.O_CREATE | os.O_TRUNC)
+}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"flag"
 	"fmt"
@@ -14,9 +15,11 @@ import (
 	"net/rpc"
 	"net/rpc/jsonrpc"
 	"os"
+	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -29,11 +32,34 @@ import (
 	"github.com/google/syzkaller/sys"
 	"github.com/google/syzkaller/vm"
 	_ "github.com/google/syzkaller/vm/adb"
+	_ "github.com/google/syzkaller/vm/gvisor"
+	_ "github.com/google/syzkaller/vm/isolated"
 	_ "github.com/google/syzkaller/vm/kvm"
 	_ "github.com/google/syzkaller/vm/local"
 	_ "github.com/google/syzkaller/vm/qemu"
 )
 
+const corpusMinimizePeriod = 5 * time.Minute
+
+// noCoverCorpusSize limits corpus size when coverage is disabled,
+// because without coverage there is no signal to minimize the corpus.
+const noCoverCorpusSize = 10000
+
+// Time to wait for sysrq dumps after hang reports.
+const hangDumpTime = 30 * time.Second
+
+// fastTimers are sysctls (relative to /proc/sys) and values applied with Fast_Timers,
+// the defaults are minutes to hours.
+var fastTimers = [][2]string{
+	{"net/ipv4/tcp_keepalive_time", "5"},
+	{"net/ipv4/tcp_keepalive_intvl", "1"},
+	{"net/ipv4/tcp_keepalive_probes", "2"},
+	{"net/ipv4/tcp_fin_timeout", "2"},
+	{"net/ipv4/tcp_retries2", "3"},
+	{"vm/dirty_writeback_centisecs", "50"},
+	{"vm/dirty_expire_centisecs", "100"},
+}
+
 var (
 	flagConfig = flag.String("config", "", "configuration file")
 	flagV      = flag.Int("v", 0, "verbosity")
@@ -43,28 +69,102 @@ var (
 type Manager struct {
 	cfg              *config.Config
 	crashdir         string
+	funcsdir         string
+	kernelBuild      string // hash of vmlinux
+	funcsCache       funcsCache
+	kernelVersion    string // /proc/version reported by the last connected fuzzer
+	kernelConfig     string // name of the archived kernel config in crashdir
+	prevKernelBuild  string // previous kernel build with saved covered functions
+	pointsOnce       sync.Once
+	points           map[string]int // number of coverage points per source file
+	pointsErr        error
+	coverFilter      cover.Filter // PC ranges of Focus_Files/Focus_Functions
+	symbolizer       *reportSymbolizer
 	port             int
 	persistentCorpus *PersistentSet
+	poisoned         *PersistentSet // candidates that crashed too many VMs
 	startTime        time.Time
 	stats            map[string]uint64
 	shutdown         uint32
 
+	instMu    sync.Mutex
+	instances map[string]*instanceStatus // live status of VM instances for /instances
+
 	mu              sync.Mutex
 	enabledSyscalls string
+	checkResult     *CheckArgs // machine check reported by the first fuzzer
+	seccompBlocked  []string   // enabled calls blocked by Seccomp_Profile
 	suppressions    []*regexp.Regexp
 
-	candidates     [][]byte // untriaged inputs
+	candidates     [][]byte       // untriaged inputs
+	candidateSigs  map[Sig]bool   // candidates that are queued, handed to fuzzers or triaged
+	redelivered    map[string]int // number of redeliveries of candidates whose VMs died
 	disabledHashes []string
 	corpus         []RpcInput
 	corpusCover    []cover.Cover
+	dirtyCalls     map[string]bool // calls with new inputs since the last corpus minimization
+	rotated        []RpcInput      // inputs set aside by corpus rotation
+	pinned         map[Sig]string  // pinned programs with notes (see pin.go)
+	returning      []RpcInput      // rotated inputs that are returned as candidates
 	prios          [][]float32
+	ngrams         []prog.NGram         // with Call_Ngrams
+	callStats      map[string]*callStat // with Adaptive_Calls
+	callWeights    []float32
+	callWeightsGen int
+	modules        []cover.Module
+	dataRaces      map[string]bool // already saved data races (with Nonfatal_Data_Races)
+	hangs          map[string]bool // already saved hangs (with Hang_Action "continue")
+	syzBugs        map[string]bool // already saved crashes of syz-fuzzer and syz-executor
+	crashTypes     map[string]bool // descriptions of kernel crashes saved in this run
+	fullLogTitles  map[string]bool // crash titles with saved full logs (with Crash_Full_Log)
+	vmcoreTitles   map[string]bool // crash titles with saved kernel dumps (with Kdump_Kernel)
+
+	disabledPatterns []string        // calls disabled at runtime on /syscalls
+	callsGen         int             // generation of fuzzer calls, changes with disabledPatterns
+	heldCandidates   [][]byte        // candidates that contain calls disabled at runtime
+	bugs             map[string]*Bug // crash description -> bug status (see bugs.go)
+
+	instanceGroups map[string]string            // instance name -> A/B group (see FuzzerOverride.Group)
+	groupStats     map[string]map[string]uint64 // A/B group -> stats of its instances
+
+	moduleObjs map[string]string // module name -> object file (with Module_Objs)
+	kallsyms   []cover.Symbol    // kernel symbols from a VM if there is no vmlinux (see kallsyms.go)
 
-	fuzzers map[string]*Fuzzer
+	fuzzers         map[string]*Fuzzer
+	triageInstances map[string]bool        // instance name -> instance has the triage role (see Triage_Count)
+	reproC          chan reproJob          // crash logs to reproduce or re-test (see Repro_Count)
+	reproDescs      map[string]*reproState // automatic reproduction of crash descriptions
+	patchC          chan *PatchJob         // patch jobs to build and test (see patch.go)
+	patchJobs       []*PatchJob
+	patchSeq        int             // ID of the next patch job
+	reproTestC      chan *ReproTest // external reproducers to test (see reprotest.go)
+	reproTests      []*ReproTest
+	reproTestSeq    int // ID of the next reproducer test
+
+	paused   bool
+	stopC    chan bool  // closed when VMs need to stop (pause/shutdown)
+	resumeC  chan bool  // closed on resume
+	active   int        // number of running instances
+	idle     *sync.Cond // signaled when an instance exits
+	exitOnce sync.Once
+	exitC    chan bool // closed to exit the manager
+	flushed  bool      // triage state and covered functions are saved, final backup is done
+
+	build    *build      // fuzzer/executor binaries used for new instances
+	staged   *build      // new build that is being verified on a canary instance
+	restarts []time.Time // start times of restarts to switch to the current build
 }
 
 type Fuzzer struct {
-	name  string
-	input int
+	name       string
+	input      int
+	triage     bool              // gets candidates (see Triage_Count)
+	candidates map[string][]byte // handed out candidates that are not acknowledged yet
+
+	callWeightsGen int // generation of call weights that the fuzzer has
+	callsGen       int // generation of enabled calls that the fuzzer has
+
+	modules []cover.Module // modules loaded in the VM (see updateModules)
 }
 
 func main() {
@@ -76,11 +176,14 @@ func main() {
 	if *flagDebug {
 		cfg.Debug = true
 		cfg.Count = 1
+		cfg.Pools = nil
 	}
 	RunManager(cfg, syscalls, suppressions)
 }
 
 func RunManager(cfg *config.Config, syscalls map[int]bool, suppressions []*regexp.Regexp) {
+	initLogFiles(cfg)
+	migrateWorkdir(cfg)
 	crashdir := filepath.Join(cfg.Workdir, "crashes")
 	os.MkdirAll(crashdir, 0700)
 
@@ -103,8 +206,31 @@ func RunManager(cfg *config.Config, syscalls map[int]bool, suppressions []*regex
 		suppressions:    suppressions,
 		corpusCover:     make([]cover.Cover, sys.CallCount),
 		fuzzers:         make(map[string]*Fuzzer),
+		triageInstances: make(map[string]bool),
+		candidateSigs:   make(map[Sig]bool),
+		dirtyCalls:      make(map[string]bool),
+		redelivered:     make(map[string]int),
+		dataRaces:       make(map[string]bool),
+		syzBugs:         make(map[string]bool),
+		crashTypes:      make(map[string]bool),
+		instanceGroups:  make(map[string]string),
+		groupStats:      make(map[string]map[string]uint64),
+		hangs:           make(map[string]bool),
+		fullLogTitles:   make(map[string]bool),
+		vmcoreTitles:    make(map[string]bool),
+		stopC:           make(chan bool),
+		exitC:           make(chan bool),
 	}
+	mgr.idle = sync.NewCond(&mgr.mu)
 
+	if cfg.Backup != "" {
+		mgr.restoreBackup()
+	}
+
+	mgr.poisoned = newPersistentSet(filepath.Join(cfg.Workdir, "poisoned"), nil)
+	mgr.loadPinned()
+	mgr.loadDisabledCalls()
+	mgr.loadBugs()
 	logf(0, "loading corpus...")
 	mgr.persistentCorpus = newPersistentSet(filepath.Join(cfg.Workdir, "corpus"), func(data []byte) bool {
 		if _, err := prog.Deserialize(data); err != nil {
@@ -133,9 +259,35 @@ func RunManager(cfg *config.Config, syscalls map[int]bool, suppressions []*regex
 			mgr.disabledHashes = append(mgr.disabledHashes, hex.EncodeToString(h[:]))
 			continue
 		}
-		mgr.candidates = append(mgr.candidates, data)
+		mgr.addCandidate(data)
 	}
 	logf(0, "loaded %v programs", len(mgr.persistentCorpus.m))
+	for _, dir := range cfg.Seeds {
+		mgr.loadSeeds(dir, syscalls)
+	}
+
+	os.RemoveAll(filepath.Join(cfg.Workdir, "bin"))
+	build, err := mgr.snapshotBuild(nil)
+	if err != nil {
+		fatalf("failed to snapshot build: %v", err)
+	}
+	mgr.build = build
+	mgr.initFuncs()
+	mgr.initFocus()
+	mgr.initSymbolizer()
+	mgr.initModules()
+	mgr.initFullLogs()
+	mgr.initKdump()
+	mgr.initBuildInfo()
+	mgr.initSeccomp()
+	mgr.initTriage()
+	mgr.initPinned()
+	mgr.updatePrios()
+	mgr.initCallWeights()
+	go mgr.corpusLoop()
+	if cfg.Corpus_Rotation != 0 {
+		go mgr.rotateLoop()
+	}
 
 	// Create HTTP server.
 	mgr.initHttp()
@@ -160,30 +312,59 @@ func RunManager(cfg *config.Config, syscalls map[int]bool, suppressions []*regex
 		}
 	}()
 
-	var shutdown uint32
+	if cfg.Repro_Count != 0 {
+		mgr.initRepro()
+		mgr.initPatches()
+		mgr.initReproTests()
+	}
+
+	// The first Triage_Count instances triage candidates, Repro_Count instances are left for reproduction.
+	// VMs of additional pools only fuzz.
+	pools := []*config.VMPool{{
+		Type:                  cfg.Type,
+		Count:                 cfg.Count - cfg.Repro_Count,
+		Params:                cfg.VM,
+		No_Output_Timeout:     cfg.No_Output_Timeout,
+		Not_Executing_Timeout: cfg.Not_Executing_Timeout,
+	}}
+	for i := range cfg.Pools {
+		pools = append(pools, &cfg.Pools[i])
+	}
 	var wg sync.WaitGroup
-	wg.Add(cfg.Count + 1)
-	for i := 0; i < cfg.Count; i++ {
-		first := i == 0
-		go func() {
-			defer wg.Done()
-			for {
-				vmCfg, err := config.CreateVMConfig(cfg)
-				if atomic.LoadUint32(&shutdown) != 0 {
-					break
-				}
-				if err != nil {
-					fatalf("failed to create VM config: %v", err)
+	for p, pool := range pools {
+		wg.Add(pool.Count)
+		for i := 0; i < pool.Count; i++ {
+			pool := pool
+			first := p == 0 && i == 0
+			triage := p == 0 && i < cfg.Triage_Count
+			go func() {
+				defer wg.Done()
+				failures := 0
+				for {
+					mgr.waitResumed()
+					vmCfg, err := config.CreatePoolVMConfig(cfg, pool)
+					if atomic.LoadUint32(&mgr.shutdown) != 0 {
+						break
+					}
+					if err != nil {
+						fatalf("failed to create VM config: %v", err)
+					}
+					mgr.mu.Lock()
+					mgr.triageInstances[vmCfg.Name] = triage
+					mgr.mu.Unlock()
+					ok := mgr.runInstance(vmCfg, pool, first)
+					if atomic.LoadUint32(&mgr.shutdown) != 0 {
+						break
+					}
+					if ok {
+						failures = 0
+						continue
+					}
+					time.Sleep(restartBackoff(cfg.Restart_Backoff, failures))
+					failures++
 				}
-				ok := mgr.runInstance(vmCfg, first)
-				if atomic.LoadUint32(&shutdown) != 0 {
-					break
-				}
-				if !ok {
-					time.Sleep(10 * time.Second)
-				}
-			}
-		}()
+			}()
+		}
 	}
 
 	go func() {
@@ -197,67 +378,239 @@ func RunManager(cfg *config.Config, syscalls map[int]bool, suppressions []*regex
 		}
 	}()
 
+	if cfg.Backup != "" {
+		go mgr.backupLoop()
+	}
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGHUP)
+		for range c {
+			msg, err := mgr.reload()
+			if err != nil {
+				logf(0, "failed to reload: %v", err)
+				continue
+			}
+			logf(0, "%v", msg)
+		}
+	}()
+
 	go func() {
 		c := make(chan os.Signal, 2)
 		signal.Notify(c, syscall.SIGINT)
 		<-c
-		wg.Done()
 		atomic.StoreUint32(&mgr.shutdown, 1)
 		*flagV = -1 // VMs will fail
 		logf(-1, "shutting down...")
-		atomic.StoreUint32(&shutdown, 1)
+		mgr.exit()
 		<-c
 		log.Fatalf("terminating")
 	}()
+	<-mgr.exitC
 	wg.Wait()
+
+	if !mgr.flushed {
+		mgr.saveTriage()
+		mgr.saveFuncs()
+		if cfg.Backup != "" {
+			logf(-1, "backing up workdir...")
+			if err := mgr.backup(); err != nil {
+				logf(-1, "%v", err)
+			}
+		}
+	}
 }
 
-func (mgr *Manager) runInstance(vmCfg *vm.Config, first bool) bool {
-	inst, err := vm.Create(mgr.cfg.Type, vmCfg)
+// loadSeeds adds programs from a seeds dir to candidates.
+// Unlike workdir/corpus, the dir is never modified: broken programs are only reported.
+func (mgr *Manager) loadSeeds(dir string, syscalls map[int]bool) {
+	files, err := ioutil.ReadDir(dir)
 	if err != nil {
-		logf(0, "failed to create instance: %v", err)
+		fatalf("failed to read seeds dir: %v", err)
+	}
+	loaded := 0
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		file := filepath.Join(dir, f.Name())
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			fatalf("failed to read seed program: %v", err)
+		}
+		p, err := prog.Deserialize(data)
+		if err != nil {
+			logf(0, "skipping broken seed program %v: %v", file, err)
+			continue
+		}
+		disabled := false
+		for _, c := range p.Calls {
+			if !syscalls[c.Meta.ID] {
+				logf(0, "skipping seed program %v: syscall %v is disabled", file, c.Meta.Name)
+				disabled = true
+				break
+			}
+		}
+		if disabled {
+			continue
+		}
+		if mgr.isPinned(data) {
+			mgr.persistentCorpus.add(data)
+		}
+		mgr.addCandidate(data)
+		loaded++
+	}
+	logf(0, "loaded %v seed programs from %v", loaded, dir)
+}
+
+func (mgr *Manager) runInstance(vmCfg *vm.Config, pool *config.VMPool, first bool) bool {
+	if len(mgr.cfg.Boot_Params) != 0 {
+		vmLogf(1, vmCfg.Name, "booting with command line '%v'", vmCfg.Cmdline)
+	}
+	stop := mgr.instanceStarted()
+	defer mgr.instanceStopped()
+	mgr.setInstanceState(vmCfg.Name, stateBooting)
+	defer mgr.instanceExited(vmCfg.Name)
+	build := mgr.chooseBuild(vmCfg.Name)
+	var crashes []string // fatal crashes of this run
+	defer func() { mgr.instanceDone(vmCfg.Name, build, len(crashes) != 0) }()
+	vmCfg.Executor = build.bin("syz-executor")
+
+	// Boot, copying and commands are canceled when VMs are stopped (pause/shutdown) or the manager exits.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+		case <-mgr.exitC:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+	fail := func(msg string, err error) bool {
+		if ctx.Err() != nil {
+			vmLogf(0, vmCfg.Name, "stopping")
+			mgr.setInstanceState(vmCfg.Name, stateStopped)
+			return true
+		}
+		vmLogf(0, vmCfg.Name, "%v: %v", msg, err)
+		mgr.instanceFailed(vmCfg.Name, stateRestarting, fmt.Sprintf("%v: %v", msg, err))
 		return false
 	}
+
+	inst, err := vm.Create(ctx, pool.Type, vmCfg)
+	if err != nil {
+		return fail("failed to create instance", err)
+	}
 	defer inst.Close()
 
 	fwdAddr, err := inst.Forward(mgr.port)
 	if err != nil {
-		logf(0, "failed to setup port forwarding: %v", err)
-		return false
+		return fail("failed to setup port forwarding", err)
 	}
-	fuzzerBin, err := inst.Copy(filepath.Join(mgr.cfg.Syzkaller, "bin", "syz-fuzzer"))
+	fuzzerBin, err := inst.Copy(ctx, build.bin("syz-fuzzer"))
 	if err != nil {
-		logf(0, "failed to copy binary: %v", err)
-		return false
+		return fail("failed to copy binary", err)
 	}
-	executorBin, err := inst.Copy(filepath.Join(mgr.cfg.Syzkaller, "bin", "syz-executor"))
+	executorBin, err := inst.Copy(ctx, build.bin("syz-executor"))
 	if err != nil {
-		logf(0, "failed to copy binary: %v", err)
-		return false
+		return fail("failed to copy binary", err)
+	}
+	seccompProfile := ""
+	if mgr.cfg.Seccomp_Profile != "" {
+		if seccompProfile, err = inst.Copy(ctx, mgr.cfg.Seccomp_Profile); err != nil {
+			return fail("failed to copy seccomp profile", err)
+		}
 	}
 
 	// Run an aux command with best effort.
 	runCommand := func(cmd string) {
-		_, errc, err := inst.Run(10*time.Second, cmd)
+		_, errc, err := inst.Run(ctx, 10*time.Second, cmd)
 		if err == nil {
 			<-errc
 		}
 	}
 	runCommand("echo -n 0 > /proc/sys/debug/exception-trace")
+	if mgr.cfg.Fast_Timers {
+		for _, sysctl := range fastTimers {
+			runCommand(fmt.Sprintf("echo -n %v > /proc/sys/%v", sysctl[1], sysctl[0]))
+		}
+	}
+	if mgr.cfg.Console_Loglevel != 0 {
+		// Writing a single number sets only console_loglevel.
+		runCommand(fmt.Sprintf("echo -n %v > /proc/sys/kernel/printk", mgr.cfg.Console_Loglevel))
+	}
+	if mgr.cfg.Printk_Ratelimit != 0 {
+		runCommand(fmt.Sprintf("echo -n %v > /proc/sys/kernel/printk_ratelimit", mgr.cfg.Printk_Ratelimit))
+	}
+	if mgr.cfg.Printk_Ratelimit_Burst != 0 {
+		runCommand(fmt.Sprintf("echo -n %v > /proc/sys/kernel/printk_ratelimit_burst", mgr.cfg.Printk_Ratelimit_Burst))
+	}
+
+	kdump := false
+	if mgr.cfg.Kdump_Kernel != "" {
+		if kdump, err = mgr.setupKdump(ctx, inst); err != nil {
+			vmLogf(0, vmCfg.Name, "failed to set up kdump: %v", err)
+		}
+	}
+	// The first fatal crash of the run, the dump is taken when the VM goes down.
+	var kdumpTitle, kdumpCrash string
+	defer func() {
+		if kdump && kdumpCrash != "" && ctx.Err() == nil {
+			mgr.saveVmcore(ctx, inst, vmCfg.Name, kdumpTitle, kdumpCrash)
+		}
+	}()
 
 	// Leak detection significantly slows down fuzzing, so detect leaks only on the first instance.
 	leak := first && mgr.cfg.Leak
 
 	// Run the fuzzer binary.
-	outputC, errorC, err := inst.Run(time.Hour, fmt.Sprintf(
-		"%v -executor=%v -name=%v -manager=%v -output=%v -procs=%v -leak=%v -cover=%v -sandbox=%v -debug=%v -v=%d",
-		fuzzerBin, executorBin, vmCfg.Name, fwdAddr, mgr.cfg.Output, mgr.cfg.Procs, leak, mgr.cfg.Cover, mgr.cfg.Sandbox, *flagDebug, *flagV))
+	fuzzerCmd := fmt.Sprintf(
+		"%v -executor=%v -name=%v -manager=%v -output=%v -procs=%v -leak=%v -cover=%v -sandbox=%v -cgroup_mem=%v -cgroup_pids=%v -debug=%v -v=%d",
+		fuzzerBin, executorBin, vmCfg.Name, fwdAddr, mgr.cfg.Output, mgr.cfg.Procs, leak, mgr.cfg.Cover, mgr.cfg.Sandbox,
+		mgr.cfg.Cgroup_Mem, mgr.cfg.Cgroup_Pids, *flagDebug, *flagV)
+	if mgr.cfg.Mem_Pressure != 0 {
+		fuzzerCmd += fmt.Sprintf(" -mem_pressure=%v", mgr.cfg.Mem_Pressure)
+	}
+	if mgr.cfg.Count_Objects {
+		fuzzerCmd += " -objects"
+	}
+	bootMarker := fmt.Sprintf("%v-%v", vmCfg.Name, time.Now().UnixNano())
+	fuzzerCmd += fmt.Sprintf(" -boot_marker=%v", bootMarker)
+	if mgr.cfg.Fuzzer_Debug_Port != 0 {
+		fuzzerCmd += fmt.Sprintf(" -debug_http=:%v", mgr.cfg.Fuzzer_Debug_Port)
+	}
+	if seccompProfile != "" {
+		fuzzerCmd += fmt.Sprintf(" -seccomp=%v", seccompProfile)
+	}
+	if env, args := mgr.cfg.FuzzerOverrides(vmCfg.Index); len(env) != 0 || args != "" {
+		if args != "" {
+			fuzzerCmd += " " + args
+		}
+		if len(env) != 0 {
+			// env works both for commands that are run by shell and for commands that are exec'ed directly.
+			fuzzerCmd = "env " + strings.Join(env, " ") + " " + fuzzerCmd
+		}
+		vmLogf(1, vmCfg.Name, "fuzzer overrides: env %v, args '%v'", env, args)
+	}
+	mgr.mu.Lock()
+	mgr.setInstanceGroup(vmCfg.Name, mgr.cfg.FuzzerGroup(vmCfg.Index))
+	mgr.mu.Unlock()
+	outputC, errorC, err := inst.Run(ctx, time.Duration(mgr.cfg.Instance_Lifetime)*time.Minute, fuzzerCmd)
 	if err != nil {
-		logf(0, "failed to run fuzzer: %v", err)
-		return false
+		return fail("failed to run fuzzer", err)
 	}
 	startTime := time.Now()
-	var crashes []string
+	saved := 0 // crashes saved in this run (see Restart_After_Crashes)
+	consoleLog := mgr.createConsoleLog(vmCfg.Name)
+	if consoleLog != nil {
+		defer consoleLog.Close()
+	}
+	archive := mgr.openConsoleArchive(vmCfg.Name)
+	if archive != nil {
+		defer archive.close()
+	}
+	progLog := newProgramLog(mgr.cfg.Crash_Programs)
 
 	saveCrasher := func(what string, output []byte) {
 		if atomic.LoadUint32(&mgr.shutdown) != 0 {
@@ -267,13 +620,31 @@ func (mgr *Manager) runInstance(vmCfg *vm.Config, first bool) bool {
 		}
 		for _, re := range mgr.suppressions {
 			if re.Match(output) {
-				logf(1, "%v: suppressing '%v' with '%v'", vmCfg.Name, what, re.String())
+				vmLogf(1, vmCfg.Name, "suppressing '%v' with '%v'", what, re.String())
 				mgr.mu.Lock()
 				mgr.stats["suppressed"]++
 				mgr.mu.Unlock()
 				return
 			}
 		}
+		syzBug := isSyzkallerBug(what)
+		nonfatal := mgr.cfg.Nonfatal_Data_Races && vm.IsDataRace(what) ||
+			mgr.cfg.Hang_Action == "continue" && vm.IsHang(what) || syzBug
+		if nonfatal && vm.IsDataRace(what) {
+			mgr.mu.Lock()
+			dup := mgr.dataRaces[what]
+			mgr.dataRaces[what] = true
+			if dup {
+				mgr.stats["data races dup"]++
+			} else {
+				mgr.stats["data races"]++
+			}
+			mgr.mu.Unlock()
+			if dup {
+				vmLogf(1, vmCfg.Name, "skipping already saved data race '%v'", what)
+				return
+			}
+		}
 		buf := new(bytes.Buffer)
 		fmt.Fprintf(buf, "\n\n")
 		if len(crashes) != 0 {
@@ -282,34 +653,92 @@ func (mgr *Manager) runInstance(vmCfg *vm.Config, first bool) bool {
 				fmt.Fprintf(buf, "\t%s\n", c)
 			}
 		}
-		crashes = append(crashes, what)
+		if !nonfatal {
+			crashes = append(crashes, what)
+		}
+		if len(mgr.cfg.Boot_Params) != 0 {
+			fmt.Fprintf(buf, "kernel command line: %v\n", vmCfg.Cmdline)
+		}
 		fmt.Fprintf(buf, "after running for %v:\n", time.Since(startTime))
 		fmt.Fprintf(buf, "%v\n", what)
 		output = append([]byte{}, output...)
 		output = append(output, buf.Bytes()...)
 		filename := fmt.Sprintf("crash-%v-%v", vmCfg.Name, time.Now().UnixNano())
-		logf(0, "%v: saving crash '%v' to %v", vmCfg.Name, what, filename)
+		vmLogf(0, vmCfg.Name, "saving crash '%v' to %v", what, filename)
 		ioutil.WriteFile(filepath.Join(mgr.crashdir, filename), output, 0660)
-		mgr.mu.Lock()
-		mgr.stats["crashes"]++
-		mgr.mu.Unlock()
+		mgr.saveBuildInfo(filepath.Join(mgr.crashdir, filename), vmCfg, build, fuzzerCmd)
+		progLog.save(filepath.Join(mgr.crashdir, filename))
+		if !syzBug {
+			mgr.queueSymbolize(filepath.Join(mgr.crashdir, filename))
+		}
+		mgr.saveFullLog(consoleLog, what, filepath.Join(mgr.crashdir, filename))
+		if !syzBug {
+			saved++
+			mgr.mu.Lock()
+			mgr.crashTypes[what] = true
+			mgr.mu.Unlock()
+		}
+		if !nonfatal && kdumpCrash == "" {
+			kdumpTitle, kdumpCrash = what, filepath.Join(mgr.crashdir, filename)
+		}
+		if !nonfatal {
+			mgr.instanceFailed(vmCfg.Name, stateCrashed, what)
+			mgr.mu.Lock()
+			mgr.stats["crashes"]++
+			mgr.groupStat(vmCfg.Name, "crashes", 1)
+			mgr.mu.Unlock()
+			if mgr.cfg.Repro_Count != 0 {
+				mgr.queueRepro(what, filepath.Join(mgr.crashdir, filename))
+			}
+		}
 	}
 
 	var output []byte
 
+	appendOutput := func(out []byte) {
+		output = append(output, out...)
+		streamer.console(vmCfg.Name, out)
+		fileLog.console(vmCfg.Name, out)
+		if consoleLog != nil {
+			consoleLog.Write(out)
+		}
+		if archive != nil {
+			archive.write(out)
+		}
+		progLog.write(out)
+	}
+
 	waitForOutput := func(dur time.Duration) {
 		timer := time.NewTimer(dur).C
 	loop:
 		for {
 			select {
 			case out := <-outputC:
-				output = append(output, out...)
+				appendOutput(out)
 			case <-timer:
 				break loop
 			}
 		}
 	}
 
+	// runDiagnostic runs cmd in the VM and appends its output to the console output.
+	// Some VM types (e.g. qemu) send console output to the last started command, so it is read from cmd too.
+	runDiagnostic := func(cmd string) {
+		outc, errc, err := inst.Run(ctx, 10*time.Second, cmd)
+		if err != nil {
+			vmLogf(1, vmCfg.Name, "failed to run '%v': %v", cmd, err)
+			return
+		}
+		for {
+			select {
+			case out := <-outc:
+				appendOutput(out)
+			case <-errc:
+				return
+			}
+		}
+	}
+
 	dumpVMState := func() {
 		// Shows all locks that are held.
 		runCommand("echo -n d > /proc/sysrq-trigger")
@@ -320,65 +749,246 @@ func (mgr *Manager) runInstance(vmCfg *vm.Config, first bool) bool {
 		waitForOutput(time.Second)
 	}
 
+	// dumpDeadVM collects diagnostics of a VM that is about to be declared dead (no output or not executing
+	// programs): CPU state from the outside (if the VM type supports it), processes, blocked tasks,
+	// memory state and goroutines of the fuzzer (SIGQUIT makes Go programs print stacks of all goroutines and exit).
+	dumpDeadVM := func() {
+		vmLogf(0, vmCfg.Name, "collecting diagnostics")
+		if dumper, ok := inst.(vm.StateDumper); ok {
+			// Hard hangs don't print anything to the console, so CPUs are dumped first while they are still wedged.
+			out, err := dumper.DumpState(ctx, mgr.cfg.Vmlinux)
+			if err != nil {
+				vmLogf(0, vmCfg.Name, "failed to dump CPU state: %v", err)
+			}
+			if len(out) != 0 {
+				appendOutput([]byte("\n\nCPU state:\n"))
+				appendOutput(out)
+			}
+		}
+		dumpVMState()
+		runDiagnostic("ps -ef || ps")
+		runDiagnostic("echo -n w > /proc/sysrq-trigger")
+		runDiagnostic("echo -n m > /proc/sysrq-trigger")
+		runDiagnostic("kill -QUIT $(pidof syz-fuzzer)")
+		waitForOutput(5 * time.Second)
+	}
+
 	matchPos := 0
+	beforeContext := mgr.cfg.Crash_Context_Before << 10
+	afterContext := mgr.cfg.Crash_Context_After << 10
+	lastExecuteTime := time.Now()
+	// Boot messages after the boot marker of the fuzzer mean that the kernel rebooted.
+	bootMarkerSeen := false
+	noOutputTimeout := time.Duration(pool.No_Output_Timeout) * time.Second
+	notExecutingTimeout := time.Duration(pool.Not_Executing_Timeout) * time.Second
+	// Console flood detection: kernel output rate is measured over floodWindow,
+	// floodWindows consecutive windows above Console_Flood KB/s are reported as a crash.
+	// Fuzzer output (programs, logs) is not counted, see kernelOutputLen.
 	const (
-		beforeContext = 256 << 10
-		afterContext  = 128 << 10
+		floodWindow  = time.Minute
+		floodWindows = 3
 	)
-	lastExecuteTime := time.Now()
-	ticker := time.NewTimer(time.Minute)
+	floodStart, floodBytes, floodCount := time.Now(), 0, 0
+	ticker := time.NewTimer(noOutputTimeout)
 	for {
-		if !ticker.Reset(time.Minute) {
+		if mgr.needRestart(vmCfg.Name, build) {
+			return true
+		}
+		if !ticker.Reset(noOutputTimeout) {
 			<-ticker.C
 		}
 		select {
 		case err := <-errorC:
 			switch err {
 			case vm.TimeoutErr:
-				logf(0, "%v: running long enough, restarting", vmCfg.Name)
+				vmLogf(0, vmCfg.Name, "running long enough, restarting")
+				return true
+			case context.Canceled:
+				vmLogf(0, vmCfg.Name, "stopping")
+				mgr.setInstanceState(vmCfg.Name, stateStopped)
 				return true
 			default:
-				logf(0, "%v: lost connection: %v", vmCfg.Name, err)
+				mgr.mu.Lock()
+				rejected := build.rejected
+				mgr.mu.Unlock()
+				if rejected {
+					vmLogf(0, vmCfg.Name, "fuzzer of rejected build %v exited", build.checksum)
+					return true
+				}
+				if exit, details, ok := fuzzerExit(output, err); ok {
+					// Not a kernel bug, so no crash is saved.
+					vmLogf(0, vmCfg.Name, "fuzzer failed: %v: %v", exit.Reason, details)
+					mgr.instanceFailed(vmCfg.Name, stateFuzzerFailed, exit.Reason+": "+details)
+					mgr.mu.Lock()
+					mgr.stats["fuzzer failed: "+exit.Reason]++
+					mgr.mu.Unlock()
+					return true
+				}
+				if desc, start, fatal, found := findSyzkallerBug(output); found && fatal {
+					vmLogf(0, vmCfg.Name, "fuzzer crashed: %v", err)
+					mgr.instanceFailed(vmCfg.Name, stateFuzzerFailed, desc)
+					if mgr.newSyzkallerBug(desc) {
+						start -= beforeContext
+						if start < 0 {
+							start = 0
+						}
+						saveCrasher(desc, output[start:])
+					}
+					return true
+				}
+				vmLogf(0, vmCfg.Name, "lost connection: %v", err)
 				saveCrasher("lost connection", output)
 				return true
 			}
 		case out := <-outputC:
-			output = append(output, out...)
+			appendOutput(out)
 			if bytes.Index(output[matchPos:], []byte("executing program")) != -1 {
 				lastExecuteTime = time.Now()
+				mgr.instanceExecuting(vmCfg.Name)
+			}
+			for {
+				desc, start, _, found := vm.FindCrash(mgr.cfg.OS, output[matchPos:])
+				if !found {
+					break
+				}
+				class := mgr.ignoredClass(desc)
+				if class == "" {
+					break
+				}
+				// Skip the report line and look for other reports after it.
+				vmLogf(1, vmCfg.Name, "ignoring '%v' (%v)", desc, class)
+				mgr.mu.Lock()
+				mgr.stats["ignored: "+class]++
+				mgr.mu.Unlock()
+				pos := matchPos + start
+				if nl := bytes.IndexByte(output[pos:], '\n'); nl != -1 {
+					matchPos = pos + nl + 1
+				} else {
+					matchPos = len(output)
+				}
 			}
-			if _, _, _, found := vm.FindCrash(output[matchPos:]); found {
+			if _, _, _, found := vm.FindCrash(mgr.cfg.OS, output[matchPos:]); found {
 				// Give it some time to finish writing the error message.
 				waitForOutput(10 * time.Second)
-				desc, start, end, _ := vm.FindCrash(output[matchPos:])
-				start = start + matchPos - beforeContext
-				if start < 0 {
-					start = 0
+				desc, start, end, _ := vm.FindCrash(mgr.cfg.OS, output[matchPos:])
+				hang := vm.IsHang(desc)
+				after := afterContext
+				if hang && !mgr.newHang(desc) {
+					vmLogf(1, vmCfg.Name, "skipping already saved hang '%v'", desc)
+					// Skip the report line, so that the hang is not found again.
+					pos := matchPos + start
+					if nl := bytes.IndexByte(output[pos:], '\n'); nl != -1 {
+						matchPos = pos + nl + 1
+					} else {
+						matchPos = len(output)
+					}
+				} else {
+					if hang {
+						// The stack of the blocked task does not show who holds the resource it waits for,
+						// so dump held locks and all tasks and give the slow console time to print them.
+						vmLogf(0, vmCfg.Name, "hang '%v', dumping VM state", desc)
+						dumpVMState()
+						waitForOutput(hangDumpTime)
+						after = mgr.cfg.Hang_Context_After << 10
+					}
+					start = start + matchPos - beforeContext
+					if start < 0 {
+						start = 0
+					}
+					end = end + matchPos + after
+					if end > len(output) {
+						end = len(output)
+					}
+					saveCrasher(desc, output[start:end])
+					// Nonfatal reports (data races, continued hangs) are followed by more output,
+					// skip the saved report, so that it's not found and saved again.
+					matchPos = end
 				}
-				end = end + matchPos + afterContext
-				if end > len(output) {
-					end = len(output)
+				if hang && mgr.cfg.Hang_Action == "restart" {
+					return true
 				}
-				saveCrasher(desc, output[start:end])
+				if mgr.cfg.Restart_After_Crashes != 0 && saved >= mgr.cfg.Restart_After_Crashes {
+					vmLogf(0, vmCfg.Name, "saved %v crashes, restarting", saved)
+					return true
+				}
+			}
+			if desc, start, fatal, found := findSyzkallerBug(output[matchPos:]); found && !fatal {
+				if mgr.newSyzkallerBug(desc) {
+					pos := matchPos + start - beforeContext
+					if pos < 0 {
+						pos = 0
+					}
+					saveCrasher(desc, output[pos:])
+				}
+				// Skip the report line, so that it's not found again.
+				pos := matchPos + start
+				if nl := bytes.IndexByte(output[pos:], '\n'); nl != -1 {
+					matchPos = pos + nl + 1
+				} else {
+					matchPos = len(output)
+				}
+			}
+			rebootFrom := matchPos
+			if !bootMarkerSeen {
+				if pos := bytes.Index(output[matchPos:], []byte(bootMarker)); pos != -1 {
+					bootMarkerSeen = true
+					rebootFrom += pos
+				}
+			}
+			if pos, found := vm.FindReboot(mgr.cfg.OS, output[rebootFrom:]); bootMarkerSeen && found {
+				if len(crashes) != 0 {
+					vmLogf(0, vmCfg.Name, "rebooted after crash")
+					return true
+				}
+				// The kernel died without printing an oops (e.g. a panic that was not flushed to the console).
+				end := rebootFrom + pos
+				if nl := bytes.IndexByte(output[end:], '\n'); nl != -1 {
+					end += nl + 1
+				}
+				saveCrasher("silent reboot", output[:end])
+				return true
+			}
+			// Rescan the last bytes in case a report is split between chunks,
+			// but not the ignored reports.
+			tail := len(output) - matchPos
+			if tail > 128 {
+				tail = 128
 			}
 			if len(output) > 2*beforeContext {
 				copy(output, output[len(output)-beforeContext:])
 				output = output[:beforeContext]
 			}
-			matchPos = len(output) - 128
+			matchPos = len(output) - tail
 			if matchPos < 0 {
 				matchPos = 0
 			}
 			// In some cases kernel constantly prints something to console,
 			// but fuzzer is not actually executing programs.
-			if mgr.cfg.Type != "local" && time.Since(lastExecuteTime) > 3*time.Minute {
-				dumpVMState()
+			if pool.Type != "local" && time.Since(lastExecuteTime) > notExecutingTimeout {
+				dumpDeadVM()
 				saveCrasher("not executing programs", output)
 				return true
 			}
+			floodBytes += kernelOutputLen(out)
+			if elapsed := time.Since(floodStart); mgr.cfg.Console_Flood != 0 && elapsed >= floodWindow {
+				if floodBytes > mgr.cfg.Console_Flood<<10*int(elapsed/time.Second) {
+					floodCount++
+				} else {
+					floodCount = 0
+				}
+				floodStart, floodBytes = time.Now(), 0
+				if floodCount >= floodWindows {
+					saveCrasher("console flooding", output)
+					return true
+				}
+			}
+		case <-stop:
+			vmLogf(0, vmCfg.Name, "stopping")
+			mgr.setInstanceState(vmCfg.Name, stateStopped)
+			return true
 		case <-ticker.C:
-			if mgr.cfg.Type != "local" {
-				dumpVMState()
+			if pool.Type != "local" {
+				dumpDeadVM()
 				saveCrasher("no output", output)
 				return true
 			}
@@ -386,42 +996,129 @@ func (mgr *Manager) runInstance(vmCfg *vm.Config, first bool) bool {
 	}
 }
 
+// kernelOutputLen returns length of kernel messages in console output out,
+// kernel messages are recognized by the printk timestamp (requires CONFIG_PRINTK_TIME).
+func kernelOutputLen(out []byte) int {
+	n := 0
+	for _, line := range bytes.SplitAfter(out, []byte{'\n'}) {
+		if printkTimeRe.Match(line) {
+			n += len(line)
+		}
+	}
+	return n
+}
+
+var printkTimeRe = regexp.MustCompile(`^\r?\[ *[0-9]+\.[0-9]+\]`)
+
+// restartBackoff returns the delay before the next restart of an instance after failures consecutive failures.
+func restartBackoff(seconds, failures int) time.Duration {
+	if failures > 5 {
+		failures = 5
+	}
+	return time.Duration(seconds) * time.Second << uint(failures)
+}
+
+// newHang records hang with description desc and returns false if it should be skipped
+// as an already saved hang (with Hang_Action "continue").
+func (mgr *Manager) newHang(desc string) bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.cfg.Hang_Action != "continue" {
+		mgr.stats["hangs"]++
+		return true
+	}
+	if mgr.hangs[desc] {
+		mgr.stats["hangs dup"]++
+		return false
+	}
+	mgr.hangs[desc] = true
+	mgr.stats["hangs"]++
+	return true
+}
+
+// ignoredClass returns the crash class of Ignores that crash description desc belongs to, if any.
+func (mgr *Manager) ignoredClass(desc string) string {
+	for _, class := range mgr.cfg.Ignores {
+		if vm.InCrashClass(class, desc) {
+			return class
+		}
+	}
+	return ""
+}
+
+// fuzzerExit recognizes exits of the fuzzer that are not kernel bugs by the line printed by the fuzzer
+// or by its exit status, if the VM type propagates it.
+func fuzzerExit(output []byte, err error) (FuzzerExit, string, bool) {
+	if exit, details, ok := ParseFuzzerExit(output); ok {
+		return exit, details, true
+	}
+	if ee, ok := err.(*exec.ExitError); ok {
+		if ws, ok := ee.Sys().(syscall.WaitStatus); ok {
+			if exit, ok := FuzzerExitStatus(ws.ExitStatus()); ok {
+				return exit, fmt.Sprintf("exit status %v", ws.ExitStatus()), true
+			}
+		}
+	}
+	return FuzzerExit{}, "", false
+}
+
+// corpusLoop periodically minimizes corpus and recalculates call priorities,
+// so that this is not done on the RPC path with mgr.mu held.
+func (mgr *Manager) corpusLoop() {
+	for {
+		time.Sleep(corpusMinimizePeriod)
+		mgr.mu.Lock()
+		start := time.Now()
+		mgr.minimizeCorpus()
+		mgr.timeStat("minimize msec", start)
+		mgr.mu.Unlock()
+		start = time.Now()
+		mgr.updatePrios()
+		mgr.mu.Lock()
+		mgr.timeStat("prios msec", start)
+		mgr.mu.Unlock()
+	}
+}
+
+// minimizeCorpus removes inputs that don't add coverage for their call.
+// Only calls that got new inputs since the last minimization are re-minimized.
 func (mgr *Manager) minimizeCorpus() {
-	if mgr.cfg.Cover && len(mgr.corpus) != 0 {
-		// First, sort corpus per call.
+	if mgr.cfg.Cover && len(mgr.dirtyCalls) != 0 {
 		type Call struct {
-			inputs []RpcInput
-			cov    []cover.Cover
+			idx []int
+			cov []cover.Cover
 		}
-		calls := make(map[string]Call)
-		for _, inp := range mgr.corpus {
+		calls := make(map[string]*Call)
+		for i, inp := range mgr.corpus {
+			if !mgr.dirtyCalls[inp.Call] {
+				continue
+			}
 			c := calls[inp.Call]
-			c.inputs = append(c.inputs, inp)
-			c.cov = append(c.cov, inp.Cover)
-			calls[inp.Call] = c
+			if c == nil {
+				c = new(Call)
+				calls[inp.Call] = c
+			}
+			c.idx = append(c.idx, i)
+			c.cov = append(c.cov, cover.MustDecompress(inp.Cover))
 		}
-		// Now minimize and build new corpus.
-		var newCorpus []RpcInput
+		drop := make([]bool, len(mgr.corpus))
 		for _, c := range calls {
+			keep := make([]bool, len(c.idx))
 			for _, idx := range cover.Minimize(c.cov) {
-				newCorpus = append(newCorpus, c.inputs[idx])
+				keep[idx] = true
+			}
+			for j, idx := range c.idx {
+				drop[idx] = !keep[j] && !mgr.isPinned(mgr.corpus[idx].Prog)
 			}
 		}
-		logf(1, "minimized corpus: %v -> %v", len(mgr.corpus), len(newCorpus))
-		mgr.corpus = newCorpus
-	}
-	var corpus []*prog.Prog
-	for _, inp := range mgr.corpus {
-		p, err := prog.Deserialize(inp.Prog)
-		if err != nil {
-			panic(err)
-		}
-		corpus = append(corpus, p)
+		n := len(mgr.corpus)
+		mgr.dropInputs(drop)
+		logf(1, "minimized corpus: %v -> %v", n, len(mgr.corpus))
+		mgr.dirtyCalls = make(map[string]bool)
 	}
-	mgr.prios = prog.CalculatePriorities(corpus)
 
 	// Don't minimize persistent corpus until fuzzers have triaged all inputs from it.
-	if len(mgr.candidates) == 0 {
+	if mgr.untriaged() == 0 {
 		hashes := make(map[string]bool)
 		for _, inp := range mgr.corpus {
 			h := hash(inp.Prog)
@@ -430,80 +1127,232 @@ func (mgr *Manager) minimizeCorpus() {
 		for _, h := range mgr.disabledHashes {
 			hashes[h] = true
 		}
+		for _, inp := range mgr.rotatedInputs() {
+			h := hash(inp.Prog)
+			hashes[hex.EncodeToString(h[:])] = true
+		}
+		for sig := range mgr.pinned {
+			hashes[hex.EncodeToString(sig[:])] = true
+		}
 		mgr.persistentCorpus.minimize(hashes)
 	}
 }
 
+// dropInputs removes corpus inputs with drop[i] set. Order of the remaining inputs is preserved
+// and fuzzer positions in corpus are adjusted, so that fuzzers don't miss new inputs.
+func (mgr *Manager) dropInputs(drop []bool) {
+	var newCorpus []RpcInput
+	pos := make([]int, len(mgr.corpus)+1) // new position of the i-th input
+	for i, inp := range mgr.corpus {
+		pos[i] = len(newCorpus)
+		if !drop[i] {
+			newCorpus = append(newCorpus, inp)
+		}
+	}
+	pos[len(mgr.corpus)] = len(newCorpus)
+	for _, f := range mgr.fuzzers {
+		f.input = pos[f.input]
+	}
+	mgr.corpus = newCorpus
+}
+
+// maxNGrams is the max number of call sequences sent to fuzzers with Call_Ngrams.
+const maxNGrams = 10000
+
+// updatePrios recalculates call priorities (and n-grams) from the current corpus without holding mgr.mu.
+func (mgr *Manager) updatePrios() {
+	mgr.mu.Lock()
+	inputs := mgr.corpus
+	mgr.mu.Unlock()
+	var corpus []*prog.Prog
+	var covered []int
+	for _, inp := range inputs {
+		p, err := prog.Deserialize(inp.Prog)
+		if err != nil {
+			panic(err)
+		}
+		corpus = append(corpus, p)
+		covered = append(covered, inp.CallIndex)
+	}
+	prios := prog.CalculatePriorities(corpus)
+	var ngrams []prog.NGram
+	if mgr.cfg.Call_Ngrams {
+		ngrams = prog.CalculateNGrams(corpus, covered, maxNGrams)
+	}
+	mgr.mu.Lock()
+	mgr.prios = prios
+	mgr.ngrams = ngrams
+	mgr.mu.Unlock()
+}
+
 func (mgr *Manager) Connect(a *ConnectArgs, r *ConnectRes) error {
-	logf(1, "fuzzer %v connected", a.Name)
+	vmLogf(1, a.Name, "fuzzer connected")
 	mgr.mu.Lock()
 	defer mgr.mu.Unlock()
 
+	if err := mgr.checkRevision(a.Name, a.Revision); err != nil {
+		return err
+	}
 	mgr.stats["vm restarts"]++
-	mgr.minimizeCorpus()
+	if f := mgr.fuzzers[a.Name]; f != nil {
+		mgr.requeueCandidates(f, false)
+	}
+	mgr.setInstanceState(a.Name, stateFuzzing)
 	mgr.fuzzers[a.Name] = &Fuzzer{
-		name:  a.Name,
-		input: 0,
+		name:       a.Name,
+		input:      0,
+		triage:     mgr.cfg.Triage_Count == 0 || mgr.triageInstances[a.Name],
+		candidates: make(map[string][]byte),
+	}
+	if len(a.Modules) != 0 {
+		mgr.updateModules(mgr.fuzzers[a.Name], a.Modules)
+	}
+	if a.KernelVersion != "" {
+		mgr.kernelVersion = a.KernelVersion
 	}
 	r.Prios = mgr.prios
-	r.EnabledCalls = mgr.enabledSyscalls
+	r.NGrams = mgr.ngrams
+	r.EnabledCalls = encodeCalls(mgr.fuzzerCalls())
+	r.ProgramLength = mgr.cfg.Program_Length
+	r.Budget = mgr.cfg.Budget()
+	r.Weights = mgr.cfg.MutationWeights()
+	r.SpliceProb = mgr.cfg.Experiments.Splice_Prob
+	r.GeneratePeriod = mgr.cfg.Experiments.Generate_Period
+	r.MutateLength = mgr.cfg.Experiments.Mutate_Length
+	r.CoverFilter = mgr.coverFilter
+	r.CallStats = mgr.cfg.Adaptive_Calls
+	r.NeedKallsyms = mgr.cfg.Vmlinux == "" && mgr.kallsyms == nil
 
 	return nil
 }
 
 func (mgr *Manager) NewInput(a *NewInputArgs, r *int) error {
-	logf(2, "new input from %v for syscall %v", a.Name, a.Call)
+	vmLogf(2, a.Name, "new input for syscall %v", a.Call)
 	mgr.mu.Lock()
 	defer mgr.mu.Unlock()
 
+	if !mgr.cfg.Cover {
+		// Without coverage fuzzers send programs in which all calls succeeded.
+		if _, ok := mgr.persistentCorpus.m[hash(a.RpcInput.Prog)]; ok || len(mgr.corpus) >= noCoverCorpusSize {
+			return nil
+		}
+		mgr.corpus = append(mgr.corpus, a.RpcInput)
+		mgr.stats["manager new inputs"]++
+		mgr.groupStat(a.Name, "manager new inputs", 1)
+		mgr.persistentCorpus.add(a.RpcInput.Prog)
+		return nil
+	}
+	cov, err := cover.Decompress(a.Cover)
+	if err != nil {
+		return err
+	}
+	if f := mgr.fuzzers[a.Name]; f != nil && len(f.modules) != 0 {
+		cov = cover.RelocateModules(cov, f.modules, mgr.modules)
+		a.RpcInput.Cover = cover.Compress(cov)
+	}
+	if mgr.newPinnedInput(a.RpcInput, cov) {
+		return nil
+	}
 	call := sys.CallID[a.Call]
-	if len(cover.Difference(a.Cover, mgr.corpusCover[call])) == 0 {
+	if len(cover.Difference(cov, mgr.corpusCover[call])) == 0 {
 		return nil
 	}
-	mgr.corpusCover[call] = cover.Union(mgr.corpusCover[call], a.Cover)
+	mgr.corpusCover[call] = cover.Union(mgr.corpusCover[call], cov)
 	mgr.corpus = append(mgr.corpus, a.RpcInput)
+	mgr.dirtyCalls[a.Call] = true
 	mgr.stats["manager new inputs"]++
+	mgr.groupStat(a.Name, "manager new inputs", 1)
 	mgr.persistentCorpus.add(a.RpcInput.Prog)
 	return nil
 }
 
 func (mgr *Manager) Poll(a *PollArgs, r *PollRes) error {
-	logf(2, "poll from %v", a.Name)
+	vmLogf(2, a.Name, "poll")
 	mgr.mu.Lock()
 	defer mgr.mu.Unlock()
 
 	for k, v := range a.Stats {
 		mgr.stats[k] += v
+		mgr.groupStat(a.Name, k, v)
 	}
 
 	f := mgr.fuzzers[a.Name]
 	if f == nil {
 		fatalf("fuzzer %v is not connected", a.Name)
 	}
+	if len(a.Modules) != 0 {
+		mgr.updateModules(f, a.Modules)
+	}
 
-	for i := 0; i < 100 && f.input < len(mgr.corpus); i++ {
-		r.NewInputs = append(r.NewInputs, mgr.corpus[f.input])
+	if !mgr.cfg.Cover {
+		mgr.candidatesToCorpus()
+	}
+	enabled := mgr.pollCalls()
+	for len(r.NewInputs) < 100 && f.input < len(mgr.corpus) {
+		inp := mgr.corpus[f.input]
 		f.input++
+		if enabled != nil && !callsEnabled(inp.Prog, enabled) {
+			continue
+		}
+		r.NewInputs = append(r.NewInputs, mgr.fuzzerInput(f, inp))
 	}
 
-	for i := 0; i < 10 && len(mgr.candidates) > 0; i++ {
-		last := len(mgr.candidates) - 1
-		r.Candidates = append(r.Candidates, mgr.candidates[last])
-		mgr.candidates = mgr.candidates[:last]
+	mgr.instancePolled(a.Name, a.Stats["exec total"])
+	mgr.doneCandidates(f, a.DoneCandidates)
+	mgr.addCallStats(a.CallStats)
+	if f.callWeightsGen != mgr.callWeightsGen {
+		r.CallWeights = mgr.callWeights
+		f.callWeightsGen = mgr.callWeightsGen
 	}
-	if len(mgr.candidates) == 0 {
-		mgr.candidates = nil
+	if f.callsGen != mgr.callsGen {
+		r.EnabledCalls = encodeCalls(mgr.fuzzerCalls())
+		f.callsGen = mgr.callsGen
+	}
+	if f.triage {
+		r.Candidates = mgr.pollCandidates(f, 10)
 	}
 
 	return nil
 }
 
+// candidatesToCorpus moves all candidates to corpus when coverage is disabled:
+// there is nothing to triage, and corpus (unlike candidates) is sent to every fuzzer,
+// including fuzzers in restarted VMs.
+func (mgr *Manager) candidatesToCorpus() {
+	for _, data := range mgr.candidates {
+		p, err := prog.Deserialize(data)
+		if err != nil {
+			panic(err)
+		}
+		last := len(p.Calls) - 1
+		if last < 0 || mgr.isPinned(data) {
+			// Pinned programs are already in corpus.
+			continue
+		}
+		mgr.corpus = append(mgr.corpus, RpcInput{Call: p.Calls[last].Meta.CallName, Prog: data, CallIndex: last})
+	}
+	mgr.candidates = nil
+}
+
 func logf(v int, msg string, args ...interface{}) {
 	if *flagV >= v {
 		log.Printf(msg, args...)
 	}
+	streamer.log(v, msg, args...)
+	fileLog.log(v, "", msg, args...)
+}
+
+// vmLogf logs a message about VM name, the message is also written to the VM log file.
+func vmLogf(v int, name, msg string, args ...interface{}) {
+	msg = fmt.Sprintf(msg, args...)
+	if *flagV >= v {
+		log.Printf("%v: %v", name, msg)
+	}
+	streamer.log(v, "%v: %v", name, msg)
+	fileLog.log(v, name, "%v", msg)
 }
 
 func fatalf(msg string, args ...interface{}) {
+	fileLog.fatal(msg, args...)
 	log.Fatalf(msg, args...)
 }
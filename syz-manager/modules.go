@@ -0,0 +1,81 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/syzkaller/cover"
+	. "github.com/google/syzkaller/rpctype"
+)
+
+// Coverage of kernel modules: fuzzers report loaded modules with their load addresses
+// (/proc/modules) on connect and whenever the list changes, and coverage is attributed
+// to modules by address (see cover.SplitByModule). Load addresses differ between VMs,
+// so the manager keeps coverage in a canonical module layout (mgr.modules), coverage is relocated
+// from the layout of the VM in NewInput and back when inputs are sent to fuzzers. Module object files are looked up
+// by module name in Module_Objs dirs on startup, with an object file the /cover page
+// of a module is symbolized with module-relative PCs in the .text section
+// (the module core is laid out starting with .text), otherwise coverage is shown per function
+// with symbols from the kallsyms snapshot (see kallsyms.go) or the PCs are exported as text.
+
+func (mgr *Manager) initModules() {
+	if len(mgr.cfg.Module_Objs) == 0 {
+		return
+	}
+	objs, err := cover.ModuleObjs(mgr.cfg.Module_Objs)
+	if err != nil {
+		logf(0, "%v", err)
+		return
+	}
+	logf(0, "found %v kernel module objects", len(objs))
+	mgr.moduleObjs = objs
+}
+
+// updateModules records modules loaded in the VM of fuzzer f and adds new modules
+// to the canonical layout, mgr.mu must be held.
+func (mgr *Manager) updateModules(f *Fuzzer, modules []cover.Module) {
+	f.modules = modules
+	mgr.modules = cover.MergeModules(mgr.modules, modules)
+}
+
+// fuzzerInput returns corpus input inp with coverage in the module layout of fuzzer f, mgr.mu must be held.
+func (mgr *Manager) fuzzerInput(f *Fuzzer, inp RpcInput) RpcInput {
+	if len(f.modules) == 0 || len(mgr.modules) == 0 || inp.Cover == nil {
+		return inp
+	}
+	cov, err := cover.Decompress(inp.Cover)
+	if err != nil {
+		return inp
+	}
+	inp.Cover = cover.Compress(cover.RelocateModules(cov, mgr.modules, f.modules))
+	return inp
+}
+
+// moduleCover writes coverage report of module for PCs in cov, mgr.mu must be held.
+func (mgr *Manager) moduleCover(w http.ResponseWriter, module string, cov []uint32) {
+	pcs := make([]uint64, len(cov))
+	for i, pc := range cov {
+		_, off := cover.ModuleOffset(mgr.modules, pc)
+		pcs[i] = uint64(off)
+	}
+	obj := mgr.moduleObjs[module]
+	if obj == "" && len(mgr.kallsyms) != 0 {
+		mgr.kallsymsCover(w, cov)
+		return
+	}
+	if obj == "" {
+		// Without the module object file export module-relative PCs
+		// which can be symbolized with addr2line -j .text -e module.ko.
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, pc := range pcs {
+			fmt.Fprintf(w, "0x%x\n", pc)
+		}
+		return
+	}
+	if err := generateCoverHtml(w, obj, ".text", pcs); err != nil {
+		http.Error(w, fmt.Sprintf("failed to generate coverage profile: %v", err), http.StatusInternalServerError)
+	}
+}
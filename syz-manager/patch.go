@@ -0,0 +1,420 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/fileutil"
+	"github.com/google/syzkaller/repro"
+)
+
+// Patch testing answers "does this patch fix the bug?": POST /test_patch (management API, see api.go)
+// with a crash log name and either a patch or a git repo and branch queues a job that builds the patched kernel
+// in a git worktree of Kernel_Src (based on Kernel_Commit), creates an image with Patch_Image_Cmd and re-tests
+// the saved reproducer of the crash on it with syz-repro -retest on Repro_Count VMs (in the repro queue).
+// Jobs are kept in workdir/patches/<id>, /patch_jobs serves their status and results as JSON.
+
+const (
+	patchQueueLen     = 20
+	patchBuildTimeout = 3 * time.Hour
+)
+
+// Statuses of patch jobs and reproducer tests (see reprotest.go).
+const (
+	JobQueued   = "queued"
+	JobBuilding = "building"
+	JobTesting  = "testing"
+	JobDone     = "done"
+	JobFailed   = "failed"
+)
+
+type PatchJob struct {
+	ID      string
+	Crash   string // name of the crash log in workdir/crashes
+	Desc    string // crash description
+	Repo    string // git repo and branch with the fix (if no patch)
+	Branch  string
+	Created time.Time
+	Status  string
+	Error   string        `json:",omitempty"` // for failed jobs
+	Result  *repro.Retest `json:",omitempty"` // for done jobs
+	Fixed   *bool         `json:",omitempty"` // for done jobs, the reproducer did not crash the patched kernel
+	dir     string
+	patch   []byte
+}
+
+func (mgr *Manager) patchDir() string {
+	return filepath.Join(mgr.cfg.Workdir, "patches")
+}
+
+// initPatches loads jobs of previous runs, the ones that were not finished are marked as failed.
+func (mgr *Manager) initPatches() {
+	mgr.patchC = make(chan *PatchJob, patchQueueLen)
+	dirs, err := ioutil.ReadDir(mgr.patchDir())
+	if err != nil && !os.IsNotExist(err) {
+		fatalf("failed to read patch jobs: %v", err)
+	}
+	for _, d := range dirs {
+		job := new(PatchJob)
+		job.dir = filepath.Join(mgr.patchDir(), d.Name())
+		data, err := ioutil.ReadFile(filepath.Join(job.dir, "job.json"))
+		if err != nil {
+			continue
+		}
+		if err := json.Unmarshal(data, job); err != nil {
+			logf(0, "failed to parse patch job %v: %v", d.Name(), err)
+			continue
+		}
+		if job.Status != JobDone && job.Status != JobFailed {
+			job.Status, job.Error = JobFailed, "interrupted by manager restart"
+			mgr.savePatchJob(job)
+		}
+		if id, err := strconv.Atoi(job.ID); err == nil && id >= mgr.patchSeq {
+			mgr.patchSeq = id + 1
+		}
+		mgr.patchJobs = append(mgr.patchJobs, job)
+	}
+	sort.Slice(mgr.patchJobs, func(i, j int) bool {
+		return mgr.patchJobs[i].Created.Before(mgr.patchJobs[j].Created)
+	})
+	go func() {
+		for job := range mgr.patchC {
+			mgr.runPatchJob(job)
+		}
+	}()
+}
+
+func (mgr *Manager) savePatchJob(job *PatchJob) {
+	data, err := json.MarshalIndent(job, "", "\t")
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(job.dir, "job.json"), data, 0660); err != nil {
+		logf(0, "failed to save patch job %v: %v", job.ID, err)
+	}
+}
+
+func (mgr *Manager) setPatchStatus(job *PatchJob, status string, err error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	job.Status = status
+	if err != nil {
+		job.Error = err.Error()
+		logf(0, "patch job %v failed: %v", job.ID, err)
+	} else {
+		logf(0, "patch job %v: %v", job.ID, status)
+	}
+	mgr.savePatchJob(job)
+}
+
+// httpTestPatch queues a patch job. Form values: crash (crash log name, the crash must have a saved reproducer)
+// and either patch (a git diff, as a value or a file) or repo and branch. It replies with the job ID.
+func (mgr *Manager) httpTestPatch(w http.ResponseWriter, r *http.Request) {
+	if !mgr.apiAuthorized(w, r) {
+		return
+	}
+	if mgr.patchC == nil {
+		http.Error(w, "patch testing is disabled (set repro_count config param)", http.StatusForbidden)
+		return
+	}
+	if mgr.cfg.Vmlinux == "" {
+		http.Error(w, "patch testing requires vmlinux config param", http.StatusForbidden)
+		return
+	}
+	job := &PatchJob{
+		Crash:   r.FormValue("crash"),
+		Repo:    r.FormValue("repo"),
+		Branch:  r.FormValue("branch"),
+		Created: time.Now(),
+		Status:  JobQueued,
+		patch:   []byte(r.FormValue("patch")),
+	}
+	if f, _, err := r.FormFile("patch"); err == nil {
+		job.patch, err = ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read patch: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if !isCrashLog(job.Crash) || filepath.Base(job.Crash) != job.Crash {
+		http.Error(w, fmt.Sprintf("bad crash log name %q", job.Crash), http.StatusBadRequest)
+		return
+	}
+	crashFile := filepath.Join(mgr.crashdir, job.Crash)
+	if _, err := os.Stat(crashFile + repro.ProgSuffix); err != nil {
+		http.Error(w, fmt.Sprintf("crash %v has no saved reproducer", job.Crash), http.StatusBadRequest)
+		return
+	}
+	if (len(job.patch) == 0) == (job.Repo == "" || job.Branch == "") {
+		http.Error(w, "specify either patch or repo and branch", http.StatusBadRequest)
+		return
+	}
+	var err error
+	if job.Desc, err = crashDesc(crashFile); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	job.ID = strconv.Itoa(mgr.patchSeq)
+	job.dir = filepath.Join(mgr.patchDir(), job.ID)
+	if err := os.MkdirAll(job.dir, 0700); err != nil {
+		http.Error(w, fmt.Sprintf("failed to create job dir: %v", err), http.StatusInternalServerError)
+		return
+	}
+	select {
+	case mgr.patchC <- job:
+	default:
+		os.RemoveAll(job.dir)
+		http.Error(w, "patch queue is full", http.StatusServiceUnavailable)
+		return
+	}
+	mgr.patchSeq++
+	mgr.patchJobs = append(mgr.patchJobs, job)
+	mgr.savePatchJob(job)
+	logf(0, "queued patch job %v for '%v'", job.ID, job.Desc)
+	fmt.Fprintf(w, "%v\n", job.ID)
+}
+
+// httpPatchJobs serves all patch jobs (or the one with the given id) as JSON.
+func (mgr *Manager) httpPatchJobs(w http.ResponseWriter, r *http.Request) {
+	mgr.mu.Lock()
+	var data []byte
+	var err error
+	if id := r.FormValue("id"); id != "" {
+		var job *PatchJob
+		for _, j := range mgr.patchJobs {
+			if j.ID == id {
+				job = j
+			}
+		}
+		if job == nil {
+			mgr.mu.Unlock()
+			http.Error(w, fmt.Sprintf("no patch job %v", id), http.StatusNotFound)
+			return
+		}
+		data, err = json.MarshalIndent(job, "", "\t")
+	} else {
+		jobs := append([]*PatchJob{}, mgr.patchJobs...)
+		data, err = json.MarshalIndent(jobs, "", "\t")
+	}
+	mgr.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (mgr *Manager) runPatchJob(job *PatchJob) {
+	kernelDir := filepath.Join(job.dir, "linux")
+	defer func() {
+		os.RemoveAll(kernelDir)
+		mgr.git(mgr.kernelSrc(), "worktree", "prune")
+	}()
+	mgr.setPatchStatus(job, JobBuilding, nil)
+	if err := mgr.buildPatchedKernel(job, kernelDir); err != nil {
+		mgr.setPatchStatus(job, JobFailed, err)
+		return
+	}
+	cfgFile, err := mgr.writePatchConfig(job, kernelDir)
+	if err != nil {
+		mgr.setPatchStatus(job, JobFailed, err)
+		return
+	}
+	// syz-repro records the result next to the crash log, so the crash is copied into the job dir.
+	crashFile := filepath.Join(job.dir, job.Crash)
+	src := filepath.Join(mgr.crashdir, job.Crash)
+	for _, suffix := range []string{"", repro.ProgSuffix} {
+		if err := fileutil.CopyFile(src+suffix, crashFile+suffix, false); err != nil {
+			mgr.setPatchStatus(job, JobFailed, err)
+			return
+		}
+	}
+	mgr.setPatchStatus(job, JobTesting, nil)
+	done := make(chan bool)
+	mgr.reproC <- reproJob{file: crashFile, retest: true, config: cfgFile, done: done}
+	<-done
+	retests, err := repro.LoadRetests(crashFile)
+	if err == nil && (len(retests) == 0 || retests[len(retests)-1].Runs == 0) {
+		err = fmt.Errorf("the reproducer was not run (see manager log)")
+	}
+	if err != nil {
+		mgr.setPatchStatus(job, JobFailed, err)
+		return
+	}
+	res := retests[len(retests)-1]
+	fixed := res.Crashes == 0
+	mgr.mu.Lock()
+	job.Result, job.Fixed = &res, &fixed
+	mgr.mu.Unlock()
+	mgr.setPatchStatus(job, JobDone, nil)
+	logf(0, "patch job %v for '%v': %v crashes in %v runs", job.ID, job.Desc, res.Crashes, res.Runs)
+}
+
+// kernelSrc returns the kernel git checkout patches are applied to.
+func (mgr *Manager) kernelSrc() string {
+	if mgr.cfg.Kernel_Src != "" || mgr.cfg.Vmlinux == "" {
+		return mgr.cfg.Kernel_Src
+	}
+	return filepath.Dir(mgr.cfg.Vmlinux)
+}
+
+// kernelConfigFile returns the kernel config, empty if it's unknown.
+func (mgr *Manager) kernelConfigFile() string {
+	if mgr.cfg.Kernel_Config != "" || mgr.cfg.Vmlinux == "" {
+		return mgr.cfg.Kernel_Config
+	}
+	return filepath.Join(filepath.Dir(mgr.cfg.Vmlinux), ".config")
+}
+
+func (mgr *Manager) buildPatchedKernel(job *PatchJob, kernelDir string) error {
+	base := mgr.cfg.Kernel_Commit
+	if base == "" {
+		base = "HEAD"
+	}
+	kernelConfig, err := ioutil.ReadFile(mgr.kernelConfigFile())
+	if err != nil {
+		return fmt.Errorf("failed to read kernel config: %v", err)
+	}
+	os.RemoveAll(kernelDir)
+	mgr.git(mgr.kernelSrc(), "worktree", "prune")
+	if err := mgr.git(mgr.kernelSrc(), "worktree", "add", "--detach", kernelDir, base); err != nil {
+		return err
+	}
+	if job.Repo != "" {
+		if err := mgr.git(kernelDir, "fetch", job.Repo, job.Branch); err != nil {
+			return err
+		}
+		if err := mgr.git(kernelDir, "checkout", "--quiet", "--detach", "FETCH_HEAD"); err != nil {
+			return err
+		}
+	} else {
+		patchFile := filepath.Join(job.dir, "patch.diff")
+		if err := ioutil.WriteFile(patchFile, job.patch, 0660); err != nil {
+			return err
+		}
+		if err := mgr.git(kernelDir, "apply", patchFile); err != nil {
+			return err
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(kernelDir, ".config"), kernelConfig, 0660); err != nil {
+		return fmt.Errorf("failed to write kernel config: %v", err)
+	}
+	if err := runBuildCmd(kernelDir, nil, "make", "olddefconfig"); err != nil {
+		return err
+	}
+	if err := runBuildCmd(kernelDir, nil, "make", fmt.Sprintf("-j%v", runtime.NumCPU())); err != nil {
+		return err
+	}
+	if mgr.cfg.Patch_Image_Cmd != "" {
+		if err := runBuildCmd(kernelDir, []string{"KERNEL_DIR=" + kernelDir}, "sh", "-c", mgr.cfg.Patch_Image_Cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mgr *Manager) git(dir string, args ...string) error {
+	return runBuildCmd(dir, nil, "git", args...)
+}
+
+func runBuildCmd(dir string, env []string, bin string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), patchBuildTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		const maxOutput = 8 << 10
+		if len(output) > maxOutput {
+			output = output[len(output)-maxOutput:]
+		}
+		return fmt.Errorf("'%v %v' failed: %v\n%s", bin, strings.Join(args, " "), err, output)
+	}
+	return nil
+}
+
+// writePatchConfig derives syz-repro config for the patched kernel from the manager config:
+// paths under the kernel build dir (vmlinux dir, e.g. qemu kernel) are redirected to the patched kernel.
+func (mgr *Manager) writePatchConfig(job *PatchJob, kernelDir string) (string, error) {
+	data, err := ioutil.ReadFile(*flagConfig)
+	if err != nil {
+		return "", err
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return "", fmt.Errorf("failed to parse manager config: %v", err)
+	}
+	buildDir := filepath.Clean(filepath.Dir(mgr.cfg.Vmlinux))
+	params = redirectPaths(params, buildDir, kernelDir).(map[string]interface{})
+	workdir := filepath.Join(job.dir, "workdir")
+	if err := os.MkdirAll(workdir, 0700); err != nil {
+		return "", err
+	}
+	setParam(params, "workdir", workdir)
+	setParam(params, "vmlinux", filepath.Join(kernelDir, "vmlinux"))
+	setParam(params, "kernel_config", filepath.Join(kernelDir, ".config"))
+	setParam(params, "count", mgr.cfg.Repro_Count)
+	setParam(params, "repro_count", 0)
+	setParam(params, "triage_count", 0)
+	setParam(params, "retest_repros", false)
+	cfgData, err := json.MarshalIndent(params, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(job.dir, "manager.cfg")
+	if err := ioutil.WriteFile(file, cfgData, 0600); err != nil {
+		return "", err
+	}
+	return file, nil
+}
+
+func redirectPaths(v interface{}, from, to string) interface{} {
+	switch v := v.(type) {
+	case string:
+		if v == from || strings.HasPrefix(v, from+"/") {
+			return to + strings.TrimPrefix(v, from)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redirectPaths(v[i], from, to)
+		}
+		return v
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = redirectPaths(v[k], from, to)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// setParam sets config param case-insensitively, as the config is parsed.
+func setParam(params map[string]interface{}, name string, v interface{}) {
+	for k := range params {
+		if strings.EqualFold(k, name) {
+			delete(params, k)
+		}
+	}
+	params[name] = v
+}
@@ -0,0 +1,186 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/google/syzkaller/cover"
+	"github.com/google/syzkaller/prog"
+	. "github.com/google/syzkaller/rpctype"
+	"github.com/google/syzkaller/sys"
+)
+
+// Pinned programs (e.g. handcrafted seeds for a specific bug area) are always in corpus:
+// they are sent to all fuzzers regardless of their coverage and are never removed by minimization
+// or set aside by corpus rotation. Pins are stored in workdir/pinned.json as a map from program hash
+// (sha1 of the program file, as in workdir/corpus) to a free-form note. The file can be edited
+// while the manager is not running, pinned programs must be in workdir/corpus or in one of Seeds dirs.
+// Corpus inputs can also be pinned and unpinned on the /corpus page.
+
+func (mgr *Manager) pinnedFile() string {
+	return filepath.Join(mgr.cfg.Workdir, "pinned.json")
+}
+
+func (mgr *Manager) loadPinned() {
+	mgr.pinned = make(map[Sig]string)
+	data, err := ioutil.ReadFile(mgr.pinnedFile())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fatalf("failed to read pinned programs: %v", err)
+		}
+		return
+	}
+	pins := make(map[string]string)
+	if err := json.Unmarshal(data, &pins); err != nil {
+		fatalf("failed to parse %v: %v", mgr.pinnedFile(), err)
+	}
+	for s, note := range pins {
+		sig, ok := parseSig(s)
+		if !ok {
+			fatalf("bad program hash '%v' in %v", s, mgr.pinnedFile())
+		}
+		mgr.pinned[sig] = note
+	}
+}
+
+func parseSig(s string) (Sig, bool) {
+	var sig Sig
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(sig) {
+		return sig, false
+	}
+	copy(sig[:], b)
+	return sig, true
+}
+
+// savePinned writes pins to workdir/pinned.json, mgr.mu must be held.
+func (mgr *Manager) savePinned() error {
+	pins := make(map[string]string)
+	for sig, note := range mgr.pinned {
+		pins[hex.EncodeToString(sig[:])] = note
+	}
+	data, err := json.MarshalIndent(pins, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp := mgr.pinnedFile() + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0660); err != nil {
+		return err
+	}
+	return os.Rename(tmp, mgr.pinnedFile())
+}
+
+// initPinned adds pinned programs that are not in corpus yet (e.g. not triaged) to corpus.
+// Their coverage is merged into corpus cover once they are triaged.
+func (mgr *Manager) initPinned() {
+	inCorpus := make(map[Sig]bool)
+	for _, inp := range mgr.corpus {
+		inCorpus[hash(inp.Prog)] = true
+	}
+	enabled := mgr.enabledCalls()
+	added := 0
+	for sig := range mgr.pinned {
+		data := mgr.persistentCorpus.m[sig]
+		if data == nil {
+			logf(0, "pinned program %v is not in corpus or seeds", hex.EncodeToString(sig[:]))
+			continue
+		}
+		if inCorpus[sig] {
+			continue
+		}
+		p, err := prog.Deserialize(data)
+		if err != nil || len(p.Calls) == 0 {
+			continue
+		}
+		disabled := false
+		for _, c := range p.Calls {
+			disabled = disabled || !enabled[c.Meta.ID]
+		}
+		if disabled {
+			logf(0, "pinned program %v uses disabled syscalls", hex.EncodeToString(sig[:]))
+			continue
+		}
+		last := len(p.Calls) - 1
+		mgr.corpus = append(mgr.corpus, RpcInput{Call: p.Calls[last].Meta.CallName, Prog: data, CallIndex: last})
+		added++
+	}
+	if len(mgr.pinned) != 0 {
+		logf(0, "%v pinned programs, %v added to corpus", len(mgr.pinned), added)
+	}
+}
+
+func (mgr *Manager) isPinned(data []byte) bool {
+	_, ok := mgr.pinned[hash(data)]
+	return ok
+}
+
+// newPinnedInput merges coverage of a triaged pinned input into its corpus entry.
+// It returns false if the input is not pinned or not in corpus.
+func (mgr *Manager) newPinnedInput(inp RpcInput, cov cover.Cover) bool {
+	if !mgr.isPinned(inp.Prog) {
+		return false
+	}
+	sig := hash(inp.Prog)
+	for i := range mgr.corpus {
+		if hash(mgr.corpus[i].Prog) != sig {
+			continue
+		}
+		call := sys.CallID[inp.Call]
+		mgr.corpusCover[call] = cover.Union(mgr.corpusCover[call], cov)
+		if len(mgr.corpus[i].Cover) == 0 {
+			mgr.corpus[i] = inp
+		}
+		return true
+	}
+	return false
+}
+
+// httpPin pins (with note) or unpins (with unpin=1) corpus input with the given hash.
+func (mgr *Manager) httpPin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	sig, ok := parseSig(r.FormValue("sig"))
+	if !ok {
+		http.Error(w, "bad program hash", http.StatusBadRequest)
+		return
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	data := mgr.persistentCorpus.m[sig]
+	if data == nil {
+		http.Error(w, "no such program in corpus", http.StatusNotFound)
+		return
+	}
+	if r.FormValue("unpin") != "" {
+		delete(mgr.pinned, sig)
+		for _, inp := range mgr.corpus {
+			if hash(inp.Prog) == sig {
+				// Let minimization reconsider the input.
+				mgr.dirtyCalls[inp.Call] = true
+			}
+		}
+		logf(0, "unpinned program %v", r.FormValue("sig"))
+	} else {
+		mgr.pinned[sig] = r.FormValue("note")
+		logf(0, "pinned program %v: %v", r.FormValue("sig"), r.FormValue("note"))
+	}
+	if err := mgr.savePinned(); err != nil {
+		http.Error(w, fmt.Sprintf("failed to save pinned programs: %v", err), http.StatusInternalServerError)
+		return
+	}
+	back := r.Referer()
+	if back == "" {
+		back = "/"
+	}
+	http.Redirect(w, r, back, http.StatusSeeOther)
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+)
+
+// Crash logs contain console output only around the crash report, so programs executed before
+// the crash are often pushed out of the log by kernel output. The manager splits console output
+// of every instance into blocks that start with "executing program" lines printed by the fuzzer
+// (with output "stdout" or "dmesg") and keeps the last Crash_Programs blocks. They are saved
+// with every crash as crash-xxx.programs, an execution log in the same format as the crash log,
+// so it can be passed to syz-execprog, and syz-repro uses it instead of the crash log if it exists.
+
+const (
+	programsSuffix  = ".programs"
+	maxProgramBlock = 16 << 10 // kernel output after a program is cut at this size
+)
+
+type programLog struct {
+	max     int
+	blocks  [][]byte // the last one is being written
+	partial []byte   // incomplete line
+}
+
+func newProgramLog(max int) *programLog {
+	return &programLog{max: max}
+}
+
+func (pl *programLog) write(out []byte) {
+	data := append(pl.partial, out...)
+	for {
+		nl := bytes.IndexByte(data, '\n')
+		if nl == -1 {
+			break
+		}
+		line := data[:nl+1]
+		data = data[nl+1:]
+		if bytes.Contains(line, []byte("executing program ")) {
+			if len(pl.blocks) == pl.max {
+				copy(pl.blocks, pl.blocks[1:])
+				pl.blocks = pl.blocks[:len(pl.blocks)-1]
+			}
+			pl.blocks = append(pl.blocks, append([]byte{}, line...))
+			continue
+		}
+		if last := len(pl.blocks) - 1; last >= 0 && len(pl.blocks[last])+len(line) <= maxProgramBlock {
+			pl.blocks[last] = append(pl.blocks[last], line...)
+		}
+	}
+	if len(data) > maxProgramBlock {
+		data = data[:0]
+	}
+	pl.partial = append(pl.partial[:0], data...)
+}
+
+// save writes the last programs next to crash log crashFile.
+func (pl *programLog) save(crashFile string) {
+	if len(pl.blocks) == 0 {
+		return
+	}
+	data := bytes.Join(pl.blocks, nil)
+	data = append(data, pl.partial...)
+	if err := ioutil.WriteFile(crashFile+programsSuffix, data, 0660); err != nil {
+		logf(0, "failed to save programs: %v", err)
+	}
+}
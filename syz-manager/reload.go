@@ -0,0 +1,190 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/syzkaller/fileutil"
+	. "github.com/google/syzkaller/rpctype"
+	"github.com/google/syzkaller/sys"
+)
+
+// Binaries that are copied into VMs. A snapshot of them is taken on startup and on reload,
+// so that rebuilding syzkaller does not affect running manager until it is explicitly reloaded.
+var buildBins = []string{"syz-fuzzer", "syz-executor"}
+
+const (
+	// Max fraction of VMs that are restarted at the same time when switching to a new build.
+	restartFraction = 4
+	// Restarted VM is considered to be up after this time even if fuzzer did not connect.
+	restartTimeout = 10 * time.Minute
+)
+
+// build is a snapshot of fuzzer/executor binaries.
+type build struct {
+	checksum string
+	dir      string
+	gen      int // incremented with every promoted build
+
+	canary   string // name of the instance that verifies the staged build
+	rejected bool   // the staged build has different descriptions revision
+}
+
+func (b *build) bin(name string) string {
+	return filepath.Join(b.dir, name)
+}
+
+// snapshotBuild copies target binaries into workdir/bin/checksum.
+// It returns nil if the binaries are the same as in the current build.
+func (mgr *Manager) snapshotBuild(current *build) (*build, error) {
+	hash := sha1.New()
+	for _, name := range buildBins {
+		f, err := os.Open(mgr.cfg.TargetBin(name))
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(hash, f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %v: %v", name, err)
+		}
+	}
+	b := &build{checksum: hex.EncodeToString(hash.Sum(nil))}
+	if current != nil && current.checksum == b.checksum {
+		return nil, nil
+	}
+	b.dir = filepath.Join(mgr.cfg.Workdir, "bin", b.checksum)
+	os.RemoveAll(b.dir)
+	if err := os.MkdirAll(b.dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create build dir: %v", err)
+	}
+	for _, name := range buildBins {
+		if err := fileutil.CopyFile(mgr.cfg.TargetBin(name), b.bin(name), false); err != nil {
+			return nil, fmt.Errorf("failed to copy %v: %v", name, err)
+		}
+		if err := os.Chmod(b.bin(name), 0700); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// reload stages the current binaries as a new build. The build is first verified on a single canary VM:
+// if the fuzzer has the same descriptions revision as the manager, the build is promoted
+// and VMs are restarted with it a few at a time. Descriptions are compiled into the manager,
+// so builds with changed descriptions are rejected and require a manager restart.
+func (mgr *Manager) reload() (string, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.staged != nil {
+		return "", fmt.Errorf("build %v is being verified", mgr.staged.checksum)
+	}
+	b, err := mgr.snapshotBuild(mgr.build)
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot build: %v", err)
+	}
+	if b == nil {
+		return fmt.Sprintf("build %v is up to date", mgr.build.checksum), nil
+	}
+	b.gen = mgr.build.gen + 1
+	mgr.staged = b
+	mgr.stats["reloads"]++
+	return fmt.Sprintf("staged build %v, waiting for a canary VM", b.checksum), nil
+}
+
+// chooseBuild returns build for a new instance, the first instance started after reload
+// is the canary for the staged build.
+func (mgr *Manager) chooseBuild(name string) *build {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.staged != nil && mgr.staged.canary == "" {
+		mgr.staged.canary = name
+		vmLogf(0, name, "verifying build %v", mgr.staged.checksum)
+		return mgr.staged
+	}
+	return mgr.build
+}
+
+// instanceDone is called when an instance that used build b exits,
+// crashed says if the VM crashed or hung (as opposed to being stopped or restarted on purpose).
+func (mgr *Manager) instanceDone(name string, b *build, crashed bool) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if f := mgr.fuzzers[name]; f != nil {
+		mgr.requeueCandidates(f, crashed)
+	}
+	if b == mgr.staged && b.canary == name {
+		// The canary crashed before the fuzzer connected, let another instance verify the build.
+		b.canary = ""
+	}
+}
+
+// checkRevision verifies that the fuzzer uses the same descriptions as the manager,
+// and promotes the staged build when its canary connects.
+func (mgr *Manager) checkRevision(name, revision string) error {
+	canary := mgr.staged != nil && mgr.staged.canary == name
+	if revision != sys.Revision {
+		err := fmt.Errorf("%v: fuzzer %v, manager %v", ErrRevisionMismatch, revision, sys.Revision)
+		if !canary {
+			// Promoted builds are verified, so this is the initial build.
+			fatalf("%v: %v (rebuild syzkaller)", name, err)
+		}
+		vmLogf(0, name, "rejecting build %v: %v (restart syz-manager to load new descriptions)",
+			mgr.staged.checksum, err)
+		mgr.staged.rejected = true
+		mgr.staged = nil
+		return err
+	}
+	if canary {
+		mgr.promoteBuild()
+	} else if len(mgr.restarts) != 0 {
+		mgr.restarts = mgr.restarts[1:]
+	}
+	return nil
+}
+
+func (mgr *Manager) promoteBuild() {
+	// Old build dir is not removed since running instances can still use it,
+	// workdir/bin is cleaned on startup.
+	logf(0, "switching to build %v", mgr.staged.checksum)
+	// The build has the same descriptions, so the corpus and priorities stay valid
+	// and are kept as is; coverage differences of the new executor are picked up by fuzzing.
+	mgr.build = mgr.staged
+	mgr.staged = nil
+	mgr.restarts = nil
+}
+
+// needRestart says if an instance that uses build b must be restarted to switch to the current build.
+// At most 1/restartFraction of VMs are restarted at the same time, so that fuzzing continues.
+func (mgr *Manager) needRestart(name string, b *build) bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if b.gen >= mgr.build.gen {
+		return false
+	}
+	for len(mgr.restarts) != 0 && time.Since(mgr.restarts[0]) > restartTimeout {
+		mgr.restarts = mgr.restarts[1:]
+	}
+	count := mgr.cfg.Count
+	for _, pool := range mgr.cfg.Pools {
+		count += pool.Count
+	}
+	max := count / restartFraction
+	if max == 0 {
+		max = 1
+	}
+	if len(mgr.restarts) >= max {
+		return false
+	}
+	mgr.restarts = append(mgr.restarts, time.Now())
+	vmLogf(0, name, "restarting to switch to build %v", mgr.build.checksum)
+	return true
+}
@@ -0,0 +1,291 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/google/syzkaller/repro"
+)
+
+// With Repro_Count the manager runs syz-repro on the first crash with every new description
+// (one job at a time, on Repro_Count VMs that are not used for fuzzing). If no reproducer is found,
+// next crashes with the description are tried as well (up to maxReproAttempts), so that the
+// reproducibility score of the description is based on several attempts.
+// syz-repro records results next to the crash log, /crashes shows them.
+// With Notify_Cmd the manager runs the command once per description when its score after an automatic
+// repro reaches Notify_Score. The command gets CRASH_TITLE, CRASH_LOG (the reproduced crash log),
+// CRASH_SCORE and CRASH_REPRO (the result as shown on /crashes) environment variables;
+// the notification is recorded in the bug history (see bugs.go).
+// With Retest_Repros the manager also queues re-tests of the latest saved reproducer of every
+// crash description when it starts with a new kernel build (syz-repro -retest), so that
+// /crashes shows bugs that no longer reproduce.
+
+const (
+	reproQueueLen    = 100
+	maxReproAttempts = 3
+	notifyTimeout    = 10 * time.Minute
+)
+
+func (mgr *Manager) initRepro() {
+	bin := filepath.Join(mgr.cfg.Syzkaller, "bin", "syz-repro")
+	if _, err := os.Stat(bin); err != nil {
+		fatalf("%v is missing, it is required for repro_count (run 'make repro')", bin)
+	}
+	if _, err := os.Stat(mgr.cfg.TargetBin("syz-execprog")); err != nil {
+		fatalf("%v is missing, it is required for repro_count (run 'make execprog')", mgr.cfg.TargetBin("syz-execprog"))
+	}
+	mgr.reproC = make(chan reproJob, reproQueueLen)
+	mgr.reproDescs = make(map[string]*reproState)
+	if mgr.cfg.Retest_Repros {
+		mgr.queueRetests()
+	}
+	go func() {
+		for job := range mgr.reproC {
+			mgr.waitResumed()
+			if atomic.LoadUint32(&mgr.shutdown) != 0 {
+				return
+			}
+			mgr.runRepro(bin, job)
+		}
+	}()
+}
+
+type reproJob struct {
+	file     string    // crash log
+	desc     string    // crash description for automatic reproduction of new crashes
+	retest   bool      // re-test the saved reproducer instead of reproducing the crash
+	config   string    // syz-repro config if it differs from the manager config (patch testing)
+	external bool      // test an external reproducer (file is its base name, see reprotest.go)
+	done     chan bool // closed when the job is finished (optional)
+}
+
+type reproState struct {
+	attempts   int  // number of queued syz-repro runs
+	pending    bool // a run is queued or running
+	reproduced bool
+}
+
+// queueRepro queues crash log file for reproduction if crashes with description desc
+// are not reproduced yet and are not being reproduced.
+func (mgr *Manager) queueRepro(desc, file string) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	st := mgr.reproDescs[desc]
+	if st == nil {
+		st = new(reproState)
+		mgr.reproDescs[desc] = st
+	}
+	if st.pending || st.reproduced || st.attempts >= maxReproAttempts {
+		return
+	}
+	select {
+	case mgr.reproC <- reproJob{file: file, desc: desc}:
+		st.attempts++
+		st.pending = true
+	default:
+		logf(0, "repro queue is full, not reproducing '%v'", desc)
+	}
+}
+
+// reproFinished updates reproduction state of the description of an automatic repro job
+// and sends notification if the description is reproducible enough.
+func (mgr *Manager) reproFinished(job reproJob) {
+	res, err := mgr.descResult(job.desc)
+	if err != nil {
+		logf(0, "%v", err)
+	}
+	mgr.mu.Lock()
+	st := mgr.reproDescs[job.desc]
+	st.pending = false
+	st.reproduced = res.Reproduced != 0
+	mgr.mu.Unlock()
+	logf(0, "reproducibility of '%v': %v", job.desc, res)
+	if mgr.cfg.Notify_Cmd == "" || res.Reproduced == 0 || res.Score() < mgr.cfg.Notify_Score {
+		return
+	}
+	mgr.mu.Lock()
+	bug := mgr.bug(job.desc)
+	notified := bug.Notified
+	mgr.mu.Unlock()
+	if notified {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", mgr.cfg.Notify_Cmd)
+	cmd.Env = append(os.Environ(), "CRASH_TITLE="+job.desc, "CRASH_LOG="+job.file,
+		fmt.Sprintf("CRASH_SCORE=%.2f", res.Score()), "CRASH_REPRO="+res.String())
+	if output, err := cmd.CombinedOutput(); err != nil {
+		logf(0, "notify_cmd failed for '%v': %v\n%s", job.desc, err, output)
+		return
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	bug.Notified = true
+	bug.change("syz-manager", "", "notified: reproducibility score %.2f", res.Score())
+	if err := mgr.saveBugs(); err != nil {
+		logf(0, "failed to save bugs: %v", err)
+	}
+}
+
+// descResult returns accumulated syz-repro results of all crash logs with description desc.
+func (mgr *Manager) descResult(desc string) (*repro.Result, error) {
+	total := new(repro.Result)
+	files, err := ioutil.ReadDir(mgr.crashdir)
+	if err != nil {
+		return total, fmt.Errorf("failed to read crashes dir: %v", err)
+	}
+	for _, f := range files {
+		if !isCrashLog(f.Name()) {
+			continue
+		}
+		file := filepath.Join(mgr.crashdir, f.Name())
+		if d, err := crashDesc(file); err != nil || d != desc {
+			continue
+		}
+		res, err := repro.Load(file)
+		if err != nil {
+			return total, err
+		}
+		total.Add(res)
+	}
+	return total, nil
+}
+
+// queueRetests queues re-tests of the latest saved reproducer of every crash description
+// that was not re-tested on the current kernel build yet.
+func (mgr *Manager) queueRetests() {
+	if mgr.kernelBuild == "" {
+		logf(0, "kernel build is unknown, not re-testing reproducers")
+		return
+	}
+	files, err := ioutil.ReadDir(mgr.crashdir)
+	if err != nil {
+		logf(0, "failed to read crashes dir: %v", err)
+		return
+	}
+	latest := make(map[string]string)
+	latestTime := make(map[string]time.Time)
+	for _, f := range files {
+		if !isCrashLog(f.Name()) {
+			continue
+		}
+		file := filepath.Join(mgr.crashdir, f.Name())
+		if _, err := os.Stat(file + repro.ProgSuffix); err != nil {
+			continue
+		}
+		desc, err := crashDesc(file)
+		if err != nil {
+			logf(0, "%v", err)
+			continue
+		}
+		if t := crashLogTime(f); latest[desc] == "" || t.After(latestTime[desc]) {
+			latest[desc] = file
+			latestTime[desc] = t
+		}
+	}
+	queued := 0
+	for desc, file := range latest {
+		retests, err := repro.LoadRetests(file)
+		if err != nil {
+			logf(0, "%v", err)
+			continue
+		}
+		if len(retests) != 0 && retests[len(retests)-1].KernelBuild == mgr.kernelBuild {
+			continue
+		}
+		select {
+		case mgr.reproC <- reproJob{file: file, retest: true}:
+			queued++
+		default:
+			logf(0, "repro queue is full, not re-testing '%v'", desc)
+		}
+	}
+	logf(0, "queued %v reproducers for re-testing on kernel build %v", queued, mgr.kernelBuild)
+}
+
+// crashLogTime returns the time a crash log was saved, it is encoded in the name (crash-<vm name>-<unix ns>),
+// modification time is used for names that don't end with a timestamp.
+func crashLogTime(f os.FileInfo) time.Time {
+	name := f.Name()
+	if ns, err := strconv.ParseInt(name[strings.LastIndexByte(name, '-')+1:], 10, 64); err == nil {
+		return time.Unix(0, ns)
+	}
+	return f.ModTime()
+}
+
+// runRepro runs syz-repro on the crash log. The job is killed if VMs are stopped (pause/shutdown).
+func (mgr *Manager) runRepro(bin string, job reproJob) {
+	if job.done != nil {
+		defer close(job.done)
+	}
+	stop := mgr.instanceStarted()
+	defer mgr.instanceStopped()
+	file := job.file
+	config := *flagConfig
+	if job.config != "" {
+		config = job.config
+	}
+	args := []string{"-config=" + config, "-count=" + strconv.Itoa(mgr.cfg.Repro_Count), file}
+	stat := "repro jobs"
+	if job.external {
+		args = append([]string{"-external"}, args...)
+		stat = "repro tests"
+		logf(0, "testing external reproducer %v", file)
+	} else if job.config != "" {
+		args = append([]string{"-retest"}, args...)
+		stat = "patch tests"
+		logf(0, "testing patch on %v", file)
+	} else if job.retest {
+		args = append([]string{"-retest"}, args...)
+		stat = "retest jobs"
+		logf(0, "re-testing reproducer of %v", file)
+	} else {
+		logf(0, "reproducing %v", file)
+	}
+	if job.desc != "" {
+		defer mgr.reproFinished(job)
+	}
+	cmd := exec.Command(bin, args...)
+	// syz-repro runs VMs as its children, so kill the whole process group.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if *flagV >= 1 {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Start(); err != nil {
+		logf(0, "failed to start syz-repro: %v", err)
+		return
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			logf(0, "syz-repro on %v failed: %v", file, err)
+			return
+		}
+		mgr.mu.Lock()
+		mgr.stats[stat]++
+		mgr.mu.Unlock()
+		logf(0, "syz-repro on %v finished", file)
+	case <-stop:
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-done
+		logf(0, "syz-repro on %v is interrupted", file)
+	}
+}
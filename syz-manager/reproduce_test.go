@@ -0,0 +1,49 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCrashLogTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syz-manager-crashes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	mtime := time.Unix(1500000000, 0)
+	tests := []struct {
+		name string
+		want time.Time
+	}{
+		// Lexicographic order of these names is the reverse of the time order.
+		{"crash-qemu-9-999999999999999999", time.Unix(0, 999999999999999999)},
+		{"crash-qemu-10-1500000000000000000", time.Unix(0, 1500000000000000000)},
+		{"crash-vm1-200", time.Unix(0, 200)},
+		{"crash-vm1-1000", time.Unix(0, 1000)},
+		{"crash-vm1", mtime},
+		{"crash-vm1-x", mtime},
+	}
+	for _, test := range tests {
+		file := filepath.Join(dir, test.name)
+		if err := ioutil.WriteFile(file, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Stat(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := crashLogTime(f); !got.Equal(test.want) {
+			t.Errorf("%v: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
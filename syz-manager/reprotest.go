@@ -0,0 +1,252 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/repro"
+)
+
+// Reproducer testing validates bugs reported elsewhere on the same infrastructure: POST /test_repro
+// (management API, see api.go) with a syzkaller program or a C reproducer queues a test that runs it
+// with syz-repro -external on Repro_Count VMs (in the repro queue) and records whether the kernel crashed
+// and the description of the crash. Tests are kept in workdir/repro_tests/<id>, /repro_tests serves
+// their status and results as JSON.
+
+const reproTestQueueLen = 20
+
+type ReproTest struct {
+	ID       string
+	Format   string // "syz" or "c"
+	Threaded bool   `json:",omitempty"` // execution options of syz programs
+	Collide  bool   `json:",omitempty"`
+	Created  time.Time
+	Status   string
+	Error    string        `json:",omitempty"` // for failed tests
+	Result   *repro.Retest `json:",omitempty"` // for done tests, Desc is the description of the first crash
+	Crashed  *bool         `json:",omitempty"` // for done tests
+	dir      string
+}
+
+func (mgr *Manager) reproTestDir() string {
+	return filepath.Join(mgr.cfg.Workdir, "repro_tests")
+}
+
+// initReproTests loads tests of previous runs, the ones that were not finished are marked as failed.
+func (mgr *Manager) initReproTests() {
+	mgr.reproTestC = make(chan *ReproTest, reproTestQueueLen)
+	dirs, err := ioutil.ReadDir(mgr.reproTestDir())
+	if err != nil && !os.IsNotExist(err) {
+		fatalf("failed to read reproducer tests: %v", err)
+	}
+	for _, d := range dirs {
+		test := new(ReproTest)
+		test.dir = filepath.Join(mgr.reproTestDir(), d.Name())
+		data, err := ioutil.ReadFile(filepath.Join(test.dir, "test.json"))
+		if err != nil {
+			continue
+		}
+		if err := json.Unmarshal(data, test); err != nil {
+			logf(0, "failed to parse reproducer test %v: %v", d.Name(), err)
+			continue
+		}
+		if test.Status != JobDone && test.Status != JobFailed {
+			test.Status, test.Error = JobFailed, "interrupted by manager restart"
+			mgr.saveReproTest(test)
+		}
+		if id, err := strconv.Atoi(test.ID); err == nil && id >= mgr.reproTestSeq {
+			mgr.reproTestSeq = id + 1
+		}
+		mgr.reproTests = append(mgr.reproTests, test)
+	}
+	sort.Slice(mgr.reproTests, func(i, j int) bool {
+		return mgr.reproTests[i].Created.Before(mgr.reproTests[j].Created)
+	})
+	go func() {
+		for test := range mgr.reproTestC {
+			mgr.runReproTest(test)
+		}
+	}()
+}
+
+func (mgr *Manager) saveReproTest(test *ReproTest) {
+	data, err := json.MarshalIndent(test, "", "\t")
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(test.dir, "test.json"), data, 0660); err != nil {
+		logf(0, "failed to save reproducer test %v: %v", test.ID, err)
+	}
+}
+
+func (mgr *Manager) setReproTestStatus(test *ReproTest, status string, err error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	test.Status = status
+	if err != nil {
+		test.Error = err.Error()
+		logf(0, "reproducer test %v failed: %v", test.ID, err)
+	} else {
+		logf(0, "reproducer test %v: %v", test.ID, status)
+	}
+	mgr.saveReproTest(test)
+}
+
+// httpTestRepro queues a reproducer test. Form values: either prog (a syzkaller program) or c (a C reproducer),
+// as a value or a file; for programs threaded and collide (execution options, "0" or "false" disables them,
+// both are enabled by default). It replies with the test ID.
+func (mgr *Manager) httpTestRepro(w http.ResponseWriter, r *http.Request) {
+	if !mgr.apiAuthorized(w, r) {
+		return
+	}
+	if mgr.reproTestC == nil {
+		http.Error(w, "reproducer testing is disabled (set repro_count config param)", http.StatusForbidden)
+		return
+	}
+	progData, err := formData(r, "prog")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	csrc, err := formData(r, "c")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if (len(progData) == 0) == (len(csrc) == 0) {
+		http.Error(w, "specify either prog or c", http.StatusBadRequest)
+		return
+	}
+	test := &ReproTest{
+		Format:  "c",
+		Created: time.Now(),
+		Status:  JobQueued,
+	}
+	if len(progData) != 0 {
+		if _, err := prog.Deserialize(progData); err != nil {
+			http.Error(w, fmt.Sprintf("failed to parse program: %v", err), http.StatusBadRequest)
+			return
+		}
+		test.Format = "syz"
+		test.Threaded = formBool(r, "threaded")
+		test.Collide = formBool(r, "collide")
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	test.ID = strconv.Itoa(mgr.reproTestSeq)
+	test.dir = filepath.Join(mgr.reproTestDir(), test.ID)
+	if err := os.MkdirAll(test.dir, 0700); err != nil {
+		http.Error(w, fmt.Sprintf("failed to create test dir: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// The reproducer is saved the way syz-repro saves found reproducers, next to a (missing) crash log.
+	file := filepath.Join(test.dir, "repro")
+	if test.Format == "syz" {
+		opts := repro.ProgOptions{Threaded: test.Threaded, Collide: test.Collide, Multiplier: 1}
+		err = repro.SaveProg(file, progData, opts)
+	} else {
+		err = ioutil.WriteFile(file+repro.CSuffix, csrc, 0660)
+	}
+	if err != nil {
+		os.RemoveAll(test.dir)
+		http.Error(w, fmt.Sprintf("failed to save reproducer: %v", err), http.StatusInternalServerError)
+		return
+	}
+	select {
+	case mgr.reproTestC <- test:
+	default:
+		os.RemoveAll(test.dir)
+		http.Error(w, "reproducer test queue is full", http.StatusServiceUnavailable)
+		return
+	}
+	mgr.reproTestSeq++
+	mgr.reproTests = append(mgr.reproTests, test)
+	mgr.saveReproTest(test)
+	logf(0, "queued reproducer test %v (%v)", test.ID, test.Format)
+	fmt.Fprintf(w, "%v\n", test.ID)
+}
+
+// formData returns form value name, or contents of the file uploaded as name.
+func formData(r *http.Request, name string) ([]byte, error) {
+	f, _, err := r.FormFile(name)
+	if err != nil {
+		return []byte(r.FormValue(name)), nil
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v: %v", name, err)
+	}
+	return data, nil
+}
+
+func formBool(r *http.Request, name string) bool {
+	v := r.FormValue(name)
+	return v != "0" && v != "false"
+}
+
+// httpReproTests serves all reproducer tests (or the one with the given id) as JSON.
+func (mgr *Manager) httpReproTests(w http.ResponseWriter, r *http.Request) {
+	mgr.mu.Lock()
+	var data []byte
+	var err error
+	if id := r.FormValue("id"); id != "" {
+		var test *ReproTest
+		for _, t := range mgr.reproTests {
+			if t.ID == id {
+				test = t
+			}
+		}
+		if test == nil {
+			mgr.mu.Unlock()
+			http.Error(w, fmt.Sprintf("no reproducer test %v", id), http.StatusNotFound)
+			return
+		}
+		data, err = json.MarshalIndent(test, "", "\t")
+	} else {
+		tests := append([]*ReproTest{}, mgr.reproTests...)
+		data, err = json.MarshalIndent(tests, "", "\t")
+	}
+	mgr.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (mgr *Manager) runReproTest(test *ReproTest) {
+	mgr.setReproTestStatus(test, JobTesting, nil)
+	file := filepath.Join(test.dir, "repro")
+	done := make(chan bool)
+	mgr.reproC <- reproJob{file: file, external: true, done: done}
+	<-done
+	retests, err := repro.LoadRetests(file)
+	if err == nil && (len(retests) == 0 || retests[len(retests)-1].Runs == 0) {
+		err = fmt.Errorf("the reproducer was not run (see manager log)")
+	}
+	if err != nil {
+		mgr.setReproTestStatus(test, JobFailed, err)
+		return
+	}
+	res := retests[len(retests)-1]
+	crashed := res.Crashes != 0
+	mgr.mu.Lock()
+	test.Result, test.Crashed = &res, &crashed
+	mgr.mu.Unlock()
+	mgr.setReproTestStatus(test, JobDone, nil)
+	logf(0, "reproducer test %v: %v crashes in %v runs '%v'", test.ID, res.Crashes, res.Runs, res.Desc)
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/google/syzkaller/cover"
+	. "github.com/google/syzkaller/rpctype"
+	"github.com/google/syzkaller/sys"
+)
+
+// Long-running fuzzing plateaus: all fuzzers mutate the same corpus and mostly rediscover
+// the same coverage. With Corpus_Rotation every rotation sets aside a random part of the corpus
+// and drops its coverage from corpus cover, so that VMs that start after that fuzz without
+// these inputs and inputs that reach the same code in different ways are accepted as new.
+// On the next rotation the set aside inputs are returned as candidates and re-triaged,
+// and on the one after that inputs that still add coverage are put back to corpus directly,
+// so that rotation never loses coverage. Rotation happens only when there is nothing to triage.
+
+// rotateLoop periodically rotates corpus.
+func (mgr *Manager) rotateLoop() {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		time.Sleep(time.Duration(mgr.cfg.Corpus_Rotation_Period) * time.Minute)
+		mgr.mu.Lock()
+		mgr.rotateCorpus(rnd)
+		mgr.mu.Unlock()
+	}
+}
+
+func (mgr *Manager) rotateCorpus(rnd *rand.Rand) {
+	if mgr.untriaged() != 0 {
+		logf(1, "not rotating corpus: %v candidates are not triaged", mgr.untriaged())
+		return
+	}
+	// Put back returned inputs that were not rediscovered during triage.
+	restored := 0
+	for _, inp := range mgr.returning {
+		call := sys.CallID[inp.Call]
+		cov := cover.MustDecompress(inp.Cover)
+		if len(cover.Difference(cov, mgr.corpusCover[call])) == 0 {
+			continue
+		}
+		mgr.corpusCover[call] = cover.Union(mgr.corpusCover[call], cov)
+		mgr.corpus = append(mgr.corpus, inp)
+		mgr.dirtyCalls[inp.Call] = true
+		restored++
+	}
+	// Return the previously set aside inputs for re-triage.
+	mgr.returning = mgr.rotated
+	for _, inp := range mgr.returning {
+		delete(mgr.candidateSigs, hash(inp.Prog))
+		mgr.addCandidate(inp.Prog)
+	}
+	// Set aside a new part of corpus, pinned inputs are never set aside.
+	var unpinned []int
+	for i, inp := range mgr.corpus {
+		if !mgr.isPinned(inp.Prog) {
+			unpinned = append(unpinned, i)
+		}
+	}
+	n := len(unpinned) * mgr.cfg.Corpus_Rotation / 100
+	drop := make([]bool, len(mgr.corpus))
+	mgr.rotated = nil
+	calls := make(map[string]bool)
+	for _, i := range rnd.Perm(len(unpinned))[:n] {
+		idx := unpinned[i]
+		drop[idx] = true
+		mgr.rotated = append(mgr.rotated, mgr.corpus[idx])
+		calls[mgr.corpus[idx].Call] = true
+	}
+	mgr.dropInputs(drop)
+	for c := range calls {
+		mgr.corpusCover[sys.CallID[c]] = nil
+	}
+	for _, inp := range mgr.corpus {
+		if calls[inp.Call] {
+			call := sys.CallID[inp.Call]
+			mgr.corpusCover[call] = cover.Union(mgr.corpusCover[call], cover.MustDecompress(inp.Cover))
+		}
+	}
+	mgr.stats["rotated inputs"] += uint64(n)
+	logf(0, "rotated corpus: set aside %v inputs, returned %v, restored %v", n, len(mgr.returning), restored)
+}
+
+// rotatedInputs returns inputs that are not in corpus because of rotation,
+// they must not be removed from the persistent corpus.
+func (mgr *Manager) rotatedInputs() []RpcInput {
+	return append(append([]RpcInput(nil), mgr.rotated...), mgr.returning...)
+}
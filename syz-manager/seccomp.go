@@ -0,0 +1,43 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+
+	"github.com/google/syzkaller/seccomp"
+	"github.com/google/syzkaller/sys"
+)
+
+// With Seccomp_Profile the profile is copied into VMs and passed to fuzzers in -seccomp flag,
+// and executor installs it in test processes. Enabled calls that the profile blocks are not reachable
+// from the sandbox, so they are disabled for fuzzing and listed on the main page
+// (calls allowed only with some arguments are fuzzed and fail with other arguments).
+
+func (mgr *Manager) initSeccomp() {
+	if mgr.cfg.Seccomp_Profile == "" {
+		return
+	}
+	data, err := ioutil.ReadFile(mgr.cfg.Seccomp_Profile)
+	if err != nil {
+		fatalf("failed to read seccomp profile: %v", err)
+	}
+	filter, err := seccomp.Parse(data)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	enabled := mgr.enabledCalls()
+	var blocked []string
+	for _, c := range sys.Calls {
+		if enabled[c.ID] && !filter.Allowed(c) {
+			blocked = append(blocked, c.Name)
+		}
+	}
+	if len(blocked) == len(enabled) {
+		fatalf("seccomp profile %v blocks all enabled syscalls", mgr.cfg.Seccomp_Profile)
+	}
+	logf(0, "seccomp profile blocks %v out of %v enabled calls", len(blocked), len(enabled))
+	mgr.seccompBlocked = blocked
+	mgr.disableCalls(blocked)
+}
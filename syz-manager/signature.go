@@ -0,0 +1,108 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Together with crash-xxx.symbolized the symbolizer writes crash-xxx.signature.json,
+// a normalized machine-readable signature of the crash for external dedup tools.
+// /crash_signatures serves signatures of all saved crashes as a JSON array.
+// The schema (CrashSignature) is documented in README, incompatible changes bump Version.
+
+const (
+	signatureSuffix  = ".signature.json"
+	signatureVersion = 1
+)
+
+type CrashSignature struct {
+	Version      int      `json:"version"`
+	Title        string   `json:"title"`                     // crash description, as on /crashes
+	Frames       []string `json:"frames"`                    // normalized function names of report frames, top first
+	GuiltyFile   string   `json:"guilty_file,omitempty"`     // relative to the kernel source dir
+	GuiltyFunc   string   `json:"guilty_function,omitempty"` // function of the guilty frame
+	Maintainers  []string `json:"maintainers,omitempty"`     // of the guilty file, from MAINTAINERS
+	KernelCommit string   `json:"kernel_commit,omitempty"`   // Kernel_Commit from the config
+	KernelBuild  string   `json:"kernel_build,omitempty"`    // hash of vmlinux
+	Log          string   `json:"log"`                       // name of the crash log file
+}
+
+// Compiler-generated suffixes of function clones (foo.isra.0, foo.constprop.3, foo.part.1, foo.cold.2).
+var cloneSuffixRe = regexp.MustCompile(`(\.(isra|constprop|part|cold|lto_priv)(\.[0-9]+)?)+$`)
+
+// normalizeFrame strips clone suffixes from function name fn.
+func normalizeFrame(fn string) string {
+	return cloneSuffixRe.ReplaceAllString(fn, "")
+}
+
+func (sym *reportSymbolizer) writeSignature(file string, frames []*reportFrame, guilty guiltyInfo) error {
+	title, err := crashDesc(file)
+	if err != nil {
+		return err
+	}
+	sig := &CrashSignature{
+		Version:      signatureVersion,
+		Title:        title,
+		Frames:       []string{},
+		GuiltyFile:   guilty.File,
+		GuiltyFunc:   guilty.Func,
+		KernelCommit: sym.kernelCommit,
+		KernelBuild:  sym.kernelBuild,
+		Log:          filepath.Base(file),
+	}
+	if guilty.Maintainers != "" {
+		sig.Maintainers = strings.Split(guilty.Maintainers, ", ")
+	}
+	for _, f := range frames {
+		fn := normalizeFrame(f.fn)
+		if n := len(sig.Frames); n == 0 || sig.Frames[n-1] != fn {
+			sig.Frames = append(sig.Frames, fn)
+		}
+	}
+	data, err := json.MarshalIndent(sig, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file+signatureSuffix, data, 0660)
+}
+
+func (mgr *Manager) httpSignatures(w http.ResponseWriter, r *http.Request) {
+	files, err := ioutil.ReadDir(mgr.crashdir)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read crashes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	var names []string
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), "crash-") && strings.HasSuffix(f.Name(), signatureSuffix) {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	sigs := []*CrashSignature{}
+	for _, name := range names {
+		data, err := ioutil.ReadFile(filepath.Join(mgr.crashdir, name))
+		if err != nil {
+			continue // the crash was deleted
+		}
+		sig := new(CrashSignature)
+		if err := json.Unmarshal(data, sig); err != nil {
+			http.Error(w, fmt.Sprintf("failed to parse %v: %v", name, err), http.StatusInternalServerError)
+			return
+		}
+		sigs = append(sigs, sig)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sigs); err != nil {
+		logf(0, "failed to write signatures: %v", err)
+	}
+}
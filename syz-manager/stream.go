@@ -0,0 +1,370 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The /log page streams manager log and console output of VMs to the browser over a WebSocket
+// (/log_stream). Query params: v is the max verbosity of manager log messages (default: -v flag),
+// vm is a VM name (e.g. vm-0) whose console output is streamed along with the log,
+// filter is a regexp, only matching lines are sent.
+// Log messages are formatted regardless of -v only while there are clients.
+// Slow clients don't block the manager, lines that don't fit into the client queue are dropped.
+
+const (
+	streamQueueLen     = 1000
+	streamWriteTimeout = 10 * time.Second
+	streamMaxPartial   = 4 << 10 // console output without new line is sent when it's that long
+)
+
+type logStreamer struct {
+	active  int32 // number of clients, accessed atomically
+	mu      sync.Mutex
+	clients map[*streamClient]bool
+	partial map[string][]byte // incomplete console lines per VM
+}
+
+type streamClient struct {
+	v       int
+	vm      string
+	filter  *regexp.Regexp
+	c       chan string
+	dropped int // guarded by logStreamer.mu
+}
+
+var streamer = &logStreamer{
+	clients: make(map[*streamClient]bool),
+	partial: make(map[string][]byte),
+}
+
+// log sends manager log message with verbosity v to clients.
+func (s *logStreamer) log(v int, msg string, args ...interface{}) {
+	if atomic.LoadInt32(&s.active) == 0 {
+		return
+	}
+	line := time.Now().Format("2006/01/02 15:04:05 ") + strings.TrimRight(fmt.Sprintf(msg, args...), "\n")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		if v <= c.v {
+			c.send(line)
+		}
+	}
+}
+
+// console sends complete lines of console output of VM name to clients that watch the VM.
+func (s *logStreamer) console(name string, out []byte) {
+	if atomic.LoadInt32(&s.active) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := append(s.partial[name], out...)
+	pos := bytes.LastIndexByte(data, '\n') + 1
+	if pos == 0 && len(data) >= streamMaxPartial {
+		pos = len(data)
+	}
+	s.partial[name] = append([]byte{}, data[pos:]...)
+	if pos == 0 {
+		return
+	}
+	for _, ln := range strings.Split(strings.TrimRight(string(data[:pos]), "\n"), "\n") {
+		line := name + ": " + strings.TrimRight(ln, "\r")
+		for c := range s.clients {
+			if c.vm == name {
+				c.send(line)
+			}
+		}
+	}
+}
+
+// send queues line for the client without blocking, s.mu must be held.
+func (c *streamClient) send(line string) {
+	if c.filter != nil && !c.filter.MatchString(line) {
+		return
+	}
+	if c.dropped != 0 {
+		select {
+		case c.c <- fmt.Sprintf("[%v lines dropped]", c.dropped):
+			c.dropped = 0
+		default:
+			c.dropped++
+			return
+		}
+	}
+	select {
+	case c.c <- line:
+	default:
+		c.dropped++
+	}
+}
+
+func (s *logStreamer) subscribe(c *streamClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[c] = true
+	atomic.AddInt32(&s.active, 1)
+}
+
+func (s *logStreamer) unsubscribe(c *streamClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, c)
+	if atomic.AddInt32(&s.active, -1) == 0 {
+		s.partial = make(map[string][]byte)
+	}
+}
+
+func parseStreamParams(r *http.Request) (*streamClient, error) {
+	c := &streamClient{
+		v:  *flagV,
+		vm: r.FormValue("vm"),
+		c:  make(chan string, streamQueueLen),
+	}
+	if v := r.FormValue("v"); v != "" {
+		var err error
+		if c.v, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("bad verbosity: %v", err)
+		}
+	}
+	if filter := r.FormValue("filter"); filter != "" {
+		var err error
+		if c.filter, err = regexp.Compile(filter); err != nil {
+			return nil, fmt.Errorf("bad filter: %v", err)
+		}
+	}
+	return c, nil
+}
+
+func (mgr *Manager) httpLog(w http.ResponseWriter, r *http.Request) {
+	if _, err := parseStreamParams(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data := &UILog{
+		V:      r.FormValue("v"),
+		VM:     r.FormValue("vm"),
+		Filter: r.FormValue("filter"),
+		Query:  r.URL.RawQuery,
+	}
+	if data.V == "" {
+		data.V = strconv.Itoa(*flagV)
+	}
+	mgr.instMu.Lock()
+	for name := range mgr.instances {
+		data.VMs = append(data.VMs, name)
+	}
+	mgr.instMu.Unlock()
+	sort.Strings(data.VMs)
+	if err := logTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+func (mgr *Manager) httpLogStream(w http.ResponseWriter, r *http.Request) {
+	c, err := parseStreamParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	conn, brw, err := websocketUpgrade(w, r)
+	if err != nil {
+		logf(1, "log stream: %v", err)
+		return
+	}
+	defer conn.Close()
+	streamer.subscribe(c)
+	defer streamer.unsubscribe(c)
+
+	// The client does not send anything useful, but reading is required to notice that it went away.
+	closed := make(chan bool)
+	go func() {
+		websocketDiscard(brw.Reader)
+		close(closed)
+	}()
+	for {
+		select {
+		case line := <-c.c:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := websocketWrite(brw.Writer, wsText, []byte(line)); err != nil {
+				return
+			}
+		case <-closed:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			websocketWrite(brw.Writer, wsClose, nil)
+			return
+		}
+	}
+}
+
+// Minimal server side of WebSocket protocol (RFC 6455): the server only sends unfragmented text messages.
+
+const (
+	wsText  = 0x1
+	wsClose = 0x8
+
+	wsGUID       = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	wsMaxPayload = 1 << 20
+)
+
+func websocketUpgrade(w http.ResponseWriter, r *http.Request) (net.Conn, *bufio.ReadWriter, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != "GET" || !headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") || key == "" {
+		http.Error(w, "websocket request expected", http.StatusBadRequest)
+		return nil, nil, fmt.Errorf("not a websocket request")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusBadRequest)
+		return nil, nil, fmt.Errorf("unsupported websocket version %q", r.Header.Get("Sec-WebSocket-Version"))
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket is not supported", http.StatusInternalServerError)
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to hijack connection: %v", err)
+	}
+	hash := sha1.Sum([]byte(key + wsGUID))
+	fmt.Fprintf(brw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: %v\r\n\r\n", base64.StdEncoding.EncodeToString(hash[:]))
+	if err := brw.Flush(); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, brw, nil
+}
+
+func headerContains(h http.Header, name, token string) bool {
+	for _, v := range h[name] {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func websocketWrite(w *bufio.Writer, opcode byte, payload []byte) error {
+	hdr := []byte{0x80 | opcode, 0}
+	switch n := len(payload); {
+	case n < 126:
+		hdr[1] = byte(n)
+	case n <= 0xffff:
+		hdr[1] = 126
+		hdr = append(hdr, 0, 0)
+		binary.BigEndian.PutUint16(hdr[2:], uint16(n))
+	default:
+		hdr[1] = 127
+		hdr = append(hdr, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(hdr[2:], uint64(n))
+	}
+	w.Write(hdr)
+	w.Write(payload)
+	return w.Flush()
+}
+
+// websocketDiscard reads and discards client frames until a close frame or an error.
+func websocketDiscard(r *bufio.Reader) {
+	var hdr [8]byte
+	for {
+		if _, err := io.ReadFull(r, hdr[:2]); err != nil {
+			return
+		}
+		opcode := hdr[0] & 0xf
+		n := uint64(hdr[1] & 0x7f)
+		switch n {
+		case 126:
+			if _, err := io.ReadFull(r, hdr[:2]); err != nil {
+				return
+			}
+			n = uint64(binary.BigEndian.Uint16(hdr[:2]))
+		case 127:
+			if _, err := io.ReadFull(r, hdr[:8]); err != nil {
+				return
+			}
+			n = binary.BigEndian.Uint64(hdr[:8])
+		}
+		if hdr[1]&0x80 != 0 {
+			n += 4 // masking key
+		}
+		if opcode == wsClose || n > wsMaxPayload {
+			return
+		}
+		if _, err := io.CopyN(ioutil.Discard, r, int64(n)); err != nil {
+			return
+		}
+	}
+}
+
+type UILog struct {
+	V      string
+	VM     string
+	Filter string
+	Query  string
+	VMs    []string
+}
+
+var logTemplate = template.Must(template.New("").Parse(`
+<!doctype html>
+<html>
+<head>
+    <title>syzkaller log</title>
+</head>
+<body>
+<form action="/log">
+	verbosity: <input name="v" value="{{.V}}" size="3">
+	console: <select name="vm">
+		<option value="">none</option>
+		{{range $vm := .VMs}}<option{{if eq $vm $.VM}} selected{{end}}>{{$vm}}</option>{{end}}
+	</select>
+	filter: <input name="filter" value="{{.Filter}}">
+	<input type="submit" value="apply">
+	<span id="status"></span>
+</form>
+<pre id="log"></pre>
+<script>
+var maxLines = 10000;
+var out = document.getElementById("log");
+var statusText = document.getElementById("status");
+var proto = window.location.protocol == "https:" ? "wss://" : "ws://";
+var ws = new WebSocket(proto + window.location.host + "/log_stream?" + {{.Query}});
+ws.onopen = function() { statusText.textContent = "streaming"; };
+ws.onclose = function() { statusText.textContent = "disconnected"; };
+ws.onmessage = function(e) {
+	var follow = window.innerHeight + window.scrollY >= document.body.scrollHeight - 10;
+	out.appendChild(document.createTextNode(e.data + "\n"));
+	while (out.childNodes.length > maxLines) {
+		out.removeChild(out.firstChild);
+	}
+	if (follow) {
+		window.scrollTo(0, document.body.scrollHeight);
+	}
+};
+</script>
+</body></html>
+`))
@@ -0,0 +1,392 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/syzkaller/maintainers"
+	"github.com/google/syzkaller/vm"
+)
+
+// Crash reports of Linux kernels are symbolized by a bounded pool of workers, so that addr2line
+// runs on a large vmlinux don't stall instance loops. For crash log crash-xxx the worker writes
+// crash-xxx.symbolized with the report where every frame (func+0xoff/0xsize) is annotated
+// with its source location, preceded by a header with the guilty file and function: the first frame
+// (innermost inlined function first) that is not part of generic reporting/allocation/locking code,
+// and maintainers and mailing lists of the guilty file from MAINTAINERS in the kernel source dir
+// (if it's there). /crashes shows them and groups crashes by the guilty function.
+// The worker also exports the crash signature (see signature.go).
+
+const (
+	symbolizeWorkers  = 4
+	symbolizeQueueLen = 100
+	symbolizedSuffix  = ".symbolized"
+)
+
+type reportSymbolizer struct {
+	vmlinux string
+	srcDir  string // prefix stripped from file names (dir of vmlinux)
+	c       chan string
+
+	kernelCommit string
+	kernelBuild  string
+
+	maintOnce   sync.Once
+	kernelSrc   string
+	maintainers *maintainers.Maintainers // nil if there is no MAINTAINERS file
+
+	symsOnce sync.Once
+	syms     map[string][]funcSymbol
+	symsErr  error
+	kallsyms func() map[string][]funcSymbol // symbols from VMs if there is no vmlinux
+}
+
+func (mgr *Manager) initSymbolizer() {
+	if mgr.cfg.OS != "linux" {
+		return
+	}
+	sym := &reportSymbolizer{
+		vmlinux:  mgr.cfg.Vmlinux,
+		srcDir:   filepath.Dir(mgr.cfg.Vmlinux) + "/",
+		c:        make(chan string, symbolizeQueueLen),
+		kallsyms: mgr.kallsymsFuncs,
+
+		kernelCommit: mgr.cfg.Kernel_Commit,
+		kernelBuild:  mgr.kernelBuild,
+		kernelSrc:    mgr.kernelSrc(),
+	}
+	for i := 0; i < symbolizeWorkers; i++ {
+		go func() {
+			for file := range sym.c {
+				if err := sym.symbolizeFile(file); err != nil {
+					logf(0, "failed to symbolize %v: %v", file, err)
+					continue
+				}
+				mgr.autoAssignBug(file)
+			}
+		}()
+	}
+	mgr.symbolizer = sym
+}
+
+// queueSymbolize queues crash log file for symbolization, it never blocks.
+func (mgr *Manager) queueSymbolize(file string) {
+	if mgr.symbolizer == nil {
+		return
+	}
+	select {
+	case mgr.symbolizer.c <- file:
+	default:
+		logf(0, "symbolization queue is full, not symbolizing %v", file)
+	}
+}
+
+func (sym *reportSymbolizer) symbols() (map[string][]funcSymbol, error) {
+	if sym.vmlinux == "" {
+		// Frames are resolved with kallsyms of VMs, source locations are not known.
+		syms := sym.kallsyms()
+		if syms == nil {
+			return nil, fmt.Errorf("no vmlinux and no kallsyms from VMs yet")
+		}
+		return syms, nil
+	}
+	sym.symsOnce.Do(func() {
+		syms, err := funcSymbols(sym.vmlinux)
+		if err != nil {
+			sym.symsErr = err
+			return
+		}
+		sym.syms = make(map[string][]funcSymbol)
+		for _, s := range syms {
+			sym.syms[s.name] = append(sym.syms[s.name], s)
+		}
+	})
+	return sym.syms, sym.symsErr
+}
+
+// emails returns maintainers and mailing lists responsible for the guilty file.
+func (sym *reportSymbolizer) emails(guilty string) []string {
+	sym.maintOnce.Do(func() {
+		if sym.kernelSrc == "" {
+			return
+		}
+		m, err := maintainers.Load(sym.kernelSrc)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logf(0, "failed to load MAINTAINERS: %v", err)
+			}
+			return
+		}
+		sym.maintainers = m
+	})
+	if sym.maintainers == nil || guilty == "" {
+		return nil
+	}
+	return sym.maintainers.Emails(guilty)
+}
+
+var frameRe = regexp.MustCompile(`([a-zA-Z0-9_.]+)\+0x([0-9a-f]+)/0x([0-9a-f]+)`)
+
+type reportFrame struct {
+	line  int    // index of the report line
+	fn    string // function name as in the report
+	pc    uint64
+	files []string // file:line, innermost first (addr2line -i)
+	funcs []string // functions of files
+}
+
+func (sym *reportSymbolizer) symbolizeFile(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	_, start, _, found := vm.FindCrash("linux", data)
+	if !found {
+		return nil
+	}
+	syms, err := sym.symbols()
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(data[start:]), "\n")
+	var frames []*reportFrame
+	for i, ln := range lines {
+		m := frameRe.FindStringSubmatch(ln)
+		if m == nil {
+			continue
+		}
+		off, err1 := strconv.ParseUint(m[2], 16, 64)
+		size, err2 := strconv.ParseUint(m[3], 16, 64)
+		if err1 != nil || err2 != nil || len(syms[m[1]]) == 0 {
+			continue
+		}
+		// Static functions can have the same name, prefer the one with the same size.
+		s := syms[m[1]][0]
+		for _, s1 := range syms[m[1]] {
+			if s1.end-s1.start == size {
+				s = s1
+				break
+			}
+		}
+		pc := s.start + off
+		// Call trace frames are return addresses, the call is the previous instruction.
+		if off != 0 && !strings.Contains(ln, "RIP:") && !strings.Contains(ln, "PC is at") {
+			pc--
+		}
+		frames = append(frames, &reportFrame{line: i, fn: m[1], pc: pc})
+	}
+	if len(frames) == 0 {
+		return sym.writeSignature(file, nil, guiltyInfo{})
+	}
+	if err := sym.addr2line(frames); err != nil {
+		return err
+	}
+	var guilty guiltyInfo
+	for _, f := range frames {
+		if len(f.files) != 0 {
+			lines[f.line] += "\t" + strings.Join(f.files, " inlined in ")
+		}
+		if guilty.Func != "" {
+			continue
+		}
+		if len(f.files) == 0 {
+			// No source info, judge by the function name only.
+			if !genericFunc(f.fn) {
+				guilty.Func = normalizeFrame(f.fn)
+			}
+			continue
+		}
+		for i, loc := range f.files {
+			file := loc[:strings.LastIndexByte(loc, ':')]
+			if !genericFrame(file) && !genericFunc(f.funcs[i]) {
+				guilty.File, guilty.Func = file, normalizeFrame(f.funcs[i])
+				break
+			}
+		}
+	}
+	if guilty.File != "" {
+		guilty.Maintainers = strings.Join(sym.emails(guilty.File), ", ")
+	}
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "guilty file: %v\nguilty function: %v\nmaintainers: %v\n\n%v",
+		guilty.File, guilty.Func, guilty.Maintainers, strings.Join(lines, "\n"))
+	if err := ioutil.WriteFile(file+symbolizedSuffix, buf.Bytes(), 0660); err != nil {
+		return err
+	}
+	return sym.writeSignature(file, frames, guilty)
+}
+
+// addr2line fills in source locations and functions of frames.
+func (sym *reportSymbolizer) addr2line(frames []*reportFrame) error {
+	if sym.vmlinux == "" {
+		return nil
+	}
+	cmd := exec.Command("addr2line", "-a", "-f", "-i", "-e", sym.vmlinux)
+	for _, f := range frames {
+		cmd.Args = append(cmd.Args, fmt.Sprintf("0x%x", f.pc))
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("addr2line failed: %v", err)
+	}
+	// Every address is followed by function and file:line pairs, innermost first.
+	idx := -1
+	fn := ""
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		ln := s.Text()
+		if strings.HasPrefix(ln, "0x") {
+			idx++
+			fn = ""
+			continue
+		}
+		if fn == "" {
+			fn = ln
+			continue
+		}
+		loc := ln
+		if idx >= 0 && idx < len(frames) && !strings.HasPrefix(loc, "??") && fn != "??" {
+			frames[idx].files = append(frames[idx].files, strings.TrimPrefix(loc, sym.srcDir))
+			frames[idx].funcs = append(frames[idx].funcs, fn)
+		}
+		fn = ""
+	}
+	return s.Err()
+}
+
+// guiltySkip are files and dirs of generic code that appears in reports of bugs in other code.
+var guiltySkip = []string{
+	"include/",
+	"arch/x86/include/",
+	"arch/x86/kernel/dumpstack",
+	"arch/x86/kernel/traps.c",
+	"arch/x86/mm/fault.c",
+	"arch/x86/entry/",
+	"kernel/panic.c",
+	"kernel/printk/",
+	"kernel/locking/",
+	"kernel/rcu/",
+	"kernel/sched/",
+	"kernel/watchdog.c",
+	"kernel/hung_task.c",
+	"kernel/kcsan/",
+	"lib/bug.c",
+	"lib/dump_stack.c",
+	"lib/fault-inject.c",
+	"mm/kasan/",
+	"mm/kmemleak.c",
+	"mm/slab.c",
+	"mm/slub.c",
+	"mm/page_alloc.c",
+	"mm/util.c",
+}
+
+// guiltySkipFuncs are prefixes of names of generic functions (reporting, checking, allocation, locking,
+// string helpers) that are not guilty in crashes. They are used in addition to guiltySkip,
+// in particular for frames without source info.
+var guiltySkipFuncs = []string{
+	"kasan_",
+	"__kasan_",
+	"__asan_",
+	"check_memory_region",
+	"kmsan_",
+	"kcsan_",
+	"__tsan_",
+	"kmalloc",
+	"__kmalloc",
+	"kfree",
+	"kzalloc",
+	"kmem_cache_",
+	"slab_",
+	"print_",
+	"printk",
+	"vprintk",
+	"dump_stack",
+	"show_stack",
+	"__warn",
+	"warn_slowpath",
+	"report_bug",
+	"panic",
+	"do_error_trap",
+	"do_invalid_op",
+	"invalid_op",
+	"fixup_bug",
+	"memcpy",
+	"memmove",
+	"memset",
+	"strlen",
+	"strcpy",
+	"strncpy",
+	"__might_sleep",
+	"___might_sleep",
+	"__might_fault",
+	"lock_",
+	"_raw_spin_",
+	"mutex_",
+	"debug_",
+	"lockdep_",
+}
+
+// genericFunc returns whether function fn is generic code that is not guilty in a crash.
+func genericFunc(fn string) bool {
+	for _, skip := range guiltySkipFuncs {
+		if strings.HasPrefix(fn, skip) {
+			return true
+		}
+	}
+	return false
+}
+
+// genericFrame returns whether file is generic code that is not guilty in a crash.
+func genericFrame(file string) bool {
+	if strings.HasPrefix(file, "/") {
+		return true // outside of the kernel source dir
+	}
+	for _, skip := range guiltySkip {
+		if strings.HasPrefix(file, skip) {
+			return true
+		}
+	}
+	return false
+}
+
+type guiltyInfo struct {
+	File        string
+	Func        string
+	Maintainers string // comma-separated
+}
+
+// loadGuilty returns the guilty file and function recorded for crash log file, if it is symbolized.
+func loadGuilty(file string) guiltyInfo {
+	var info guiltyInfo
+	data, err := ioutil.ReadFile(file + symbolizedSuffix)
+	if err != nil {
+		return info
+	}
+	for _, ln := range strings.Split(string(data), "\n") {
+		if ln == "" {
+			break // end of the header
+		}
+		switch {
+		case strings.HasPrefix(ln, "guilty file: "):
+			info.File = strings.TrimPrefix(ln, "guilty file: ")
+		case strings.HasPrefix(ln, "guilty function: "):
+			info.Func = strings.TrimPrefix(ln, "guilty function: ")
+		case strings.HasPrefix(ln, "maintainers: "):
+			info.Maintainers = strings.TrimPrefix(ln, "maintainers: ")
+		}
+	}
+	return info
+}
@@ -0,0 +1,210 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/syzkaller/config"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys"
+)
+
+// Calls can be disabled on a live manager with POST /syscalls API requests (e.g. to stop fuzzing a noisy subsystem
+// immediately), without a restart that loses the triage queue. The requests take call names or glob patterns
+// in the format of the disable_syscalls config param. Fuzzers get the new set of enabled calls on the next
+// poll: they don't generate calls that are disabled and don't mutate corpus programs that contain them.
+// Candidates and corpus inputs that contain disabled calls are not sent to fuzzers (candidates are held
+// in mgr.heldCandidates until the calls are enabled again).
+// Disabled patterns are stored in workdir/disabled_calls.json and apply after restarts until they are
+// enabled again.
+
+func (mgr *Manager) disabledCallsFile() string {
+	return filepath.Join(mgr.cfg.Workdir, "disabled_calls.json")
+}
+
+func (mgr *Manager) loadDisabledCalls() {
+	data, err := ioutil.ReadFile(mgr.disabledCallsFile())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fatalf("failed to read disabled calls: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &mgr.disabledPatterns); err != nil {
+		fatalf("failed to parse %v: %v", mgr.disabledCallsFile(), err)
+	}
+	for _, pattern := range mgr.disabledPatterns {
+		if _, err := config.MatchSyscalls(pattern); err != nil {
+			fatalf("bad pattern in %v: %v", mgr.disabledCallsFile(), err)
+		}
+	}
+	if len(mgr.disabledPatterns) != 0 {
+		logf(0, "calls disabled at runtime: %v", mgr.disabledPatterns)
+	}
+}
+
+// saveDisabledCalls writes disabled patterns to workdir/disabled_calls.json, mgr.mu must be held.
+func (mgr *Manager) saveDisabledCalls() error {
+	data, err := json.MarshalIndent(mgr.disabledPatterns, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp := mgr.disabledCallsFile() + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0660); err != nil {
+		return err
+	}
+	return os.Rename(tmp, mgr.disabledCallsFile())
+}
+
+// fuzzerCalls returns IDs of calls enabled for fuzzers (see ConnectRes.EnabledCalls), that is enabled calls
+// without calls that match disabled patterns.
+func (mgr *Manager) fuzzerCalls() map[int]bool {
+	enabled := mgr.enabledCalls()
+	for _, pattern := range mgr.disabledPatterns {
+		calls, _ := config.MatchSyscalls(pattern)
+		for _, c := range calls {
+			delete(enabled, c.ID)
+		}
+	}
+	return enabled
+}
+
+// pollCalls returns fuzzer calls for filtering of programs sent to fuzzers,
+// nil if no calls are disabled at runtime (nothing to filter). mgr.mu must be held.
+func (mgr *Manager) pollCalls() map[int]bool {
+	if len(mgr.disabledPatterns) == 0 {
+		return nil
+	}
+	return mgr.fuzzerCalls()
+}
+
+// callsEnabled says if program data contains only calls from enabled.
+func callsEnabled(data []byte, enabled map[int]bool) bool {
+	p, err := prog.Deserialize(data)
+	if err != nil {
+		panic(err)
+	}
+	for _, c := range p.Calls {
+		if !enabled[c.Meta.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+type UISyscalls struct {
+	Patterns []string
+	Disabled []string // calls that are enabled in config, but are disabled by Patterns
+	Enabled  int
+	Held     int // candidates that contain disabled calls
+}
+
+// httpSyscalls shows calls disabled at runtime. Authorized POST API requests disable calls
+// that match pattern (or enable them again with enable=1).
+func (mgr *Manager) httpSyscalls(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		if !mgr.apiAuthorized(w, r) {
+			return
+		}
+		pattern, enable := r.FormValue("pattern"), r.FormValue("enable") != ""
+		mgr.mu.Lock()
+		err := mgr.toggleCalls(pattern, enable)
+		mgr.mu.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if enable {
+			fmt.Fprintf(w, "enabled calls %v\n", pattern)
+		} else {
+			fmt.Fprintf(w, "disabled calls %v\n", pattern)
+		}
+		return
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	data := &UISyscalls{Patterns: mgr.disabledPatterns, Held: len(mgr.heldCandidates)}
+	enabled, fuzzed := mgr.enabledCalls(), mgr.fuzzerCalls()
+	for id := range enabled {
+		if !fuzzed[id] {
+			data.Disabled = append(data.Disabled, sys.Calls[id].Name)
+		}
+	}
+	sort.Strings(data.Disabled)
+	data.Enabled = len(fuzzed)
+	if err := syscallsTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// toggleCalls adds pattern to disabled patterns or removes it, mgr.mu must be held.
+func (mgr *Manager) toggleCalls(pattern string, enable bool) error {
+	calls, err := config.MatchSyscalls(pattern)
+	if err != nil {
+		return err
+	}
+	idx := -1
+	for i, p := range mgr.disabledPatterns {
+		if p == pattern {
+			idx = i
+		}
+	}
+	if enable {
+		if idx == -1 {
+			return fmt.Errorf("%v is not disabled", pattern)
+		}
+		mgr.disabledPatterns = append(mgr.disabledPatterns[:idx:idx], mgr.disabledPatterns[idx+1:]...)
+		logf(0, "enabled calls %v", pattern)
+		// Held candidates go back to the queue in the original order, the next poll filters them again.
+		for i := len(mgr.heldCandidates) - 1; i >= 0; i-- {
+			mgr.candidates = append(mgr.candidates, mgr.heldCandidates[i])
+		}
+		mgr.heldCandidates = nil
+	} else {
+		if idx != -1 {
+			return fmt.Errorf("%v is already disabled", pattern)
+		}
+		if len(calls) == 0 {
+			return fmt.Errorf("no calls match %v", pattern)
+		}
+		mgr.disabledPatterns = append(mgr.disabledPatterns, pattern)
+		if len(mgr.fuzzerCalls()) == 0 {
+			mgr.disabledPatterns = mgr.disabledPatterns[:len(mgr.disabledPatterns)-1]
+			return fmt.Errorf("%v disables all calls", pattern)
+		}
+		logf(0, "disabled calls %v (%v calls)", pattern, len(calls))
+	}
+	mgr.callsGen++
+	return mgr.saveDisabledCalls()
+}
+
+var syscallsTemplate = template.Must(template.New("").Parse(`
+<!doctype html>
+<html>
+<head>
+    <title>syzkaller syscalls</title>
+</head>
+<body>
+Enabled calls: {{.Enabled}} <br>
+Calls are disabled with POST /syscalls API requests (pattern=ioctl$DRM_*), enabled with pattern=...&amp;enable=1.<br>
+<br>
+{{if .Patterns}}
+Disabled patterns: {{range $p := .Patterns}}{{$p}} {{end}}<br>
+{{end}}
+{{if .Disabled}}
+Disabled calls: {{range $c := .Disabled}}{{$c}} {{end}}<br>
+{{end}}
+{{if .Held}}
+Held candidates: {{.Held}}<br>
+{{end}}
+</body></html>
+`))
@@ -0,0 +1,75 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/google/syzkaller/vm"
+)
+
+// Crashes of syz-fuzzer and syz-executor themselves are bugs in syzkaller rather than in the kernel.
+// They are saved with descriptions starting with syzBugPrefix, are not reproduced or symbolized
+// and are counted in "syzkaller bugs" statistics instead of "crashes".
+
+const syzBugPrefix = "SYZKALLER BUG: "
+
+var (
+	// Go panics and runtime fatal errors are followed by goroutine stacks (possibly after a [signal ...] line).
+	goPanicRe = regexp.MustCompile(`(?m)^(?:panic|fatal error): (.*)\n(?:.*\n){0,3}?goroutine [0-9]+ \[`)
+	// Unhandled signals in userspace are reported by the kernel as e.g.
+	// "syz-executor3[4567]: segfault at 0 ip ... sp ... error 4 in syz-executor3[400000+b6000]".
+	executorSegfaultRe = regexp.MustCompile(`syz-executor[0-9]*\[[0-9]+\]: segfault at `)
+)
+
+// syzBugSearch limits how far after a panic line the fuzzer frames are looked for.
+const syzBugSearch = 64 << 10
+
+// findSyzkallerBug finds the first crash of syz-fuzzer or syz-executor in output.
+// fatal is set for fuzzer panics (the fuzzer exits), executor segfaults are not fatal (the fuzzer restarts it).
+func findSyzkallerBug(output []byte) (desc string, start int, fatal, found bool) {
+	if loc := executorSegfaultRe.FindIndex(output); loc != nil {
+		start = bytes.LastIndexByte(output[:loc[0]], '\n') + 1
+		return syzBugPrefix + "executor segfault", start, false, true
+	}
+	for pos := 0; pos < len(output); {
+		match := goPanicRe.FindSubmatchIndex(output[pos:])
+		if match == nil {
+			break
+		}
+		start = pos + match[0]
+		end := start + syzBugSearch
+		if end > len(output) {
+			end = len(output)
+		}
+		// Other Go programs (e.g. the gVisor sentry) panic too, only panics with fuzzer frames are syzkaller bugs.
+		if bytes.Contains(output[start:end], []byte("/syz-fuzzer/")) {
+			msg := string(output[pos+match[2] : pos+match[3]])
+			return syzBugPrefix + "fuzzer panic: " + vm.NormalizeDesc("linux", msg), start, true, true
+		}
+		pos += match[1]
+	}
+	return "", 0, false, false
+}
+
+// isSyzkallerBug returns true if crash description desc is returned by findSyzkallerBug.
+func isSyzkallerBug(desc string) bool {
+	return strings.HasPrefix(desc, syzBugPrefix)
+}
+
+// newSyzkallerBug records syzkaller bug desc and returns false if it was already saved
+// (the same bug usually happens on all VMs over and over).
+func (mgr *Manager) newSyzkallerBug(desc string) bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.syzBugs[desc] {
+		mgr.stats["syzkaller bugs dup"]++
+		return false
+	}
+	mgr.syzBugs[desc] = true
+	mgr.stats["syzkaller bugs"]++
+	return true
+}
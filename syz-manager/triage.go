@@ -0,0 +1,156 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/google/syzkaller/cover"
+	"github.com/google/syzkaller/prog"
+	. "github.com/google/syzkaller/rpctype"
+	"github.com/google/syzkaller/sys"
+)
+
+// Triaged corpus inputs (with their coverage) are periodically saved to workdir/triage,
+// so that a restarted manager resumes triage instead of re-triaging the whole corpus.
+// Candidates are programs from the persistent corpus that are not in the saved state,
+// so candidates that were handed to fuzzers but not triaged before the restart are triaged again.
+// The state is valid only for the same kernel build, fuzzer/executor binaries and coverage focus.
+
+const triageSavePeriod = 10 * time.Minute
+
+type triageState struct {
+	KernelBuild string
+	Build       string
+	CoverFilter cover.Filter
+	Modules     []cover.Module // canonical module layout of Inputs coverage (see updateModules)
+	Inputs      []triageInput
+}
+
+type triageInput struct {
+	Sig       string // hash of the program in the persistent corpus
+	Call      string
+	CallIndex int
+	Cover     []byte // compressed with cover.Compress
+}
+
+func (mgr *Manager) initTriage() {
+	if !mgr.cfg.Cover || mgr.kernelBuild == "" {
+		return
+	}
+	mgr.loadTriage()
+	go func() {
+		for {
+			time.Sleep(triageSavePeriod)
+			mgr.saveTriage()
+		}
+	}()
+}
+
+// loadTriage moves candidates that were triaged before the restart to corpus.
+func (mgr *Manager) loadTriage() {
+	file := filepath.Join(mgr.cfg.Workdir, "triage")
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logf(0, "failed to read triage state: %v", err)
+		}
+		return
+	}
+	state := new(triageState)
+	if err := json.Unmarshal(data, state); err != nil {
+		logf(0, "failed to parse triage state: %v", err)
+		return
+	}
+	if state.KernelBuild != mgr.kernelBuild || state.Build != mgr.build.checksum ||
+		!reflect.DeepEqual(state.CoverFilter, mgr.coverFilter) {
+		logf(0, "kernel, binaries or coverage focus have changed, re-triaging corpus")
+		return
+	}
+	mgr.modules = state.Modules
+	candidates := make(map[string][]byte)
+	for _, data := range mgr.candidates {
+		h := hash(data)
+		candidates[hex.EncodeToString(h[:])] = data
+	}
+	restored := make(map[string]bool)
+	for _, inp := range state.Inputs {
+		data := candidates[inp.Sig]
+		if data == nil {
+			continue
+		}
+		p, err := prog.Deserialize(data)
+		if err != nil {
+			continue
+		}
+		if _, ok := sys.CallID[inp.Call]; !ok || inp.CallIndex < 0 || inp.CallIndex >= len(p.Calls) ||
+			p.Calls[inp.CallIndex].Meta.CallName != inp.Call {
+			continue
+		}
+		cov, err := cover.Decompress(inp.Cover)
+		if err != nil {
+			continue
+		}
+		call := sys.CallID[inp.Call]
+		mgr.corpusCover[call] = cover.Union(mgr.corpusCover[call], cov)
+		mgr.corpus = append(mgr.corpus, RpcInput{Call: inp.Call, Prog: data, CallIndex: inp.CallIndex, Cover: inp.Cover})
+		mgr.dirtyCalls[inp.Call] = true
+		// A program can give several inputs (for different calls), so candidates
+		// are removed only after all inputs are restored.
+		restored[inp.Sig] = true
+	}
+	// Keep the original order of candidates.
+	old := mgr.candidates
+	mgr.candidates = nil
+	for _, data := range old {
+		h := hash(data)
+		if !restored[hex.EncodeToString(h[:])] {
+			mgr.candidates = append(mgr.candidates, data)
+		}
+	}
+	logf(0, "restored %v triaged inputs, %v candidates left", len(mgr.corpus), len(mgr.candidates))
+}
+
+// saveTriage saves triaged corpus inputs.
+func (mgr *Manager) saveTriage() {
+	if !mgr.cfg.Cover || mgr.kernelBuild == "" {
+		return
+	}
+	mgr.mu.Lock()
+	state := &triageState{
+		KernelBuild: mgr.kernelBuild,
+		Build:       mgr.build.checksum,
+		CoverFilter: mgr.coverFilter,
+		Modules:     mgr.modules,
+	}
+	for _, inp := range mgr.corpus {
+		h := hash(inp.Prog)
+		state.Inputs = append(state.Inputs, triageInput{
+			Sig:       hex.EncodeToString(h[:]),
+			Call:      inp.Call,
+			CallIndex: inp.CallIndex,
+			Cover:     inp.Cover,
+		})
+	}
+	mgr.mu.Unlock()
+	data, err := json.Marshal(state)
+	if err != nil {
+		logf(0, "failed to marshal triage state: %v", err)
+		return
+	}
+	file := filepath.Join(mgr.cfg.Workdir, "triage")
+	if err := ioutil.WriteFile(file+".tmp", data, 0600); err != nil {
+		logf(0, "failed to write triage state: %v", err)
+		return
+	}
+	if err := os.Rename(file+".tmp", file); err != nil {
+		logf(0, "failed to write triage state: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	. "github.com/google/syzkaller/rpctype"
+	"github.com/google/syzkaller/sys"
+)
+
+// With Adaptive_Calls fuzzers report how many times every call was executed in generated
+// and mutated programs and how many of these executions gave new coverage. Every
+// callWeightsPeriod the manager recalculates call weights from the yield (new coverage
+// per execution) relative to the median yield, and fuzzers scale call priorities by the weights.
+// Statistics decay with every recalculation, so weights follow recent yield: calls that
+// stopped yielding are gradually de-emphasized and calls that start yielding again recover.
+
+const (
+	callWeightsPeriod = 10 * time.Minute
+	callStatsDecay    = 0.5
+	minCallExecs      = 1000 // calls with fewer (decayed) executions keep weight 1
+	minCallWeight     = 0.1
+	maxCallWeight     = 10
+)
+
+type callStat struct {
+	execs    float64
+	newCover float64
+}
+
+func (mgr *Manager) initCallWeights() {
+	if !mgr.cfg.Adaptive_Calls {
+		return
+	}
+	mgr.callStats = make(map[string]*callStat)
+	go func() {
+		for {
+			time.Sleep(callWeightsPeriod)
+			mgr.mu.Lock()
+			mgr.updateCallWeights()
+			mgr.mu.Unlock()
+		}
+	}()
+}
+
+// addCallStats accumulates call statistics from Poll.
+func (mgr *Manager) addCallStats(stats map[string]CallStat) {
+	if mgr.callStats == nil {
+		return
+	}
+	for name, st := range stats {
+		if sys.CallMap[name] == nil {
+			continue
+		}
+		cs := mgr.callStats[name]
+		if cs == nil {
+			cs = new(callStat)
+			mgr.callStats[name] = cs
+		}
+		cs.execs += float64(st.Execs)
+		cs.newCover += float64(st.NewCover)
+	}
+}
+
+func (mgr *Manager) updateCallWeights() {
+	var yields []float64
+	for _, cs := range mgr.callStats {
+		if cs.execs >= minCallExecs {
+			yields = append(yields, cs.newCover/cs.execs)
+		}
+	}
+	if len(yields) == 0 {
+		return
+	}
+	sort.Float64s(yields)
+	median := yields[len(yields)/2]
+	if median == 0 {
+		// Most calls don't yield anything, compare with the smallest non-zero yield.
+		median = yields[len(yields)-1]
+		for _, y := range yields {
+			if y != 0 {
+				median = y
+				break
+			}
+		}
+	}
+	weights := make([]float32, len(sys.Calls))
+	for i := range weights {
+		weights[i] = 1
+	}
+	low := 0
+	for name, cs := range mgr.callStats {
+		if cs.execs >= minCallExecs && median != 0 {
+			// The square root dampens the effect, so that a call does not vanish from programs
+			// because of a single unlucky period.
+			w := math.Sqrt(cs.newCover / cs.execs / median)
+			w = math.Max(minCallWeight, math.Min(maxCallWeight, w))
+			weights[sys.CallMap[name].ID] = float32(w)
+			if w < 1 {
+				low++
+			}
+		}
+		cs.execs *= callStatsDecay
+		cs.newCover *= callStatsDecay
+	}
+	mgr.callWeights = weights
+	mgr.callWeightsGen++
+	logf(1, "updated call weights: %v calls with stats, %v de-emphasized", len(yields), low)
+}
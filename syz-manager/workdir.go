@@ -0,0 +1,152 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/syzkaller/config"
+	"github.com/google/syzkaller/vm"
+)
+
+// Workdir layout is versioned with workdir/version, so that managers upgrade workdirs of older managers
+// on startup instead of ignoring or misinterpreting their state, and refuse to run on workdirs
+// of newer managers. Workdirs without the version file are version 0. workdirMigrations[i]
+// upgrades layout i to i+1; the version is written after every successful step, so a migration
+// that is interrupted is restarted from that step on the next start (migrations must be idempotent).
+
+const workdirVersionFile = "version"
+
+var workdirMigrations = []func(cfg *config.Config) error{
+	migrateLegacyCrashNames,
+	migrateCrashDescs,
+}
+
+func workdirVersion() int {
+	return len(workdirMigrations)
+}
+
+func migrateWorkdir(cfg *config.Config) {
+	file := filepath.Join(cfg.Workdir, workdirVersionFile)
+	version := 0
+	if data, err := ioutil.ReadFile(file); err == nil {
+		v, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil || v < 0 {
+			fatalf("bad workdir layout version in %v: %q", file, data)
+		}
+		version = v
+	} else if !os.IsNotExist(err) {
+		fatalf("failed to read workdir layout version: %v", err)
+	} else if !exists(filepath.Join(cfg.Workdir, "corpus")) && !exists(filepath.Join(cfg.Workdir, "crashes")) {
+		version = workdirVersion() // new workdir
+	}
+	if version > workdirVersion() {
+		fatalf("workdir layout version %v is newer than supported version %v (use a newer syz-manager)",
+			version, workdirVersion())
+	}
+	for ; version < workdirVersion(); version++ {
+		logf(0, "migrating workdir layout from version %v to %v", version, version+1)
+		if err := workdirMigrations[version](cfg); err != nil {
+			fatalf("failed to migrate workdir layout to version %v: %v", version+1, err)
+		}
+		writeWorkdirVersion(file, version+1)
+	}
+	if !exists(file) {
+		writeWorkdirVersion(file, version)
+	}
+}
+
+func writeWorkdirVersion(file string, version int) {
+	tmp := file + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(fmt.Sprintf("%v\n", version)), 0660); err != nil {
+		fatalf("failed to write workdir layout version: %v", err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		fatalf("failed to write workdir layout version: %v", err)
+	}
+}
+
+func exists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
+
+// Old managers saved crash logs as crashes/crash<vm index>-<time>, the current name is crash-<vm name>-<time>.
+var legacyCrashRe = regexp.MustCompile(`^crash([0-9]+)-([0-9]+)$`)
+
+// migrateLegacyCrashNames renames old crash logs, so that /crashes, re-tests and signatures see them.
+func migrateLegacyCrashNames(cfg *config.Config) error {
+	dir := filepath.Join(cfg.Workdir, "crashes")
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	renamed := 0
+	for _, f := range files {
+		m := legacyCrashRe.FindStringSubmatch(f.Name())
+		if m == nil {
+			continue
+		}
+		name := fmt.Sprintf("crash-%v-%v-%v", cfg.Type, m[1], m[2])
+		if err := os.Rename(filepath.Join(dir, f.Name()), filepath.Join(dir, name)); err != nil {
+			return err
+		}
+		renamed++
+	}
+	if renamed != 0 {
+		logf(0, "renamed %v crash logs with legacy names", renamed)
+	}
+	return nil
+}
+
+// migrateCrashDescs normalizes descriptions of crash logs saved before vm.NormalizeDesc,
+// so that old crashes are grouped with new crashes of the same bug.
+func migrateCrashDescs(cfg *config.Config) error {
+	dir := filepath.Join(cfg.Workdir, "crashes")
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	updated := 0
+	for _, f := range files {
+		if !isCrashLog(f.Name()) {
+			continue
+		}
+		file := filepath.Join(dir, f.Name())
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		trimmed := strings.TrimRight(string(data), "\n")
+		pos := strings.LastIndexByte(trimmed, '\n') + 1
+		desc := vm.NormalizeDesc(cfg.OS, trimmed[pos:])
+		if desc == trimmed[pos:] {
+			continue
+		}
+		tmp := file + ".tmp"
+		if err := ioutil.WriteFile(tmp, []byte(trimmed[:pos]+desc+"\n"), 0660); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp, file); err != nil {
+			return err
+		}
+		updated++
+	}
+	if updated != 0 {
+		logf(0, "normalized descriptions of %v crash logs", updated)
+	}
+	return nil
+}
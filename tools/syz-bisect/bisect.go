@@ -0,0 +1,368 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-bisect finds the kernel commit that introduced a bug. It bisects kernel git history
+// between -good and -bad commits: every tested commit is built in -kernel dir with -kernel_config,
+// booted in VMs described by the manager config (which must point to the kernel built in -kernel,
+// e.g. vmlinux and qemu kernel params) and the reproducer (syzkaller program or C source) is run
+// -runs times. A commit is bad if the kernel crashes in any run (with -title only crashes with
+// that title count, other crashes make the run inconclusive), commits that fail to build,
+// boot or have only inconclusive runs are skipped. With -fix it finds the commit that fixed the bug instead: the bug must
+// reproduce on -good and must not reproduce on -bad.
+// With -crash the bisection runs on a crash saved by syz-manager (workdir/crashes/crash-xxx): the saved
+// reproducer (crash-xxx.prog) is used if no reproducer is given, -title defaults to the crash description
+// and the result is recorded in crash-xxx.bisect, so that /crashes shows it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/bisect"
+	"github.com/google/syzkaller/config"
+	"github.com/google/syzkaller/csource"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/repro"
+	"github.com/google/syzkaller/vm"
+	_ "github.com/google/syzkaller/vm/adb"
+	_ "github.com/google/syzkaller/vm/gvisor"
+	_ "github.com/google/syzkaller/vm/isolated"
+	_ "github.com/google/syzkaller/vm/kvm"
+	_ "github.com/google/syzkaller/vm/qemu"
+)
+
+var (
+	flagConfig       = flag.String("config", "", "manager configuration file")
+	flagKernel       = flag.String("kernel", "", "kernel git checkout (the tree is reset to tested commits)")
+	flagKernelConfig = flag.String("kernel_config", "", "kernel .config (default: kernel_config from manager config)")
+	flagGood         = flag.String("good", "", "commit where the bug does not reproduce")
+	flagBad          = flag.String("bad", "HEAD", "commit where the bug reproduces")
+	flagFix          = flag.Bool("fix", false, "find the fixing commit (the bug reproduces on -good and does not reproduce on -bad)")
+	flagImageCmd     = flag.String("image_cmd", "", "command that creates VM image after kernel build (run with sh -c in kernel dir)")
+	flagJobs         = flag.Int("jobs", runtime.NumCPU(), "make -j for kernel builds")
+	flagRuns         = flag.Int("runs", 4, "number of reproducer runs on every commit (in separate VMs)")
+	flagTimeout      = flag.Duration("timeout", 5*time.Minute, "duration of every reproducer run")
+	flagThreaded     = flag.Bool("threaded", true, "run program in threaded mode (syzkaller programs only)")
+	flagCollide      = flag.Bool("collide", true, "collide syscalls (syzkaller programs only)")
+	flagTitle        = flag.String("title", "", "title of the crash the reproducer triggers (default: any crash)")
+	flagCrash        = flag.String("crash", "", "crash log saved by syz-manager (workdir/crashes/crash-xxx) to bisect and record the result for")
+
+	buildTimeout = 3 * time.Hour
+)
+
+func main() {
+	flag.Parse()
+	if (len(flag.Args()) != 1 && (*flagCrash == "" || len(flag.Args()) != 0)) || *flagKernel == "" || *flagGood == "" {
+		fmt.Fprintf(os.Stderr, "usage: syz-bisect -config=manager.cfg -kernel=linux -good=v4.9 [-bad=HEAD] repro.prog|repro.c\n")
+		fmt.Fprintf(os.Stderr, "       syz-bisect -config=manager.cfg -kernel=linux -good=v4.9 [-bad=HEAD] -crash=workdir/crashes/crash-xxx [repro.prog|repro.c]\n")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	cfg, _, _, err := config.Parse(*flagConfig)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	kernelConfig := *flagKernelConfig
+	if kernelConfig == "" {
+		kernelConfig = cfg.Kernel_Config
+	}
+	if kernelConfig == "" {
+		log.Fatalf("specify kernel config with -kernel_config flag or kernel_config config param")
+	}
+	// The config is copied, because it may be in the kernel dir, which is reset on checkouts.
+	kernelConfigData, err := ioutil.ReadFile(kernelConfig)
+	if err != nil {
+		log.Fatalf("failed to read kernel config: %v", err)
+	}
+	var reproFile string
+	if len(flag.Args()) != 0 {
+		reproFile = flag.Args()[0]
+	}
+	if *flagCrash != "" {
+		if reproFile, err = loadCrash(*flagCrash, reproFile); err != nil {
+			log.Fatalf("%v", err)
+		}
+		if len(flag.Args()) == 0 {
+			defer os.Remove(reproFile)
+		}
+	}
+	reproducer, err := newReproducer(cfg, reproFile)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	defer reproducer.close()
+
+	crashes := make(map[string]string) // commit hash -> crash title
+	test := func(commit string) (bisect.Verdict, error) {
+		if err := buildKernel(*flagKernel, kernelConfigData); err != nil {
+			log.Printf("%v", err)
+			return bisect.Skip, nil
+		}
+		verdict, crash := reproducer.test()
+		crashes[commit] = crash
+		return verdict, nil
+	}
+	bisectFunc, what := bisect.Bisect, "bad"
+	if *flagFix {
+		bisectFunc, what = bisect.BisectFix, "fixing"
+	}
+	res, err := bisectFunc(*flagKernel, *flagGood, *flagBad, test, log.Printf)
+	if err != nil {
+		log.Fatalf("bisection failed: %v", err)
+	}
+	if *flagCrash != "" {
+		if err := recordBisection(*flagCrash, res, crashes); err != nil {
+			log.Fatalf("failed to record bisection result: %v", err)
+		}
+	}
+	log.Printf("tested commits:")
+	for _, c := range res.Tested {
+		log.Printf("  %v %-4v %v", c.Hash[:12], c.Verdict, c.Title)
+		if c.Verdict == bisect.Bad {
+			log.Printf("               crash: %v", crashes[c.Hash])
+		}
+	}
+	if res.Commit != nil {
+		log.Printf("the first %v commit:\n%v %v\nAuthor: %v", what, res.Commit.Hash, res.Commit.Title, res.Commit.Author)
+		return
+	}
+	log.Printf("the first %v commit could be any of (the others can't be tested):", what)
+	for _, c := range res.Suspects {
+		log.Printf("%v %v", c.Hash, c.Title)
+	}
+}
+
+// loadCrash sets -title to the description of crash log file unless it is set,
+// and returns reproFile or the saved reproducer of the crash (in a temp file) if reproFile is empty.
+// The saved reproducer is run with the options it crashed the kernel with.
+func loadCrash(file, reproFile string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read crash log: %v", err)
+	}
+	if *flagTitle == "" {
+		lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+		*flagTitle = lines[len(lines)-1]
+	}
+	if reproFile != "" {
+		return reproFile, nil
+	}
+	prog, opts, err := repro.LoadProg(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to load saved reproducer: %v", err)
+	}
+	*flagThreaded, *flagCollide = opts.Threaded, opts.Collide
+	f, err := ioutil.TempFile("", "syz-bisect")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.Write(prog); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// recordBisection records bisection result res next to crash log file.
+func recordBisection(file string, res *bisect.Result, crashes map[string]string) error {
+	b := repro.Bisection{Time: time.Now(), Fix: *flagFix}
+	if res.Commit != nil {
+		b.Commit, b.Title, b.Author = res.Commit.Hash, res.Commit.Title, res.Commit.Author
+		if !b.Fix {
+			b.Crash = crashes[b.Commit]
+		}
+	}
+	for _, c := range res.Suspects {
+		b.Suspects = append(b.Suspects, c.Hash+" "+c.Title)
+	}
+	return repro.RecordBisection(file, b)
+}
+
+func buildKernel(dir string, kernelConfig []byte) error {
+	if err := ioutil.WriteFile(filepath.Join(dir, ".config"), kernelConfig, 0600); err != nil {
+		return fmt.Errorf("failed to write kernel config: %v", err)
+	}
+	if err := run(dir, nil, "make", "olddefconfig"); err != nil {
+		return err
+	}
+	if err := run(dir, nil, "make", fmt.Sprintf("-j%v", *flagJobs)); err != nil {
+		return err
+	}
+	if *flagImageCmd != "" {
+		if err := run(dir, []string{"KERNEL_DIR=" + dir}, "sh", "-c", *flagImageCmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func run(dir string, env []string, bin string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), buildTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		const maxOutput = 8 << 10
+		if len(output) > maxOutput {
+			output = output[len(output)-maxOutput:]
+		}
+		return fmt.Errorf("'%v %v' failed: %v\n%s", bin, strings.Join(args, " "), err, output)
+	}
+	return nil
+}
+
+type reproducer struct {
+	cfg  *config.Config
+	file string // syzkaller program or compiled C reproducer
+	bin  bool
+}
+
+func newReproducer(cfg *config.Config, file string) (*reproducer, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reproducer: %v", err)
+	}
+	if strings.HasSuffix(file, ".c") {
+		bin, err := csource.Build(file)
+		if err != nil {
+			return nil, err
+		}
+		return &reproducer{cfg: cfg, file: bin, bin: true}, nil
+	}
+	if _, err := prog.Deserialize(data); err != nil {
+		return nil, fmt.Errorf("failed to parse reproducer program: %v", err)
+	}
+	if _, err := os.Stat(cfg.TargetBin("syz-execprog")); err != nil {
+		return nil, fmt.Errorf("%v is missing (run 'make execprog')", cfg.TargetBin("syz-execprog"))
+	}
+	return &reproducer{cfg: cfg, file: file}, nil
+}
+
+func (r *reproducer) close() {
+	if r.bin {
+		os.Remove(r.file)
+	}
+}
+
+// test runs the reproducer in -runs VMs (at most cfg.Count at a time).
+// The commit is bad if any run crashes the kernel, it is skipped if no run is conclusive.
+// The title of the first crash is returned along with the verdict.
+func (r *reproducer) test() (bisect.Verdict, string) {
+	var mu sync.Mutex
+	crash, conclusive := "", 0
+	var wg sync.WaitGroup
+	sem := make(chan bool, r.cfg.Count)
+	for i := 0; i < *flagRuns; i++ {
+		wg.Add(1)
+		sem <- true
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			ok, desc := r.runOnce()
+			mu.Lock()
+			defer mu.Unlock()
+			if ok {
+				conclusive++
+			}
+			if crash == "" {
+				crash = desc
+			}
+		}()
+	}
+	wg.Wait()
+	switch {
+	case crash != "":
+		return bisect.Bad, crash
+	case conclusive == 0:
+		return bisect.Skip, ""
+	default:
+		return bisect.Good, ""
+	}
+}
+
+// runOnce boots a VM and runs the reproducer in it, it returns whether the run is conclusive
+// and the title of the crash (empty if the kernel did not crash). Runs where the VM does not boot,
+// the reproducer fails without a kernel oops or the kernel crashes with a title other than -title
+// are inconclusive.
+func (r *reproducer) runOnce() (bool, string) {
+	vmCfg, err := config.CreateVMConfig(r.cfg)
+	if err != nil {
+		log.Fatalf("failed to create VM config: %v", err)
+	}
+	defer os.RemoveAll(vmCfg.Workdir)
+	ctx := context.Background()
+	inst, err := vm.Create(ctx, r.cfg.Type, vmCfg)
+	if err != nil {
+		log.Printf("failed to boot VM: %v", err)
+		return false, ""
+	}
+	defer inst.Close()
+	file, err := inst.Copy(ctx, r.file)
+	if err != nil {
+		log.Printf("failed to copy to VM: %v", err)
+		return false, ""
+	}
+	command := file
+	if !r.bin {
+		execprogBin, err := inst.Copy(ctx, r.cfg.TargetBin("syz-execprog"))
+		if err != nil {
+			log.Printf("failed to copy to VM: %v", err)
+			return false, ""
+		}
+		executorBin, err := inst.Copy(ctx, r.cfg.TargetBin("syz-executor"))
+		if err != nil {
+			log.Printf("failed to copy to VM: %v", err)
+			return false, ""
+		}
+		command = fmt.Sprintf("%v -executor %v -cover=0 -procs=%v -repeat=0 -threaded=%v -collide=%v -leak=%v %v",
+			execprogBin, executorBin, r.cfg.Procs, *flagThreaded, *flagCollide, r.cfg.Leak, file)
+	}
+	outc, errc, err := inst.Run(ctx, *flagTimeout, command)
+	if err != nil {
+		log.Printf("failed to run command in VM: %v", err)
+		return false, ""
+	}
+	var output []byte
+	for {
+		select {
+		case out := <-outc:
+			output = append(output, out...)
+			if desc, _, _, found := vm.FindCrash(r.cfg.OS, output); found {
+				if *flagTitle != "" && desc != *flagTitle {
+					log.Printf("%v: kernel crashed with a different crash: %s", vmCfg.Name, desc)
+					return false, ""
+				}
+				log.Printf("%v: kernel crashed: %s", vmCfg.Name, desc)
+				return true, desc
+			}
+		case err := <-errc:
+			switch {
+			case err == vm.TimeoutErr:
+				log.Printf("%v: no crash", vmCfg.Name)
+				return true, ""
+			case err != nil:
+				// Lost connection, OOM, etc without a kernel oops say nothing about the bug.
+				log.Printf("%v: reproducer failed without a crash: %v", vmCfg.Name, err)
+				return false, ""
+			default:
+				// C reproducers exit after one iteration.
+				log.Printf("%v: no crash", vmCfg.Name)
+				return true, ""
+			}
+		}
+	}
+}
@@ -29,6 +29,7 @@ var (
 	flagCoverFile = flag.String("coverfile", "", "write coverage to the file")
 	flagRepeat    = flag.Int("repeat", 1, "repeat execution that many times (0 for infinite loop)")
 	flagProcs     = flag.Int("procs", 1, "number of parallel processes to execute programs")
+	flagErrnos    = flag.Bool("errnos", false, "print errnos of calls of every program ('errnos <index>: <errno>...', -1 if not executed)")
 )
 
 func main() {
@@ -63,6 +64,23 @@ func main() {
 		flags |= ipc.FlagCover
 		flags &= ^ipc.FlagDedupCover
 	}
+	checkLeaks := func() {
+		leaks, err := ipc.KmemleakScan()
+		if err != nil {
+			log.Fatalf("failed to scan for leaks: %v", err)
+		}
+		if len(leaks) != 0 {
+			fmt.Printf("%s", leaks)
+		}
+	}
+	var leakCallback func()
+	if flags&ipc.FlagLeak != 0 {
+		if err := ipc.KmemleakInit(true); err != nil {
+			log.Fatalf("%v", err)
+		}
+		leakCallback = checkLeaks
+	}
+	gate := ipc.NewGate(2**flagProcs, leakCallback)
 
 	var wg sync.WaitGroup
 	wg.Add(*flagProcs)
@@ -91,12 +109,22 @@ func main() {
 					return
 				}
 				p := progs[idx%len(progs)]
-				output, cov, _, failed, hanged, err := env.Exec(p)
+				gateIdx := gate.Enter()
+				output, cov, errnos, failed, hanged, err := env.Exec(p)
+				gate.Leave(gateIdx)
 				if atomic.LoadUint32(&shutdown) != 0 {
 					return
 				}
+				if *flagErrnos && err == nil {
+					buf := new(bytes.Buffer)
+					fmt.Fprintf(buf, "errnos %v:", idx%len(progs))
+					for _, errno := range errnos {
+						fmt.Fprintf(buf, " %v", errno)
+					}
+					fmt.Printf("%s\n", buf.Bytes())
+				}
 				if failed {
-					fmt.Printf("BUG: executor-detected bug:\n%s", output)
+					fmt.Printf("%s\nBUG: executor-detected bug\n", output)
 				}
 				if flags&ipc.FlagDebug != 0 || err != nil {
 					fmt.Printf("result: failed=%v hanged=%v err=%v\n\n%s", failed, hanged, err, output)
@@ -136,4 +164,8 @@ func main() {
 	}()
 
 	wg.Wait()
+	if flags&ipc.FlagLeak != 0 {
+		// Check leaks of the programs executed after the last gate callback.
+		checkLeaks()
+	}
 }
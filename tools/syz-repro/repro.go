@@ -4,12 +4,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
-	"path/filepath"
 	"sort"
 	"time"
 
@@ -17,15 +17,25 @@ import (
 	"github.com/google/syzkaller/csource"
 	"github.com/google/syzkaller/fileutil"
 	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/repro"
 	"github.com/google/syzkaller/vm"
 	_ "github.com/google/syzkaller/vm/adb"
+	_ "github.com/google/syzkaller/vm/gvisor"
+	_ "github.com/google/syzkaller/vm/isolated"
 	_ "github.com/google/syzkaller/vm/kvm"
 	_ "github.com/google/syzkaller/vm/qemu"
 )
 
 var (
-	flagConfig = flag.String("config", "", "configuration file")
-	flagCount  = flag.Int("count", 0, "number of VMs to use (overrides config count param)")
+	flagConfig      = flag.String("config", "", "configuration file")
+	flagCount       = flag.Int("count", 0, "number of VMs to use (overrides config count param)")
+	flagConfirm     = flag.Int("confirm", 5, "number of confirmation re-runs of the found reproducer")
+	flagMemPressure = flag.Int("mem_pressure", -1, "run programs under memory pressure: MB of memory left available "+
+		"to test processes (overrides mem_pressure config param, 0 disables pressure)")
+	flagRetest = flag.Bool("retest", false, "re-run the reproducer saved for the crash log -confirm times on the current kernel "+
+		"(to find out whether the bug is fixed)")
+	flagExternal = flag.Bool("external", false, "run the external reproducer saved for the file (file.prog "+
+		"with options like -retest, or C reproducer file.c) -confirm times on the current kernel (to validate bugs found elsewhere)")
 
 	instances    chan VM
 	bootRequests chan bool
@@ -46,25 +56,21 @@ func main() {
 	if *flagCount > 0 {
 		cfg.Count = *flagCount
 	}
-	if _, err := os.Stat(filepath.Join(cfg.Syzkaller, "bin/syz-execprog")); err != nil {
-		log.Fatalf("bin/syz-execprog is missing (run 'make execprog')")
+	if *flagMemPressure >= 0 {
+		cfg.Mem_Pressure = *flagMemPressure
+	}
+	if _, err := os.Stat(cfg.TargetBin("syz-execprog")); err != nil {
+		log.Fatalf("%v is missing (run 'make execprog')", cfg.TargetBin("syz-execprog"))
 	}
 
 	if len(flag.Args()) != 1 {
 		log.Fatalf("usage: syz-repro -config=config.file execution.log")
 	}
-	data, err := ioutil.ReadFile(flag.Args()[0])
-	if err != nil {
-		log.Fatalf("failed to open log file: %v", err)
-	}
-	entries := prog.ParseLog(data)
-	log.Printf("parsed %v programs", len(entries))
-
-	crashDesc, crashStart, _, found := vm.FindCrash(data)
-	if !found {
-		log.Fatalf("can't find crash message in the log")
+	var entries []*prog.LogEntry
+	crashStart := 0
+	if !*flagExternal {
+		entries, crashStart = parseCrashLog(cfg, flag.Args()[0])
 	}
-	log.Printf("target crash: '%s'", crashDesc)
 
 	instances = make(chan VM, cfg.Count)
 	bootRequests = make(chan bool, cfg.Count)
@@ -76,15 +82,15 @@ func main() {
 				if err != nil {
 					log.Fatalf("failed to create VM config: %v", err)
 				}
-				inst, err := vm.Create(cfg.Type, vmCfg)
+				inst, err := vm.Create(context.Background(), cfg.Type, vmCfg)
 				if err != nil {
 					log.Fatalf("failed to create VM: %v", err)
 				}
-				execprogBin, err := inst.Copy(filepath.Join(cfg.Syzkaller, "bin/syz-execprog"))
+				execprogBin, err := inst.Copy(context.Background(), cfg.TargetBin("syz-execprog"))
 				if err != nil {
 					log.Fatalf("failed to copy to VM: %v", err)
 				}
-				executorBin, err := inst.Copy(filepath.Join(cfg.Syzkaller, "bin/syz-executor"))
+				executorBin, err := inst.Copy(context.Background(), cfg.TargetBin("syz-executor"))
 				if err != nil {
 					log.Fatalf("failed to copy to VM: %v", err)
 				}
@@ -93,7 +99,26 @@ func main() {
 		}()
 	}
 
-	repro(cfg, entries, crashStart)
+	if *flagRetest || *flagExternal {
+		r, err := retest(cfg, flag.Args()[0])
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		log.Printf("the reproducer crashed the kernel %v times out of %v", r.Crashes, r.Runs)
+		if r.Desc != "" {
+			log.Printf("the first crash: '%v'", r.Desc)
+		}
+		if err := repro.RecordRetest(flag.Args()[0], r); err != nil {
+			log.Printf("failed to save results: %v", err)
+		}
+	} else {
+		res := reproduce(cfg, entries, crashStart)
+		log.Printf("reproducibility: %v", res)
+		// Results are accumulated next to the crash log, syz-manager sorts crashes by them.
+		if err := repro.Record(flag.Args()[0], res); err != nil {
+			log.Printf("failed to save results: %v", err)
+		}
+	}
 
 	for {
 		select {
@@ -105,7 +130,38 @@ func main() {
 	}
 }
 
-func repro(cfg *config.Config, entries []*prog.LogEntry, crashStart int) {
+// parseCrashLog returns programs executed before the crash in crash log file and offset of the crash.
+func parseCrashLog(cfg *config.Config, file string) ([]*prog.LogEntry, int) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatalf("failed to open log file: %v", err)
+	}
+	entries := prog.ParseLog(data)
+	log.Printf("parsed %v programs", len(entries))
+
+	crashDesc, crashStart, _, found := vm.FindCrash(cfg.OS, data)
+	if !found {
+		log.Fatalf("can't find crash message in the log")
+	}
+	log.Printf("target crash: '%s'", crashDesc)
+
+	// syz-manager saves the last executed programs of the VM next to the crash log,
+	// the crash log itself may contain only a few of them.
+	if progs, err := ioutil.ReadFile(file + ".programs"); err == nil {
+		if progEntries := prog.ParseLog(progs); len(progEntries) > len(entries) {
+			log.Printf("using %v programs from %v.programs", len(progEntries), file)
+			entries = progEntries
+			crashStart = len(progs)
+			if _, start, _, found := vm.FindCrash(cfg.OS, progs); found {
+				crashStart = start
+			}
+		}
+	}
+	return entries, crashStart
+}
+
+func reproduce(cfg *config.Config, entries []*prog.LogEntry, crashStart int) *repro.Result {
+	res := &repro.Result{Attempts: 1}
 	// Cut programs that were executed after crash.
 	for i, ent := range entries {
 		if ent.Start > crashStart {
@@ -136,7 +192,7 @@ func repro(cfg *config.Config, entries []*prog.LogEntry, crashStart int) {
 	multiplier := 1
 	for ; p == nil && multiplier <= 100; multiplier *= 10 {
 		for _, ent := range suspected {
-			if testProg(cfg, ent.P, multiplier, true, true) {
+			if _, crashed := testProg(cfg, ent.P, multiplier, true, true); crashed {
 				p = ent.P
 				break
 			}
@@ -144,25 +200,33 @@ func repro(cfg *config.Config, entries []*prog.LogEntry, crashStart int) {
 	}
 	if p == nil {
 		log.Printf("no program crashed")
-		return
+		return res
 	}
+	res.Reproduced = 1
 	log.Printf("minimizing program")
 
 	p, _ = prog.Minimize(p, -1, func(p1 *prog.Prog, callIndex int) bool {
-		return testProg(cfg, p1, multiplier, true, true)
+		_, crashed := testProg(cfg, p1, multiplier, true, true)
+		return crashed
 	})
 
 	opts := csource.Options{
 		Threaded: true,
 		Collide:  true,
 	}
-	if testProg(cfg, p, multiplier, true, false) {
+	if _, crashed := testProg(cfg, p, multiplier, true, false); crashed {
 		opts.Collide = false
-		if testProg(cfg, p, multiplier, false, false) {
+		if _, crashed := testProg(cfg, p, multiplier, false, false); crashed {
 			opts.Threaded = false
 		}
 	}
 
+	// The program is saved to be re-tested on new kernels (see -retest).
+	progOpts := repro.ProgOptions{Threaded: opts.Threaded, Collide: opts.Collide, Multiplier: multiplier}
+	if err := repro.SaveProg(flag.Args()[0], p.Serialize(), progOpts); err != nil {
+		log.Printf("failed to save reproducer: %v", err)
+	}
+
 	src := csource.Write(p, opts)
 	log.Printf("C source:\n%s\n", src)
 	srcf, err := fileutil.WriteTempFile(src)
@@ -174,7 +238,55 @@ func repro(cfg *config.Config, entries []*prog.LogEntry, crashStart int) {
 		log.Fatalf("%v", err)
 	}
 	defer os.Remove(bin)
-	testBin(cfg, bin)
+	for i := 0; i < *flagConfirm; i++ {
+		res.Runs++
+		if _, crashed := testBin(cfg, bin); crashed {
+			res.Crashes++
+		}
+	}
+	return res
+}
+
+// retest re-runs the reproducer saved for crashFile on the current kernel.
+// With -external it may be a C reproducer.
+func retest(cfg *config.Config, crashFile string) (repro.Retest, error) {
+	r := repro.Retest{Time: time.Now(), KernelCommit: cfg.Kernel_Commit}
+	var run func() (string, bool)
+	src := crashFile + repro.CSuffix
+	if _, err := os.Stat(src); *flagExternal && err == nil {
+		bin, err := csource.Build(src)
+		if err != nil {
+			return r, err
+		}
+		defer os.Remove(bin)
+		run = func() (string, bool) { return testBin(cfg, bin) }
+	} else {
+		data, opts, err := repro.LoadProg(crashFile)
+		if err != nil {
+			return r, fmt.Errorf("failed to load reproducer: %v", err)
+		}
+		p, err := prog.Deserialize(data)
+		if err != nil {
+			return r, fmt.Errorf("failed to parse reproducer: %v", err)
+		}
+		run = func() (string, bool) { return testProg(cfg, p, opts.Multiplier, opts.Threaded, opts.Collide) }
+	}
+	if cfg.Vmlinux != "" {
+		var err error
+		if r.KernelBuild, err = fileutil.Hash(cfg.Vmlinux); err != nil {
+			return r, fmt.Errorf("failed to hash vmlinux: %v", err)
+		}
+	}
+	for i := 0; i < *flagConfirm; i++ {
+		r.Runs++
+		if desc, crashed := run(); crashed {
+			r.Crashes++
+			if r.Desc == "" {
+				r.Desc = desc
+			}
+		}
+	}
+	return r, nil
 }
 
 func returnInstance(inst VM, res bool) {
@@ -188,7 +300,8 @@ func returnInstance(inst VM, res bool) {
 	}
 }
 
-func testProg(cfg *config.Config, p *prog.Prog, multiplier int, threaded, collide bool) (res bool) {
+// testProg runs program p in a VM, it returns whether the kernel crashed and the crash description.
+func testProg(cfg *config.Config, p *prog.Prog, multiplier int, threaded, collide bool) (desc string, res bool) {
 	log.Printf("booting VM")
 	inst := <-instances
 	defer func() {
@@ -201,7 +314,7 @@ func testProg(cfg *config.Config, p *prog.Prog, multiplier int, threaded, collid
 		log.Fatalf("%v", err)
 	}
 	defer os.Remove(progFile)
-	bin, err := inst.Copy(progFile)
+	bin, err := inst.Copy(context.Background(), progFile)
 	if err != nil {
 		log.Fatalf("failed to copy to VM: %v", err)
 	}
@@ -215,30 +328,32 @@ func testProg(cfg *config.Config, p *prog.Prog, multiplier int, threaded, collid
 	repeat *= multiplier
 	timeoutSec *= multiplier
 	timeout := time.Duration(timeoutSec) * time.Second
-	command := fmt.Sprintf("%v -executor %v -cover=0 -procs=%v -repeat=%v -threaded=%v -collide=%v %v",
-		inst.execprogBin, inst.executorBin, cfg.Procs, repeat, threaded, collide, bin)
+	command := fmt.Sprintf("%v -executor %v -cover=0 -procs=%v -repeat=%v -threaded=%v -collide=%v -leak=%v "+
+		"-cgroup_mem=%v -mem_pressure=%v %v",
+		inst.execprogBin, inst.executorBin, cfg.Procs, repeat, threaded, collide, cfg.Leak,
+		cfg.Cgroup_Mem, cfg.Mem_Pressure, bin)
 	log.Printf("testing program (threaded=%v, collide=%v, repeat=%v, timeout=%v):\n%s\n",
 		threaded, collide, repeat, timeout, pstr)
-	return testImpl(inst, command, timeout)
+	return testImpl(cfg, inst, command, timeout)
 }
 
-func testBin(cfg *config.Config, bin string) (res bool) {
+func testBin(cfg *config.Config, bin string) (desc string, res bool) {
 	log.Printf("booting VM")
 	inst := <-instances
 	defer func() {
 		returnInstance(inst, res)
 	}()
 
-	bin, err := inst.Copy(bin)
+	bin, err := inst.Copy(context.Background(), bin)
 	if err != nil {
 		log.Fatalf("failed to copy to VM: %v", err)
 	}
 	log.Printf("testing compiled C program")
-	return testImpl(inst, bin, 10*time.Second)
+	return testImpl(cfg, inst, bin, 10*time.Second)
 }
 
-func testImpl(inst vm.Instance, command string, timeout time.Duration) (res bool) {
-	outc, errc, err := inst.Run(timeout, command)
+func testImpl(cfg *config.Config, inst vm.Instance, command string, timeout time.Duration) (string, bool) {
+	outc, errc, err := inst.Run(context.Background(), timeout, command)
 	if err != nil {
 		log.Fatalf("failed to run command in VM: %v", err)
 	}
@@ -247,17 +362,17 @@ func testImpl(inst vm.Instance, command string, timeout time.Duration) (res bool
 		select {
 		case out := <-outc:
 			output = append(output, out...)
-			if desc, _, _, found := vm.FindCrash(output); found {
+			if desc, _, _, found := vm.FindCrash(cfg.OS, output); found {
 				log.Printf("program crashed with '%s'", desc)
-				return true
+				return desc, true
 			}
 		case err := <-errc:
 			if err != nil {
 				log.Printf("program crashed with result '%v'", err)
-				return true
+				return "lost connection", true
 			}
 			log.Printf("program did not crash")
-			return false
+			return "", false
 		}
 	}
 }
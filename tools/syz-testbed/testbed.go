@@ -0,0 +1,499 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-testbed compares fuzzer configurations and revisions: it runs several syz-manager instances
+// for every checkout (a syzkaller checkout with built binaries and a manager config) at the same time
+// against the same kernel for a fixed duration, samples their statistics (/debug/vars) every minute
+// and compares the final coverage, corpus size, executed programs and crashes of the checkouts
+// with the first one (the baseline) with the Mann-Whitney U test.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/syzkaller/fileutil"
+)
+
+var flagConfig = flag.String("config", "", "testbed config file")
+
+type Config struct {
+	Workdir   string // manager workdirs, configs and results are stored here
+	Duration  int    // minutes every manager runs
+	Runs      int    // number of managers started for every checkout (default: 3)
+	Corpus    string // corpus.db every manager starts with (optional, by default managers start from scratch)
+	Checkouts []Checkout
+}
+
+type Checkout struct {
+	Name      string // name in results
+	Syzkaller string // syzkaller checkout with built binaries (replaces syzkaller param of the manager config)
+	Config    string // manager config, workdir and http params are replaced for every manager
+}
+
+// Sample is a snapshot of manager statistics.
+type Sample struct {
+	Minute     int
+	Corpus     int
+	Cover      int
+	Execs      uint64
+	Crashes    uint64
+	CrashTypes int
+}
+
+var metrics = []struct {
+	name string
+	get  func(s Sample) float64
+}{
+	{"cover", func(s Sample) float64 { return float64(s.Cover) }},
+	{"corpus", func(s Sample) float64 { return float64(s.Corpus) }},
+	{"execs", func(s Sample) float64 { return float64(s.Execs) }},
+	{"crashes", func(s Sample) float64 { return float64(s.Crashes) }},
+	{"crash types", func(s Sample) float64 { return float64(s.CrashTypes) }},
+}
+
+const sampleInterval = time.Minute
+
+type instance struct {
+	checkout *Checkout
+	name     string
+	dir      string
+	http     string
+	samples  []Sample
+}
+
+func main() {
+	flag.Parse()
+	cfg, err := parseConfig(*flagConfig)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	var instances []*instance
+	for i := range cfg.Checkouts {
+		for run := 0; run < cfg.Runs; run++ {
+			inst, err := createInstance(cfg, &cfg.Checkouts[i], run)
+			if err != nil {
+				log.Fatalf("%v", err)
+			}
+			instances = append(instances, inst)
+		}
+	}
+	log.Printf("running %v managers for %v minutes", len(instances), cfg.Duration)
+	var wg sync.WaitGroup
+	for _, inst := range instances {
+		inst := inst
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := inst.run(time.Duration(cfg.Duration) * time.Minute); err != nil {
+				log.Printf("%v: %v", inst.name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	res := summary(cfg, instances)
+	for name, data := range map[string][]byte{
+		"summary.txt": res,
+		"series.csv":  series(cfg, instances),
+	} {
+		if err := ioutil.WriteFile(filepath.Join(cfg.Workdir, name), data, 0660); err != nil {
+			log.Printf("failed to write results: %v", err)
+		}
+	}
+	os.Stdout.Write(res)
+}
+
+func parseConfig(filename string) (*Config, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("supply config with -config flag")
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+	cfg := &Config{Runs: 3}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	}
+	if cfg.Workdir == "" {
+		return nil, fmt.Errorf("config param workdir is empty")
+	}
+	if cfg.Duration <= 0 {
+		return nil, fmt.Errorf("config param duration must be positive")
+	}
+	if cfg.Runs <= 0 {
+		return nil, fmt.Errorf("config param runs must be positive")
+	}
+	if len(cfg.Checkouts) < 2 {
+		return nil, fmt.Errorf("specify at least 2 checkouts to compare")
+	}
+	names := make(map[string]bool)
+	for _, c := range cfg.Checkouts {
+		if c.Name == "" || strings.ContainsAny(c.Name, "/ ") || names[c.Name] {
+			return nil, fmt.Errorf("bad or duplicate checkout name %q", c.Name)
+		}
+		names[c.Name] = true
+		if c.Config == "" {
+			return nil, fmt.Errorf("checkout %v: manager config is empty", c.Name)
+		}
+	}
+	return cfg, nil
+}
+
+// createInstance creates workdir and config of a manager, the manager gets a fresh workdir
+// (with the initial corpus) and a free http port.
+func createInstance(cfg *Config, checkout *Checkout, run int) (*instance, error) {
+	inst := &instance{
+		checkout: checkout,
+		name:     fmt.Sprintf("%v-%v", checkout.Name, run),
+	}
+	inst.dir = filepath.Join(cfg.Workdir, inst.name)
+	workdir := filepath.Join(inst.dir, "workdir")
+	os.RemoveAll(inst.dir)
+	if err := os.MkdirAll(workdir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create workdir: %v", err)
+	}
+	if cfg.Corpus != "" {
+		if err := fileutil.CopyFile(cfg.Corpus, filepath.Join(workdir, "corpus.db"), false); err != nil {
+			return nil, fmt.Errorf("failed to copy corpus: %v", err)
+		}
+	}
+	data, err := ioutil.ReadFile(checkout.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manager config: %v", err)
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse manager config %v: %v", checkout.Config, err)
+	}
+	if inst.http, err = freeAddr(); err != nil {
+		return nil, err
+	}
+	setParam(params, "workdir", workdir)
+	setParam(params, "http", inst.http)
+	if checkout.Syzkaller != "" {
+		setParam(params, "syzkaller", checkout.Syzkaller)
+	}
+	data, err = json.MarshalIndent(params, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(inst.dir, "manager.cfg"), data, 0600); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+// setParam sets config param case-insensitively, as the config is parsed.
+func setParam(params map[string]interface{}, name string, v interface{}) {
+	for k := range params {
+		if strings.EqualFold(k, name) {
+			delete(params, k)
+		}
+	}
+	params[name] = v
+}
+
+func freeAddr() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to find a free port: %v", err)
+	}
+	defer ln.Close()
+	return ln.Addr().String(), nil
+}
+
+// run runs the manager for duration and samples its statistics.
+func (inst *instance) run(duration time.Duration) error {
+	syzkaller := inst.checkout.Syzkaller
+	if syzkaller == "" {
+		syzkaller = "."
+	}
+	logFile, err := os.Create(filepath.Join(inst.dir, "manager.log"))
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	cmd := exec.Command(filepath.Join(syzkaller, "bin", "syz-manager"), "-config", filepath.Join(inst.dir, "manager.cfg"))
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start manager: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	start := time.Now()
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+	deadline := time.After(duration)
+	for {
+		select {
+		case err := <-done:
+			return fmt.Errorf("manager exited prematurely: %v (see %v)", err, logFile.Name())
+		case <-ticker.C:
+			s, err := inst.sample()
+			if err != nil {
+				log.Printf("%v: %v", inst.name, err)
+				continue
+			}
+			s.Minute = int(time.Since(start) / time.Minute)
+			inst.samples = append(inst.samples, s)
+		case <-deadline:
+			// The manager saves its state on SIGINT.
+			cmd.Process.Signal(os.Interrupt)
+			select {
+			case <-done:
+			case <-time.After(time.Minute):
+				cmd.Process.Kill()
+				<-done
+			}
+			data, err := json.MarshalIndent(inst.samples, "", "\t")
+			if err != nil {
+				return err
+			}
+			return ioutil.WriteFile(filepath.Join(inst.dir, "samples.json"), data, 0660)
+		}
+	}
+}
+
+func (inst *instance) sample() (Sample, error) {
+	var s Sample
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get("http://" + inst.http + "/debug/vars")
+	if err != nil {
+		return s, err
+	}
+	defer resp.Body.Close()
+	var vars struct {
+		Stats      map[string]uint64 `json:"stats"`
+		Corpus     int               `json:"corpus"`
+		Cover      int               `json:"cover"`
+		CrashTypes int               `json:"crash_types"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		return s, fmt.Errorf("failed to parse manager vars: %v", err)
+	}
+	s.Corpus = vars.Corpus
+	s.Cover = vars.Cover
+	s.Execs = vars.Stats["exec total"]
+	s.Crashes = vars.Stats["crashes"]
+	s.CrashTypes = vars.CrashTypes
+	return s, nil
+}
+
+// finalValues returns the last sampled values of metric for managers of every checkout.
+func finalValues(instances []*instance, get func(Sample) float64) map[string][]float64 {
+	res := make(map[string][]float64)
+	for _, inst := range instances {
+		if len(inst.samples) == 0 {
+			continue
+		}
+		res[inst.checkout.Name] = append(res[inst.checkout.Name], get(inst.samples[len(inst.samples)-1]))
+	}
+	return res
+}
+
+// summary returns a table with final values of all metrics for all checkouts and p-values
+// of the difference with the baseline (the first checkout).
+func summary(cfg *Config, instances []*instance) []byte {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	base := cfg.Checkouts[0].Name
+	for _, m := range metrics {
+		values := finalValues(instances, m.get)
+		fmt.Fprintf(w, "%v\tmean\tstddev\tmin\tmax\truns\tdiff\tp-value\n", m.name)
+		for _, c := range cfg.Checkouts {
+			v := values[c.Name]
+			if len(v) == 0 {
+				fmt.Fprintf(w, "  %v\t-\t-\t-\t-\t0\t\t\n", c.Name)
+				continue
+			}
+			sort.Float64s(v)
+			diff, p := "", ""
+			if c.Name != base && len(values[base]) != 0 {
+				if baseMean := mean(values[base]); baseMean != 0 {
+					diff = fmt.Sprintf("%+.1f%%", (mean(v)-baseMean)/baseMean*100)
+				}
+				p = fmt.Sprintf("%.3f", mannWhitneyP(values[base], v))
+			}
+			fmt.Fprintf(w, "  %v\t%.1f\t%.1f\t%.0f\t%.0f\t%v\t%v\t%v\n",
+				c.Name, mean(v), stddev(v), v[0], v[len(v)-1], len(v), diff, p)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+	w.Flush()
+	fmt.Fprintf(buf, "p-value is the probability that the difference with %v is by chance (Mann-Whitney U test),\n"+
+		"use more runs if it is above 0.05.\n", base)
+	return buf.Bytes()
+}
+
+// series returns mean values of all metrics of every checkout at every minute in CSV format.
+func series(cfg *Config, instances []*instance) []byte {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "minute")
+	for _, m := range metrics {
+		for _, c := range cfg.Checkouts {
+			fmt.Fprintf(buf, ",%v %v", c.Name, m.name)
+		}
+	}
+	fmt.Fprintf(buf, "\n")
+	for minute := 1; minute <= cfg.Duration; minute++ {
+		fmt.Fprintf(buf, "%v", minute)
+		for _, m := range metrics {
+			for _, c := range cfg.Checkouts {
+				var v []float64
+				for _, inst := range instances {
+					if inst.checkout.Name != c.Name {
+						continue
+					}
+					for _, s := range inst.samples {
+						if s.Minute == minute {
+							v = append(v, m.get(s))
+							break
+						}
+					}
+				}
+				if len(v) == 0 {
+					fmt.Fprintf(buf, ",")
+					continue
+				}
+				fmt.Fprintf(buf, ",%.1f", mean(v))
+			}
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+	return buf.Bytes()
+}
+
+func mean(v []float64) float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+	return sum / float64(len(v))
+}
+
+func stddev(v []float64) float64 {
+	if len(v) < 2 {
+		return 0
+	}
+	m := mean(v)
+	sum := 0.0
+	for _, x := range v {
+		sum += (x - m) * (x - m)
+	}
+	return math.Sqrt(sum / float64(len(v)-1))
+}
+
+// maxExactSplits is the max number of ways to split ranks between two samples
+// for which mannWhitneyP computes the exact p-value.
+const maxExactSplits = 1e6
+
+// mannWhitneyP returns two-sided p-value of the Mann-Whitney U test of samples a and b, ties get average ranks.
+// The p-value is exact for small samples (the usual 3-10 runs per checkout), for larger ones
+// the normal approximation with continuity correction is used.
+func mannWhitneyP(a, b []float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 1
+	}
+	type value struct {
+		x     float64
+		first bool
+	}
+	var all []value
+	for _, x := range a {
+		all = append(all, value{x, true})
+	}
+	for _, x := range b {
+		all = append(all, value{x, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].x < all[j].x })
+	// Ranks are 1-based and doubled, so that average ranks of ties are integers.
+	ranks := make([]int, len(all))
+	rankSum := 0
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].x == all[i].x {
+			j++
+		}
+		for k := i; k < j; k++ {
+			ranks[k] = i + j + 1
+			if all[k].first {
+				rankSum += ranks[k]
+			}
+		}
+		i = j
+	}
+	if binomial(len(all), len(a)) <= maxExactSplits {
+		return mannWhitneyExactP(ranks, len(a), rankSum)
+	}
+	n1, n2 := float64(len(a)), float64(len(b))
+	u := float64(rankSum)/2 - n1*(n1+1)/2
+	mu := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 * (n1 + n2 + 1) / 12)
+	if sigma == 0 {
+		return 1
+	}
+	z := math.Max(math.Abs(u-mu)-0.5, 0) / sigma
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// mannWhitneyExactP enumerates all splits of (doubled) ranks into a sample of n1 values and the rest
+// and returns the fraction of splits with rank sum of the sample at least as far from the mean as rankSum.
+// This is the exact distribution of U under the null hypothesis, including ties.
+func mannWhitneyExactP(ranks []int, n1, rankSum int) float64 {
+	n := len(ranks)
+	mean := n1 * (n + 1) // doubled mean rank sum
+	dev := abs(rankSum - mean)
+	extreme, total := 0, 0
+	var split func(i, left, sum int)
+	split = func(i, left, sum int) {
+		if left == 0 {
+			total++
+			if abs(sum-mean) >= dev {
+				extreme++
+			}
+			return
+		}
+		if n-i < left {
+			return
+		}
+		split(i+1, left-1, sum+ranks[i])
+		split(i+1, left, sum)
+	}
+	split(0, n1, 0)
+	return float64(extreme) / float64(total)
+}
+
+// binomial returns n choose k.
+func binomial(n, k int) float64 {
+	res := 1.0
+	for i := 1; i <= k; i++ {
+		res = res * float64(n-k+i) / float64(i)
+	}
+	return res
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
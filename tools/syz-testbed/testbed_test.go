@@ -0,0 +1,42 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMannWhitneyP(t *testing.T) {
+	seq := func(from, to int) []float64 {
+		var res []float64
+		for i := from; i <= to; i++ {
+			res = append(res, float64(i))
+		}
+		return res
+	}
+	// Reference p-values: 2*P(U <= u) from exact U tables for small samples without ties,
+	// count of rank splits for ties and normal approximation with continuity correction for 20+20 runs.
+	tests := []struct {
+		a, b []float64
+		p    float64
+	}{
+		{seq(1, 3), seq(4, 6), 0.1},
+		{seq(4, 6), seq(1, 3), 0.1},
+		{[]float64{1, 3, 5}, []float64{2, 4, 6}, 0.7},
+		{seq(1, 5), seq(6, 10), 0.007937},
+		{[]float64{1, 2, 3, 4, 6}, []float64{5, 7, 8, 9, 10}, 0.01587},
+		{seq(1, 4), seq(5, 9), 0.01587},
+		{[]float64{1, 1, 2}, []float64{2, 3, 3}, 0.2},
+		{[]float64{5, 5, 5}, []float64{5, 5, 5}, 1},
+		{seq(1, 20), seq(21, 40), 6.796e-8},
+		{[]float64{1}, nil, 1},
+	}
+	for i, test := range tests {
+		p := mannWhitneyP(test.a, test.b)
+		if math.Abs(p-test.p) > test.p*1e-3 {
+			t.Errorf("test #%v: got p=%v, want %v", i, p, test.p)
+		}
+	}
+}
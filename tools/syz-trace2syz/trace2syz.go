@@ -0,0 +1,529 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-trace2syz converts strace output into syzkaller programs that can be used to seed the corpus.
+// Trace is expected to be produced with "strace -f -o trace.txt cmd". Every process in the trace
+// becomes a separate program (split into chunks of -length calls). Arguments are mapped back
+// to description types: integers and flags (resolved with sys/*.const files), strings and buffers,
+// and file descriptors which become references to results of calls that created them.
+// Arguments that can't be mapped (e.g. structs) are replaced with pointers to empty memory,
+// calls that can't be mapped are dropped.
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys"
+)
+
+var (
+	flagConsts = flag.String("consts", "sys/*_"+runtime.GOARCH+".const", "glob of const files used to resolve flag names")
+	flagCorpus = flag.String("corpus", "", "write programs into this dir (e.g. workdir/corpus) instead of stdout")
+	flagLength = flag.Int("length", 30, "max number of calls in a program")
+	flagV      = flag.Bool("v", false, "print dropped calls")
+)
+
+const (
+	dataBase  = 0x7f0000000000
+	dataPages = 4096
+	pageSize  = 4 << 10
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: syz-trace2syz [flags] trace.txt\n")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	consts, err := readConsts(*flagConsts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open trace: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	traces, err := parseTrace(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to parse trace: %v\n", err)
+		os.Exit(1)
+	}
+	var pids []int
+	for pid := range traces {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+	total, dropped := 0, 0
+	for _, pid := range pids {
+		calls := traces[pid]
+		for len(calls) != 0 {
+			n := len(calls)
+			if n > *flagLength {
+				n = *flagLength
+			}
+			p := newConverter(consts)
+			for _, call := range calls[:n] {
+				if err := p.add(call); err != nil {
+					dropped++
+					if *flagV {
+						fmt.Fprintf(os.Stderr, "dropping %v: %v\n", call.name, err)
+					}
+				}
+			}
+			calls = calls[n:]
+			if p.ncalls != 0 {
+				total++
+				if err := output(p.data()); err != nil {
+					fmt.Fprintf(os.Stderr, "%v\n", err)
+					os.Exit(1)
+				}
+			}
+		}
+	}
+	fmt.Fprintf(os.Stderr, "converted %v processes into %v programs, dropped %v calls\n", len(pids), total, dropped)
+}
+
+func output(data []byte) error {
+	if *flagCorpus == "" {
+		fmt.Printf("%s\n", data)
+		return nil
+	}
+	sig := sha1.Sum(data)
+	file := filepath.Join(*flagCorpus, hex.EncodeToString(sig[:]))
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		return fmt.Errorf("failed to write program: %v", err)
+	}
+	return nil
+}
+
+// readConsts reads name = value lines from const files produced by syz-extract.
+func readConsts(glob string) (map[string]uint64, error) {
+	files, err := filepath.Glob(glob)
+	if err != nil || len(files) == 0 {
+		return nil, fmt.Errorf("no const files match %v (run from syzkaller checkout or set -consts)", glob)
+	}
+	consts := make(map[string]uint64)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read const file: %v", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			eq := strings.IndexByte(line, '=')
+			if line == "" || line[0] == '#' || eq == -1 {
+				continue
+			}
+			if v, err := strconv.ParseUint(strings.TrimSpace(line[eq+1:]), 0, 64); err == nil {
+				consts[strings.TrimSpace(line[:eq])] = v
+			}
+		}
+	}
+	return consts, nil
+}
+
+type traceCall struct {
+	name string
+	args []string
+	ret  string
+}
+
+var (
+	// [pid 123] name(args) = ret ...
+	callRe = regexp.MustCompile(`^(?:\[pid\s+(\d+)\]\s+|(\d+)\s+)?([a-z0-9_]+)\((.*)\)\s+=\s+(0x[0-9a-f]+|-?[0-9]+|\?)`)
+	// [pid 123] name(args <unfinished ...>
+	unfinishedRe = regexp.MustCompile(`^(?:\[pid\s+(\d+)\]\s+|(\d+)\s+)?(.*)\s*<unfinished \.\.\.>$`)
+	// [pid 123] <... name resumed> args) = ret
+	resumedRe = regexp.MustCompile(`^(?:\[pid\s+(\d+)\]\s+|(\d+)\s+)?<\.\.\. [a-z0-9_]+ resumed>\s*(.*)$`)
+)
+
+// parseTrace returns calls for every pid in the trace.
+func parseTrace(f io.Reader) (map[int][]*traceCall, error) {
+	traces := make(map[int][]*traceCall)
+	unfinished := make(map[int]string)
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 64<<20)
+	pidOf := func(m []string) int {
+		pid, _ := strconv.Atoi(m[1] + m[2])
+		return pid
+	}
+	for s.Scan() {
+		line := s.Text()
+		if m := unfinishedRe.FindStringSubmatch(line); m != nil {
+			unfinished[pidOf(m)] = strings.TrimSpace(m[3])
+			continue
+		}
+		if m := resumedRe.FindStringSubmatch(line); m != nil {
+			pid := pidOf(m)
+			prefix, ok := unfinished[pid]
+			if !ok {
+				continue
+			}
+			delete(unfinished, pid)
+			line = prefix + m[3]
+			if pid != 0 {
+				line = fmt.Sprintf("[pid %v] %v", pid, line)
+			}
+		}
+		m := callRe.FindStringSubmatch(line)
+		if m == nil {
+			continue // signals, exits, etc
+		}
+		pid := pidOf(m)
+		traces[pid] = append(traces[pid], &traceCall{
+			name: m[3],
+			args: splitArgs(m[4]),
+			ret:  m[5],
+		})
+	}
+	return traces, s.Err()
+}
+
+// splitArgs splits comma-separated args taking into account strings, arrays and structs.
+func splitArgs(s string) []string {
+	var args []string
+	depth, start := 0, 0
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[' || c == '{' || c == '(':
+			depth++
+		case c == ']' || c == '}' || c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if arg := strings.TrimSpace(s[start:]); arg != "" {
+		args = append(args, arg)
+	}
+	return args
+}
+
+// converter incrementally builds a program, every added call is verified with prog.Deserialize.
+type converter struct {
+	consts map[string]uint64
+	buf    []byte
+	ncalls int
+	nvars  int
+	page   int
+	fds    map[uint64]resource
+}
+
+type resource struct {
+	name string
+	kind sys.ResourceKind
+}
+
+func newConverter(consts map[string]uint64) *converter {
+	c := &converter{
+		consts: consts,
+		fds:    make(map[uint64]resource),
+	}
+	c.buf = []byte(fmt.Sprintf("mmap(&(0x%x)=nil, (0x%x), 0x3, 0x32, 0xffffffffffffffff, 0x0)\n",
+		dataBase, dataPages*pageSize))
+	return c
+}
+
+func (c *converter) data() []byte {
+	return c.buf
+}
+
+func (c *converter) add(call *traceCall) error {
+	meta := c.chooseCall(call)
+	if meta == nil {
+		return fmt.Errorf("no matching description")
+	}
+	// strace omits args that are not used (e.g. mode for open without O_CREAT).
+	for len(call.args) < len(meta.Args) {
+		call.args = append(call.args, "0")
+	}
+	saved := *c
+	created := make(map[uint64]resource)
+	var args []string
+	for i, typ := range meta.Args {
+		arg, err := c.convertArg(typ, call.args[i], created)
+		if err != nil {
+			*c = saved
+			return fmt.Errorf("arg %v: %v", typ.Name(), err)
+		}
+		args = append(args, arg)
+	}
+	line := fmt.Sprintf("%v(%v)\n", meta.Name, strings.Join(args, ", "))
+	if res, ok := meta.Ret.(sys.ResourceType); ok {
+		if ret, err := strconv.ParseInt(call.ret, 0, 64); err == nil && ret >= 0 {
+			name := c.newVar()
+			created[uint64(ret)] = resource{name, res.Kind}
+			line = name + " = " + line
+		}
+	}
+	buf := append(append([]byte{}, c.buf...), line...)
+	if _, err := prog.Deserialize(buf); err != nil {
+		*c = saved
+		return err
+	}
+	c.buf = buf
+	c.ncalls++
+	for fd, res := range created {
+		c.fds[fd] = res
+	}
+	return nil
+}
+
+// chooseCall returns a variant of the syscall whose const args match the trace,
+// or the generic syscall.
+func (c *converter) chooseCall(call *traceCall) *sys.Call {
+	var variants []*sys.Call
+	for _, meta := range sys.Calls {
+		if meta.CallName == call.name && meta.Name != call.name {
+			variants = append(variants, meta)
+		}
+	}
+	for _, meta := range variants {
+		if len(meta.Args) > len(call.args) {
+			continue
+		}
+		matched := 0
+		for i, typ := range meta.Args {
+			if typ, ok := typ.(sys.ConstType); ok {
+				v, err := c.parseInt(call.args[i])
+				if err != nil || uintptr(v) != typ.Val {
+					matched = -1
+					break
+				}
+				matched++
+			}
+		}
+		if matched > 0 {
+			return meta
+		}
+	}
+	return sys.CallMap[call.name]
+}
+
+func (c *converter) newVar() string {
+	name := fmt.Sprintf("r%v", c.nvars)
+	c.nvars++
+	return name
+}
+
+func (c *converter) convertArg(typ sys.Type, val string, created map[uint64]resource) (string, error) {
+	switch t := typ.(type) {
+	case sys.ConstType:
+		return fmt.Sprintf("0x%x", t.Val), nil
+	case sys.IntType, sys.FlagsType, sys.LenType, sys.FileoffType:
+		v, err := c.parseInt(val)
+		if err != nil {
+			v = uint64(typ.Default())
+		}
+		return fmt.Sprintf("0x%x", v), nil
+	case sys.ResourceType:
+		v, err := c.parseInt(val)
+		if err != nil {
+			return fmt.Sprintf("0x%x", t.Default()), nil
+		}
+		if res, ok := c.fds[v]; ok && res.kind == t.Kind {
+			return res.name, nil
+		}
+		return fmt.Sprintf("0x%x", v), nil
+	case sys.VmaType:
+		addr, err := c.alloc(pageSize)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("&%v=nil", addr), nil
+	case sys.PtrType:
+		if val == "NULL" {
+			return "0x0", nil
+		}
+		inner, size, err := c.convertPointee(t, val, created)
+		if err != nil {
+			return "", err
+		}
+		addr, err := c.alloc(size)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("&%v=%v", addr, inner), nil
+	default:
+		return "", fmt.Errorf("unsupported arg type %T", typ)
+	}
+}
+
+// convertPointee converts data pointed to by an arg and returns it along with its size.
+func (c *converter) convertPointee(ptr sys.PtrType, val string, created map[uint64]resource) (string, int, error) {
+	out := ptr.Dir == sys.DirOut
+	switch t := ptr.Type.(type) {
+	case sys.StrConstType:
+		return fmt.Sprintf("\"%v\"", hex.EncodeToString([]byte(t.Val))), len(t.Val), nil
+	case sys.FilenameType, sys.BufferType:
+		data, ok := parseString(val)
+		if !ok {
+			break
+		}
+		if out {
+			data = make([]byte, len(data))
+		} else if buf, ok := t.(sys.BufferType); !ok || buf.Kind == sys.BufferString {
+			data = append(data, 0) // C strings
+		}
+		return fmt.Sprintf("\"%v\"", hex.EncodeToString(data)), len(data), nil
+	case sys.StructType:
+		// Resources returned via pointers, e.g. pipe([3, 4]).
+		elems := parseArray(val)
+		if len(elems) != len(t.Fields) {
+			break
+		}
+		var fields []string
+		for i, fld := range t.Fields {
+			res, ok := fld.(sys.ResourceType)
+			if !ok {
+				return "nil", pageSize, nil
+			}
+			fields = append(fields, c.convertResource(res, elems[i], out, created))
+		}
+		return "{" + strings.Join(fields, ", ") + "}", int(t.Size()), nil
+	case sys.ArrayType:
+		res, ok := t.Type.(sys.ResourceType)
+		elems := parseArray(val)
+		if !ok || elems == nil {
+			break
+		}
+		var inner []string
+		for _, elem := range elems {
+			inner = append(inner, c.convertResource(res, elem, out, created))
+		}
+		return "[" + strings.Join(inner, ", ") + "]", len(elems) * int(res.Size()), nil
+	}
+	return "nil", pageSize, nil
+}
+
+func (c *converter) convertResource(typ sys.ResourceType, val string, out bool, created map[uint64]resource) string {
+	v, err := c.parseInt(val)
+	if !out {
+		if res, ok := c.fds[v]; err == nil && ok && res.kind == typ.Kind {
+			return res.name
+		}
+		if err != nil {
+			v = uint64(typ.Default())
+		}
+		return fmt.Sprintf("0x%x", v)
+	}
+	if err != nil {
+		return "0x0"
+	}
+	name := c.newVar()
+	created[v] = resource{name, typ.Kind}
+	return fmt.Sprintf("<%v=>0x0", name)
+}
+
+// alloc allocates size bytes in the mmapped data region.
+func (c *converter) alloc(size int) (string, error) {
+	npages := (size + pageSize - 1) / pageSize
+	if npages == 0 {
+		npages = 1
+	}
+	if c.page+npages > dataPages {
+		return "", fmt.Errorf("out of data memory")
+	}
+	addr := fmt.Sprintf("(0x%x)", dataBase+c.page*pageSize)
+	c.page += npages
+	return addr, nil
+}
+
+// parseInt parses numbers and flag expressions like O_RDWR|O_CREAT|0x100.
+func (c *converter) parseInt(val string) (uint64, error) {
+	var res uint64
+	for _, part := range strings.Split(val, "|") {
+		part = strings.TrimSpace(part)
+		if v, err := strconv.ParseInt(part, 0, 64); err == nil {
+			res |= uint64(v)
+		} else if v, err := strconv.ParseUint(part, 0, 64); err == nil {
+			res |= v
+		} else if v, ok := c.consts[part]; ok {
+			res |= v
+		} else {
+			return 0, fmt.Errorf("can't parse '%v'", part)
+		}
+	}
+	return res, nil
+}
+
+// parseArray splits [a, b, c] into elements.
+func parseArray(val string) []string {
+	if len(val) < 2 || val[0] != '[' || val[len(val)-1] != ']' {
+		return nil
+	}
+	return splitArgs(val[1 : len(val)-1])
+}
+
+// parseString decodes a C-escaped strace string (possibly truncated with "...").
+func parseString(val string) ([]byte, bool) {
+	val = strings.TrimSuffix(val, "...")
+	if len(val) < 2 || val[0] != '"' || val[len(val)-1] != '"' {
+		return nil, false
+	}
+	val = val[1 : len(val)-1]
+	var data []byte
+	for i := 0; i < len(val); i++ {
+		if val[i] != '\\' || i+1 == len(val) {
+			data = append(data, val[i])
+			continue
+		}
+		i++
+		switch val[i] {
+		case 'n':
+			data = append(data, '\n')
+		case 't':
+			data = append(data, '\t')
+		case 'r':
+			data = append(data, '\r')
+		case 'v':
+			data = append(data, '\v')
+		case 'f':
+			data = append(data, '\f')
+		case 'x':
+			if i+2 < len(val) {
+				if v, err := strconv.ParseUint(val[i+1:i+3], 16, 8); err == nil {
+					data = append(data, byte(v))
+					i += 2
+					continue
+				}
+			}
+			data = append(data, 'x')
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			j := i
+			for j < len(val) && j < i+3 && val[j] >= '0' && val[j] <= '7' {
+				j++
+			}
+			v, _ := strconv.ParseUint(val[i:j], 8, 8)
+			data = append(data, byte(v))
+			i = j - 1
+		default:
+			data = append(data, val[i])
+		}
+	}
+	return data, true
+}
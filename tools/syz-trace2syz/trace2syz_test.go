@@ -0,0 +1,213 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseTrace(t *testing.T) {
+	tests := []struct {
+		trace string
+		want  map[int][]*traceCall
+	}{
+		{
+			"open(\"/tmp/x\", O_RDONLY) = 3\n" +
+				"--- SIGCHLD {si_signo=SIGCHLD} ---\n" +
+				"close(3) = 0\n" +
+				"+++ exited with 0 +++\n",
+			map[int][]*traceCall{
+				0: {
+					{name: "open", args: []string{"\"/tmp/x\"", "O_RDONLY"}, ret: "3"},
+					{name: "close", args: []string{"3"}, ret: "0"},
+				},
+			},
+		},
+		{
+			"[pid 10] read(3,  <unfinished ...>\n" +
+				"[pid 11] getpid() = 11\n" +
+				"[pid 10] <... read resumed> \"ab\", 2) = 2\n" +
+				"12 mmap(NULL, 4096, PROT_READ, MAP_PRIVATE|MAP_ANONYMOUS, -1, 0) = 0x7f0000001000\n" +
+				"[pid 11] write(1, \"a, b\", 4) = -1 EBADF (Bad file descriptor)\n" +
+				"[pid 12] exit_group(0) = ?\n",
+			map[int][]*traceCall{
+				10: {
+					{name: "read", args: []string{"3", "\"ab\"", "2"}, ret: "2"},
+				},
+				11: {
+					{name: "getpid", ret: "11"},
+					{name: "write", args: []string{"1", "\"a, b\"", "4"}, ret: "-1"},
+				},
+				12: {
+					{name: "mmap", args: []string{"NULL", "4096", "PROT_READ", "MAP_PRIVATE|MAP_ANONYMOUS", "-1", "0"},
+						ret: "0x7f0000001000"},
+					{name: "exit_group", args: []string{"0"}, ret: "?"},
+				},
+			},
+		},
+		{
+			// Resumed call without the unfinished part (e.g. trace started in the middle).
+			"<... read resumed> \"ab\", 2) = 2\n",
+			map[int][]*traceCall{},
+		},
+	}
+	for i, test := range tests {
+		got, err := parseTrace(strings.NewReader(test.trace))
+		if err != nil {
+			t.Fatalf("test #%v: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test #%v: got:\n%v\nwant:\n%v", i, dumpTraces(got), dumpTraces(test.want))
+		}
+	}
+}
+
+func dumpTraces(traces map[int][]*traceCall) string {
+	var pids []int
+	for pid := range traces {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+	res := ""
+	for _, pid := range pids {
+		for _, c := range traces[pid] {
+			res += fmt.Sprintf("%v: %v(%v) = %v\n", pid, c.name, strings.Join(c.args, ", "), c.ret)
+		}
+	}
+	return res
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		args string
+		want []string
+	}{
+		{"", nil},
+		{"3", []string{"3"}},
+		{"3, \"a, b\", 4", []string{"3", "\"a, b\"", "4"}},
+		{"\"a\\\", b\", 1", []string{"\"a\\\", b\"", "1"}},
+		{"[3, 4], {st_mode=S_IFREG, st_size=0}, f(1, 2)", []string{"[3, 4]", "{st_mode=S_IFREG, st_size=0}", "f(1, 2)"}},
+	}
+	for i, test := range tests {
+		if got := splitArgs(test.args); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test #%v: got %q, want %q", i, got, test.want)
+		}
+	}
+}
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		val  string
+		want string
+		ok   bool
+	}{
+		{"\"abc\"", "abc", true},
+		{"\"abc\"...", "abc", true},
+		{"\"a\\nb\\t\\\\\\\"\"", "a\nb\t\\\"", true},
+		{"\"\\x41\\x4g\"", "Ax4g", true},
+		{"\"\\0\\1\\177\\0011\"", "\x00\x01\x7f\x011", true},
+		{"0x1234", "", false},
+		{"NULL", "", false},
+	}
+	for i, test := range tests {
+		got, ok := parseString(test.val)
+		if ok != test.ok || string(got) != test.want {
+			t.Errorf("test #%v: got %q/%v, want %q/%v", i, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	c := newConverter(map[string]uint64{"O_RDWR": 2, "O_CREAT": 0x40})
+	tests := []struct {
+		val  string
+		want uint64
+		ok   bool
+	}{
+		{"0", 0, true},
+		{"0644", 0644, true},
+		{"0x10", 0x10, true},
+		{"-1", ^uint64(0), true},
+		{"0xffffffffffffffff", ^uint64(0), true},
+		{"O_RDWR|O_CREAT|0x100", 0x142, true},
+		{"O_RDWR | O_CREAT", 0x42, true},
+		{"O_UNKNOWN", 0, false},
+	}
+	for i, test := range tests {
+		got, err := c.parseInt(test.val)
+		if (err == nil) != test.ok || got != test.want {
+			t.Errorf("test #%v: got 0x%x/%v, want 0x%x/%v", i, got, err, test.want, test.ok)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	consts := map[string]uint64{"O_RDWR": 2, "O_CREAT": 0x40, "AF_INET": 2, "SOCK_STREAM": 1}
+	const mmap = "mmap(&(0x7f0000000000)=nil, (0x1000000), 0x3, 0x32, 0xffffffffffffffff, 0x0)\n"
+	tests := []struct {
+		trace   string
+		want    string
+		dropped int
+	}{
+		{
+			"open(\"/tmp/x\", O_RDWR|O_CREAT, 0644) = 3\n" +
+				"write(3, \"abc\\n\", 4) = 4\n" +
+				"close(3) = 0\n",
+			mmap +
+				"r0 = open(&(0x7f0000000000)=\"2f746d702f7800\", 0x42, 0x1a4)\n" +
+				"write(r0, &(0x7f0000001000)=\"6162630a\", 0x4)\n" +
+				"close(r0)\n",
+			0,
+		},
+		{
+			// Resources returned via pointers, output buffers are zeroed.
+			"pipe([3, 4]) = 0\n" +
+				"read(3, \"ab\", 2) = 2\n" +
+				"close(4) = 0\n",
+			mmap +
+				"pipe(&(0x7f0000000000)={<r0=>0x0, <r1=>0x0})\n" +
+				"read(r0, &(0x7f0000001000)=\"0000\", 0x2)\n" +
+				"close(r1)\n",
+			0,
+		},
+		{
+			// Failed open does not create a resource, unknown fds are passed as is.
+			"open(\"/nonexistent\", O_RDWR) = -1 ENOENT (No such file or directory)\n" +
+				"close(3) = -1 EBADF (Bad file descriptor)\n",
+			mmap +
+				"open(&(0x7f0000000000)=\"2f6e6f6e6578697374656e7400\", 0x2, 0x0)\n" +
+				"close(0x3)\n",
+			0,
+		},
+		{
+			// Unknown calls are dropped, unparsable pointers become pointers to empty memory.
+			"foobar(1) = 0\n" +
+				"read(7, 0x1234, 10) = 10\n",
+			mmap +
+				"read(0x7, &(0x7f0000000000)=nil, 0xa)\n",
+			1,
+		},
+	}
+	for i, test := range tests {
+		traces, err := parseTrace(strings.NewReader(test.trace))
+		if err != nil {
+			t.Fatalf("test #%v: %v", i, err)
+		}
+		c := newConverter(consts)
+		dropped := 0
+		for _, call := range traces[0] {
+			if err := c.add(call); err != nil {
+				dropped++
+			}
+		}
+		if got := string(c.data()); got != test.want || dropped != test.dropped {
+			t.Errorf("test #%v: dropped %v calls, want %v, got:\n%v\nwant:\n%v",
+				i, dropped, test.dropped, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-verifier does differential fuzzing: it executes the same generated programs on two kernels
+// (e.g. two kernel versions, or Linux and gVisor) and reports programs whose calls fail with
+// different errnos as potential semantic bugs. Programs are executed sequentially (without threads
+// and collisions) and divergences are re-run several times on both kernels, so that flaky results
+// (e.g. calls that depend on timing) are not reported.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/config"
+	"github.com/google/syzkaller/fileutil"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys"
+	"github.com/google/syzkaller/vm"
+	_ "github.com/google/syzkaller/vm/adb"
+	_ "github.com/google/syzkaller/vm/gvisor"
+	_ "github.com/google/syzkaller/vm/isolated"
+	_ "github.com/google/syzkaller/vm/kvm"
+	_ "github.com/google/syzkaller/vm/qemu"
+)
+
+var (
+	flagConfig1 = flag.String("config1", "", "manager config of the first kernel")
+	flagConfig2 = flag.String("config2", "", "manager config of the second kernel")
+	flagOutput  = flag.String("output", "divergences", "dir to save diverging programs to")
+	flagBatch   = flag.Int("batch", 100, "number of programs executed in a VM at once")
+	flagLength  = flag.Int("length", 10, "number of calls in generated programs")
+	flagRuns    = flag.Int("runs", 3, "number of re-runs of a diverging program on every kernel")
+	flagBatches = flag.Int("batches", 0, "stop after that many batches (0 means run until killed)")
+)
+
+// errnosRe matches results printed by syz-execprog -errnos.
+var errnosRe = regexp.MustCompile(`(?m)^errnos ([0-9]+):((?: -?[0-9]+)*)\r?$`)
+
+// kernel is a VM with one of the compared kernels, it is reused for all batches until it crashes.
+type kernel struct {
+	name        string
+	cfg         *config.Config
+	inst        vm.Instance
+	workdir     string // of the VM
+	execprogBin string
+	executorBin string
+}
+
+func main() {
+	flag.Parse()
+	if *flagConfig1 == "" || *flagConfig2 == "" {
+		fmt.Fprintf(os.Stderr, "usage: syz-verifier -config1=kernel1.cfg -config2=kernel2.cfg\n")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	cfg1, syscalls1, _, err := config.Parse(*flagConfig1)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	cfg2, syscalls2, _, err := config.Parse(*flagConfig2)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := os.MkdirAll(*flagOutput, 0700); err != nil {
+		log.Fatalf("failed to create output dir: %v", err)
+	}
+	kernels := []*kernel{{name: "kernel1", cfg: cfg1}, {name: "kernel2", cfg: cfg2}}
+	for _, k := range kernels {
+		if _, err := os.Stat(k.cfg.TargetBin("syz-execprog")); err != nil {
+			log.Fatalf("%v is missing (run 'make execprog')", k.cfg.TargetBin("syz-execprog"))
+		}
+	}
+	ct := prog.BuildChoiceTable(prog.CalculatePriorities(nil), enabledCalls(syscalls1, syscalls2))
+	rs := rand.NewSource(time.Now().UnixNano())
+	executed, diverged := 0, 0
+	for batch := 0; *flagBatches == 0 || batch < *flagBatches; batch++ {
+		var progs []*prog.Prog
+		for i := 0; i < *flagBatch; i++ {
+			progs = append(progs, prog.Generate(rs, *flagLength, ct))
+		}
+		res, err := runAll(kernels, progs, 1)
+		if err != nil {
+			log.Printf("%v", err)
+			continue
+		}
+		executed += len(progs)
+		var suspects []*prog.Prog
+		for i, p := range progs {
+			if len(res[0][i]) != 0 && len(res[1][i]) != 0 && !equalErrnos(res[0][i][0], res[1][i][0]) {
+				suspects = append(suspects, p)
+			}
+		}
+		if len(suspects) != 0 {
+			res, err = runAll(kernels, suspects, *flagRuns)
+			if err != nil {
+				log.Printf("%v", err)
+				continue
+			}
+			for i, p := range suspects {
+				if !stableDivergence(res[0][i], res[1][i]) {
+					continue
+				}
+				diverged++
+				if err := saveDivergence(p, res[0][i][0], res[1][i][0]); err != nil {
+					log.Printf("%v", err)
+				}
+			}
+		}
+		log.Printf("executed %v programs, found %v divergences", executed, diverged)
+	}
+	for _, k := range kernels {
+		if k.inst != nil {
+			k.reset()
+		}
+	}
+}
+
+// enabledCalls returns calls that are enabled in both configs (an empty set means all calls).
+func enabledCalls(syscalls1, syscalls2 map[int]bool) map[*sys.Call]bool {
+	if len(syscalls1) == 0 && len(syscalls2) == 0 {
+		return nil
+	}
+	calls := make(map[*sys.Call]bool)
+	for _, c := range sys.Calls {
+		if (len(syscalls1) == 0 || syscalls1[c.ID]) && (len(syscalls2) == 0 || syscalls2[c.ID]) {
+			calls[c] = true
+		}
+	}
+	return calls
+}
+
+// runAll runs progs repeat times on all kernels in parallel and returns their results
+// (errnos of every run of every program on every kernel).
+func runAll(kernels []*kernel, progs []*prog.Prog, repeat int) ([][][][]int, error) {
+	res := make([][][][]int, len(kernels))
+	errs := make(chan error, len(kernels))
+	for i, k := range kernels {
+		i, k := i, k
+		go func() {
+			var err error
+			res[i], err = k.run(progs, repeat)
+			errs <- err
+		}()
+	}
+	var err error
+	for range kernels {
+		if err1 := <-errs; err1 != nil {
+			err = err1
+		}
+	}
+	return res, err
+}
+
+// run executes progs repeat times in the VM of the kernel (booting it if needed)
+// and returns errnos of every run of every program.
+func (k *kernel) run(progs []*prog.Prog, repeat int) ([][][]int, error) {
+	if k.inst == nil {
+		if err := k.boot(); err != nil {
+			return nil, fmt.Errorf("%v: failed to boot VM: %v", k.name, err)
+		}
+	}
+	buf := new(bytes.Buffer)
+	for _, p := range progs {
+		fmt.Fprintf(buf, "executing program 0:\n%s\n", p.Serialize())
+	}
+	progFile, err := fileutil.WriteTempFile(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(progFile)
+	ctx := context.Background()
+	vmProgFile, err := k.inst.Copy(ctx, progFile)
+	if err != nil {
+		k.reset()
+		return nil, fmt.Errorf("%v: failed to copy to VM: %v", k.name, err)
+	}
+	command := fmt.Sprintf("%v -executor %v -cover=0 -threaded=0 -collide=0 -procs=1 -repeat=%v -sandbox=%v -errnos %v",
+		k.execprogBin, k.executorBin, repeat, k.cfg.Sandbox, vmProgFile)
+	timeout := time.Minute + time.Duration(len(progs)*repeat)*10*time.Second
+	outc, errc, err := k.inst.Run(ctx, timeout, command)
+	if err != nil {
+		k.reset()
+		return nil, fmt.Errorf("%v: failed to run command in VM: %v", k.name, err)
+	}
+	var output []byte
+	for done := false; !done; {
+		select {
+		case out := <-outc:
+			output = append(output, out...)
+			if desc, _, _, found := vm.FindCrash(k.cfg.OS, output); found {
+				k.reset()
+				return nil, fmt.Errorf("%v: kernel crashed: %v", k.name, desc)
+			}
+		case err := <-errc:
+			if err != nil {
+				k.reset()
+				return nil, fmt.Errorf("%v: execution failed: %v", k.name, err)
+			}
+			// Output may be delivered after the command exits.
+			for drained := false; !drained; {
+				select {
+				case out := <-outc:
+					output = append(output, out...)
+				default:
+					drained = true
+				}
+			}
+			done = true
+		}
+	}
+	res := make([][][]int, len(progs))
+	for _, match := range errnosRe.FindAllSubmatch(output, -1) {
+		idx, _ := strconv.Atoi(string(match[1]))
+		if idx >= len(progs) {
+			continue
+		}
+		var errnos []int
+		for _, s := range strings.Fields(string(match[2])) {
+			errno, _ := strconv.Atoi(s)
+			errnos = append(errnos, errno)
+		}
+		res[idx] = append(res[idx], errnos)
+	}
+	return res, nil
+}
+
+func (k *kernel) boot() error {
+	vmCfg, err := config.CreateVMConfig(k.cfg)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	inst, err := vm.Create(ctx, k.cfg.Type, vmCfg)
+	if err != nil {
+		os.RemoveAll(vmCfg.Workdir)
+		return err
+	}
+	k.inst, k.workdir = inst, vmCfg.Workdir
+	if k.execprogBin, err = inst.Copy(ctx, k.cfg.TargetBin("syz-execprog")); err != nil {
+		k.reset()
+		return err
+	}
+	if k.executorBin, err = inst.Copy(ctx, k.cfg.TargetBin("syz-executor")); err != nil {
+		k.reset()
+		return err
+	}
+	return nil
+}
+
+// reset closes the VM after a failure, a new one is booted for the next batch.
+func (k *kernel) reset() {
+	k.inst.Close()
+	k.inst = nil
+	os.RemoveAll(k.workdir)
+}
+
+func equalErrnos(errnos1, errnos2 []int) bool {
+	if len(errnos1) != len(errnos2) {
+		return false
+	}
+	for i := range errnos1 {
+		if errnos1[i] != errnos2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// stableDivergence returns true if all runs on each kernel have the same results,
+// and the results for the same calls differ between the kernels.
+func stableDivergence(runs1, runs2 [][]int) bool {
+	if len(runs1) != *flagRuns || len(runs2) != *flagRuns {
+		return false
+	}
+	for i := 1; i < *flagRuns; i++ {
+		if !equalErrnos(runs1[0], runs1[i]) || !equalErrnos(runs2[0], runs2[i]) {
+			return false
+		}
+	}
+	return len(runs1[0]) == len(runs2[0]) && !equalErrnos(runs1[0], runs2[0])
+}
+
+// saveDivergence saves the program with errnos of its calls on both kernels as a comment,
+// so that the file can be executed with syz-execprog as is.
+func saveDivergence(p *prog.Prog, errnos1, errnos2 []int) error {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "# kernel1: %v\n# kernel2: %v\n", *flagConfig1, *flagConfig2)
+	for i, c := range p.Calls {
+		mark := ""
+		if errnos1[i] != errnos2[i] {
+			mark = " <--"
+		}
+		fmt.Fprintf(buf, "# %v: errno %v vs %v%v\n", c.Meta.Name, errnos1[i], errnos2[i], mark)
+	}
+	buf.Write(p.Serialize())
+	file := filepath.Join(*flagOutput, fmt.Sprintf("divergence-%v", time.Now().UnixNano()))
+	log.Printf("saving divergence to %v", file)
+	return ioutil.WriteFile(file, buf.Bytes(), 0660)
+}
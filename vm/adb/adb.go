@@ -4,12 +4,17 @@
 package adb
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -17,15 +22,35 @@ import (
 )
 
 func init() {
-	vm.Register("adb", ctor)
+	vm.Register("adb", ctor, validate)
+}
+
+// Config is the "adb" section of the manager config.
+type Config struct {
+	Bin     string   // adb binary name (default: adb)
+	Console string   // console device of the phone (e.g. /dev/ttyUSB0), if there is a single device
+	Devices []Device // devices, instance N uses device N modulo the number of devices
+}
+
+// Device is a device connected over USB or TCP, possibly to an adb server of a device farm.
+type Device struct {
+	// adb serial (e.g. "0123456789ABCDEF") or "host:port" of a device with adb over TCP
+	// (such devices are connected with "adb connect"), optional for a single device.
+	Serial string
+	// Console source: tty device (e.g. /dev/ttyUSB0) or remote serial server ("tcp://host:port").
+	Console string
+	// adb server that the device is connected to ("host:port"), for device farms (optional).
+	Server string
 }
 
 type instance struct {
 	cfg    *vm.Config
+	params *Config
+	device Device
 	closed chan bool
 }
 
-func ctor(cfg *vm.Config) (vm.Instance, error) {
+func ctor(ctx context.Context, cfg *vm.Config) (vm.Instance, error) {
 	inst := &instance{
 		cfg:    cfg,
 		closed: make(chan bool),
@@ -36,38 +61,98 @@ func ctor(cfg *vm.Config) (vm.Instance, error) {
 			closeInst.Close()
 		}
 	}()
-	if err := validateConfig(cfg); err != nil {
+	var err error
+	if inst.params, err = parseConfig(cfg.Params); err != nil {
 		return nil, err
 	}
-	if err := inst.repair(); err != nil {
+	inst.device = inst.params.Devices[cfg.Index%len(inst.params.Devices)]
+	if err := inst.repair(ctx); err != nil {
 		return nil, err
 	}
 	// Remove temp files from previous runs.
-	inst.adb("shell", "rm -Rf /data/syzkaller*")
+	inst.adb(ctx, "shell", "rm -Rf /data/syzkaller*")
 	closeInst = nil
 	return inst, nil
 }
 
-func validateConfig(cfg *vm.Config) error {
-	if cfg.Bin == "" {
-		cfg.Bin = "adb"
+func validate(params json.RawMessage) error {
+	_, err := parseConfig(params)
+	return err
+}
+
+func parseConfig(params json.RawMessage) (*Config, error) {
+	cfg := &Config{Bin: "adb"}
+	if err := vm.ParseParams("adb", params, cfg); err != nil {
+		return nil, err
 	}
-	if _, err := os.Stat(cfg.ConsoleDev); err != nil {
-		return fmt.Errorf("console device '%v' is missing: %v", cfg.ConsoleDev, err)
+	if len(cfg.Devices) == 0 {
+		if cfg.Console == "" {
+			return nil, fmt.Errorf("config param adb.console is required (or adb.devices)")
+		}
+		cfg.Devices = []Device{{Console: cfg.Console}}
+	} else if cfg.Console != "" {
+		return nil, fmt.Errorf("config params adb.console and adb.devices are mutually exclusive")
 	}
-	return nil
+	for i, dev := range cfg.Devices {
+		if dev.Serial == "" && len(cfg.Devices) > 1 {
+			return nil, fmt.Errorf("config param adb.devices[%v].serial is required for multiple devices", i)
+		}
+		if dev.Server != "" {
+			if _, _, err := net.SplitHostPort(dev.Server); err != nil {
+				return nil, fmt.Errorf("bad config param adb.devices[%v].server: %v", i, err)
+			}
+		}
+		if dev.Console == "" {
+			return nil, fmt.Errorf("config param adb.devices[%v].console is required", i)
+		}
+		if addr := strings.TrimPrefix(dev.Console, "tcp://"); addr != dev.Console {
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				return nil, fmt.Errorf("bad config param adb.devices[%v].console: %v", i, err)
+			}
+		} else if _, err := os.Stat(dev.Console); err != nil {
+			return nil, fmt.Errorf("bad config param adb.devices[%v].console: %v", i, err)
+		}
+	}
+	return cfg, nil
+}
+
+// adbArgs returns adb arguments that select the adb server and the device.
+func (inst *instance) adbArgs(device bool, args ...string) []string {
+	var res []string
+	if inst.device.Server != "" {
+		host, port, _ := net.SplitHostPort(inst.device.Server)
+		res = append(res, "-H", host, "-P", port)
+	}
+	if device && inst.device.Serial != "" {
+		res = append(res, "-s", inst.device.Serial)
+	}
+	return append(res, args...)
+}
+
+// connect connects a device with adb over TCP, it needs to be done after every reboot.
+func (inst *instance) connect(ctx context.Context) {
+	if _, _, err := net.SplitHostPort(inst.device.Serial); err != nil {
+		return
+	}
+	inst.run(ctx, inst.adbArgs(false, "connect", inst.device.Serial)...)
 }
 
 func (inst *instance) Forward(port int) (string, error) {
 	// If 35099 turns out to be busy, try to forward random ports several times.
 	devicePort := 35099
-	if err := inst.adb("reverse", fmt.Sprintf("tcp:%v", devicePort), fmt.Sprintf("tcp:%v", port)); err != nil {
+	if err := inst.adb(context.Background(), "reverse", fmt.Sprintf("tcp:%v", devicePort), fmt.Sprintf("tcp:%v", port)); err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("127.0.0.1:%v", devicePort), nil
 }
 
-func (inst *instance) adb(args ...string) error {
+func (inst *instance) adb(ctx context.Context, args ...string) error {
+	return inst.run(ctx, inst.adbArgs(true, args...)...)
+}
+
+// run runs adb with the given args (without device selection).
+// adb is killed if it hangs for more than a minute or if ctx is canceled.
+func (inst *instance) run(ctx context.Context, args ...string) error {
 	if inst.cfg.Debug {
 		log.Printf("executing adb %+v", args)
 	}
@@ -77,45 +162,42 @@ func (inst *instance) adb(args ...string) error {
 	}
 	defer wpipe.Close()
 	defer rpipe.Close()
-	cmd := exec.Command(inst.cfg.Bin, args...)
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, inst.params.Bin, args...)
 	cmd.Stdout = wpipe
 	cmd.Stderr = wpipe
 	if err := cmd.Start(); err != nil {
 		return err
 	}
 	wpipe.Close()
-	done := make(chan bool)
-	go func() {
-		select {
-		case <-time.After(time.Minute):
-			if inst.cfg.Debug {
-				log.Printf("adb hanged")
-			}
-			cmd.Process.Kill()
-		case <-done:
-		}
-	}()
 	if err := cmd.Wait(); err != nil {
-		close(done)
+		if inst.cfg.Debug && ctx.Err() == context.DeadlineExceeded {
+			log.Printf("adb hanged")
+		}
 		out, _ := ioutil.ReadAll(rpipe)
 		if inst.cfg.Debug {
 			log.Printf("adb failed: %v\n%s", err, out)
 		}
 		return fmt.Errorf("adb %+v failed: %v\n%s", args, err, out)
 	}
-	close(done)
 	if inst.cfg.Debug {
 		log.Printf("adb returned")
 	}
 	return nil
 }
 
-func (inst *instance) repair() error {
+func (inst *instance) repair(ctx context.Context) error {
 	// Give the device up to 5 minutes to come up (it can be rebooting after a previous crash).
-	time.Sleep(3 * time.Second)
+	if err := vm.Sleep(ctx, 3*time.Second); err != nil {
+		return err
+	}
 	for i := 0; i < 300; i++ {
-		time.Sleep(time.Second)
-		if inst.adb("shell", "pwd") == nil {
+		if err := vm.Sleep(ctx, time.Second); err != nil {
+			return err
+		}
+		inst.connect(ctx)
+		if inst.adb(ctx, "shell", "pwd") == nil {
 			return nil
 		}
 	}
@@ -123,16 +205,21 @@ func (inst *instance) repair() error {
 	// adb reboot episodically hangs, so we use a more reliable way.
 	// Ignore errors because all other adb commands hang as well
 	// and the binary can already be on the device.
-	inst.adb("push", inst.cfg.Executor, "/data/syz-executor")
-	if err := inst.adb("shell", "/data/syz-executor", "reboot"); err != nil {
+	inst.adb(ctx, "push", inst.cfg.Executor, "/data/syz-executor")
+	if err := inst.adb(ctx, "shell", "/data/syz-executor", "reboot"); err != nil {
 		return err
 	}
 	// Now give it another 5 minutes.
-	time.Sleep(10 * time.Second)
+	if err := vm.Sleep(ctx, 10*time.Second); err != nil {
+		return err
+	}
 	var err error
 	for i := 0; i < 300; i++ {
-		time.Sleep(time.Second)
-		if err = inst.adb("shell", "pwd"); err == nil {
+		if err := vm.Sleep(ctx, time.Second); err != nil {
+			return err
+		}
+		inst.connect(ctx)
+		if err = inst.adb(ctx, "shell", "pwd"); err == nil {
 			return nil
 		}
 	}
@@ -144,15 +231,15 @@ func (inst *instance) Close() {
 	os.RemoveAll(inst.cfg.Workdir)
 }
 
-func (inst *instance) Copy(hostSrc string) (string, error) {
+func (inst *instance) Copy(ctx context.Context, hostSrc string) (string, error) {
 	vmDst := filepath.Join("/data", filepath.Base(hostSrc))
-	if err := inst.adb("push", hostSrc, vmDst); err != nil {
+	if err := inst.adb(ctx, "push", hostSrc, vmDst); err != nil {
 		return "", err
 	}
 	return vmDst, nil
 }
 
-func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
+func (inst *instance) Run(ctx context.Context, timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
 	rpipe, wpipe, err := os.Pipe()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create pipe: %v", err)
@@ -161,32 +248,21 @@ func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte,
 		syscall.Syscall(syscall.SYS_FCNTL, wpipe.Fd(), syscall.F_SETPIPE_SZ, uintptr(sz))
 	}
 
-	cat := exec.Command("cat", inst.cfg.ConsoleDev)
-	cat.Stdout = wpipe
-	cat.Stderr = wpipe
-	if err := cat.Start(); err != nil {
+	stopConsole, consoleDone, err := inst.openConsole(ctx, wpipe)
+	if err != nil {
 		rpipe.Close()
 		wpipe.Close()
-		return nil, nil, fmt.Errorf("failed to start cat %v: %v", inst.cfg.ConsoleDev, err)
-
+		return nil, nil, err
 	}
-	catDone := make(chan error, 1)
-	go func() {
-		err := cat.Wait()
-		if inst.cfg.Debug {
-			log.Printf("cat exited: %v", err)
-		}
-		catDone <- fmt.Errorf("cat exited: %v", err)
-	}()
 
 	if inst.cfg.Debug {
 		log.Printf("starting: adb shell %v", command)
 	}
-	adb := exec.Command(inst.cfg.Bin, "shell", "cd /data; "+command)
+	adb := exec.Command(inst.params.Bin, inst.adbArgs(true, "shell", "cd /data; "+command)...)
 	adb.Stdout = wpipe
 	adb.Stderr = wpipe
 	if err := adb.Start(); err != nil {
-		cat.Process.Kill()
+		stopConsole()
 		rpipe.Close()
 		wpipe.Close()
 		return nil, nil, fmt.Errorf("failed to start adb: %v", err)
@@ -237,25 +313,73 @@ func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte,
 	}()
 
 	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
 		select {
-		case <-time.After(timeout):
+		case <-timer.C:
 			signal(vm.TimeoutErr)
-			cat.Process.Kill()
+			stopConsole()
+			adb.Process.Kill()
+		case <-ctx.Done():
+			signal(ctx.Err())
+			stopConsole()
 			adb.Process.Kill()
 		case <-inst.closed:
 			if inst.cfg.Debug {
 				log.Printf("instance closed")
 			}
 			signal(fmt.Errorf("instance closed"))
-			cat.Process.Kill()
+			stopConsole()
 			adb.Process.Kill()
-		case err := <-catDone:
+		case err := <-consoleDone:
 			signal(err)
 			adb.Process.Kill()
 		case err := <-adbDone:
 			signal(err)
-			cat.Process.Kill()
+			stopConsole()
 		}
 	}()
 	return outc, errc, nil
 }
+
+// openConsole starts copying console output of the device to w (w can be closed after that).
+// It returns a function that stops copying and a channel that gets an error when copying stops.
+func (inst *instance) openConsole(ctx context.Context, w *os.File) (func(), <-chan error, error) {
+	done := make(chan error, 1)
+	if addr := strings.TrimPrefix(inst.device.Console, "tcp://"); addr != inst.device.Console {
+		fd, err := syscall.Dup(int(w.Fd()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dup pipe: %v", err)
+		}
+		w1 := os.NewFile(uintptr(fd), w.Name())
+		dialer := &net.Dialer{Timeout: time.Minute}
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			w1.Close()
+			return nil, nil, fmt.Errorf("failed to connect to console %v: %v", addr, err)
+		}
+		go func() {
+			_, err := io.Copy(w1, conn)
+			w1.Close()
+			if inst.cfg.Debug {
+				log.Printf("console connection closed: %v", err)
+			}
+			done <- fmt.Errorf("console connection closed: %v", err)
+		}()
+		return func() { conn.Close() }, done, nil
+	}
+	cat := exec.Command("cat", inst.device.Console)
+	cat.Stdout = w
+	cat.Stderr = w
+	if err := cat.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start cat %v: %v", inst.device.Console, err)
+	}
+	go func() {
+		err := cat.Wait()
+		if inst.cfg.Debug {
+			log.Printf("cat exited: %v", err)
+		}
+		done <- fmt.Errorf("cat exited: %v", err)
+	}()
+	return func() { cat.Process.Kill() }, done, nil
+}
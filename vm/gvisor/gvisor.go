@@ -0,0 +1,194 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package gvisor implements a VM backend that runs programs inside of gVisor (runsc) sandboxes
+// on the host. gVisor implements Linux syscall surface in a user-space kernel (the Sentry),
+// so Linux fuzzer and executor binaries are used as is. Coverage is provided by the kcov
+// emulation of the Sentry (requires runsc built with coverage instrumentation).
+package gvisor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/syzkaller/fileutil"
+	"github.com/google/syzkaller/vm"
+)
+
+func init() {
+	vm.Register("gvisor", ctor, validate)
+}
+
+// Config is the "gvisor" section of the manager config.
+type Config struct {
+	Runsc    string   // runsc binary (default: runsc)
+	Platform string   // runsc platform: ptrace (default) or kvm
+	Args     []string // additional runsc flags
+}
+
+type instance struct {
+	cfg    *vm.Config
+	params *Config
+	root   string // runsc state dir
+	closed chan bool
+}
+
+func ctor(ctx context.Context, cfg *vm.Config) (vm.Instance, error) {
+	params, err := parseConfig(cfg.Params)
+	if err != nil {
+		return nil, err
+	}
+	inst := &instance{
+		cfg:    cfg,
+		params: params,
+		root:   filepath.Join(cfg.Workdir, "runsc"),
+		closed: make(chan bool),
+	}
+	if err := os.MkdirAll(inst.root, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create runsc root: %v", err)
+	}
+	return inst, nil
+}
+
+func validate(params json.RawMessage) error {
+	_, err := parseConfig(params)
+	return err
+}
+
+func parseConfig(params json.RawMessage) (*Config, error) {
+	cfg := &Config{Runsc: "runsc", Platform: "ptrace"}
+	if err := vm.ParseParams("gvisor", params, cfg); err != nil {
+		return nil, err
+	}
+	if _, err := exec.LookPath(cfg.Runsc); err != nil {
+		return nil, fmt.Errorf("bad config param gvisor.runsc: %v", err)
+	}
+	switch cfg.Platform {
+	case "ptrace", "kvm":
+	default:
+		return nil, fmt.Errorf("config param gvisor.platform must contain one of ptrace/kvm")
+	}
+	return cfg, nil
+}
+
+func (inst *instance) Close() {
+	close(inst.closed)
+	inst.cleanup()
+	os.RemoveAll(inst.cfg.Workdir)
+}
+
+// cleanup destroys all sandboxes that were left running (e.g. when runsc was killed on timeout).
+func (inst *instance) cleanup() {
+	out, err := exec.Command(inst.params.Runsc, "-root", inst.root, "list", "-quiet").Output()
+	if err != nil {
+		return
+	}
+	for _, id := range strings.Fields(string(out)) {
+		exec.Command(inst.params.Runsc, "-root", inst.root, "delete", "-force", id).Run()
+	}
+}
+
+func (inst *instance) Forward(port int) (string, error) {
+	// Sandboxes use host network.
+	return fmt.Sprintf("127.0.0.1:%v", port), nil
+}
+
+func (inst *instance) Copy(ctx context.Context, hostSrc string) (string, error) {
+	// Sandboxes see host file system, so files are just copied into the instance workdir.
+	dst := filepath.Join(inst.cfg.Workdir, filepath.Base(hostSrc))
+	if err := fileutil.CopyFile(hostSrc, dst, false); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(dst, 0777); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+func (inst *instance) Run(ctx context.Context, timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
+	rpipe, wpipe, err := os.Pipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create pipe: %v", err)
+	}
+	// Sentry panics and warnings go to runsc stderr along with the command output.
+	args := []string{
+		"-root", inst.root,
+		"-platform", inst.params.Platform,
+		"-network", "host",
+		"-alsologtostderr",
+	}
+	args = append(args, inst.params.Args...)
+	args = append(args, "do", "sh", "-c", command)
+	cmd := exec.Command(inst.params.Runsc, args...)
+	cmd.Dir = inst.cfg.Workdir
+	cmd.Stdout = wpipe
+	cmd.Stderr = wpipe
+	if err := cmd.Start(); err != nil {
+		rpipe.Close()
+		wpipe.Close()
+		return nil, nil, err
+	}
+	wpipe.Close()
+	outputC := make(chan []byte, 10)
+	errorC := make(chan error, 1)
+	done := make(chan bool)
+	signal := func(err error) {
+		time.Sleep(3 * time.Second) // wait for any pending output
+		select {
+		case errorC <- err:
+		default:
+		}
+	}
+	go func() {
+		var buf [64 << 10]byte
+		var output []byte
+		for {
+			n, err := rpipe.Read(buf[:])
+			if n != 0 {
+				if inst.cfg.Debug {
+					os.Stdout.Write(buf[:n])
+					os.Stdout.Write([]byte{'\n'})
+				}
+				output = append(output, buf[:n]...)
+				select {
+				case outputC <- output:
+					output = nil
+				default:
+				}
+			}
+			if err != nil {
+				rpipe.Close()
+				return
+			}
+		}
+	}()
+	go func() {
+		err := cmd.Wait()
+		signal(err)
+		close(done)
+	}()
+	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			signal(vm.TimeoutErr)
+			cmd.Process.Signal(syscall.SIGKILL)
+		case <-done:
+		case <-ctx.Done():
+			signal(ctx.Err())
+			cmd.Process.Signal(syscall.SIGKILL)
+		case <-inst.closed:
+			signal(fmt.Errorf("closed"))
+			cmd.Process.Signal(syscall.SIGKILL)
+		}
+	}()
+	return outputC, errorC, nil
+}
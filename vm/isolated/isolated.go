@@ -0,0 +1,315 @@
+// Copyright 2016 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package isolated implements a VM backend for dedicated machines (physical or otherwise
+// isolated, e.g. bhyve guests managed outside of syzkaller) that are accessible over ssh.
+// Machines are not created by syzkaller, instead every instance reboots the machine
+// to get a clean kernel and the kernel log is read over ssh.
+package isolated
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/vm"
+)
+
+const (
+	// First port used for reverse tunnels on the target.
+	tunnelPort = 35099
+)
+
+func init() {
+	vm.Register("isolated", ctor, validate)
+}
+
+// Config is the "isolated" section of the manager config.
+type Config struct {
+	Targets    []string // target machines as host or host:port, instance i uses target i%len(targets)
+	Target_Dir string   // directory on targets for binaries and temp files (default: /tmp/syzkaller)
+	Sshkey     string   // root ssh key for targets
+	User       string   // ssh user (default: root)
+}
+
+// logCommands stream new kernel messages on the target.
+var logCommands = map[string]string{
+	"linux": "dmesg --clear; dmesg --follow",
+}
+
+type instance struct {
+	cfg    *vm.Config
+	params *Config
+	target string
+	port   string
+
+	mu      sync.Mutex
+	tunnels []string // ssh -R specs for reverse tunnels
+}
+
+func ctor(ctx context.Context, cfg *vm.Config) (vm.Instance, error) {
+	inst := &instance{cfg: cfg}
+	closeInst := inst
+	defer func() {
+		if closeInst != nil {
+			closeInst.Close()
+		}
+	}()
+
+	var err error
+	if inst.params, err = parseConfig(cfg.Params); err != nil {
+		return nil, err
+	}
+	inst.target = inst.params.Targets[cfg.Index%len(inst.params.Targets)]
+	inst.port = "22"
+	if host, port, ok := splitHostPort(inst.target); ok {
+		inst.target, inst.port = host, port
+	}
+	if err := inst.repair(ctx); err != nil {
+		return nil, err
+	}
+	closeInst = nil
+	return inst, nil
+}
+
+func validate(params json.RawMessage) error {
+	_, err := parseConfig(params)
+	return err
+}
+
+func parseConfig(params json.RawMessage) (*Config, error) {
+	cfg := &Config{Target_Dir: "/tmp/syzkaller", User: "root"}
+	if err := vm.ParseParams("isolated", params, cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Targets) == 0 {
+		return nil, fmt.Errorf("config param isolated.targets is empty")
+	}
+	if cfg.Target_Dir == "" || cfg.Target_Dir == "/" {
+		return nil, fmt.Errorf("bad config param isolated.target_dir: '%v'", cfg.Target_Dir)
+	}
+	if cfg.Sshkey == "" {
+		return nil, fmt.Errorf("config param isolated.sshkey is required")
+	}
+	// ssh silently ignores keys that are accessible by others.
+	if st, err := os.Stat(cfg.Sshkey); err != nil {
+		return nil, fmt.Errorf("bad config param isolated.sshkey: %v", err)
+	} else if st.Mode().Perm()&077 != 0 {
+		return nil, fmt.Errorf("bad config param isolated.sshkey: %v is accessible by others (mode %o), ssh will refuse to use it",
+			cfg.Sshkey, st.Mode().Perm())
+	}
+	return cfg, nil
+}
+
+func splitHostPort(target string) (string, string, bool) {
+	colon := strings.LastIndexByte(target, ':')
+	if colon == -1 || strings.IndexByte(target[:colon], ':') != -1 {
+		return "", "", false // no port or IPv6 address without brackets
+	}
+	return strings.Trim(target[:colon], "[]"), target[colon+1:], true
+}
+
+// repair reboots the target to get a clean kernel (previous instance could have crashed it
+// or left junk behind) and prepares target dir.
+func (inst *instance) repair(ctx context.Context) error {
+	if err := inst.waitForSsh(ctx, 10*time.Minute); err != nil {
+		return err
+	}
+	inst.ssh(ctx, time.Minute, "reboot")
+	// Give it time to actually go down.
+	if err := vm.Sleep(ctx, 30*time.Second); err != nil {
+		return err
+	}
+	if err := inst.waitForSsh(ctx, 10*time.Minute); err != nil {
+		return err
+	}
+	dir := inst.params.Target_Dir
+	if out, err := inst.ssh(ctx, time.Minute, fmt.Sprintf("rm -rf %v && mkdir -p %v", dir, dir)); err != nil {
+		return fmt.Errorf("failed to prepare target dir: %v\n%s", err, out)
+	}
+	return nil
+}
+
+func (inst *instance) waitForSsh(ctx context.Context, timeout time.Duration) error {
+	var err error
+	var out []byte
+	start := time.Now()
+	for time.Since(start) < timeout {
+		if out, err = inst.ssh(ctx, time.Minute, "pwd"); err == nil {
+			return nil
+		}
+		if err := vm.Sleep(ctx, 10*time.Second); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("target %v is not accessible over ssh: %v\n%s", inst.target, err, out)
+}
+
+func (inst *instance) ssh(ctx context.Context, timeout time.Duration, command string) ([]byte, error) {
+	args := append(inst.sshArgs("-p"), inst.params.User+"@"+inst.target, command)
+	return runWithTimeout(ctx, timeout, "ssh", args...)
+}
+
+func (inst *instance) Close() {
+	os.RemoveAll(inst.cfg.Workdir)
+}
+
+func (inst *instance) Forward(port int) (string, error) {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	targetPort := tunnelPort + len(inst.tunnels)
+	inst.tunnels = append(inst.tunnels, fmt.Sprintf("%v:127.0.0.1:%v", targetPort, port))
+	return fmt.Sprintf("127.0.0.1:%v", targetPort), nil
+}
+
+func (inst *instance) Copy(ctx context.Context, hostSrc string) (string, error) {
+	dst := filepath.Join(inst.params.Target_Dir, filepath.Base(hostSrc))
+	args := append(inst.sshArgs("-P"), hostSrc, inst.params.User+"@"+inst.target+":"+dst)
+	if out, err := runWithTimeout(ctx, 3*time.Minute, "scp", args...); err != nil {
+		return "", fmt.Errorf("failed to copy %v: %v\n%s", hostSrc, err, out)
+	}
+	return dst, nil
+}
+
+func (inst *instance) CopyFrom(ctx context.Context, vmSrc, hostDst string) error {
+	args := append(inst.sshArgs("-P"), inst.params.User+"@"+inst.target+":"+vmSrc, hostDst)
+	if out, err := exec.CommandContext(ctx, "scp", args...).CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to copy %v: %v\n%s", vmSrc, err, out)
+	}
+	return nil
+}
+
+func (inst *instance) Run(ctx context.Context, timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
+	rpipe, wpipe, err := os.Pipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create pipe: %v", err)
+	}
+	defer wpipe.Close()
+
+	// Kernel log goes into the same pipe as the command output.
+	logCmd := logCommands[inst.cfg.OS]
+	if logCmd == "" {
+		logCmd = logCommands["linux"]
+	}
+	logArgs := append(inst.sshArgs("-p"), inst.params.User+"@"+inst.target, logCmd)
+	dmesg := exec.Command("ssh", logArgs...)
+	dmesg.Stdout = wpipe
+	dmesg.Stderr = wpipe
+	if err := dmesg.Start(); err != nil {
+		rpipe.Close()
+		return nil, nil, fmt.Errorf("failed to start kernel log reader: %v", err)
+	}
+
+	args := inst.sshArgs("-p")
+	inst.mu.Lock()
+	if len(inst.tunnels) != 0 {
+		// Don't run the command if the tunnel can't be established, it won't be able to connect anyway.
+		args = append(args, "-o", "ExitOnForwardFailure=yes")
+	}
+	for _, tunnel := range inst.tunnels {
+		args = append(args, "-R", tunnel)
+	}
+	inst.mu.Unlock()
+	args = append(args, inst.params.User+"@"+inst.target, "cd "+inst.params.Target_Dir+" && "+command)
+	cmd := exec.Command("ssh", args...)
+	cmd.Stdout = wpipe
+	cmd.Stderr = wpipe
+	if err := cmd.Start(); err != nil {
+		dmesg.Process.Kill()
+		dmesg.Wait()
+		rpipe.Close()
+		return nil, nil, err
+	}
+
+	outputC := make(chan []byte, 10)
+	errorC := make(chan error, 1)
+	done := make(chan bool)
+	signal := func(err error) {
+		select {
+		case errorC <- err:
+		default:
+		}
+	}
+	go func() {
+		var buf [64 << 10]byte
+		var output []byte
+		for {
+			n, err := rpipe.Read(buf[:])
+			if n != 0 {
+				if inst.cfg.Debug {
+					os.Stdout.Write(buf[:n])
+					os.Stdout.Write([]byte{'\n'})
+				}
+				// Don't block if the caller does not read output anymore.
+				output = append(output, buf[:n]...)
+				select {
+				case outputC <- output:
+					output = nil
+				default:
+				}
+			}
+			if err != nil {
+				rpipe.Close()
+				return
+			}
+		}
+	}()
+	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			signal(vm.TimeoutErr)
+			cmd.Process.Kill()
+		case <-ctx.Done():
+			signal(ctx.Err())
+			cmd.Process.Kill()
+		case <-done:
+		}
+	}()
+	go func() {
+		err := cmd.Wait()
+		close(done)
+		time.Sleep(3 * time.Second) // wait for any pending kernel output
+		dmesg.Process.Kill()
+		dmesg.Wait()
+		signal(err)
+	}()
+	return outputC, errorC, nil
+}
+
+func (inst *instance) sshArgs(portArg string) []string {
+	return []string{
+		"-i", inst.params.Sshkey,
+		portArg, inst.port,
+		"-o", "ConnectionAttempts=10",
+		"-o", "ConnectTimeout=10",
+		"-o", "BatchMode=yes",
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-o", "IdentitiesOnly=yes",
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "LogLevel=error",
+		"-o", "ServerAliveInterval=5",
+		"-o", "ServerAliveCountMax=3",
+	}
+}
+
+// runWithTimeout runs bin with args, it is killed after timeout or if ctx is canceled.
+func runWithTimeout(ctx context.Context, timeout time.Duration, bin string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, bin, args...).CombinedOutput()
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return out, err
+}
@@ -4,6 +4,8 @@
 package kvm
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -24,11 +26,22 @@ const (
 )
 
 func init() {
-	vm.Register("kvm", ctor)
+	vm.Register("kvm", ctor, validate)
+}
+
+// Config is the "kvm" section of the manager config.
+type Config struct {
+	Bin    string // lkvm binary name (default: lkvm)
+	Kernel string // e.g. arch/x86/boot/bzImage
+	Cpu    int    // number of VM CPUs
+	Mem    int    // amount of VM memory in MBs
+	// Kernel command line additions for VMs of this type (appended after the manager cmdline).
+	Cmdline string
 }
 
 type instance struct {
 	cfg         *vm.Config
+	params      *Config
 	sandbox     string
 	sandboxPath string
 	lkvm        *exec.Cmd
@@ -40,7 +53,7 @@ type instance struct {
 	outputC chan []byte
 }
 
-func ctor(cfg *vm.Config) (vm.Instance, error) {
+func ctor(ctx context.Context, cfg *vm.Config) (vm.Instance, error) {
 	sandbox := fmt.Sprintf("syz-%v", cfg.Index)
 	inst := &instance{
 		cfg:         cfg,
@@ -54,13 +67,14 @@ func ctor(cfg *vm.Config) (vm.Instance, error) {
 		}
 	}()
 
-	if err := validateConfig(cfg); err != nil {
+	var err error
+	if inst.params, err = parseConfig(cfg.Params); err != nil {
 		return nil, err
 	}
 
 	os.RemoveAll(inst.sandboxPath)
 	os.Remove(inst.sandboxPath + ".sock")
-	out, err := exec.Command(inst.cfg.Bin, "setup", sandbox).CombinedOutput()
+	out, err := exec.CommandContext(ctx, inst.params.Bin, "setup", sandbox).CombinedOutput()
 	if err != nil {
 		return nil, fmt.Errorf("failed to lkvm setup: %v\n%s", err, out)
 	}
@@ -78,12 +92,12 @@ func ctor(cfg *vm.Config) (vm.Instance, error) {
 	}
 
 	inst.lkvm = exec.Command("taskset", "-c", strconv.Itoa(inst.cfg.Index%runtime.NumCPU()),
-		inst.cfg.Bin, "sandbox",
+		inst.params.Bin, "sandbox",
 		"--disk", inst.sandbox,
-		"--kernel", inst.cfg.Kernel,
-		"--params", "slub_debug=UZ "+inst.cfg.Cmdline,
-		"--mem", strconv.Itoa(inst.cfg.Mem),
-		"--cpus", strconv.Itoa(inst.cfg.Cpu),
+		"--kernel", inst.params.Kernel,
+		"--params", "slub_debug=UZ "+inst.cfg.Cmdline+" "+inst.params.Cmdline,
+		"--mem", strconv.Itoa(inst.params.Mem),
+		"--cpus", strconv.Itoa(inst.params.Cpu),
 		"--network", "mode=user",
 		"--sandbox", scriptPath,
 	)
@@ -135,7 +149,7 @@ func ctor(cfg *vm.Config) (vm.Instance, error) {
 	}()
 
 	// Wait for the script to start serving.
-	_, errc, err := inst.Run(10*time.Minute, "mount -t debugfs none /sys/kernel/debug/")
+	_, errc, err := inst.Run(ctx, 10*time.Minute, "mount -t debugfs none /sys/kernel/debug/")
 	if err == nil {
 		err = <-errc
 	}
@@ -147,26 +161,29 @@ func ctor(cfg *vm.Config) (vm.Instance, error) {
 	return inst, nil
 }
 
-func validateConfig(cfg *vm.Config) error {
-	if cfg.Bin == "" {
-		cfg.Bin = "lkvm"
-	}
-	if cfg.Image != "" {
-		return fmt.Errorf("lkvm does not support custom images")
+func validate(params json.RawMessage) error {
+	_, err := parseConfig(params)
+	return err
+}
+
+func parseConfig(params json.RawMessage) (*Config, error) {
+	cfg := &Config{Bin: "lkvm"}
+	if err := vm.ParseParams("kvm", params, cfg); err != nil {
+		return nil, err
 	}
-	if cfg.Sshkey != "" {
-		return fmt.Errorf("lkvm does not need ssh key")
+	if cfg.Kernel == "" {
+		return nil, fmt.Errorf("config param kvm.kernel is required")
 	}
 	if _, err := os.Stat(cfg.Kernel); err != nil {
-		return fmt.Errorf("kernel file '%v' does not exist: %v", cfg.Kernel, err)
+		return nil, fmt.Errorf("bad config param kvm.kernel: %v", err)
 	}
 	if cfg.Cpu <= 0 || cfg.Cpu > 1024 {
-		return fmt.Errorf("bad qemu cpu: %v, want [1-1024]", cfg.Cpu)
+		return nil, fmt.Errorf("invalid config param kvm.cpu: %v, want [1-1024]", cfg.Cpu)
 	}
 	if cfg.Mem < 128 || cfg.Mem > 1048576 {
-		return fmt.Errorf("bad qemu mem: %v, want [128-1048576]", cfg.Mem)
+		return nil, fmt.Errorf("invalid config param kvm.mem: %v, want [128-1048576]", cfg.Mem)
 	}
-	return nil
+	return cfg, nil
 }
 
 func (inst *instance) Close() {
@@ -185,7 +202,7 @@ func (inst *instance) Forward(port int) (string, error) {
 	return fmt.Sprintf("%v:%v", hostAddr, port), nil
 }
 
-func (inst *instance) Copy(hostSrc string) (string, error) {
+func (inst *instance) Copy(ctx context.Context, hostSrc string) (string, error) {
 	vmDst := filepath.Join("/", filepath.Base(hostSrc))
 	dst := filepath.Join(inst.sandboxPath, vmDst)
 	if err := fileutil.CopyFile(hostSrc, dst, false); err != nil {
@@ -197,7 +214,7 @@ func (inst *instance) Copy(hostSrc string) (string, error) {
 	return vmDst, nil
 }
 
-func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
+func (inst *instance) Run(ctx context.Context, timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
 	outputC := make(chan []byte, 10)
 	errorC := make(chan error, 1)
 	inst.mu.Lock()
@@ -235,6 +252,9 @@ func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte,
 			case <-timeoutTicker.C:
 				resultErr = vm.TimeoutErr
 				break loop
+			case <-ctx.Done():
+				resultErr = ctx.Err()
+				break loop
 			case <-secondTicker.C:
 				if _, err := os.Stat(cmdFile); err != nil {
 					resultErr = nil
@@ -4,6 +4,7 @@
 package local
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -17,7 +18,7 @@ import (
 )
 
 func init() {
-	vm.Register("local", ctor)
+	vm.Register("local", ctor, nil)
 }
 
 type instance struct {
@@ -25,7 +26,7 @@ type instance struct {
 	closed chan bool
 }
 
-func ctor(cfg *vm.Config) (vm.Instance, error) {
+func ctor(ctx context.Context, cfg *vm.Config) (vm.Instance, error) {
 	// Disable annoying segfault dmesg messages, fuzzer is going to crash a lot.
 	etrace, err := os.Open("/proc/sys/debug/exception-trace")
 	if err == nil {
@@ -52,7 +53,7 @@ func (inst *instance) Forward(port int) (string, error) {
 	return fmt.Sprintf("127.0.0.1:%v", port), nil
 }
 
-func (inst *instance) Copy(hostSrc string) (string, error) {
+func (inst *instance) Copy(ctx context.Context, hostSrc string) (string, error) {
 	vmDst := filepath.Join(inst.cfg.Workdir, filepath.Base(hostSrc))
 	if err := fileutil.CopyFile(hostSrc, vmDst, false); err != nil {
 		return "", err
@@ -63,7 +64,7 @@ func (inst *instance) Copy(hostSrc string) (string, error) {
 	return vmDst, nil
 }
 
-func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
+func (inst *instance) Run(ctx context.Context, timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
 	rpipe, wpipe, err := os.Pipe()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create pipe: %v", err)
@@ -134,6 +135,11 @@ func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte,
 			case <-done:
 				timeout.Stop()
 				return
+			case <-ctx.Done():
+				signal(ctx.Err())
+				cmd.Process.Kill()
+				timeout.Stop()
+				return
 			case <-inst.closed:
 				signal(fmt.Errorf("closed"))
 				cmd.Process.Kill()
@@ -4,6 +4,8 @@
 package qemu
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"net"
@@ -21,15 +23,44 @@ import (
 
 const (
 	hostAddr = "10.0.2.10"
+	// First port used for reverse tunnels inside of the VM.
+	tunnelPort = 35099
 )
 
 func init() {
-	vm.Register("qemu", ctor)
+	vm.Register("qemu", ctor, validate)
+}
+
+// Config is the "qemu" section of the manager config.
+type Config struct {
+	Bin    string // qemu binary name (default: qemu-system-x86_64)
+	Kernel string // e.g. arch/x86/boot/bzImage (optional, the image is booted with its own kernel otherwise)
+	Initrd string // linux initial ramdisk (optional)
+	Image  string // linux image for VMs
+	Sshkey string // ssh key for the image
+	User   string // ssh user (default: root)
+	Cpu    int    // number of VM CPUs
+	Mem    int    // amount of VM memory in MBs
+	// Gdb binary (e.g. "gdb" or "gdb-multiarch") that dumps registers and backtraces of all CPUs of wedged VMs
+	// over qemu gdbstub (optional, gdbstub is not started otherwise).
+	Gdb string
+	// Kernel command line additions for VMs of this type, e.g. "slub_debug=FZP panic_on_warn=1"
+	// (appended after the default and the manager cmdline, requires Kernel).
+	Cmdline string
+	// Forward fuzzer RPC connections to manager through ssh reverse tunnels
+	// (for VMs that can't connect to the manager host directly, e.g. behind NAT).
+	Tunnel bool
+	// Use TCG with instruction counting instead of KVM ("auto" or shift 0-10, see qemu -icount).
+	// The guest clock does not wait for the host when the guest is idle (sleep=off),
+	// so long kernel timers (TCP keepalive, writeback) fire quickly.
+	Icount string
 }
 
 type instance struct {
 	cfg     *vm.Config
+	params  *Config
 	port    int
+	gdbPort int
 	rpipe   *os.File
 	wpipe   *os.File
 	qemu    *exec.Cmd
@@ -39,22 +70,23 @@ type instance struct {
 	mu      sync.Mutex
 	outputB []byte
 	outputC chan []byte
+	tunnels []string // ssh -R specs for reverse tunnels
 }
 
-func ctor(cfg *vm.Config) (vm.Instance, error) {
+func ctor(ctx context.Context, cfg *vm.Config) (vm.Instance, error) {
 	for i := 0; ; i++ {
-		inst, err := ctorImpl(cfg)
+		inst, err := ctorImpl(ctx, cfg)
 		if err == nil {
 			return inst, nil
 		}
-		if i < 1000 && strings.Contains(err.Error(), "could not set up host forwarding rule") {
+		if i < 1000 && ctx.Err() == nil && strings.Contains(err.Error(), "could not set up host forwarding rule") {
 			continue
 		}
 		return nil, err
 	}
 }
 
-func ctorImpl(cfg *vm.Config) (vm.Instance, error) {
+func ctorImpl(ctx context.Context, cfg *vm.Config) (vm.Instance, error) {
 	inst := &instance{cfg: cfg}
 	closeInst := inst
 	defer func() {
@@ -63,11 +95,10 @@ func ctorImpl(cfg *vm.Config) (vm.Instance, error) {
 		}
 	}()
 
-	if err := validateConfig(cfg); err != nil {
+	var err error
+	if inst.params, err = parseConfig(cfg.Params); err != nil {
 		return nil, err
 	}
-
-	var err error
 	inst.rpipe, inst.wpipe, err = os.Pipe()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pipe: %v", err)
@@ -76,7 +107,7 @@ func ctorImpl(cfg *vm.Config) (vm.Instance, error) {
 		syscall.Syscall(syscall.SYS_FCNTL, inst.wpipe.Fd(), syscall.F_SETPIPE_SZ, uintptr(sz))
 	}
 
-	if err := inst.Boot(); err != nil {
+	if err := inst.Boot(ctx); err != nil {
 		return nil, err
 	}
 
@@ -84,23 +115,55 @@ func ctorImpl(cfg *vm.Config) (vm.Instance, error) {
 	return inst, nil
 }
 
-func validateConfig(cfg *vm.Config) error {
-	if cfg.Bin == "" {
-		cfg.Bin = "qemu-system-x86_64"
+func validate(params json.RawMessage) error {
+	_, err := parseConfig(params)
+	return err
+}
+
+func parseConfig(params json.RawMessage) (*Config, error) {
+	cfg := &Config{Bin: "qemu-system-x86_64", User: "root"}
+	if err := vm.ParseParams("qemu", params, cfg); err != nil {
+		return nil, err
 	}
-	if _, err := os.Stat(cfg.Image); err != nil {
-		return fmt.Errorf("image file '%v' does not exist: %v", cfg.Image, err)
+	if cfg.Image == "" {
+		return nil, fmt.Errorf("config param qemu.image is required")
+	}
+	if cfg.Sshkey == "" {
+		return nil, fmt.Errorf("config param qemu.sshkey is required")
+	}
+	if cfg.Gdb != "" {
+		if _, err := exec.LookPath(cfg.Gdb); err != nil {
+			return nil, fmt.Errorf("bad config param qemu.gdb: %v", err)
+		}
+	}
+	if cfg.Cmdline != "" && cfg.Kernel == "" {
+		return nil, fmt.Errorf("config param qemu.cmdline requires qemu.kernel (the image boots with its own command line)")
+	}
+	if cfg.Icount != "" && cfg.Icount != "auto" {
+		if shift, err := strconv.Atoi(cfg.Icount); err != nil || shift < 0 || shift > 10 {
+			return nil, fmt.Errorf("bad config param qemu.icount: %q, want auto or 0-10", cfg.Icount)
+		}
+	}
+	for name, file := range map[string]string{"kernel": cfg.Kernel, "initrd": cfg.Initrd, "image": cfg.Image, "sshkey": cfg.Sshkey} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("bad config param qemu.%v: %v", name, err)
+		}
 	}
-	if _, err := os.Stat(cfg.Sshkey); err != nil {
-		return fmt.Errorf("ssh key '%v' does not exist: %v", cfg.Sshkey, err)
+	// ssh silently ignores keys that are accessible by others.
+	if st, err := os.Stat(cfg.Sshkey); err == nil && st.Mode().Perm()&077 != 0 {
+		return nil, fmt.Errorf("bad config param qemu.sshkey: %v is accessible by others (mode %o), ssh will refuse to use it",
+			cfg.Sshkey, st.Mode().Perm())
 	}
 	if cfg.Cpu <= 0 || cfg.Cpu > 1024 {
-		return fmt.Errorf("bad qemu cpu: %v, want [1-1024]", cfg.Cpu)
+		return nil, fmt.Errorf("invalid config param qemu.cpu: %v, want [1-1024]", cfg.Cpu)
 	}
 	if cfg.Mem < 128 || cfg.Mem > 1048576 {
-		return fmt.Errorf("bad qemu mem: %v, want [128-1048576]", cfg.Mem)
+		return nil, fmt.Errorf("invalid config param qemu.mem: %v, want [128-1048576]", cfg.Mem)
 	}
-	return nil
+	return cfg, nil
 }
 
 func (inst *instance) Close() {
@@ -119,46 +182,48 @@ func (inst *instance) Close() {
 	os.RemoveAll(inst.cfg.Workdir)
 }
 
-func (inst *instance) Boot() error {
-	for {
-		// Find an unused TCP port.
-		inst.port = rand.Intn(64<<10-1<<10) + 1<<10
-		ln, err := net.Listen("tcp", fmt.Sprintf("localhost:%v", inst.port))
-		if err == nil {
-			ln.Close()
-			break
-		}
-	}
-	// TODO: ignores inst.cfg.Cpu
+// Boot starts qemu and waits for the ssh server, qemu is killed by Close if ctx is canceled.
+func (inst *instance) Boot(ctx context.Context) error {
+	inst.port = unusedPort()
+	// TODO: ignores inst.params.Cpu
 	args := []string{
-		"-hda", inst.cfg.Image,
+		"-hda", inst.params.Image,
 		"-snapshot",
-		"-m", strconv.Itoa(inst.cfg.Mem),
+		"-m", strconv.Itoa(inst.params.Mem),
 		"-net", "nic",
 		"-net", fmt.Sprintf("user,host=%v,hostfwd=tcp::%v-:22", hostAddr, inst.port),
 		"-nographic",
-		"-enable-kvm",
 		"-numa", "node,nodeid=0,cpus=0-1", "-numa", "node,nodeid=1,cpus=2-3",
 		"-smp", "sockets=2,cores=2,threads=1",
 		"-usb", "-usbdevice", "mouse", "-usbdevice", "tablet",
 		"-soundhw", "all",
 	}
-	if inst.cfg.Initrd != "" {
+	if inst.params.Gdb != "" {
+		inst.gdbPort = unusedPort()
+		args = append(args, "-gdb", fmt.Sprintf("tcp:localhost:%v", inst.gdbPort))
+	}
+	if inst.params.Icount != "" {
+		args = append(args, "-icount", "shift="+inst.params.Icount+",sleep=off")
+	} else {
+		args = append(args, "-enable-kvm")
+	}
+	if inst.params.Initrd != "" {
 		args = append(args,
-			"-initrd", inst.cfg.Initrd,
+			"-initrd", inst.params.Initrd,
 		)
 	}
-	if inst.cfg.Kernel != "" {
+	if inst.params.Kernel != "" {
 		args = append(args,
-			"-kernel", inst.cfg.Kernel,
-			"-append", "console=ttyS0 root=/dev/sda debug earlyprintk=serial slub_debug=UZ "+inst.cfg.Cmdline,
+			"-kernel", inst.params.Kernel,
+			"-append", "console=ttyS0 root=/dev/sda debug earlyprintk=serial slub_debug=UZ "+
+				inst.cfg.Cmdline+" "+inst.params.Cmdline,
 		)
 	}
-	qemu := exec.Command(inst.cfg.Bin, args...)
+	qemu := exec.Command(inst.params.Bin, args...)
 	qemu.Stdout = inst.wpipe
 	qemu.Stderr = inst.wpipe
 	if err := qemu.Start(); err != nil {
-		return fmt.Errorf("failed to start %v %+v: %v", inst.cfg.Bin, args, err)
+		return fmt.Errorf("failed to start %v %+v: %v", inst.params.Bin, args, err)
 	}
 	inst.qemu = qemu
 	// Qemu has started.
@@ -204,10 +269,13 @@ func (inst *instance) Boot() error {
 	}()
 
 	// Wait for ssh server to come up.
-	time.Sleep(10 * time.Second)
+	if err := vm.Sleep(ctx, 10*time.Second); err != nil {
+		return err
+	}
 	start := time.Now()
+	dialer := &net.Dialer{Timeout: 3 * time.Second}
 	for {
-		c, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%v", inst.port), 3*time.Second)
+		c, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("localhost:%v", inst.port))
 		if err == nil {
 			c.SetDeadline(time.Now().Add(3 * time.Second))
 			var tmp [1]byte
@@ -216,7 +284,12 @@ func (inst *instance) Boot() error {
 			if err == nil && n > 0 {
 				break // ssh is up and responding
 			}
-			time.Sleep(3 * time.Second)
+			if err := vm.Sleep(ctx, 3*time.Second); err != nil {
+				return err
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 		select {
 		case err := <-inst.waiterC:
@@ -243,33 +316,78 @@ func (inst *instance) Boot() error {
 }
 
 func (inst *instance) Forward(port int) (string, error) {
-	return fmt.Sprintf("%v:%v", hostAddr, port), nil
+	if !inst.params.Tunnel {
+		return fmt.Sprintf("%v:%v", hostAddr, port), nil
+	}
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	vmPort := tunnelPort + len(inst.tunnels)
+	inst.tunnels = append(inst.tunnels, fmt.Sprintf("%v:127.0.0.1:%v", vmPort, port))
+	return fmt.Sprintf("127.0.0.1:%v", vmPort), nil
 }
 
-func (inst *instance) Copy(hostSrc string) (string, error) {
+func (inst *instance) Copy(ctx context.Context, hostSrc string) (string, error) {
 	vmDst := filepath.Join("/", filepath.Base(hostSrc))
-	args := append(inst.sshArgs("-P"), hostSrc, "root@localhost:"+vmDst)
-	cmd := exec.Command("scp", args...)
-	if err := cmd.Start(); err != nil {
-		return "", err
-	}
-	done := make(chan bool)
-	go func() {
-		select {
-		case <-time.After(time.Minute):
-			cmd.Process.Kill()
-		case <-done:
+	args := append(inst.sshArgs("-P"), hostSrc, inst.params.User+"@localhost:"+vmDst)
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if err := exec.CommandContext(ctx, "scp", args...).Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
 		}
-	}()
-	err := cmd.Wait()
-	close(done)
-	if err != nil {
 		return "", err
 	}
 	return vmDst, nil
 }
 
-func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
+func unusedPort() int {
+	for {
+		port := rand.Intn(64<<10-1<<10) + 1<<10
+		ln, err := net.Listen("tcp", fmt.Sprintf("localhost:%v", port))
+		if err == nil {
+			ln.Close()
+			return port
+		}
+	}
+}
+
+// DumpState attaches gdb to qemu gdbstub (every vCPU is a thread) and detaches after dumping the CPUs.
+func (inst *instance) DumpState(ctx context.Context, vmlinux string) ([]byte, error) {
+	if inst.params.Gdb == "" {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	args := []string{"-batch", "-nx",
+		"-ex", "set pagination off",
+		"-ex", fmt.Sprintf("target remote localhost:%v", inst.gdbPort),
+		"-ex", "info threads",
+		"-ex", "thread apply all info registers",
+		"-ex", "thread apply all bt 64",
+		"-ex", "detach",
+	}
+	if _, err := os.Stat(vmlinux); vmlinux != "" && err == nil {
+		args = append(args, vmlinux)
+	}
+	out, err := exec.CommandContext(ctx, inst.params.Gdb, args...).CombinedOutput()
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return out, err
+}
+
+func (inst *instance) CopyFrom(ctx context.Context, vmSrc, hostDst string) error {
+	args := append(inst.sshArgs("-P"), inst.params.User+"@localhost:"+vmSrc, hostDst)
+	if out, err := exec.CommandContext(ctx, "scp", args...).CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to copy %v: %v\n%s", vmSrc, err, out)
+	}
+	return nil
+}
+
+func (inst *instance) Run(ctx context.Context, timeout time.Duration, command string) (<-chan []byte, <-chan error, error) {
 	outputC := make(chan []byte, 10)
 	errorC := make(chan error, 1)
 	inst.mu.Lock()
@@ -289,7 +407,17 @@ func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte,
 		default:
 		}
 	}
-	args := append(inst.sshArgs("-p"), "root@localhost", command)
+	args := inst.sshArgs("-p")
+	inst.mu.Lock()
+	if len(inst.tunnels) != 0 {
+		// Don't run the command if the tunnel can't be established, it won't be able to connect anyway.
+		args = append(args, "-o", "ExitOnForwardFailure=yes")
+	}
+	for _, tunnel := range inst.tunnels {
+		args = append(args, "-R", tunnel)
+	}
+	inst.mu.Unlock()
+	args = append(args, inst.params.User+"@localhost", command)
 	cmd := exec.Command("ssh", args...)
 	cmd.Stdout = inst.wpipe
 	cmd.Stderr = inst.wpipe
@@ -301,10 +429,15 @@ func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte,
 	}
 	done := make(chan bool)
 	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
 		select {
-		case <-time.After(timeout):
+		case <-timer.C:
 			signal(vm.TimeoutErr)
 			cmd.Process.Kill()
+		case <-ctx.Done():
+			signal(ctx.Err())
+			cmd.Process.Kill()
 		case <-done:
 		}
 	}()
@@ -318,7 +451,7 @@ func (inst *instance) Run(timeout time.Duration, command string) (<-chan []byte,
 
 func (inst *instance) sshArgs(portArg string) []string {
 	return []string{
-		"-i", inst.cfg.Sshkey,
+		"-i", inst.params.Sshkey,
 		portArg, strconv.Itoa(inst.port),
 		"-o", "ConnectionAttempts=10",
 		"-o", "ConnectTimeout=10",
@@ -5,67 +5,161 @@ package vm
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 )
 
 // Instance represents a Linux VM or a remote physical machine.
+// Cancellation of ctx passed to Create, Copy and Run aborts the operation promptly
+// (child processes like qemu, ssh/scp and adb are killed), the instance still needs to be closed.
 type Instance interface {
 	// Copy copies a hostSrc file into vm and returns file name in vm.
-	Copy(hostSrc string) (string, error)
+	Copy(ctx context.Context, hostSrc string) (string, error)
 
 	// Forward setups forwarding from within VM to host port port
-	// and returns address to use in VM.
+	// and returns address to use in VM. Depending on the backend and config
+	// (e.g. qemu tunnel param) this is either a direct address of the host,
+	// or an in-VM address of a reverse tunnel over the control channel (ssh/adb).
+	// In the latter case the tunnel is only active while a command started by Run is running.
 	Forward(port int) (string, error)
 
 	// Run runs cmd inside of the VM (think of ssh cmd).
 	// outc receives combined cmd and kernel console output.
-	// errc receives either command Wait return error or vm.TimeoutErr.
-	Run(timeout time.Duration, command string) (outc <-chan []byte, errc <-chan error, err error)
+	// errc receives either command Wait return error, vm.TimeoutErr or ctx.Err() if ctx is canceled
+	// (the command is killed in the latter two cases).
+	Run(ctx context.Context, timeout time.Duration, command string) (outc <-chan []byte, errc <-chan error, err error)
 
 	// Close stops and destroys the VM.
 	Close()
 }
 
+// Fetcher is implemented by instances that can copy files from the VM to the host
+// (e.g. to retrieve kernel crash dumps).
+type Fetcher interface {
+	// CopyFrom copies vmSrc file in the VM to hostDst file.
+	CopyFrom(ctx context.Context, vmSrc, hostDst string) error
+}
+
+// StateDumper is implemented by instances that can dump CPU state of the machine from the outside
+// (e.g. over qemu gdbstub), which works even when the kernel is wedged and does not print anything.
+type StateDumper interface {
+	// DumpState returns registers and backtraces of all CPUs, vmlinux is used for symbols if it exists.
+	// It returns nil output and error if the instance is not configured to dump state.
+	DumpState(ctx context.Context, vmlinux string) ([]byte, error)
+}
+
 type Config struct {
-	Name       string
-	Index      int
-	Workdir    string
-	Bin        string
-	Initrd     string
-	Kernel     string
-	Cmdline    string
-	Image      string
-	Sshkey     string
-	Executor   string
-	ConsoleDev string
-	Cpu        int
-	Mem        int
-	Debug      bool
+	Name     string
+	Index    int
+	Workdir  string
+	Executor string
+	OS       string // OS of the machine (linux, gvisor)
+	Cmdline  string
+	Debug    bool
+	Params   json.RawMessage // backend-specific config section (e.g. "qemu": {...}), parsed by the backend
+}
+
+type ctorFunc func(ctx context.Context, cfg *Config) (Instance, error)
+
+// validateFunc checks backend-specific params upfront, before any instances are created.
+type validateFunc func(params json.RawMessage) error
+
+type backend struct {
+	ctor     ctorFunc
+	validate validateFunc
 }
 
-type ctorFunc func(cfg *Config) (Instance, error)
+var backends = make(map[string]backend)
 
-var ctors = make(map[string]ctorFunc)
+// Register registers a VM backend. validate can be nil if the backend does not have any params.
+func Register(typ string, ctor ctorFunc, validate validateFunc) {
+	backends[typ] = backend{ctor, validate}
+}
 
-func Register(typ string, ctor ctorFunc) {
-	ctors[typ] = ctor
+// Types returns sorted names of all registered backends.
+func Types() []string {
+	var types []string
+	for typ := range backends {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Validate checks backend-specific params for VM type typ.
+func Validate(typ string, params json.RawMessage) error {
+	b, ok := backends[typ]
+	if !ok {
+		return fmt.Errorf("unknown instance type '%v'", typ)
+	}
+	if b.validate == nil {
+		if len(params) != 0 {
+			return fmt.Errorf("type %v does not have config params", typ)
+		}
+		return nil
+	}
+	return b.validate(params)
 }
 
 // Create creates and boots a new VM instance.
-func Create(typ string, cfg *Config) (Instance, error) {
-	ctor := ctors[typ]
-	if ctor == nil {
+// If ctx is canceled while the instance boots, Create returns ctx.Err().
+func Create(ctx context.Context, typ string, cfg *Config) (Instance, error) {
+	b, ok := backends[typ]
+	if !ok {
 		return nil, fmt.Errorf("unknown instance type '%v'", typ)
 	}
-	return ctor(cfg)
+	return b.ctor(ctx, cfg)
+}
+
+// Sleep sleeps for d, it returns ctx.Err() if ctx is canceled before that.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ParseParams parses backend-specific params of VM type typ into struct v.
+// Unknown params are rejected, so that typos and params of other backends are detected.
+func ParseParams(typ string, params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return nil
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return fmt.Errorf("failed to parse %v config: %v", typ, err)
+	}
+	t := reflect.TypeOf(v).Elem()
+	for name := range fields {
+		if _, ok := t.FieldByNameFunc(func(field string) bool { return strings.EqualFold(field, name) }); !ok {
+			return fmt.Errorf("unknown config param %v.%v", typ, name)
+		}
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return fmt.Errorf("failed to parse %v config: %v", typ, err)
+	}
+	return nil
 }
 
-// FindCrash searches kernel console output for oops messages.
-// Desc contains a more-or-less representative description of the first oops,
-// start and end denote region of output with oops message(s).
-func FindCrash(output []byte) (desc string, start int, end int, found bool) {
+// FindCrash searches kernel console output of the given OS for oops messages.
+// Desc contains a more-or-less representative description of the first oops
+// (normalized with NormalizeDesc), start and end denote region of output with oops message(s).
+// Linux hangs are described by the blocked function (see hangDesc) if their stack trace is already in output,
+// UBSAN and KMSAN reports by the function with the bug (see sanitizerDesc). Reports enclosed in
+// separator lines (KASAN, UBSAN, KMSAN, KCSAN) include the separators.
+func FindCrash(os string, output []byte) (desc string, start int, end int, found bool) {
+	closing := -1 // end of the first report line if the report is enclosed in separators
 	for pos := 0; pos < len(output); {
 		next := bytes.IndexByte(output[pos:], '\n')
 		if next != -1 {
@@ -73,7 +167,7 @@ func FindCrash(output []byte) (desc string, start int, end int, found bool) {
 		} else {
 			next = len(output)
 		}
-		for _, oops := range oopses {
+		for _, oops := range oopses[os] {
 			match := bytes.Index(output[pos:next], oops)
 			if match == -1 {
 				continue
@@ -85,27 +179,329 @@ func FindCrash(output []byte) (desc string, start int, end int, found bool) {
 				if desc[len(desc)-1] == '\r' {
 					desc = desc[:len(desc)-1]
 				}
+				desc = NormalizeDesc(os, canonicalDataRace(desc))
+				if os == "linux" {
+					desc = hangDesc(desc, output[next:])
+					desc = sanitizerDesc(desc, output[next:])
+					if prev := bytes.LastIndexByte(output[:pos], '\n'); prev != -1 {
+						prev = bytes.LastIndexByte(output[:prev], '\n') + 1
+						if separatorRe.Match(output[prev : pos-1]) {
+							start = prev
+							closing = next
+						}
+					}
+				}
 			}
 			end = next
 		}
 		pos = next + 1
 	}
+	if closing != -1 {
+		// The closing separator is printed after the stack traces of the report.
+		for pos := closing + 1; pos < len(output); {
+			next := bytes.IndexByte(output[pos:], '\n')
+			if next != -1 {
+				next += pos
+			} else {
+				next = len(output)
+			}
+			if separatorRe.Match(output[pos:next]) {
+				if next > end {
+					end = next
+				}
+				break
+			}
+			pos = next + 1
+		}
+	}
 	return
 }
 
 var (
-	oopses = [][]byte{
-		[]byte("Kernel panic"),
-		[]byte("BUG:"),
-		[]byte("kernel BUG"),
-		[]byte("WARNING:"),
-		[]byte("INFO:"),
-		[]byte("unable to handle"),
-		[]byte("Unable to handle kernel"),
-		[]byte("general protection fault"),
-		[]byte("UBSAN:"),
-		[]byte("unreferenced object"),
+	oopses = map[string][][]byte{
+		"linux": {
+			[]byte("Kernel panic"),
+			[]byte("BUG:"),
+			[]byte("kernel BUG"),
+			[]byte("WARNING:"),
+			[]byte("INFO:"),
+			[]byte("unable to handle"),
+			[]byte("Unable to handle kernel"),
+			[]byte("general protection fault"),
+			[]byte("UBSAN:"),
+			[]byte("unreferenced object"),
+		},
+		// Sentry is written in Go.
+		"gvisor": {
+			[]byte("panic:"),
+			[]byte("fatal error:"),
+			[]byte("WARNING: DATA RACE"),
+		},
+	}
+
+	// The first messages of booting kernels (after the optional printk timestamp).
+	bootBanners = map[string]*regexp.Regexp{
+		"linux": regexp.MustCompile(`(?m)^(\[ *[0-9]+\.[0-9]+\] )?(Linux version [0-9]|Booting Linux on physical CPU)`),
 	}
 
+	// Sanitizer reports are enclosed in lines of '=' (possibly with printk timestamps).
+	separatorRe = regexp.MustCompile(`^(\[[^\]]*\] )?={20,}\r?$`)
+
+	// UBSAN reports: "UBSAN: shift-out-of-bounds in net/core/filter.c:123:4", old kernels print
+	// "UBSAN: Undefined behaviour in kernel/time/hrtimer.c:310:16" and the kind of the error on the next line.
+	ubsanRe    = regexp.MustCompile(`^UBSAN: (.+) in ([^ ]+?)(:[0-9]+)*$`)
+	ubsanKinds = []struct {
+		re   *regexp.Regexp
+		kind string
+	}{
+		{regexp.MustCompile(`shift exponent|left shift of`), "shift-out-of-bounds"},
+		{regexp.MustCompile(`index .* is out of range`), "array-index-out-of-bounds"},
+		{regexp.MustCompile(`unsigned integer overflow`), "unsigned-integer-overflow"},
+		{regexp.MustCompile(`signed integer overflow`), "signed-integer-overflow"},
+		{regexp.MustCompile(`negation of`), "negation-overflow"},
+		{regexp.MustCompile(`division (of|by zero)`), "division-overflow"},
+		{regexp.MustCompile(`load of value`), "invalid-load"},
+		{regexp.MustCompile(`null pointer`), "null-ptr-deref"},
+		{regexp.MustCompile(`misaligned address`), "misaligned-access"},
+		{regexp.MustCompile(`insufficient space|object size`), "object-size-mismatch"},
+		{regexp.MustCompile(`__builtin_unreachable`), "unreachable"},
+	}
+	// KMSAN reports: "BUG: KMSAN: uninit-value in foo+0x12/0x30", old kernels print "use of uninitialized memory".
+	kmsanRe = regexp.MustCompile(`^BUG: KMSAN: ([a-z -]+?) in ([^ ]+)`)
+	// Functions of the sanitizer runtimes and of the reporting machinery, and functions that only copy
+	// uninit memory to the user (KMSAN reports the check in copy_to_user as the place of an infoleak).
+	sanitizerGenericFuncRe = regexp.MustCompile(`^_*(dump_stack|show_stack|ubsan_|handle_overflow|` +
+		`handle_null_ptr_deref|handle_misaligned_access|handle_object_size_mismatch|kmsan_|msan_|` +
+		`instrument_|_?copy_to_user|copyout|copy_to_iter|_copy_to_iter|copy_page_to_iter|` +
+		`simple_read_from_buffer|print_|panic|check_panic_on_warn|report_)`)
+
+	// KCSAN reports look like "BUG: KCSAN: data-race in foo+0x12/0x30 / bar+0x45/0x60".
+	dataRaceRe = regexp.MustCompile(`^BUG: KCSAN: data-race in ([^ ]+) / ([^ ]+)`)
+	funcOffRe  = regexp.MustCompile(`\+0x[0-9a-f]+/0x[0-9a-f]+$`)
+
+	// Parts of crash descriptions that differ between crashes of the same bug and their replacements.
+	descNormalizers = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		// Function offsets: "in foo+0x123/0x456".
+		{regexp.MustCompile(`\+0x[0-9a-f]+/0x[0-9a-f]+`), ""},
+		// Current CPU and task of WARNINGs: "WARNING: CPU: 2 PID: 2636 at ipc/shm.c:162".
+		{regexp.MustCompile(`CPU: [0-9]+ PID: [0-9]+ `), ""},
+		// Task names and PIDs: "INFO: task syz-executor:1234 blocked", "by task a.out/6260".
+		{regexp.MustCompile(`task [^ ]+[:/][0-9]+`), "task"},
+		{regexp.MustCompile(`\b(pid|PID):? [0-9]+`), "$1 PID"},
+		// Kernel addresses: "at addr ffff88002db3cf50", "unreferenced object 0xffff880039a55260".
+		{regexp.MustCompile(`\b0x[0-9a-fA-F]{6,}\b`), "ADDR"},
+	}
+	// Addresses without 0x prefix, words like "deadbeef" are not addresses unless they have digits.
+	bareAddrRe = regexp.MustCompile(`\b[0-9a-f]{8,16}\b`)
+
+	// Hang reports, the title of the refined description and the regexp that matches both descriptions.
+	hangs = []struct {
+		re    *regexp.Regexp
+		title string
+	}{
+		{regexp.MustCompile(`^INFO: task (hung in |.*blocked for more than)`), "INFO: task hung in "},
+		{regexp.MustCompile(`^INFO: rcu(_[a-z]+)? (self-)?detected (expedited )?stalls?`), "INFO: rcu detected stall in "},
+		{regexp.MustCompile(`^(BUG|watchdog: BUG): soft lockup`), "BUG: soft lockup in "},
+	}
+	// Stack frames: " schedule_timeout+0x1c5/0x2a0 kernel/time/timer.c:1790", "RIP: 0010:foo+0x12/0x30"
+	// and inlined frames of symbolized traces: " __mutex_lock_common kernel/locking/mutex.c:756 [inline]".
+	frameRe = regexp.MustCompile(`(?:RIP: [0-9a-f]{4}:|[ \]])([a-zA-Z0-9_.]+)(?:\+0x[0-9a-f]+/0x[0-9a-f]+| [^ ]+:[0-9]+ \[inline\])`)
+	// Functions that are in stacks of all hangs: scheduling and waiting primitives, locking,
+	// and the watchdog/NMI/interrupt machinery that prints the report.
+	hangGenericFuncRe = regexp.MustCompile(`^_*(schedule|preempt_schedule|io_schedule|context_switch|` +
+		`finish_task_switch|mutex_lock|rwsem_down|down(_read|_write|_interruptible|_killable|_timeout)?$|` +
+		`wait_for_completion|wait_on_bit|out_of_line_wait_on_bit|bit_wait|prepare_to_wait|finish_wait|` +
+		`rcu_|synchronize_rcu|print_|dump_stack|show_stack|sched_show_task|watchdog|khungtaskd|check_hung|` +
+		`hung_task|nmi_|arch_trigger|trigger_|local_apic|apic_|smp_apic|sysvec_|asm_|irq_|do_IRQ|` +
+		`common_interrupt|do_softirq|hrtimer|tick_|update_process_times|native_|raw_spin|do_raw_spin|` +
+		`queued_spin|lock_acquire|lock_release|sanitizer_cov|kthread|ret_from_fork|entry_SYSCALL|` +
+		`do_syscall_64|default_idle|do_idle|cpu_startup_entry|start_secondary)`)
+
 	TimeoutErr = errors.New("timeout")
 )
+
+// Max number of lines after a report that are searched for the stack trace.
+const traceLines = 100
+
+// FindReboot searches kernel console output of the given OS for messages of a booting kernel
+// and returns offset of the first one. It is used to detect machines that rebooted without printing
+// a recognizable oops (e.g. hard panics that were not flushed to the console).
+func FindReboot(os string, output []byte) (pos int, found bool) {
+	re := bootBanners[os]
+	if re == nil {
+		return 0, false
+	}
+	loc := re.FindIndex(output)
+	if loc == nil {
+		return 0, false
+	}
+	return loc[0], true
+}
+
+// NormalizeDesc strips parts of crash description desc that differ between crashes of the same bug
+// (function offsets, addresses, task names and PIDs), so that the same bug has the same description.
+// NormalizeDesc is idempotent.
+func NormalizeDesc(os, desc string) string {
+	for _, n := range descNormalizers {
+		desc = n.re.ReplaceAllString(desc, n.repl)
+	}
+	desc = bareAddrRe.ReplaceAllStringFunc(desc, func(s string) string {
+		if strings.IndexAny(s, "0123456789") == -1 {
+			return s
+		}
+		return "ADDR"
+	})
+	return strings.TrimSpace(desc)
+}
+
+// Crash classes are broad kinds of crashes that can be ignored as a whole (see Ignores config param),
+// they are matched against crash descriptions. A crash can belong to several classes
+// (e.g. "WARNING: possible circular locking dependency detected" is a warning and a lockdep report).
+var crashClasses = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"warning", regexp.MustCompile(`^WARNING:`)},
+	{"hung-task", hangs[0].re},
+	{"rcu-stall", hangs[1].re},
+	{"soft-lockup", hangs[2].re},
+	{"lockdep", regexp.MustCompile(`^(INFO|WARNING): (possible|inconsistent lock state|suspicious RCU usage)`)},
+	{"leak", regexp.MustCompile(`^(unreferenced object|BUG: memory leak)`)},
+	{"data-race", regexp.MustCompile(`^BUG: KCSAN: data-race`)},
+	{"ubsan", regexp.MustCompile(`^UBSAN:`)},
+	{"kmsan", regexp.MustCompile(`^BUG: KMSAN:`)},
+}
+
+// CrashClasses returns names of all crash classes.
+func CrashClasses() []string {
+	var names []string
+	for _, c := range crashClasses {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// InCrashClass returns true if crash with description desc (as returned by FindCrash) belongs to class.
+func InCrashClass(class, desc string) bool {
+	for _, c := range crashClasses {
+		if c.name == class {
+			return c.re.MatchString(desc)
+		}
+	}
+	return false
+}
+
+// IsHang returns true if desc (as returned by FindCrash) describes a hung task, an RCU stall or a soft lockup.
+func IsHang(desc string) bool {
+	for _, h := range hangs {
+		if h.re.MatchString(desc) {
+			return true
+		}
+	}
+	return false
+}
+
+// hangDesc refines description desc of a hang report to name the blocked function, the first function
+// in the stack trace in trace (output after the report line) that is not a generic scheduling, locking
+// or interrupt function, e.g. "INFO: task hung in lo_release". All hangs have the same description otherwise
+// (e.g. "INFO: task blocked for more than 120 seconds."), while the blocked function identifies the bug.
+// Desc is returned intact if it is not a hang or the trace is not printed yet.
+func hangDesc(desc string, trace []byte) string {
+	title := ""
+	for _, h := range hangs {
+		if h.re.MatchString(desc) {
+			title = h.title
+		}
+	}
+	if title == "" || strings.HasPrefix(desc, title) {
+		return desc
+	}
+	if fn := traceFunc(trace, hangGenericFuncRe); fn != "" {
+		return title + fn
+	}
+	return desc
+}
+
+// traceFunc returns the first function in the stack trace in trace that does not match generic.
+// The trace ends at the closing separator of the report.
+func traceFunc(trace []byte, generic *regexp.Regexp) string {
+	for i, ln := range bytes.SplitN(trace, []byte{'\n'}, traceLines+1) {
+		if i == traceLines || i != 0 && separatorRe.Match(ln) {
+			break
+		}
+		// Frames marked with "?" are stale stack contents.
+		if bytes.Contains(ln, []byte(" ? ")) {
+			continue
+		}
+		match := frameRe.FindSubmatch(ln)
+		if match == nil || generic.Match(match[1]) {
+			continue
+		}
+		return string(match[1])
+	}
+	return ""
+}
+
+// sanitizerDesc refines description desc of UBSAN and KMSAN reports, so that it has the kind of the error
+// and the function with the bug from the stack trace in trace (output after the report line),
+// e.g. "UBSAN: shift-out-of-bounds in tcp_init_sock" or "BUG: KMSAN: kernel-infoleak in sctp_getsockopt".
+// UBSAN reports name the source line otherwise, which changes with every kernel build,
+// and KMSAN infoleaks name the copy_to_user check. Other descriptions are returned intact.
+func sanitizerDesc(desc string, trace []byte) string {
+	if match := ubsanRe.FindStringSubmatch(desc); match != nil {
+		kind := match[1]
+		if kind == "Undefined behaviour" {
+			kind = "undefined-behaviour"
+			lines := bytes.SplitN(trace, []byte{'\n'}, 4)
+			if len(lines) == 4 {
+				lines = lines[:3]
+			}
+			for _, k := range ubsanKinds {
+				if k.re.Match(bytes.Join(lines, nil)) {
+					kind = k.kind
+					break
+				}
+			}
+		}
+		where := match[2]
+		if fn := traceFunc(trace, sanitizerGenericFuncRe); fn != "" {
+			where = fn
+		}
+		return "UBSAN: " + kind + " in " + where
+	}
+	if match := kmsanRe.FindStringSubmatch(desc); match != nil {
+		kind := match[1]
+		if kind == "use of uninitialized memory" {
+			kind = "uninit-value"
+		}
+		where := match[2]
+		if sanitizerGenericFuncRe.MatchString(where) {
+			if fn := traceFunc(trace, sanitizerGenericFuncRe); fn != "" {
+				where = fn
+			}
+		}
+		return "BUG: KMSAN: " + kind + " in " + where
+	}
+	return desc
+}
+
+// IsDataRace returns true if desc (as returned by FindCrash) describes a KCSAN data race.
+func IsDataRace(desc string) bool {
+	return strings.HasPrefix(desc, "BUG: KCSAN: data-race in ")
+}
+
+// canonicalDataRace strips function offsets from KCSAN data race descriptions
+// and orders the racing functions, so that the same race is described identically
+// regardless of code layout and of which access was observed first.
+func canonicalDataRace(desc string) string {
+	match := dataRaceRe.FindStringSubmatch(desc)
+	if match == nil {
+		return desc
+	}
+	funcs := []string{funcOffRe.ReplaceAllString(match[1], ""), funcOffRe.ReplaceAllString(match[2], "")}
+	sort.Strings(funcs)
+	return fmt.Sprintf("BUG: KCSAN: data-race in %v / %v", funcs[0], funcs[1])
+}
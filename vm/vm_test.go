@@ -14,28 +14,79 @@ func TestFindCrash(t *testing.T) {
 [   50.583499] something 
 [   50.583499] BUG: unable to handle kernel paging request at 00000000ffffff8a
 [   50.583499] IP: [<     inline     >] list_del include/linux/list.h:107 
-`: "BUG: unable to handle kernel paging request at 00000000ffffff8a",
+`: "BUG: unable to handle kernel paging request at ADDR",
 		`
 [   50.583499] something
 [   50.583499] INFO: rcu_sched self-detected stall on CPU
 [   50.583499]         0: (20822 ticks this GP) idle=94b/140000000000001/0
 `: "INFO: rcu_sched self-detected stall on CPU",
 		`
+[  246.451216] INFO: task syz-executor3:10432 blocked for more than 120 seconds.
+[  246.451223]       Not tainted 4.15.0-rc3+ #224
+[  246.451236] "echo 0 > /proc/sys/kernel/hung_task_timeout_secs" disables this message.
+[  246.451244] syz-executor3   D23712 10432   3475 0x00000004
+[  246.451252] Call Trace:
+[  246.451267]  context_switch kernel/sched/core.c:2800 [inline]
+[  246.451274]  __schedule+0x8eb/0x2060 kernel/sched/core.c:3376
+[  246.451290]  ? __sched_text_start+0x8/0x8
+[  246.451312]  schedule+0xf5/0x430 kernel/sched/core.c:3435
+[  246.451336]  schedule_preempt_disabled+0x10/0x20 kernel/sched/core.c:3493
+[  246.451343]  __mutex_lock_common kernel/locking/mutex.c:833 [inline]
+[  246.451350]  __mutex_lock+0xaad/0x1a80 kernel/locking/mutex.c:893
+[  246.451430]  mutex_lock_nested+0x16/0x20 kernel/locking/mutex.c:908
+[  246.451437]  lo_ioctl+0x8b/0x1b70 drivers/block/loop.c:1355
+[  246.451500]  __blkdev_driver_ioctl block/ioctl.c:303 [inline]
+`: "INFO: task hung in lo_ioctl",
+		`
+[  366.351013] INFO: rcu_sched self-detected stall on CPU
+[  366.356306] 	1-...: (124999 ticks this GP) idle=a3a/140000000000001/0 softirq=10297/10297 fqs=31221
+[  366.366008] NMI backtrace for cpu 1
+[  366.378005] Call Trace:
+[  366.380568]  <IRQ>
+[  366.382706]  dump_stack+0x194/0x257
+[  366.386324]  nmi_cpu_backtrace+0x1d2/0x210
+[  366.396042]  nmi_trigger_cpumask_backtrace+0x123/0x180
+[  366.401302]  arch_trigger_cpumask_backtrace+0x14/0x20
+[  366.406471]  rcu_dump_cpu_stacks+0x186/0x1d0
+[  366.410858]  rcu_check_callbacks+0x1c62/0x1e10
+[  366.434536]  update_process_times+0x30/0x60
+[  366.438840]  tick_sched_handle+0x85/0x160
+[  366.442968]  tick_sched_timer+0x42/0x120
+[  366.447013]  __hrtimer_run_queues+0x358/0xe20
+[  366.460217]  hrtimer_interrupt+0x1c2/0x5e0
+[  366.464433]  smp_apic_timer_interrupt+0x14a/0x700
+[  366.482087]  apic_timer_interrupt+0xa9/0xb0
+[  366.486361]  </IRQ>
+[  366.488572] RIP: 0010:__sanitizer_cov_trace_pc+0x0/0x50
+[  366.529232]  pipe_read+0x175/0x8c0 fs/pipe.c:287
+[  366.533451]  __vfs_read+0x2f7/0x560 fs/read_write.c:411
+`: "INFO: rcu detected stall in pipe_read",
+		`
+[  122.460013] watchdog: BUG: soft lockup - CPU#0 stuck for 22s! [syz-executor0:4051]
+[  122.467681] Modules linked in:
+[  122.470856] CPU: 0 PID: 4051 Comm: syz-executor0 Not tainted 4.15.0-rc8+ #263
+[  122.486974] RIP: 0010:queued_spin_lock_slowpath+0x1a/0x4f0
+[  122.514536] Call Trace:
+[  122.517109]  do_raw_spin_lock+0x1e4/0x250
+[  122.521247]  _raw_spin_lock+0x32/0x40
+[  122.525004]  tun_chr_poll+0x88/0x3c0 drivers/net/tun.c:2115
+`: "BUG: soft lockup in tun_chr_poll",
+		`
 [   50.583499] general protection fault: 0000 [#1] SMP KASAN
 [   50.583499] Modules linked in: 
 `: "general protection fault: 0000 [#1] SMP KASAN",
 		`
 [   50.583499] BUG: unable to handle kernel NULL pointer dereference at 000000000000003a
 [   50.583499] Modules linked in: 
-`: "BUG: unable to handle kernel NULL pointer dereference at 000000000000003a",
+`: "BUG: unable to handle kernel NULL pointer dereference at ADDR",
 		`
 [   50.583499] WARNING: CPU: 2 PID: 2636 at ipc/shm.c:162 shm_open+0x74/0x80()
 [   50.583499] Modules linked in: 
-`: "WARNING: CPU: 2 PID: 2636 at ipc/shm.c:162 shm_open+0x74/0x80()",
+`: "WARNING: at ipc/shm.c:162 shm_open()",
 		`
 [   50.583499] BUG: KASAN: use after free in remove_wait_queue+0xfb/0x120 at addr ffff88002db3cf50
 [   50.583499] Write of size 8 by task syzkaller_execu/10568 
-`: "BUG: KASAN: use after free in remove_wait_queue+0xfb/0x120 at addr ffff88002db3cf50",
+`: "BUG: KASAN: use after free in remove_wait_queue at addr ADDR",
 		`
 BUG UNIX (Not tainted): kasan: bad access detected
 `: "",
@@ -46,20 +97,70 @@ BUG UNIX (Not tainted): kasan: bad access detected
 		`
 BUG: unable to handle kernel paging request at 00000000ffffff8a
 IP: [<ffffffff810a376f>] __call_rcu.constprop.76+0x1f/0x280 kernel/rcu/tree.c:3046
-`: "BUG: unable to handle kernel paging request at 00000000ffffff8a",
+`: "BUG: unable to handle kernel paging request at ADDR",
 		`
 ==================================================================
 BUG: KASAN: slab-out-of-bounds in memcpy+0x1d/0x40 at addr ffff88003a6bd110
 Read of size 8 by task a.out/6260
-`: "BUG: KASAN: slab-out-of-bounds in memcpy+0x1d/0x40 at addr ffff88003a6bd110",
+`: "BUG: KASAN: slab-out-of-bounds in memcpy at addr ADDR",
 		`
 [   50.583499] unreferenced object 0xffff880039a55260 (size 64):
 [   50.583499]   comm "executor", pid 11746, jiffies 4298984475 (age 16.078s)
-`: "unreferenced object 0xffff880039a55260 (size 64):",
+`: "unreferenced object ADDR (size 64):",
 		`
 [   50.583499] UBSAN: Undefined behaviour in kernel/time/hrtimer.c:310:16
 [   50.583499] signed integer overflow:
-`: "UBSAN: Undefined behaviour in kernel/time/hrtimer.c:310:16",
+`: "UBSAN: signed-integer-overflow in kernel/time/hrtimer.c",
+		`
+[   32.142516] ================================================================================
+[   32.143224] UBSAN: Undefined behaviour in net/ipv4/tcp_input.c:3826:8
+[   32.143863] shift exponent 64 is too large for 64-bit type 'long unsigned int'
+[   32.144534] CPU: 1 PID: 4243 Comm: syz-executor2 Not tainted 4.15.0-rc8+ #263
+[   32.145209] Call Trace:
+[   32.145479]  __dump_stack lib/dump_stack.c:17 [inline]
+[   32.145479]  dump_stack+0x194/0x257 lib/dump_stack.c:53
+[   32.146069]  ubsan_epilogue+0xe/0x81 lib/ubsan.c:164
+[   32.146622]  __ubsan_handle_shift_out_of_bounds+0x293/0x2e8 lib/ubsan.c:421
+[   32.147341]  ? tcp_parse_options+0x4c/0x1070
+[   32.147819]  tcp_ack_update_rtt net/ipv4/tcp_input.c:3826 [inline]
+[   32.147819]  tcp_ack+0x2a2a/0x5a30 net/ipv4/tcp_input.c:3650
+[   32.148365] ================================================================================
+`: "UBSAN: shift-out-of-bounds in tcp_ack_update_rtt",
+		`
+[   61.348154] UBSAN: array-index-out-of-bounds in drivers/net/wireless/mac80211_hwsim.c:1047:12
+[   61.349054] index 8 is out of range for type 'u8 [8]'
+[   61.349663] Call Trace:
+[   61.349939]  dump_stack+0x107/0x163
+[   61.350289]  ubsan_epilogue+0xb/0x5a
+[   61.350693]  __ubsan_handle_out_of_bounds.cold+0x62/0x6c
+[   61.351227]  hwsim_tx_frame+0x5b5/0x610
+`: "UBSAN: array-index-out-of-bounds in hwsim_tx_frame",
+		`
+==================================================================
+BUG: KMSAN: uninit-value in __skb_checksum+0x3f3/0x10f0
+CPU: 0 PID: 3520 Comm: syz-executor5 Not tainted 4.16.0+ #82
+Call Trace:
+ dump_stack+0x185/0x1d0
+ kmsan_report+0x142/0x240
+ __msan_warning_32+0x6c/0xb0
+ __skb_checksum+0x3f3/0x10f0 net/core/skbuff.c:2287
+`: "BUG: KMSAN: uninit-value in __skb_checksum",
+		`
+BUG: KMSAN: use of uninitialized memory in tcp_rcv_established+0x13e/0x2a0
+`: "BUG: KMSAN: uninit-value in tcp_rcv_established",
+		`
+==================================================================
+BUG: KMSAN: kernel-infoleak in kmsan_internal_check_memory+0x164/0x1d0
+CPU: 1 PID: 4641 Comm: syz-executor1 Not tainted 4.16.0+ #82
+Call Trace:
+ dump_stack+0x185/0x1d0
+ kmsan_report+0x142/0x240
+ kmsan_internal_check_memory+0x164/0x1d0
+ kmsan_copy_to_user+0x69/0x160
+ copy_to_user include/linux/uaccess.h:184 [inline]
+ sctp_getsockopt_events net/sctp/socket.c:5637 [inline]
+ sctp_getsockopt+0x7c8d/0x10e40 net/sctp/socket.c:7310
+`: "BUG: KMSAN: kernel-infoleak in sctp_getsockopt_events",
 		`
 ------------[ cut here ]------------
 kernel BUG at fs/buffer.c:1917!
@@ -68,12 +169,20 @@ invalid opcode: 0000 [#1] SMP
 		`
 BUG: sleeping function called from invalid context at include/linux/wait.h:1095 
 in_atomic(): 1, irqs_disabled(): 0, pid: 3658, name: syz-fuzzer 
-`: "BUG: sleeping function called from invalid context at include/linux/wait.h:1095 ",
+`: "BUG: sleeping function called from invalid context at include/linux/wait.h:1095",
 		`
 ------------[ cut here ]------------
 WARNING: CPU: 3 PID: 1975 at fs/locks.c:241
 locks_free_lock_context+0x118/0x180()
-`: "WARNING: CPU: 3 PID: 1975 at fs/locks.c:241",
+`: "WARNING: at fs/locks.c:241",
+		`
+[   50.583499] ==================================================================
+[   50.583499] BUG: KCSAN: data-race in pipe_write+0x1a2/0x8d0 / do_readv+0x66/0x2a0
+[   50.583499] 
+`: "BUG: KCSAN: data-race in do_readv / pipe_write",
+		`
+BUG: KCSAN: data-race in do_readv+0x70/0x2a0 / pipe_write+0x1a2/0x8d0
+`: "BUG: KCSAN: data-race in do_readv / pipe_write",
 	}
 	for log, crash := range tests {
 		if strings.Index(log, "\r\n") != -1 {
@@ -82,7 +191,7 @@ locks_free_lock_context+0x118/0x180()
 		tests[strings.Replace(log, "\n", "\r\n", -1)] = crash
 	}
 	for log, crash := range tests {
-		desc, _, _, found := FindCrash([]byte(log))
+		desc, _, _, found := FindCrash("linux", []byte(log))
 		//t.Logf("%v\nexpect '%v', found '%v'\n", log, crash, desc)
 		if !found && crash != "" {
 			t.Fatalf("did not find crash message '%v' in:\n%v", crash, log)
@@ -95,3 +204,160 @@ locks_free_lock_context+0x118/0x180()
 		}
 	}
 }
+
+func TestFindCrashBoundaries(t *testing.T) {
+	log := `something
+[   32.142516] ================================================================================
+[   32.143224] UBSAN: Undefined behaviour in net/ipv4/tcp_input.c:3826:8
+[   32.143863] shift exponent 64 is too large for 64-bit type 'long unsigned int'
+[   32.147819]  tcp_ack+0x2a2a/0x5a30 net/ipv4/tcp_input.c:3650
+[   32.148365] ================================================================================
+something else
+`
+	_, start, end, found := FindCrash("linux", []byte(log))
+	if !found {
+		t.Fatalf("did not find the report")
+	}
+	want := log[strings.Index(log, "[   32.142516]"):strings.Index(log, "\nsomething else")]
+	if got := log[start:end]; got != want {
+		t.Fatalf("bad report boundaries:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestFindCrashGVisor(t *testing.T) {
+	tests := map[string]string{
+		`
+executing program 0:
+panic: runtime error: index out of range
+
+goroutine 123 [running]:
+gvisor.googlesource.com/gvisor/pkg/sentry/kernel.(*Task).run(0xc420106000, 0x1)
+`: "panic: runtime error: index out of range",
+		`
+fatal error: concurrent map writes
+`: "fatal error: concurrent map writes",
+		`
+W0612 12:00:00.000000       1 task_run.go:89] Unsupported syscall: 0x13f
+`: "",
+	}
+	for log, crash := range tests {
+		desc, _, _, found := FindCrash("gvisor", []byte(log))
+		if found != (crash != "") || desc != crash {
+			t.Fatalf("extracted bad crash message:\n%v\nwant:\n%v", desc, crash)
+		}
+	}
+}
+
+func TestNormalizeDesc(t *testing.T) {
+	tests := []struct {
+		os   string
+		desc string
+		want string
+	}{
+		{"linux", "general protection fault in tun_chr_close+0x1d/0x40", "general protection fault in tun_chr_close"},
+		{"linux", "general protection fault in tun_chr_close+0x2b/0x40", "general protection fault in tun_chr_close"},
+		{"linux", "INFO: task syz-executor3:4523 blocked for more than 120 seconds.",
+			"INFO: task blocked for more than 120 seconds."},
+		{"linux", "BUG: KASAN: use-after-free in sock_release+0x1a/0x2b0 at addr ffff88003a6bd110",
+			"BUG: KASAN: use-after-free in sock_release at addr ADDR"},
+		{"linux", "WARNING: CPU: 0 PID: 1 at mm/slab_common.c:996 kmalloc_slab+0x5d/0x70",
+			"WARNING: at mm/slab_common.c:996 kmalloc_slab"},
+		{"linux", "BUG: unable to handle kernel paging request at deadbeef", "BUG: unable to handle kernel paging request at deadbeef"},
+		{"linux", "BUG: unable to handle kernel paging request at 00000000ffffff8a ",
+			"BUG: unable to handle kernel paging request at ADDR"},
+	}
+	for _, test := range tests {
+		got := NormalizeDesc(test.os, test.desc)
+		if got != test.want {
+			t.Errorf("%v: %q: got %q, want %q", test.os, test.desc, got, test.want)
+		}
+		if again := NormalizeDesc(test.os, got); again != got {
+			t.Errorf("%v: %q is not idempotent: %q", test.os, got, again)
+		}
+	}
+}
+
+func TestIsDataRace(t *testing.T) {
+	if !IsDataRace("BUG: KCSAN: data-race in do_readv / pipe_write") {
+		t.Fatalf("data race is not detected")
+	}
+	if IsDataRace("BUG: KASAN: use after free in remove_wait_queue+0xfb/0x120") {
+		t.Fatalf("KASAN report is detected as data race")
+	}
+}
+
+func TestIsHang(t *testing.T) {
+	tests := map[string]bool{
+		"INFO: task blocked for more than 120 seconds.":              true,
+		"INFO: task hung in lo_ioctl":                                true,
+		"INFO: rcu_sched self-detected stall on CPU":                 true,
+		"INFO: rcu detected stall in pipe_read":                      true,
+		"BUG: soft lockup - CPU#1 stuck for 22s! [syz-executor:PID]": true,
+		"BUG: soft lockup in tun_chr_poll":                           true,
+		"INFO: possible recursive locking detected":                  false,
+		"BUG: KASAN: use after free in lo_ioctl":                     false,
+	}
+	for desc, want := range tests {
+		if got := IsHang(desc); got != want {
+			t.Errorf("%q: IsHang = %v, want %v", desc, got, want)
+		}
+	}
+}
+
+func TestCrashClasses(t *testing.T) {
+	tests := map[string][]string{
+		"WARNING: at fs/locks.c:241":                                   {"warning"},
+		"INFO: task blocked for more than 120 seconds.":                {"hung-task"},
+		"INFO: rcu_sched self-detected stall on CPU":                   {"rcu-stall"},
+		"INFO: rcu_preempt detected stalls on CPUs/tasks":              {"rcu-stall"},
+		"INFO: task hung in lo_ioctl":                                  {"hung-task"},
+		"INFO: rcu detected stall in pipe_read":                        {"rcu-stall"},
+		"BUG: soft lockup in tun_chr_poll":                             {"soft-lockup"},
+		"BUG: soft lockup - CPU#1 stuck for 22s! [syz-executor:PID]":   {"soft-lockup"},
+		"WARNING: possible circular locking dependency detected":       {"warning", "lockdep"},
+		"INFO: possible recursive locking detected":                    {"lockdep"},
+		"unreferenced object ADDR (size 64):":                          {"leak"},
+		"BUG: KCSAN: data-race in do_readv / pipe_write":               {"data-race"},
+		"UBSAN: Undefined behaviour in kernel/time/hrtimer.c:310:16":   {"ubsan"},
+		"UBSAN: shift-out-of-bounds in tcp_ack_update_rtt":             {"ubsan"},
+		"BUG: KMSAN: uninit-value in __skb_checksum":                   {"kmsan"},
+		"BUG: KASAN: use after free in remove_wait_queue at addr ADDR": nil,
+		"general protection fault: 0000 [#1] SMP KASAN":                nil,
+	}
+	for desc, want := range tests {
+		var got []string
+		for _, class := range CrashClasses() {
+			if InCrashClass(class, desc) {
+				got = append(got, class)
+			}
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%q: got classes %q, want %q", desc, got, want)
+		}
+	}
+	if InCrashClass("no-such-class", "WARNING: at fs/locks.c:241") {
+		t.Errorf("unknown class matched")
+	}
+}
+
+func TestFindReboot(t *testing.T) {
+	tests := map[string]int{
+		"[  120.123456] syzkaller: boot marker qemu-1-123\n" +
+			"[    0.000000] Linux version 4.15.0+ (user@host) (gcc version 7.1.0) #1 SMP\n": 49,
+		"Booting Linux on physical CPU 0x0\n[    0.000000] Linux version 4.4.0\n":    0,
+		"executing program 1:\nmmap(&(0x7f0000000000/0x1000)=nil)\n":                 -1,
+		"[  120.123456] some driver: unsupported Linux version 2 of the interface\n": -1,
+	}
+	for output, want := range tests {
+		pos, found := FindReboot("linux", []byte(output))
+		if !found {
+			pos = -1
+		}
+		if pos != want {
+			t.Errorf("%q: got reboot at %v, want %v", output, pos, want)
+		}
+	}
+	if _, found := FindReboot("gvisor", []byte("Linux version 4.15.0")); found {
+		t.Errorf("found reboot for os without boot banners")
+	}
+}